@@ -45,6 +45,41 @@ func main() {
 	go startSessionCleanup(log, repo.Session)
 	log.Info("session cleanup started")
 
+	// Start background webmention processing
+	if cfg.Webmention.Enabled {
+		go startWebmentionWorker(log, services.Webmention, cfg.Webmention.PollInterval)
+		log.Info("webmention worker started")
+	}
+
+	// Start background email notifications and newsletter
+	if cfg.Notifications.EmailEnabled || cfg.Newsletter.Enabled {
+		go startNotificationWorker(log, cfg, services.Notification, services.Newsletter)
+		log.Info("notification worker started", slog.String("mail_transport", cfg.Mail.Transport))
+	}
+
+	// Live post events: other replicas' events arrive with LISTEN/NOTIFY
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	defer stopListening()
+	if cfg.Events.Enabled {
+		if cfg.Events.Notify {
+			go services.Event.Listen(listenCtx)
+		}
+		go startEventCleanup(log, services.Event)
+		log.Info("post events started", slog.Bool("notify", cfg.Events.Notify))
+	}
+
+	// Start background like and comment count reconciliation
+	if cfg.Counters.ReconcileInterval > 0 {
+		go startCounterReconciliation(log, services.Counter, cfg.Counters.ReconcileInterval)
+		log.Info("counter reconciliation started")
+	}
+
+	// Start background removal of deleted comments left without live replies
+	if cfg.Comments.TombstoneSweep > 0 {
+		go startTombstoneSweep(log, services.Comment, cfg.Comments.TombstoneSweep)
+		log.Info("deleted comment sweep started")
+	}
+
 	// HTTP Server
 	srv := &http.Server{
 		Addr:         cfg.HTTPServer.Address,
@@ -53,6 +88,8 @@ func main() {
 		WriteTimeout: cfg.HTTPServer.Timeout,
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
+	// Event streams never finish on their own, end them so shutdown doesn't wait
+	srv.RegisterOnShutdown(services.Event.Close)
 
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -76,6 +113,14 @@ func main() {
 		log.Error("failed to shutdown server", slog.String("error", err.Error()))
 	}
 	log.Info("server stopped")
+
+	// Deliveries started by requests run in the background and outlive them
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelWait()
+
+	if err := services.Wait(waitCtx); err != nil {
+		log.Warn("background deliveries did not finish", slog.String("error", err.Error()))
+	}
 }
 
 // startSessionCleanup runs periodic cleanup of expired sessions
@@ -94,3 +139,89 @@ func startSessionCleanup(log *slog.Logger, sessionRepo repository.SessionReposit
 		cancel()
 	}
 }
+
+// startWebmentionWorker periodically verifies received and sends queued webmentions
+func startWebmentionWorker(log *slog.Logger, webmentions service.WebmentionService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		if err := webmentions.ProcessQueue(ctx); err != nil {
+			log.Error("failed to process webmentions", slog.String("error", err.Error()))
+		}
+		cancel()
+	}
+}
+
+// startEventCleanup periodically deletes post events too old to resume from
+func startEventCleanup(log *slog.Logger, events service.EventService) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		deleted, err := events.Cleanup(ctx)
+		if err != nil {
+			log.Error("failed to cleanup post events", slog.String("error", err.Error()))
+		} else if deleted > 0 {
+			log.Info("cleaned up post events", slog.Int64("count", deleted))
+		}
+		cancel()
+	}
+}
+
+// startNotificationWorker periodically emails queued notifications and newsletter posts
+func startNotificationWorker(log *slog.Logger, cfg *config.Config, notifications service.NotificationService, newsletter service.NewsletterService) {
+	ticker := time.NewTicker(cfg.Notifications.PollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		if cfg.Notifications.EmailEnabled {
+			if err := notifications.ProcessEmails(ctx); err != nil {
+				log.Error("failed to process email notifications", slog.String("error", err.Error()))
+			}
+		}
+		if cfg.Newsletter.Enabled {
+			if err := newsletter.ProcessQueue(ctx); err != nil {
+				log.Error("failed to process newsletter", slog.String("error", err.Error()))
+			}
+		}
+		cancel()
+	}
+}
+
+// startCounterReconciliation periodically fixes like and comment counts that drifted
+func startCounterReconciliation(log *slog.Logger, counters service.CounterService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		report, err := counters.Reconcile(ctx, false)
+		if err != nil {
+			log.Error("failed to reconcile counters", slog.String("error", err.Error()))
+		} else if len(report.Drift) > 0 {
+			log.Warn("reconciled drifted counters", slog.Int("count", len(report.Drift)))
+		}
+		cancel()
+	}
+}
+
+// startTombstoneSweep periodically removes deleted comments whose replies are all gone
+func startTombstoneSweep(log *slog.Logger, comments service.CommentService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		purged, err := comments.PurgeTombstones(ctx)
+		if err != nil {
+			log.Error("failed to purge deleted comments", slog.String("error", err.Error()))
+		} else if purged > 0 {
+			log.Info("purged deleted comments", slog.Int("count", purged))
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,20 @@
+// Command vapid generates a VAPID key pair for Web Push notifications
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"personal-web-platform/internal/pkg/webpush"
+)
+
+func main() {
+	publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate keys: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("VAPID_PUBLIC_KEY=%s\n", publicKey)
+	fmt.Printf("VAPID_PRIVATE_KEY=%s\n", privateKey)
+}
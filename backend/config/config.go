@@ -7,19 +7,33 @@ import (
 	"strings"
 	"time"
 
+	"personal-web-platform/internal/pkg/webpush"
+
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 // Config represents application configuration
 type Config struct {
-	Env        string        `yaml:"env" env-default:"local"`
-	HTTPServer HTTPServer    `yaml:"http_server"`
-	Database   Database      `yaml:"database"`
-	Profile    ProfileConfig `yaml:"profile"`
-	Auth       Auth          `yaml:"auth"`
-	OAuth      OAuth         `yaml:"oauth"`
-	CORS       CORS          `yaml:"cors"`
-	RateLimit  RateLimit     `yaml:"rate_limit"`
+	Env           string        `yaml:"env" env-default:"local"`
+	HTTPServer    HTTPServer    `yaml:"http_server"`
+	Database      Database      `yaml:"database"`
+	Profile       ProfileConfig `yaml:"profile"`
+	Auth          Auth          `yaml:"auth"`
+	OAuth         OAuth         `yaml:"oauth"`
+	CORS          CORS          `yaml:"cors"`
+	RateLimit     RateLimit     `yaml:"rate_limit"`
+	Languages     Languages     `yaml:"languages"`
+	Reactions     Reactions     `yaml:"reactions"`
+	Comments      Comments      `yaml:"comments"`
+	ActivityPub   ActivityPub   `yaml:"activitypub"`
+	Webmention    Webmention    `yaml:"webmention"`
+	Mail          Mail          `yaml:"mail"`
+	SMTP          SMTP          `yaml:"smtp"`
+	Newsletter    Newsletter    `yaml:"newsletter"`
+	Notifications Notifications `yaml:"notifications"`
+	Push          Push          `yaml:"push"`
+	Events        Events        `yaml:"events"`
+	Counters      Counters      `yaml:"counters"`
 }
 
 // HTTPServer represents HTTP server configuration
@@ -94,6 +108,117 @@ type RateLimit struct {
 	WindowSeconds int  `yaml:"window_seconds" env-default:"60"`  // time window in seconds
 }
 
+// Languages represents content localization configuration
+type Languages struct {
+	Default   string   `yaml:"default" env-default:"ru"`      // language of untranslated content
+	Supported []string `yaml:"supported" env-default:"ru,en"` // languages posts can be translated to
+}
+
+// Reactions represents the set of emoji readers can react with
+type Reactions struct {
+	Emoji []string `yaml:"emoji" env-default:"👍,❤️,😂,😮,😢,🔥"` // "👍" is always allowed, likes are stored as it
+}
+
+// Comments represents comment moderation settings
+type Comments struct {
+	Moderation      string        `yaml:"moderation" env-default:"none"`    // none, first_comment or all; admins are never held
+	EditWindow      time.Duration `yaml:"edit_window" env-default:"1h"`     // how long authors can edit a comment, 0 for no limit
+	ReportThreshold int           `yaml:"report_threshold" env-default:"3"` // reports from different users that hide a comment for review, 0 to never hide
+	MaxDepth        int           `yaml:"max_depth" env-default:"4"`        // deeper replies are shown under the deepest allowed ancestor, 0 for no limit
+	CollapseAfter   int           `yaml:"collapse_after" env-default:"3"`   // replies to a comment beyond this many are shown collapsed, 0 to never collapse
+	TombstoneSweep  time.Duration `yaml:"tombstone_sweep" env-default:"1h"` // how often deleted comments left without live replies are removed, 0 to only check on delete
+	Spam            Spam          `yaml:"spam"`
+}
+
+// Spam represents comment spam scoring; suspicious comments are held for moderation
+type Spam struct {
+	Enabled         bool                `yaml:"enabled" env-default:"true"`
+	HoldScore       int                 `yaml:"hold_score" env-default:"5"`         // comments scoring at least this are held
+	RateWindow      time.Duration       `yaml:"rate_window" env-default:"10m"`      // window of the per-user posting rate
+	RateLimit       int                 `yaml:"rate_limit" env-default:"5"`         // comments per window before flooding scores
+	MaxLinks        int                 `yaml:"max_links" env-default:"2"`          // links allowed before each one scores
+	DuplicateWindow time.Duration       `yaml:"duplicate_window" env-default:"24h"` // how far back identical comments are looked for
+	NewAccountAge   time.Duration       `yaml:"new_account_age" env-default:"24h"`  // accounts younger than this score
+	StopWords       map[string][]string `yaml:"stop_words"`                         // per language, replaces the built-in list of that language
+}
+
+// ActivityPub represents federation settings of the blog actor
+type ActivityPub struct {
+	Enabled         bool          `yaml:"enabled" env-default:"false"`
+	BaseURL         string        `yaml:"base_url" env:"ACTIVITYPUB_BASE_URL"` // public backend URL, defaults to oauth.base_url
+	Username        string        `yaml:"username" env-default:"blog"`         // actor handle: @username@host
+	DeliveryTimeout time.Duration `yaml:"delivery_timeout" env-default:"10s"`  // timeout of a single remote request
+}
+
+// Webmention represents Webmention sending and receiving settings
+type Webmention struct {
+	Enabled       bool          `yaml:"enabled" env-default:"true"`
+	Timeout       time.Duration `yaml:"timeout" env-default:"10s"`       // timeout of a single remote request
+	PollInterval  time.Duration `yaml:"poll_interval" env-default:"30s"` // how often the queue is processed
+	BatchSize     int           `yaml:"batch_size" env-default:"20"`     // mentions processed per poll
+	MaxAttempts   int           `yaml:"max_attempts" env-default:"5"`    // attempts before giving up
+	RetryInterval time.Duration `yaml:"retry_interval" env-default:"1m"` // first retry delay, doubled on every attempt
+}
+
+// Mail represents the email transport
+type Mail struct {
+	Transport string `yaml:"transport" env:"MAIL_TRANSPORT"` // smtp, file or log; defaults to smtp when smtp.host is set
+	Dir       string `yaml:"dir" env-default:"./tmp/mail"`   // where the file transport writes .eml files
+}
+
+// SMTP represents the outgoing mail server
+type SMTP struct {
+	Host     string        `yaml:"host" env:"SMTP_HOST"`
+	Port     int           `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string        `yaml:"username" env:"SMTP_USERNAME"` // empty disables authentication
+	Password string        `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string        `yaml:"from" env:"SMTP_FROM" env-default:"Blog <blog@localhost>"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"10s"`
+}
+
+// Newsletter represents email subscription settings
+type Newsletter struct {
+	Enabled         bool          `yaml:"enabled" env-default:"false"`
+	ConfirmationTTL time.Duration `yaml:"confirmation_ttl" env-default:"48h"` // how long a confirmation link is valid
+	ResendInterval  time.Duration `yaml:"resend_interval" env-default:"10m"`  // at most one confirmation email per address within this interval
+	BatchSize       int           `yaml:"batch_size" env-default:"100"`       // emails sent per worker run
+	MaxAttempts     int           `yaml:"max_attempts" env-default:"3"`       // attempts before an email is dropped
+	RetryInterval   time.Duration `yaml:"retry_interval" env-default:"5m"`    // delay before retrying a failed email, doubled every attempt
+}
+
+// Notifications represents email notifications to registered users
+type Notifications struct {
+	EmailEnabled  bool          `yaml:"email_enabled" env-default:"true"`
+	PollInterval  time.Duration `yaml:"poll_interval" env-default:"1m"`  // how often pending notifications are sent
+	BatchDelay    time.Duration `yaml:"batch_delay" env-default:"5m"`    // notifications wait this long to be batched together
+	MinInterval   time.Duration `yaml:"min_interval" env-default:"1h"`   // at most one email per user within this interval
+	MaxAttempts   int           `yaml:"max_attempts" env-default:"3"`    // attempts before a notification is dropped
+	RetryInterval time.Duration `yaml:"retry_interval" env-default:"5m"` // delay before retrying a failed email
+}
+
+// Push represents Web Push notification settings
+type Push struct {
+	Enabled         bool          `yaml:"enabled" env-default:"false"`
+	VAPIDPublicKey  string        `yaml:"vapid_public_key" env:"VAPID_PUBLIC_KEY"` // generate a pair with: go run ./cmd/vapid
+	VAPIDPrivateKey string        `yaml:"vapid_private_key" env:"VAPID_PRIVATE_KEY"`
+	Subject         string        `yaml:"subject" env:"VAPID_SUBJECT"` // mailto: or https: contact, defaults to profile.contacts.email
+	TTL             time.Duration `yaml:"ttl" env-default:"24h"`       // how long push services keep undelivered messages
+	Timeout         time.Duration `yaml:"timeout" env-default:"10s"`   // timeout of a single push request
+}
+
+// Events represents live updates of comments and likes (Server-Sent Events)
+type Events struct {
+	Enabled   bool          `yaml:"enabled" env-default:"true"`
+	Notify    bool          `yaml:"notify" env-default:"false"`  // share events between replicas with PostgreSQL LISTEN/NOTIFY
+	Heartbeat time.Duration `yaml:"heartbeat" env-default:"25s"` // comment sent to idle streams so proxies keep them open
+	Retention time.Duration `yaml:"retention" env-default:"24h"` // how long events are kept for resuming with Last-Event-ID
+}
+
+// Counters represents the job recomputing denormalized like and comment counts
+type Counters struct {
+	ReconcileInterval time.Duration `yaml:"reconcile_interval" env-default:"24h"` // 0 disables the job, admins can still run it
+}
+
 // MustLoad loads configuration from file or panics if unable to load
 func MustLoad() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
@@ -138,6 +263,37 @@ func MustLoad() *Config {
 		cfg.OAuth.FrontendURL = cfg.OAuth.BaseURL
 	}
 
+	if cfg.Mail.Transport == "" {
+		cfg.Mail.Transport = "log"
+		if cfg.SMTP.Host != "" {
+			cfg.Mail.Transport = "smtp"
+		}
+	}
+
+	if cfg.Push.Enabled {
+		if _, err := webpush.NewVAPID(cfg.Push.VAPIDPublicKey, cfg.Push.VAPIDPrivateKey, cfg.Push.Subject); err != nil {
+			log.Fatalf("push is enabled but the vapid keys are invalid: %s", err)
+		}
+		if cfg.Push.Subject == "" && cfg.Profile.Contacts.Email != "" {
+			cfg.Push.Subject = "mailto:" + cfg.Profile.Contacts.Email
+		}
+		// Some push services reject requests without a contact
+		if cfg.Push.Subject == "" {
+			log.Fatalf("push is enabled but push.subject is empty")
+		}
+	}
+
+	switch cfg.Comments.Moderation {
+	case "none", "first_comment", "all":
+	default:
+		log.Fatalf("comments.moderation must be none, first_comment or all, got: %s", cfg.Comments.Moderation)
+	}
+
+	if cfg.ActivityPub.BaseURL == "" {
+		cfg.ActivityPub.BaseURL = cfg.OAuth.BaseURL
+	}
+	cfg.ActivityPub.BaseURL = strings.TrimSuffix(cfg.ActivityPub.BaseURL, "/")
+
 	// Validate OAuth URLs
 	if !strings.HasPrefix(cfg.OAuth.BaseURL, "http://") &&
 		!strings.HasPrefix(cfg.OAuth.BaseURL, "https://") {
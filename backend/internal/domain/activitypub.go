@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// ActivityPubProvider is the oauth_providers entry linking remote authors to local users
+const ActivityPubProvider = "activitypub"
+
+// ActivityPubKey is the key pair the blog actor signs federation requests with
+type ActivityPubKey struct {
+	PrivateKeyPEM string    `json:"-"`
+	PublicKeyPEM  string    `json:"public_key_pem"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Follower is a remote ActivityPub actor following the blog
+type Follower struct {
+	ID          int       `json:"id"`
+	ActorID     string    `json:"actor_id"`
+	Inbox       string    `json:"inbox"`
+	SharedInbox string    `json:"shared_inbox,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// UserBan puts a user in read-only mode: they can sign in and read, but not
+// comment, like or react. A ban without an expiry lasts until it is lifted.
+type UserBan struct {
+	ID        int        `json:"id"`
+	UserID    int        `json:"user_id"`
+	Reason    string     `json:"reason"`
+	IssuedBy  *int       `json:"issued_by"` // nil once the admin is deleted
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	LiftedAt  *time.Time `json:"lifted_at,omitempty"`
+	LiftedBy  *int       `json:"lifted_by,omitempty"`
+}
+
+// Active reports whether the ban is in effect at now
+func (b *UserBan) Active(now time.Time) bool {
+	return b.LiftedAt == nil && (b.ExpiresAt == nil || b.ExpiresAt.After(now))
+}
+
+// BanUserRequest represents the request to ban a user
+type BanUserRequest struct {
+	Reason    string     `json:"reason" validate:"required,min=1,max=1000"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // no expiry when nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+// BulkAction is an operation applied to a batch of posts or comments
+type BulkAction string
+
+// Bulk actions for posts
+const (
+	BulkActionPublish      BulkAction = "publish"
+	BulkActionUnpublish    BulkAction = "unpublish"
+	BulkActionDelete       BulkAction = "delete"
+	BulkActionRestore      BulkAction = "restore"
+	BulkActionMoveToSeries BulkAction = "move_to_series"
+)
+
+// Bulk actions for comments (BulkActionDelete is shared with posts)
+const (
+	BulkActionApprove  BulkAction = "approve"
+	BulkActionMarkSpam BulkAction = "mark_spam"
+	BulkActionReject   BulkAction = "reject"
+)
+
+// BulkPostsRequest represents an admin batch operation on posts
+type BulkPostsRequest struct {
+	IDs    []int      `json:"ids" validate:"required,min=1,max=100,dive,gt=0"`
+	Action BulkAction `json:"action" validate:"required,oneof=publish unpublish delete restore move_to_series"`
+	// Series is the target of move_to_series, an empty string removes the posts from their series
+	Series string `json:"series" validate:"max=255"`
+}
+
+// BulkCommentsRequest represents an admin batch operation on comments
+type BulkCommentsRequest struct {
+	IDs    []int      `json:"ids" validate:"required,min=1,max=100,dive,gt=0"`
+	Action BulkAction `json:"action" validate:"required,oneof=delete approve mark_spam reject"`
+}
+
+// BulkItemResult is the outcome of a bulk action for a single item
+type BulkItemResult struct {
+	ID      int    `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkResponse contains per-item results of a bulk operation
+type BulkResponse struct {
+	Action    BulkAction       `json:"action"`
+	Results   []BulkItemResult `json:"results"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+}
@@ -1,21 +1,79 @@
 package domain
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
+
+// Comment moderation statuses. Only approved comments are shown to readers,
+// pending ones are also shown to their author and admins.
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusSpam     = "spam"
+	CommentStatusRejected = "rejected"
+)
+
+// Comment moderation policies
+const (
+	ModerationPolicyNone         = "none"          // comments are published right away
+	ModerationPolicyFirstComment = "first_comment" // held until the author has an approved comment
+	ModerationPolicyAll          = "all"           // every comment is held
+)
+
+// Comment list orders. Replies are always listed oldest first, the order
+// applies to top-level comments.
+const (
+	CommentSortOldest = "oldest"
+	CommentSortNewest = "newest"
+	CommentSortTop    = "top" // most liked first
+)
+
+// IsValidCommentSort reports whether sort is a known comment list order
+func IsValidCommentSort(sort string) bool {
+	return sort == CommentSortOldest || sort == CommentSortNewest || sort == CommentSortTop
+}
 
 // Comment represents a comment on a post
 type Comment struct {
-	ID         int        `json:"id"`
-	PostID     int        `json:"post_id" validate:"required"`
-	UserID     int        `json:"user_id" validate:"required"`
-	Content    string     `json:"content" validate:"required,min=1,max=5000"`
-	ParentID   *int       `json:"parent_id,omitempty"`
-	CreatedAt  time.Time  `json:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at"`
-	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
-	LikesCount int        `json:"likes_count" db:"likes_count"`
-	IsLiked    bool       `json:"is_liked" db:"-"`
-	User       *User      `json:"user,omitempty"`
-	Replies    []*Comment `json:"replies,omitempty"`
+	ID               int               `json:"id"`
+	PostID           int               `json:"post_id" validate:"required"`
+	UserID           int               `json:"user_id" validate:"required"`
+	Content          string            `json:"content" validate:"required,min=1,max=5000"`
+	ParentID         *int              `json:"parent_id,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	DeletedAt        *time.Time        `json:"deleted_at,omitempty"`
+	ModerationStatus string            `json:"moderation_status" db:"moderation_status"`
+	SpamScore        int               `json:"spam_score,omitempty" db:"spam_score"`     // set in the moderation queue only
+	SpamReasons      []string          `json:"spam_reasons,omitempty" db:"spam_reasons"` // set in the moderation queue only
+	Fingerprint      string            `json:"-" db:"content_fingerprint"`
+	Edited           bool              `json:"edited" db:"-"`
+	EditCount        int               `json:"edit_count" db:"edit_count"`
+	LikesCount       int               `json:"likes_count" db:"likes_count"`
+	IsLiked          bool              `json:"is_liked" db:"-"`
+	Reactions        []ReactionSummary `json:"reactions" db:"-"`
+	Mentions         []CommentMention  `json:"mentions,omitempty" db:"-"`
+	User             *User             `json:"user,omitempty"`
+	Replies          []*Comment        `json:"replies,omitempty"`
+	RepliesCount     int               `json:"replies_count" db:"-"`       // all replies in the thread below this comment
+	Collapsed        bool              `json:"collapsed,omitempty" db:"-"` // shown folded, the parent has many replies
+}
+
+// CommentMention is a user mentioned in a comment. Handle is the mention as
+// written, without "@", so that clients can link it to the user.
+type CommentMention struct {
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	Handle string `json:"handle"`
+}
+
+// CommentRevision is the content of a comment before one of its edits
+type CommentRevision struct {
+	ID        int       `json:"id"`
+	CommentID int       `json:"comment_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"` // when the content was replaced
 }
 
 // CreateCommentRequest represents the request to create a comment
@@ -28,3 +86,103 @@ type CreateCommentRequest struct {
 type UpdateCommentRequest struct {
 	Content string `json:"content" validate:"required,min=1,max=5000"`
 }
+
+// QueuedComment is a comment in the moderation queue with the post it was left on
+type QueuedComment struct {
+	Comment
+	PostTitle string `json:"post_title"`
+	PostSlug  string `json:"post_slug"`
+}
+
+// ModerationQueueRequest represents the moderation queue filter
+type ModerationQueueRequest struct {
+	Status string `json:"status" validate:"omitempty,oneof=pending spam rejected"`
+	Page   int    `json:"page" validate:"omitempty,min=1"`
+	Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ModerationQueueResponse represents a page of the moderation queue
+type ModerationQueueResponse struct {
+	Comments   []QueuedComment `json:"comments"`
+	TotalCount int             `json:"total_count"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+	TotalPages int             `json:"total_pages"`
+}
+
+// Comment report reasons
+const (
+	ReportReasonSpam     = "spam"
+	ReportReasonAbuse    = "abuse"
+	ReportReasonOffTopic = "off_topic"
+	ReportReasonOther    = "other"
+)
+
+// Comment report statuses. Open reports wait for an admin, who resolves them
+// by hiding the comment or dismisses them by keeping it.
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// CommentReport is a reader's complaint about a comment
+type CommentReport struct {
+	ID         int        `json:"id"`
+	CommentID  int        `json:"comment_id"`
+	UserID     int        `json:"user_id"`
+	Reason     string     `json:"reason"`
+	Details    string     `json:"details,omitempty"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	ResolvedBy *int       `json:"resolved_by,omitempty"`
+}
+
+// ReportCommentRequest represents the request to report a comment
+type ReportCommentRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam abuse off_topic other"`
+	Details string `json:"details" validate:"max=1000"`
+}
+
+// ReportedComment is a comment in the reports inbox with its reports
+type ReportedComment struct {
+	QueuedComment
+	Reports []CommentReport `json:"reports"`
+}
+
+// ReportsRequest represents the reports inbox filter
+type ReportsRequest struct {
+	Status string `json:"status" validate:"omitempty,oneof=open resolved dismissed"`
+	Page   int    `json:"page" validate:"omitempty,min=1"`
+	Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ReportsResponse represents a page of the reports inbox
+type ReportsResponse struct {
+	Comments   []ReportedComment `json:"comments"`
+	TotalCount int               `json:"total_count"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+	TotalPages int               `json:"total_pages"`
+}
+
+// CommentPermalink is a single comment with what is needed to show it in
+// its thread on the post page
+type CommentPermalink struct {
+	Comment   *Comment  `json:"comment"`   // with its first replies
+	Ancestors []Comment `json:"ancestors"` // from the top-level comment down to the parent, without replies
+	PostID    int       `json:"post_id"`
+	PostSlug  string    `json:"post_slug"`
+	PostTitle string    `json:"post_title"`
+	// ThreadID is the top-level comment of the thread and ThreadPosition its
+	// index among the post's threads oldest first, to scroll to it
+	ThreadID       int    `json:"thread_id"`
+	ThreadPosition int    `json:"thread_position"`
+	Anchor         string `json:"anchor"` // URL fragment of the comment on the post page
+}
+
+// CommentAnchor is the URL fragment of a comment on the post page
+func CommentAnchor(id int) string {
+	return "comment-" + strconv.Itoa(id)
+}
@@ -0,0 +1,22 @@
+package domain
+
+// Denormalized counters kept next to the rows they count
+const (
+	CounterPostLikes    = "post_likes"    // posts.likes_count, "👍" reactions to the post
+	CounterPostComments = "post_comments" // posts.comments_count, published comments
+	CounterCommentLikes = "comment_likes" // comments.likes_count, "👍" reactions to the comment
+)
+
+// CounterDrift is a stored counter that differs from what it counts
+type CounterDrift struct {
+	Counter string `json:"counter"`
+	ID      int    `json:"id"` // post or comment ID
+	Stored  int    `json:"stored"`
+	Actual  int    `json:"actual"`
+}
+
+// CounterReport lists the counters that drifted and whether they were fixed
+type CounterReport struct {
+	Drift []CounterDrift `json:"drift"`
+	Fixed bool           `json:"fixed"`
+}
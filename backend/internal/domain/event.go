@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Post event types, streamed to readers of the post
+const (
+	PostEventCommentCreated = "comment.created"
+	PostEventCommentUpdated = "comment.updated"
+	PostEventCommentDeleted = "comment.deleted"
+	PostEventLikesUpdated   = "likes.updated"
+	// PostEventReset tells a resuming reader that too much was missed and the post should be reloaded
+	PostEventReset = "reset"
+)
+
+// PostEvent is a change of a post's discussion. IDs grow, a reader resumes
+// after the last ID it received.
+type PostEvent struct {
+	ID        int64           `json:"id"`
+	PostID    int             `json:"post_id"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// CommentDeletedEvent is the data of a comment.deleted event
+type CommentDeletedEvent struct {
+	ID     int `json:"id"`
+	PostID int `json:"post_id"`
+	// Removed is false when the comment has replies and only its content was replaced
+	Removed bool `json:"removed"`
+}
+
+// LikesUpdatedEvent is the data of a likes.updated event, for the post itself when CommentID is nil
+type LikesUpdatedEvent struct {
+	PostID     int  `json:"post_id"`
+	CommentID  *int `json:"comment_id,omitempty"`
+	LikesCount int  `json:"likes_count"`
+}
@@ -21,3 +21,46 @@ type LikeStatus struct {
 	IsLiked    bool `json:"is_liked"`
 	LikesCount int  `json:"likes_count"`
 }
+
+// Liker is a user who liked a post or comment, with their public info only
+type Liker struct {
+	UserID    int       `json:"user_id"`
+	Name      string    `json:"name"`
+	AvatarURL string    `json:"avatar_url"`
+	LikedAt   time.Time `json:"liked_at"`
+}
+
+// LikesRequest represents pagination of like lists
+type LikesRequest struct {
+	Page  int `json:"page" validate:"omitempty,min=1"`
+	Limit int `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// LikersResponse represents a page of users who liked a post or comment, newest first
+type LikersResponse struct {
+	Users      []Liker `json:"users"`
+	TotalCount int     `json:"total_count"`
+	Page       int     `json:"page"`
+	Limit      int     `json:"limit"`
+	TotalPages int     `json:"total_pages"`
+}
+
+// LikedItem is a post, or a comment on a post, liked by a user
+type LikedItem struct {
+	Target         ReactionTarget `json:"target"`
+	PostID         int            `json:"post_id"`
+	PostSlug       string         `json:"post_slug"`
+	PostTitle      string         `json:"post_title"`
+	CommentID      *int           `json:"comment_id,omitempty"`
+	CommentContent string         `json:"comment_content,omitempty"`
+	LikedAt        time.Time      `json:"liked_at"`
+}
+
+// LikedItemsResponse represents a page of what a user liked, newest first
+type LikedItemsResponse struct {
+	Items      []LikedItem `json:"items"`
+	TotalCount int         `json:"total_count"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	TotalPages int         `json:"total_pages"`
+}
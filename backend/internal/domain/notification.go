@@ -8,6 +8,7 @@ type NotificationSettings struct {
 	EmailEnabled    bool      `json:"email_enabled"`
 	PushEnabled     bool      `json:"push_enabled"`
 	NewPostsEnabled bool      `json:"new_posts_enabled"`
+	Language        string    `json:"language"` // language of emails, empty means the site default
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
@@ -20,6 +21,8 @@ type Notification struct {
 	Title     string    `json:"title" validate:"required,max=255"`
 	Message   string    `json:"message" validate:"required"`
 	Read      bool      `json:"read"`
+	PostID    *int      `json:"post_id,omitempty"`
+	CommentID *int      `json:"comment_id,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -27,4 +30,61 @@ type Notification struct {
 const (
 	NotificationTypeNewPost    = "new_post"
 	NotificationTypeNewComment = "new_comment"
+	// NotificationTypeCommentReply is a reply to the user's comment
+	NotificationTypeCommentReply = "comment_reply"
+	// NotificationTypeCommentMention is a comment mentioning the user with @handle
+	NotificationTypeCommentMention = "comment_mention"
 )
+
+// UpdateNotificationSettingsRequest changes notification preferences, omitted fields keep their value
+type UpdateNotificationSettingsRequest struct {
+	EmailEnabled    *bool   `json:"email_enabled"`
+	PushEnabled     *bool   `json:"push_enabled"`
+	NewPostsEnabled *bool   `json:"new_posts_enabled"`
+	Language        *string `json:"language" validate:"omitempty,max=10"`
+}
+
+// Email notification statuses
+const (
+	EmailNotificationPending = "pending"
+	EmailNotificationSent    = "sent"
+	EmailNotificationFailed  = "failed"
+	// EmailNotificationSkipped is set when the user turned email off before it was sent
+	EmailNotificationSkipped = "skipped"
+)
+
+// EmailNotification is an event waiting to be emailed to a user
+type EmailNotification struct {
+	ID            int
+	UserID        int
+	Type          string
+	PostID        int
+	CommentID     *int
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+
+	// Joined for rendering
+	PostTitle      string
+	PostSlug       string
+	PostPreview    string
+	CommentContent string
+	ActorName      string
+	// Withdrawn is set when the post or comment was deleted or hidden since
+	Withdrawn bool
+}
+
+// EmailDelivery records an attempt to send an email
+type EmailDelivery struct {
+	ID            int
+	UserID        int
+	Recipient     string
+	Template      string
+	Subject       string
+	Notifications int
+	Status        string
+	Attempt       int
+	Error         string
+	CreatedAt     time.Time
+}
@@ -4,22 +4,61 @@ import "time"
 
 // Post represents a blog post
 type Post struct {
-	ID              int       `json:"id"`
-	Title           string    `json:"title" validate:"required,min=3,max=255"`
-	Slug            string    `json:"slug" validate:"required,min=3,max=255"`
-	Content         string    `json:"content" validate:"required,min=10"`
-	Preview         string    `json:"preview"`
-	AuthorID        int       `json:"author_id" validate:"required"`
-	Published       bool      `json:"published"`
-	PublishedAt     time.Time `json:"published_at,omitempty"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
-	CoverImage      string    `json:"cover_image,omitempty" db:"cover_image"`
-	ReadTimeMinutes int       `json:"read_time_minutes" db:"read_time_minutes"`
-	LikesCount      int       `json:"likes_count" db:"likes_count"`
-	CommentsCount   int       `json:"comments_count" db:"comments_count"`
-	IsLiked         bool      `json:"is_liked" db:"-"`
-	Author          *User     `json:"author,omitempty"`
+	ID              int               `json:"id"`
+	Title           string            `json:"title" validate:"required,min=3,max=255"`
+	Slug            string            `json:"slug" validate:"required,min=3,max=255"`
+	Content         string            `json:"content" validate:"required,min=10"`
+	Preview         string            `json:"preview"`
+	Language        string            `json:"language" db:"language"`
+	AuthorID        int               `json:"author_id" validate:"required"`
+	Published       bool              `json:"published"`
+	PublishedAt     time.Time         `json:"published_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	DeletedAt       *time.Time        `json:"deleted_at,omitempty"`
+	CoverImage      string            `json:"cover_image,omitempty" db:"cover_image"`
+	ReadTimeMinutes int               `json:"read_time_minutes" db:"read_time_minutes"`
+	LikesCount      int               `json:"likes_count" db:"likes_count"`
+	CommentsCount   int               `json:"comments_count" db:"comments_count"`
+	IsLiked         bool              `json:"is_liked" db:"-"`
+	IsBookmarked    bool              `json:"is_bookmarked" db:"-"`
+	Reactions       []ReactionSummary `json:"reactions" db:"-"`
+	Author          *User             `json:"author,omitempty"`
+	Alternates      []PostAlternate   `json:"alternates,omitempty" db:"-"`
+	// Series groups related posts, empty when the post is not part of one
+	Series string `json:"series,omitempty" db:"series"`
+
+	// Discussion settings, zero days means no limit
+	CommentsDisabled          bool `json:"comments_disabled" db:"comments_disabled"`
+	CommentsCloseAfterDays    int  `json:"comments_close_after_days" db:"comments_close_after_days"`
+	CommentsMinAccountAgeDays int  `json:"comments_min_account_age_days" db:"comments_min_account_age_days"`
+	// CommentsClosedReason tells the viewer why they cannot comment, empty when they can
+	CommentsClosedReason string `json:"comments_closed_reason,omitempty" db:"-"`
+}
+
+// Reasons a post does not accept comments
+const (
+	CommentsClosedDisabled      = "disabled"        // turned off for the post
+	CommentsClosedExpired       = "expired"         // closed some days after publication
+	CommentsClosedAccountTooNew = "account_too_new" // the user's account is younger than required
+)
+
+// CommentingClosed returns why user cannot comment on the post at now, or
+// an empty string when they can. Without a user only the reasons that
+// apply to everyone are checked. Admins are not held to the account age.
+func (p *Post) CommentingClosed(user *User, now time.Time) string {
+	if p.CommentsDisabled {
+		return CommentsClosedDisabled
+	}
+	if p.CommentsCloseAfterDays > 0 && !p.PublishedAt.IsZero() &&
+		!now.Before(p.PublishedAt.AddDate(0, 0, p.CommentsCloseAfterDays)) {
+		return CommentsClosedExpired
+	}
+	if user != nil && user.Role != RoleAdmin && p.CommentsMinAccountAgeDays > 0 &&
+		now.Before(user.CreatedAt.AddDate(0, 0, p.CommentsMinAccountAgeDays)) {
+		return CommentsClosedAccountTooNew
+	}
+	return ""
 }
 
 // Media represents media attached to a post
@@ -39,6 +78,12 @@ type CreatePostRequest struct {
 	Preview    string `json:"preview"`
 	Published  bool   `json:"published"`
 	CoverImage string `json:"cover_image" validate:"omitempty"`
+	Language   string `json:"language" validate:"omitempty,min=2,max=8"`
+	Series     string `json:"series" validate:"omitempty,max=255"`
+
+	CommentsDisabled          bool `json:"comments_disabled"`
+	CommentsCloseAfterDays    int  `json:"comments_close_after_days" validate:"min=0,max=3650"`
+	CommentsMinAccountAgeDays int  `json:"comments_min_account_age_days" validate:"min=0,max=3650"`
 }
 
 // UpdatePostRequest represents the request to update a post
@@ -48,14 +93,26 @@ type UpdatePostRequest struct {
 	Preview    string `json:"preview"`
 	Published  bool   `json:"published"`
 	CoverImage string `json:"cover_image" validate:"omitempty"`
+	Language   string `json:"language" validate:"omitempty,min=2,max=8"`
+	// Series is kept when omitted, an empty string removes the post from its series
+	Series *string `json:"series" validate:"omitempty,max=255"`
+
+	// Discussion settings are kept when omitted
+	CommentsDisabled          *bool `json:"comments_disabled"`
+	CommentsCloseAfterDays    *int  `json:"comments_close_after_days" validate:"omitempty,min=0,max=3650"`
+	CommentsMinAccountAgeDays *int  `json:"comments_min_account_age_days" validate:"omitempty,min=0,max=3650"`
 }
 
 // ListPostsRequest represents query parameters for listing posts
 type ListPostsRequest struct {
-	Page      int   `json:"page" validate:"omitempty,min=1"`
-	Limit     int   `json:"limit" validate:"omitempty,min=1,max=100"`
-	Published *bool `json:"published,omitempty"`
-	UserID    int   `json:"user_id,omitempty"`
+	Page         int                `json:"page" validate:"omitempty,min=1"`
+	Limit        int                `json:"limit" validate:"omitempty,min=1,max=100"`
+	Published    *bool              `json:"published,omitempty"`
+	UserID       int                `json:"user_id,omitempty"`
+	Series       string             `json:"series,omitempty"`
+	Deleted      bool               `json:"-"`
+	BookmarkedBy int                `json:"-"` // only posts bookmarked by this user
+	Language     LanguagePreference `json:"-"`
 }
 
 // PostsListResponse represents paginated posts response
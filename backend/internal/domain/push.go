@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// PushSubscription is a browser registered for Web Push notifications of a user
+type PushSubscription struct {
+	ID        int
+	UserID    int
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	UserAgent string
+	CreatedAt time.Time
+
+	// Language of the user's notifications, joined from notification settings
+	Language string
+}
+
+// PushSubscriptionKeys are the encryption keys of a browser push subscription
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh" validate:"required,max=128"`
+	Auth   string `json:"auth" validate:"required,max=64"`
+}
+
+// PushSubscriptionRequest is the JSON of a browser PushSubscription
+type PushSubscriptionRequest struct {
+	Endpoint string               `json:"endpoint" validate:"required,url,max=2048"`
+	Keys     PushSubscriptionKeys `json:"keys"`
+}
+
+// PushUnsubscribeRequest removes the subscription of a browser
+type PushUnsubscribeRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,max=2048"`
+}
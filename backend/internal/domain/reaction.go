@@ -0,0 +1,20 @@
+package domain
+
+// LikeEmoji is the reaction that backs the classic like endpoints and likes_count
+const LikeEmoji = "👍"
+
+// ReactionTarget is the kind of entity a reaction belongs to
+type ReactionTarget string
+
+// Reaction targets
+const (
+	ReactionTargetPost    ReactionTarget = "post"
+	ReactionTargetComment ReactionTarget = "comment"
+)
+
+// ReactionSummary is the aggregated count of a single emoji
+type ReactionSummary struct {
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+	Reacted bool   `json:"reacted"` // the current user reacted with this emoji
+}
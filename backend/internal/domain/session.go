@@ -4,9 +4,20 @@ import "time"
 
 // Session represents user session
 type Session struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id" validate:"required"`
-	Token     string    `json:"token" validate:"required"`
-	ExpiresAt time.Time `json:"expires_at" validate:"required"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id" validate:"required"`
+	Token      string    `json:"-" validate:"required"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	ExpiresAt  time.Time `json:"expires_at" validate:"required"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Current marks the session the request was made with
+	Current bool `json:"current"`
+}
+
+// SessionClient describes the client a session is created for
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
 }
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// Subscriber statuses
+const (
+	SubscriberStatusPending      = "pending"
+	SubscriberStatusConfirmed    = "confirmed"
+	SubscriberStatusUnsubscribed = "unsubscribed"
+)
+
+// Subscriber is an email address receiving new posts
+type Subscriber struct {
+	ID               int
+	Email            string
+	Status           string
+	ConfirmToken     string
+	ConfirmExpiresAt *time.Time
+	ConfirmSentAt    *time.Time
+	UnsubscribeToken string
+	ConfirmedAt      *time.Time
+	CreatedAt        time.Time
+}
+
+// Newsletter delivery statuses
+const (
+	NewsletterDeliveryPending = "pending"
+	NewsletterDeliverySent    = "sent"
+	NewsletterDeliveryFailed  = "failed"
+	NewsletterDeliverySkipped = "skipped"
+)
+
+// NewsletterDelivery is a post queued to be emailed to a subscriber
+type NewsletterDelivery struct {
+	ID            int
+	PostID        int
+	SubscriberID  int
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+
+	// Joined for rendering
+	Email            string
+	UnsubscribeToken string
+	PostTitle        string
+	PostSlug         string
+	PostPreview      string
+	PostCoverImage   string
+	// Withdrawn is set when the post was unpublished or the subscriber left since
+	Withdrawn bool
+}
+
+// SubscribeRequest represents a newsletter subscription request
+type SubscribeRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
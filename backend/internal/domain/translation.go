@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// PostTranslation represents a localized version of a post
+type PostTranslation struct {
+	ID        int       `json:"id"`
+	PostID    int       `json:"post_id"`
+	Language  string    `json:"language"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Content   string    `json:"content"`
+	Preview   string    `json:"preview"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PostAlternate describes a language version of a post (used for hreflang links)
+type PostAlternate struct {
+	Language string `json:"hreflang"`
+	Slug     string `json:"slug"`
+	Default  bool   `json:"default,omitempty"`
+}
+
+// UpsertTranslationRequest represents the request to add or edit a post translation
+type UpsertTranslationRequest struct {
+	Title   string `json:"title" validate:"required,min=3,max=255"`
+	Content string `json:"content" validate:"required,min=10"`
+	Preview string `json:"preview"`
+}
+
+// LanguagePreference describes which content language a client asked for
+type LanguagePreference struct {
+	// Explicit is the language requested via ?lang=, honored whenever available
+	Explicit string
+	// Accepted lists languages from Accept-Language ordered by quality
+	Accepted []string
+}
@@ -21,4 +21,61 @@ type User struct {
 	AvatarURL string    `json:"avatar_url"`
 	Role      Role      `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
+	Ban       *UserBan  `json:"ban,omitempty"` // the active ban, set for the signed in user and admins
+}
+
+// DeletedUserEmail identifies the account that keeps the comments of
+// deleted users. It is created on first use and cannot be deleted.
+const DeletedUserEmail = "deleted@users.invalid"
+
+// DeletedUserName is shown as the author of comments of deleted users
+const DeletedUserName = "Deleted user"
+
+// What happens to the comments of a deleted user
+const (
+	UserCommentsAnonymize = "anonymize" // kept, attributed to the deleted user account
+	UserCommentsRemove    = "remove"    // removed, replies by others stay under a placeholder
+)
+
+// UserSummary is a user with activity counts, as listed to admins
+type UserSummary struct {
+	User
+	CommentsCount int `json:"comments_count"` // not deleted, in any moderation status
+	LikesCount    int `json:"likes_count"`    // likes given to posts and comments
+	SessionsCount int `json:"sessions_count"` // not expired
+}
+
+// UserDetails is a user with the accounts used to sign in and the active sessions
+type UserDetails struct {
+	UserSummary
+	Providers []OAuthProvider `json:"providers"`
+	Sessions  []Session       `json:"sessions"`
+}
+
+// ListUsersRequest represents the admin user list filter.
+// Search matches the email or name.
+type ListUsersRequest struct {
+	Search string `json:"search" validate:"max=255"`
+	Role   Role   `json:"role" validate:"omitempty,oneof=admin user"`
+	Page   int    `json:"page" validate:"omitempty,min=1"`
+	Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// UserListResponse represents a page of users
+type UserListResponse struct {
+	Users      []UserSummary `json:"users"`
+	TotalCount int           `json:"total_count"`
+	Page       int           `json:"page"`
+	Limit      int           `json:"limit"`
+	TotalPages int           `json:"total_pages"`
+}
+
+// UpdateUserRoleRequest represents the request to change a user's role
+type UpdateUserRoleRequest struct {
+	Role Role `json:"role" validate:"required,oneof=admin user"`
+}
+
+// DeleteUserRequest represents the request to delete a user
+type DeleteUserRequest struct {
+	Comments string `json:"comments" validate:"omitempty,oneof=anonymize remove"`
 }
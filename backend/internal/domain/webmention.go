@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// Webmention statuses
+const (
+	// Received mentions
+	WebmentionStatusPending  = "pending"
+	WebmentionStatusVerified = "verified"
+	WebmentionStatusRejected = "rejected"
+
+	// Sent mentions (pending is shared)
+	WebmentionStatusSent       = "sent"
+	WebmentionStatusNoEndpoint = "no_endpoint"
+	WebmentionStatusFailed     = "failed"
+)
+
+// Webmention is a mention of a post received from another site
+type Webmention struct {
+	ID            int        `json:"id"`
+	PostID        int        `json:"post_id"`
+	Source        string     `json:"source"`
+	Target        string     `json:"target"`
+	Title         string     `json:"title,omitempty"`
+	Status        string     `json:"-"`
+	Attempts      int        `json:"-"`
+	NextAttemptAt time.Time  `json:"-"`
+	LastError     string     `json:"-"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// OutgoingWebmention is a mention sent for a link found in a post
+type OutgoingWebmention struct {
+	ID            int
+	PostID        int
+	Source        string
+	Target        string
+	Endpoint      string
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+}
@@ -0,0 +1,143 @@
+// Package activitypub contains the ActivityStreams vocabulary used by the blog
+// and a client for signed server-to-server requests
+package activitypub
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Media types
+const (
+	ContentType    = "application/activity+json"
+	JRDContentType = "application/jrd+json"
+	acceptHeader   = `application/activity+json, application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+)
+
+// JSON-LD contexts and well-known addresses
+const (
+	ActivityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	SecurityContext        = "https://w3id.org/security/v1"
+	PublicCollection       = "https://www.w3.org/ns/activitystreams#Public"
+)
+
+// Object and activity types
+const (
+	TypePerson                = "Person"
+	TypeArticle               = "Article"
+	TypeNote                  = "Note"
+	TypeImage                 = "Image"
+	TypeFollow                = "Follow"
+	TypeUndo                  = "Undo"
+	TypeAccept                = "Accept"
+	TypeCreate                = "Create"
+	TypeOrderedCollection     = "OrderedCollection"
+	TypeOrderedCollectionPage = "OrderedCollectionPage"
+)
+
+// Actor is an ActivityPub actor (the blog owner or a remote account)
+type Actor struct {
+	Context           any        `json:"@context,omitempty"`
+	ID                string     `json:"id"`
+	Type              string     `json:"type"`
+	PreferredUsername string     `json:"preferredUsername"`
+	Name              string     `json:"name,omitempty"`
+	Summary           string     `json:"summary,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	Icon              *Image     `json:"icon,omitempty"`
+	Inbox             string     `json:"inbox"`
+	Outbox            string     `json:"outbox,omitempty"`
+	Followers         string     `json:"followers,omitempty"`
+	Endpoints         *Endpoints `json:"endpoints,omitempty"`
+	PublicKey         PublicKey  `json:"publicKey"`
+}
+
+// PublicKey is the key remote servers verify actor signatures with
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// Endpoints lists optional actor endpoints
+type Endpoints struct {
+	SharedInbox string `json:"sharedInbox,omitempty"`
+}
+
+// Image is an avatar or an attachment
+type Image struct {
+	Type      string `json:"type"`
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url"`
+}
+
+// Object is a content object such as an Article or a Note
+type Object struct {
+	Context      any        `json:"@context,omitempty"`
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	AttributedTo string     `json:"attributedTo,omitempty"`
+	InReplyTo    string     `json:"inReplyTo,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Summary      string     `json:"summary,omitempty"`
+	Content      string     `json:"content,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	Image        *Image     `json:"image,omitempty"`
+	Published    *time.Time `json:"published,omitempty"`
+	To           []string   `json:"to,omitempty"`
+	Cc           []string   `json:"cc,omitempty"`
+}
+
+// Activity wraps an object; Object is either an embedded object or its id
+type Activity struct {
+	Context   any             `json:"@context,omitempty"`
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	Object    json.RawMessage `json:"object"`
+	Published *time.Time      `json:"published,omitempty"`
+	To        []string        `json:"to,omitempty"`
+	Cc        []string        `json:"cc,omitempty"`
+}
+
+// OrderedCollection is a collection or a page of it (outbox, followers)
+type OrderedCollection struct {
+	Context      any         `json:"@context,omitempty"`
+	ID           string      `json:"id"`
+	Type         string      `json:"type"`
+	TotalItems   int         `json:"totalItems"`
+	First        string      `json:"first,omitempty"`
+	PartOf       string      `json:"partOf,omitempty"`
+	Next         string      `json:"next,omitempty"`
+	OrderedItems []*Activity `json:"orderedItems,omitempty"`
+}
+
+// WebFinger is a JSON Resource Descriptor returned by /.well-known/webfinger
+type WebFinger struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases,omitempty"`
+	Links   []WebFingerLink `json:"links"`
+}
+
+// WebFingerLink is a link of a JSON Resource Descriptor
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href"`
+}
+
+// ObjectID returns the id of an embedded object or the object reference itself
+func ObjectID(raw json.RawMessage) string {
+	var id string
+	if err := json.Unmarshal(raw, &id); err == nil {
+		return id
+	}
+
+	var object struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil {
+		return object.ID
+	}
+	return ""
+}
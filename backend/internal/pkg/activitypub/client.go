@@ -0,0 +1,95 @@
+package activitypub
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"personal-web-platform/internal/pkg/httpsig"
+)
+
+// maxResponseSize limits remote documents read by the client
+const maxResponseSize = 1 << 20
+
+// Client fetches remote actors and delivers activities with signed requests
+type Client struct {
+	http  *http.Client
+	keyID string
+	key   *rsa.PrivateKey
+}
+
+// NewClient creates a client signing requests with key under keyID
+func NewClient(httpClient *http.Client, keyID string, key *rsa.PrivateKey) *Client {
+	return &Client{
+		http:  httpClient,
+		keyID: keyID,
+		key:   key,
+	}
+}
+
+// FetchActor retrieves a remote actor document. Requests are signed so that
+// servers running in authorized fetch mode answer too.
+func (c *Client) FetchActor(ctx context.Context, id string) (*Actor, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", acceptHeader)
+	if err := httpsig.Sign(req, c.keyID, c.key, nil); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch actor: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch actor: unexpected status %d", resp.StatusCode)
+	}
+
+	var actor Actor
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&actor); err != nil {
+		return nil, fmt.Errorf("failed to decode actor: %w", err)
+	}
+	if actor.ID != id {
+		return nil, fmt.Errorf("actor id %q does not match requested %q", actor.ID, id)
+	}
+
+	return &actor, nil
+}
+
+// Deliver posts an activity to a remote inbox
+func (c *Client) Deliver(ctx context.Context, inbox string, activity *Activity) error {
+	body, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("failed to encode activity: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, inbox, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", ContentType)
+	if err := httpsig.Sign(req, c.keyID, c.key, body); err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver activity: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to deliver activity: unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
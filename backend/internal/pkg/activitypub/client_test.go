@@ -0,0 +1,116 @@
+package activitypub
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/pkg/httpsig"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*Client, string) {
+	t.Helper()
+	privatePEM, publicPEM, err := httpsig.GenerateKey()
+	require.NoError(t, err)
+	key, err := httpsig.ParsePrivateKey(privatePEM)
+	require.NoError(t, err)
+	return NewClient(http.DefaultClient, "https://blog.example/ap/actor#main-key", key), publicPEM
+}
+
+func TestClient_FetchActor(t *testing.T) {
+	client, publicPEM := newTestClient(t)
+	publicKey, err := httpsig.ParsePublicKey(publicPEM)
+	require.NoError(t, err)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fetches are signed for servers in authorized fetch mode
+		sig, err := httpsig.Parse(r)
+		if err != nil || httpsig.Verify(r, nil, sig, publicKey) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Contains(t, r.Header.Get("Accept"), ContentType)
+
+		_ = json.NewEncoder(w).Encode(Actor{ID: server.URL + r.URL.Path, Type: TypePerson, PreferredUsername: "alice"})
+	}))
+	defer server.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		actor, err := client.FetchActor(context.Background(), server.URL+"/users/alice")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", actor.PreferredUsername)
+	})
+
+	t.Run("Id mismatch", func(t *testing.T) {
+		mismatch := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(Actor{ID: "https://elsewhere.example/users/alice"})
+		}))
+		defer mismatch.Close()
+
+		_, err := client.FetchActor(context.Background(), mismatch.URL+"/users/alice")
+		assert.Error(t, err)
+	})
+
+	t.Run("Gone", func(t *testing.T) {
+		gone := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer gone.Close()
+
+		_, err := client.FetchActor(context.Background(), gone.URL+"/users/alice")
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_Deliver(t *testing.T) {
+	client, publicPEM := newTestClient(t)
+	publicKey, err := httpsig.ParsePublicKey(publicPEM)
+	require.NoError(t, err)
+
+	var received Activity
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sig, err := httpsig.Parse(r)
+		if err != nil || httpsig.Verify(r, body, sig, publicKey) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, ContentType, r.Header.Get("Content-Type"))
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	activity := &Activity{
+		ID:     "https://blog.example/ap/posts/1#create",
+		Type:   TypeCreate,
+		Actor:  "https://blog.example/ap/actor",
+		Object: json.RawMessage(`"https://blog.example/ap/posts/1"`),
+	}
+
+	require.NoError(t, client.Deliver(context.Background(), server.URL+"/inbox", activity))
+	assert.Equal(t, TypeCreate, received.Type)
+	assert.Equal(t, "https://blog.example/ap/posts/1", ObjectID(received.Object))
+
+	t.Run("Rejected", func(t *testing.T) {
+		rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer rejecting.Close()
+
+		assert.Error(t, client.Deliver(context.Background(), rejecting.URL+"/inbox", activity))
+	})
+}
+
+func TestObjectID(t *testing.T) {
+	assert.Equal(t, "https://a.example/1", ObjectID(json.RawMessage(`"https://a.example/1"`)))
+	assert.Equal(t, "https://a.example/2", ObjectID(json.RawMessage(`{"id":"https://a.example/2","type":"Note"}`)))
+	assert.Equal(t, "", ObjectID(json.RawMessage(`[1,2]`)))
+}
@@ -0,0 +1,183 @@
+// Package httpsig implements HTTP Signatures (draft-cavage-http-signatures-12)
+// with rsa-sha256, the scheme used for ActivityPub server-to-server requests
+package httpsig
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Algorithm is the only supported signature algorithm
+const Algorithm = "rsa-sha256"
+
+// MaxClockSkew is how far the Date header may drift from the local clock
+const MaxClockSkew = 12 * time.Hour
+
+// ErrInvalidSignature is returned when a request signature can't be verified
+var ErrInvalidSignature = errors.New("invalid http signature")
+
+// Signature is a parsed Signature header
+type Signature struct {
+	KeyID     string
+	Algorithm string
+	Headers   []string
+	Signature []byte
+}
+
+// Digest returns the value of the Digest header for body
+func Digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Sign adds Date, Digest (when body is not nil) and Signature headers to req
+func Sign(req *http.Request, keyID string, key *rsa.PrivateKey, body []byte) error {
+	if req.Header.Get("Date") == "" {
+		req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	}
+
+	headers := []string{"(request-target)", "host", "date"}
+	if body != nil {
+		req.Header.Set("Digest", Digest(body))
+		headers = append(headers, "digest")
+	}
+
+	signingString, err := buildSigningString(req, headers)
+	if err != nil {
+		return err
+	}
+
+	hashed := sha256.Sum256([]byte(signingString))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	req.Header.Set("Signature", fmt.Sprintf(`keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		keyID, Algorithm, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
+
+// Parse extracts the Signature header of req
+func Parse(req *http.Request) (*Signature, error) {
+	header := req.Header.Get("Signature")
+	if header == "" {
+		// Some servers send the signature as an Authorization scheme
+		header, _ = strings.CutPrefix(req.Header.Get("Authorization"), "Signature ")
+	}
+	if header == "" {
+		return nil, fmt.Errorf("%w: missing signature header", ErrInvalidSignature)
+	}
+
+	sig := &Signature{}
+	for _, param := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed parameter %q", ErrInvalidSignature, param)
+		}
+		value = strings.Trim(value, `"`)
+
+		switch key {
+		case "keyId":
+			sig.KeyID = value
+		case "algorithm":
+			sig.Algorithm = value
+		case "headers":
+			sig.Headers = strings.Fields(strings.ToLower(value))
+		case "signature":
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: malformed signature: %v", ErrInvalidSignature, err)
+			}
+			sig.Signature = decoded
+		}
+	}
+
+	if sig.KeyID == "" || len(sig.Signature) == 0 {
+		return nil, fmt.Errorf("%w: keyId and signature are required", ErrInvalidSignature)
+	}
+	if len(sig.Headers) == 0 {
+		sig.Headers = []string{"date"}
+	}
+
+	return sig, nil
+}
+
+// Verify checks sig against the request, its body and the signer public key
+func Verify(req *http.Request, body []byte, sig *Signature, key *rsa.PublicKey) error {
+	// hs2019 means "derive from the key", which is RSA for every supported key
+	if sig.Algorithm != "" && sig.Algorithm != Algorithm && sig.Algorithm != "hs2019" {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, sig.Algorithm)
+	}
+
+	signed := make(map[string]bool, len(sig.Headers))
+	for _, h := range sig.Headers {
+		signed[h] = true
+	}
+	if !signed["(request-target)"] || !signed["date"] {
+		return fmt.Errorf("%w: (request-target) and date must be signed", ErrInvalidSignature)
+	}
+
+	date, err := http.ParseTime(req.Header.Get("Date"))
+	if err != nil {
+		return fmt.Errorf("%w: malformed date header", ErrInvalidSignature)
+	}
+	if skew := time.Since(date); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return fmt.Errorf("%w: date is out of range", ErrInvalidSignature)
+	}
+
+	// A body is only trusted when its digest is covered by the signature
+	if len(body) > 0 {
+		if !signed["digest"] {
+			return fmt.Errorf("%w: digest must be signed", ErrInvalidSignature)
+		}
+		if subtle.ConstantTimeCompare([]byte(req.Header.Get("Digest")), []byte(Digest(body))) != 1 {
+			return fmt.Errorf("%w: digest mismatch", ErrInvalidSignature)
+		}
+	}
+
+	signingString, err := buildSigningString(req, sig.Headers)
+	if err != nil {
+		return err
+	}
+
+	hashed := sha256.Sum256([]byte(signingString))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], sig.Signature); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	return nil
+}
+
+// buildSigningString joins the signed header values as described in the draft
+func buildSigningString(req *http.Request, headers []string) (string, error) {
+	lines := make([]string, 0, len(headers))
+	for _, h := range headers {
+		var value string
+		switch h {
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		default:
+			value = req.Header.Get(h)
+		}
+		if value == "" {
+			return "", fmt.Errorf("%w: missing signed header %q", ErrInvalidSignature, h)
+		}
+		lines = append(lines, h+": "+value)
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,155 @@
+package httpsig
+
+import (
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeys(t *testing.T) (*rsa.PrivateKey, *rsa.PublicKey) {
+	t.Helper()
+	privatePEM, publicPEM, err := GenerateKey()
+	require.NoError(t, err)
+
+	private, err := ParsePrivateKey(privatePEM)
+	require.NoError(t, err)
+	public, err := ParsePublicKey(publicPEM)
+	require.NoError(t, err)
+	return private, public
+}
+
+// received converts a client request into what a server handler would see
+func received(t *testing.T, req *http.Request, body string) *http.Request {
+	t.Helper()
+	server := httptest.NewRequest(req.Method, req.URL.String(), strings.NewReader(body))
+	server.Header = req.Header.Clone()
+	return server
+}
+
+func TestSignAndVerify(t *testing.T) {
+	private, public := newKeys(t)
+	body := `{"type":"Follow"}`
+
+	req, err := http.NewRequest(http.MethodPost, "https://remote.example/users/alice/inbox", strings.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, Sign(req, "https://blog.example/ap/actor#main-key", private, []byte(body)))
+
+	assert.Equal(t, Digest([]byte(body)), req.Header.Get("Digest"))
+	assert.NotEmpty(t, req.Header.Get("Date"))
+
+	server := received(t, req, body)
+	sig, err := Parse(server)
+	require.NoError(t, err)
+	assert.Equal(t, "https://blog.example/ap/actor#main-key", sig.KeyID)
+	assert.Equal(t, []string{"(request-target)", "host", "date", "digest"}, sig.Headers)
+
+	assert.NoError(t, Verify(server, []byte(body), sig, public))
+}
+
+func TestSign_GetWithoutBody(t *testing.T) {
+	private, public := newKeys(t)
+
+	req, err := http.NewRequest(http.MethodGet, "https://remote.example/users/alice", nil)
+	require.NoError(t, err)
+	require.NoError(t, Sign(req, "key", private, nil))
+	assert.Empty(t, req.Header.Get("Digest"))
+
+	server := received(t, req, "")
+	sig, err := Parse(server)
+	require.NoError(t, err)
+	assert.NoError(t, Verify(server, nil, sig, public))
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	private, public := newKeys(t)
+	_, otherPublic := newKeys(t)
+	body := `{"type":"Create"}`
+
+	signed := func(t *testing.T) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, "https://blog.example/ap/inbox", strings.NewReader(body))
+		require.NoError(t, err)
+		require.NoError(t, Sign(req, "key", private, []byte(body)))
+		return received(t, req, body)
+	}
+
+	t.Run("Tampered body", func(t *testing.T) {
+		req := signed(t)
+		sig, err := Parse(req)
+		require.NoError(t, err)
+		assert.ErrorIs(t, Verify(req, []byte(`{"type":"Delete"}`), sig, public), ErrInvalidSignature)
+	})
+
+	t.Run("Wrong key", func(t *testing.T) {
+		req := signed(t)
+		sig, err := Parse(req)
+		require.NoError(t, err)
+		assert.ErrorIs(t, Verify(req, []byte(body), sig, otherPublic), ErrInvalidSignature)
+	})
+
+	t.Run("Different path", func(t *testing.T) {
+		req := signed(t)
+		sig, err := Parse(req)
+		require.NoError(t, err)
+		req.URL.Path = "/ap/other"
+		assert.ErrorIs(t, Verify(req, []byte(body), sig, public), ErrInvalidSignature)
+	})
+
+	t.Run("Stale date", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "https://blog.example/ap/inbox", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Date", time.Now().Add(-2*MaxClockSkew).UTC().Format(http.TimeFormat))
+		require.NoError(t, Sign(req, "key", private, []byte(body)))
+
+		server := received(t, req, body)
+		sig, err := Parse(server)
+		require.NoError(t, err)
+		assert.ErrorIs(t, Verify(server, []byte(body), sig, public), ErrInvalidSignature)
+	})
+
+	t.Run("Unsigned digest", func(t *testing.T) {
+		req := signed(t)
+		sig, err := Parse(req)
+		require.NoError(t, err)
+		sig.Headers = []string{"(request-target)", "host", "date"}
+		assert.ErrorIs(t, Verify(req, []byte(body), sig, public), ErrInvalidSignature)
+	})
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		wantErr bool
+	}{
+		{name: "Missing header", header: "", wantErr: true},
+		{name: "Missing keyId", header: `signature="YWJj"`, wantErr: true},
+		{name: "Malformed parameter", header: `keyId`, wantErr: true},
+		{name: "Invalid base64", header: `keyId="key",signature="!!"`, wantErr: true},
+		{name: "Valid", header: `keyId="key",algorithm="rsa-sha256",headers="(request-target) date",signature="YWJj"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/ap/inbox", nil)
+			if tt.header != "" {
+				req.Header.Set("Signature", tt.header)
+			}
+
+			sig, err := Parse(req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSignature)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "key", sig.KeyID)
+			assert.Equal(t, []string{"(request-target)", "date"}, sig.Headers)
+			assert.Equal(t, []byte("abc"), sig.Signature)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package language provides utilities for content language negotiation
+package language
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Normalize lowercases a language tag and converts underscores to hyphens
+func Normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
+
+// Base returns the primary subtag of a language tag ("en-US" -> "en")
+func Base(tag string) string {
+	tag = Normalize(tag)
+	if idx := strings.Index(tag, "-"); idx != -1 {
+		return tag[:idx]
+	}
+	return tag
+}
+
+// ParseAcceptLanguage parses an Accept-Language header value and returns
+// language tags ordered by quality (highest first). Wildcards and tags
+// with zero quality are skipped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag := part
+		q := 1.0
+		if idx := strings.Index(part, ";"); idx != -1 {
+			tag = strings.TrimSpace(part[:idx])
+			for _, param := range strings.Split(part[idx+1:], ";") {
+				param = strings.TrimSpace(param)
+				if !strings.HasPrefix(param, "q=") {
+					continue
+				}
+				parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+
+		if tag == "" || tag == "*" || q <= 0 {
+			continue
+		}
+		items = append(items, weighted{tag: Normalize(tag), q: q})
+	}
+
+	// Stable sort keeps header order for equal weights
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].q > items[j].q
+	})
+
+	tags := make([]string, 0, len(items))
+	for _, item := range items {
+		tags = append(tags, item.tag)
+	}
+	return tags
+}
+
+// Match returns the first preferred language that is available.
+// Tags are compared by their primary subtag, so "en-GB" matches "en".
+// Returns empty string if nothing matches.
+func Match(preferred, available []string) string {
+	for _, pref := range preferred {
+		base := Base(pref)
+		if base == "" {
+			continue
+		}
+		for _, lang := range available {
+			if Base(lang) == base {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// Contains reports whether lang is present in the list (case-insensitive)
+func Contains(list []string, lang string) bool {
+	lang = Normalize(lang)
+	for _, item := range list {
+		if Normalize(item) == lang {
+			return true
+		}
+	}
+	return false
+}
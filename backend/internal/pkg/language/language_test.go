@@ -0,0 +1,67 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []string
+	}{
+		{
+			name:     "Empty header",
+			header:   "",
+			expected: []string{},
+		},
+		{
+			name:     "Single language",
+			header:   "en",
+			expected: []string{"en"},
+		},
+		{
+			name:     "Ordered by quality",
+			header:   "en;q=0.5, ru-RU, ru;q=0.9",
+			expected: []string{"ru-ru", "ru", "en"},
+		},
+		{
+			name:     "Skips wildcard and zero quality",
+			header:   "de;q=0, *;q=0.1, en-US",
+			expected: []string{"en-us"},
+		},
+		{
+			name:     "Invalid quality is treated as zero",
+			header:   "fr;q=abc, en",
+			expected: []string{"en"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	available := []string{"ru", "en"}
+
+	assert.Equal(t, "en", Match([]string{"en-GB"}, available))
+	assert.Equal(t, "ru", Match([]string{"de", "ru-RU", "en"}, available))
+	assert.Equal(t, "", Match([]string{"de", "fr"}, available))
+	assert.Equal(t, "", Match(nil, available))
+}
+
+func TestBase(t *testing.T) {
+	assert.Equal(t, "en", Base("en-US"))
+	assert.Equal(t, "pt", Base("pt_BR"))
+	assert.Equal(t, "ru", Base("RU"))
+}
+
+func TestContains(t *testing.T) {
+	assert.True(t, Contains([]string{"ru", "en"}, "EN"))
+	assert.False(t, Contains([]string{"ru", "en"}, "de"))
+}
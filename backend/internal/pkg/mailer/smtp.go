@@ -0,0 +1,130 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// implicitTLSPort is the SMTPS port where TLS starts before the SMTP greeting
+const implicitTLSPort = 465
+
+// SMTPOptions configures an SMTP server connection
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	Username string // empty disables authentication
+	Password string
+	Timeout  time.Duration
+}
+
+// SMTPSender delivers messages through an SMTP server.
+// STARTTLS is used whenever the server offers it.
+type SMTPSender struct {
+	opts SMTPOptions
+}
+
+// NewSMTPSender creates an SMTP sender
+func NewSMTPSender(opts SMTPOptions) *SMTPSender {
+	return &SMTPSender{opts: opts}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	from, err := Address(msg.From)
+	if err != nil {
+		return err
+	}
+	to, err := Address(msg.To)
+	if err != nil {
+		return err
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := s.deadline(ctx); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.opts.Host)
+	if err != nil {
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if _, isTLS := conn.(*tls.Conn); !isTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: s.opts.Host, MinVersion: tls.VersionTLS12}); err != nil {
+				return fmt.Errorf("failed to start tls: %w", err)
+			}
+		}
+	}
+
+	if s.opts.Username != "" {
+		auth := smtp.PlainAuth("", s.opts.Username, s.opts.Password, s.opts.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("smtp RCPT TO failed: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// deadline bounds the whole SMTP session by the timeout and the context
+func (s *SMTPSender) deadline(ctx context.Context) (time.Time, bool) {
+	deadline, ok := ctx.Deadline()
+	if s.opts.Timeout > 0 {
+		if byTimeout := time.Now().Add(s.opts.Timeout); !ok || byTimeout.Before(deadline) {
+			return byTimeout, true
+		}
+	}
+	return deadline, ok
+}
+
+func (s *SMTPSender) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port))
+	dialer := &net.Dialer{Timeout: s.opts.Timeout}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.opts.Port == implicitTLSPort {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.opts.Host, MinVersion: tls.VersionTLS12}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,71 @@
+package mailer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPSender_Send(t *testing.T) {
+	server := testutil.NewFakeSMTPServer(t)
+	sender := NewSMTPSender(SMTPOptions{
+		Host:     server.Host,
+		Port:     server.Port,
+		Username: "mailer",
+		Password: "secret",
+		Timeout:  5 * time.Second,
+	})
+
+	err := sender.Send(context.Background(), &Message{
+		From:    "Блог <blog@example.com>",
+		To:      "reader@example.com",
+		Subject: "Новый пост",
+		Text:    "Hello,\nread the post: https://blog.example/blog/hello\n.leading dot",
+		HTML:    `<p>Hello, read <a href="https://blog.example/blog/hello">the post</a></p>`,
+		Headers: map[string]string{"list-unsubscribe": "<https://blog.example/unsubscribe>"},
+	})
+	require.NoError(t, err)
+
+	emails := server.WaitForEmails(t, 1)
+	email := emails[0]
+	assert.Equal(t, "blog@example.com", email.From)
+	assert.Equal(t, []string{"reader@example.com"}, email.To)
+
+	username, password := server.Credentials()
+	assert.Equal(t, "mailer", username)
+	assert.Equal(t, "secret", password)
+
+	date, err := email.Message.Header.Date()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), date, time.Minute)
+	assert.Equal(t, "<https://blog.example/unsubscribe>", email.Message.Header.Get("List-Unsubscribe"))
+	assert.NotEmpty(t, email.Message.Header.Get("Message-Id"))
+
+	assert.Equal(t, "Hello,\nread the post: https://blog.example/blog/hello\n.leading dot", email.Text)
+	assert.Contains(t, email.HTML, `<a href="https://blog.example/blog/hello">`)
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	t.Run("Text only", func(t *testing.T) {
+		data, err := (&Message{From: "blog@example.com", To: "reader@example.com", Subject: "Hi", Text: "plain"}).Bytes()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Content-Type: text/plain; charset=utf-8\r\n")
+		assert.NotContains(t, string(data), "multipart")
+	})
+
+	t.Run("Non-ASCII subject is encoded", func(t *testing.T) {
+		data, err := (&Message{From: "blog@example.com", To: "reader@example.com", Subject: "Привет", Text: "x"}).Bytes()
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "Subject: =?utf-8?q?")
+	})
+
+	t.Run("Invalid recipient", func(t *testing.T) {
+		_, err := (&Message{From: "blog@example.com", To: "not an address", Text: "x"}).Bytes()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,106 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+
+	"personal-web-platform/internal/pkg/language"
+)
+
+// subjectBlock is the block of a text template holding the email subject
+const subjectBlock = "subject"
+
+// Templates renders localized emails. An email named "welcome" in English is
+// made of "welcome.en.txt.tmpl" and an optional "welcome.en.html.tmpl"; the
+// text template defines the subject in a {{define "subject"}} block.
+type Templates struct {
+	text     map[string]*texttemplate.Template
+	html     map[string]*htmltemplate.Template
+	fallback string
+}
+
+// ParseTemplates loads all *.tmpl files in the root of fsys.
+// Emails missing in a language are rendered in the fallback language.
+func ParseTemplates(fsys fs.FS, fallback string) (*Templates, error) {
+	t := &Templates{
+		text:     make(map[string]*texttemplate.Template),
+		html:     make(map[string]*htmltemplate.Template),
+		fallback: language.Normalize(fallback),
+	}
+
+	files, err := fs.Glob(fsys, "*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+
+	for _, file := range files {
+		// name.lang.kind.tmpl
+		parts := strings.Split(strings.TrimSuffix(path.Base(file), ".tmpl"), ".")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid email template name %q, want name.lang.txt.tmpl or name.lang.html.tmpl", file)
+		}
+		key := parts[0] + "." + language.Normalize(parts[1])
+
+		switch parts[2] {
+		case "txt":
+			tmpl, err := texttemplate.ParseFS(fsys, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if tmpl.Lookup(subjectBlock) == nil {
+				return nil, fmt.Errorf("email template %s has no %q block", file, subjectBlock)
+			}
+			t.text[key] = tmpl
+		case "html":
+			tmpl, err := htmltemplate.ParseFS(fsys, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			t.html[key] = tmpl
+		default:
+			return nil, fmt.Errorf("invalid email template kind in %q", file)
+		}
+	}
+
+	return t, nil
+}
+
+// Render executes an email in lang, falling back to its base language
+// ("en-US" -> "en") and then to the fallback language
+func (t *Templates) Render(name, lang string, data any) (*Message, error) {
+	for _, candidate := range []string{language.Normalize(lang), language.Base(lang), t.fallback} {
+		key := name + "." + candidate
+		text, ok := t.text[key]
+		if !ok {
+			continue
+		}
+
+		var subject, body bytes.Buffer
+		if err := text.ExecuteTemplate(&subject, subjectBlock, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s subject: %w", key, err)
+		}
+		if err := text.Execute(&body, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s text: %w", key, err)
+		}
+
+		msg := &Message{
+			Subject: strings.Join(strings.Fields(subject.String()), " "),
+			Text:    strings.TrimLeft(body.String(), "\n"),
+		}
+		if html, ok := t.html[key]; ok {
+			var buf bytes.Buffer
+			if err := html.Execute(&buf, data); err != nil {
+				return nil, fmt.Errorf("failed to render %s html: %w", key, err)
+			}
+			msg.HTML = buf.String()
+		}
+		return msg, nil
+	}
+
+	return nil, fmt.Errorf("email template %q not found for language %q", name, lang)
+}
@@ -0,0 +1,59 @@
+package mailer
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	fsys := fstest.MapFS{
+		"welcome.en.txt.tmpl":  {Data: []byte("{{define \"subject\"}}\n  Welcome, {{.Name}}\n{{end}}\nHello, {{.Name}}!\n")},
+		"welcome.en.html.tmpl": {Data: []byte(`<p>Hello, {{.Name}}!</p>`)},
+		"welcome.ru.txt.tmpl":  {Data: []byte(`{{define "subject"}}Добро пожаловать, {{.Name}}{{end}}Привет, {{.Name}}!`)},
+		"digest.en.txt.tmpl":   {Data: []byte(`{{define "subject"}}Digest{{end}}Nothing new`)},
+	}
+	templates, err := ParseTemplates(fsys, "en")
+	require.NoError(t, err)
+
+	t.Run("Exact language", func(t *testing.T) {
+		msg, err := templates.Render("welcome", "en", map[string]string{"Name": "<Ann>"})
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome, <Ann>", msg.Subject)
+		assert.Equal(t, "Hello, <Ann>!\n", msg.Text)
+		assert.Equal(t, "<p>Hello, &lt;Ann&gt;!</p>", msg.HTML)
+	})
+
+	t.Run("Base language without HTML", func(t *testing.T) {
+		msg, err := templates.Render("welcome", "ru-RU", map[string]string{"Name": "Аня"})
+		require.NoError(t, err)
+		assert.Equal(t, "Добро пожаловать, Аня", msg.Subject)
+		assert.Equal(t, "Привет, Аня!", msg.Text)
+		assert.Empty(t, msg.HTML)
+	})
+
+	t.Run("Fallback language", func(t *testing.T) {
+		msg, err := templates.Render("digest", "ru", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Digest", msg.Subject)
+	})
+
+	t.Run("Unknown template", func(t *testing.T) {
+		_, err := templates.Render("missing", "en", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTemplates_Invalid(t *testing.T) {
+	t.Run("No subject", func(t *testing.T) {
+		_, err := ParseTemplates(fstest.MapFS{"welcome.en.txt.tmpl": {Data: []byte("Hello")}}, "en")
+		assert.ErrorContains(t, err, "subject")
+	})
+
+	t.Run("Bad name", func(t *testing.T) {
+		_, err := ParseTemplates(fstest.MapFS{"welcome.txt.tmpl": {Data: []byte(`{{define "subject"}}Hi{{end}}`)}}, "en")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSender_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sender := NewFileSender(dir)
+
+	msg := &Message{From: "blog@example.com", To: "reader@example.com", Subject: "Hello", Text: "Hi there"}
+	require.NoError(t, sender.Send(context.Background(), msg))
+	require.NoError(t, sender.Send(context.Background(), msg))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", parsed.Header.Get("Subject"))
+	assert.Equal(t, "<reader@example.com>", parsed.Header.Get("To"))
+}
+
+func TestLogSender_Send(t *testing.T) {
+	var buf bytes.Buffer
+	sender := NewLogSender(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	require.NoError(t, sender.Send(context.Background(), &Message{From: "blog@example.com", To: "reader@example.com", Subject: "Hello", Text: "Hi"}))
+	assert.Contains(t, buf.String(), "to=reader@example.com")
+	assert.Contains(t, buf.String(), "subject=Hello")
+
+	// Invalid messages fail like they would with a real transport
+	assert.Error(t, sender.Send(context.Background(), &Message{To: "reader@example.com"}))
+}
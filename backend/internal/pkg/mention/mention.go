@@ -0,0 +1,55 @@
+// Package mention finds @mentions in comment text.
+package mention
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxPerText limits how many people one comment can mention
+	MaxPerText = 10
+	// maxHandleLength matches the handle column, longer words are not names
+	maxHandleLength = 100
+)
+
+// pattern matches "@handle" not preceded by a letter or digit, so that email
+// addresses are not mistaken for mentions
+var pattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([\p{L}\p{N}_][\p{L}\p{N}_.\-]*)`)
+
+// Parse returns the handles mentioned in the text, without "@", in order of
+// appearance. Handles with the same key are returned once.
+func Parse(text string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+
+	for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+		// A mention at the end of a sentence: "thanks @anna."
+		handle := strings.TrimRight(m[1], ".-")
+		key := Key(handle)
+		if key == "" || seen[key] || utf8.RuneCountInString(handle) > maxHandleLength {
+			continue
+		}
+		seen[key] = true
+		handles = append(handles, handle)
+		if len(handles) == MaxPerText {
+			break
+		}
+	}
+
+	return handles
+}
+
+// Key is what a handle and a user name are compared by: lowercased, with
+// spaces and punctuation removed, so "@anna_smith" matches "Anna Smith"
+func Key(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package mention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "no mentions", text: "Great post", want: nil},
+		{name: "start of text", text: "@anna agreed", want: []string{"anna"}},
+		{name: "several", text: "cc @anna, @Ivan_Petrov and @bob", want: []string{"anna", "Ivan_Petrov", "bob"}},
+		{name: "end of sentence", text: "Thanks @anna.", want: []string{"anna"}},
+		{name: "cyrillic", text: "Согласен с @Мария!", want: []string{"Мария"}},
+		{name: "email is not a mention", text: "write to me@example.com", want: nil},
+		{name: "same person twice", text: "@Anna_Smith and @annasmith", want: []string{"Anna_Smith"}},
+		{name: "bare at sign", text: "meet @ 5pm", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.text))
+		})
+	}
+}
+
+func TestParse_Limit(t *testing.T) {
+	text := ""
+	for _, name := range []string{"a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8", "a9", "a10", "a11", "a12"} {
+		text += "@" + name + " "
+	}
+	assert.Len(t, Parse(text), MaxPerText)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "annasmith", Key("Anna Smith"))
+	assert.Equal(t, Key("Anna Smith"), Key("anna_smith"))
+	assert.Equal(t, "мария", Key("Мария"))
+}
@@ -0,0 +1,113 @@
+package netguard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700::6810:85e5", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.5", false},
+		{"172.16.3.4", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, IsPublic(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestNewClient_RefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Second)
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+
+	// Host names are checked after resolution
+	_, err = client.Get("http://localhost:1/")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+}
+
+func TestNewClient_RefusesRedirectsToInternalAddresses(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer internal.Close()
+
+	// The redirecting server is reached through an unguarded client, the
+	// guarded transport must still refuse the redirect target
+	redirect := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusFound))
+	defer redirect.Close()
+
+	client := NewClient(time.Second)
+	guarded := client.Transport
+	client.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Host == redirect.Listener.Addr().String() {
+			return http.DefaultTransport.RoundTrip(r)
+		}
+		return guarded.RoundTrip(r)
+	})
+
+	_, err := client.Get(redirect.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// staticResolver resolves every host to the same addresses
+type staticResolver []netip.Addr
+
+func (r staticResolver) LookupNetIP(context.Context, string, string) ([]netip.Addr, error) {
+	return r, nil
+}
+
+func TestCheckHost(t *testing.T) {
+	ctx := context.Background()
+	public := staticResolver{netip.MustParseAddr("93.184.216.34")}
+
+	assert.NoError(t, CheckHost(ctx, public, "push.example"))
+	assert.NoError(t, CheckHost(ctx, public, "93.184.216.34"))
+
+	assert.ErrorIs(t, CheckHost(ctx, public, "10.0.0.5"), ErrForbiddenAddress)
+	assert.ErrorIs(t, CheckHost(ctx, public, "::1"), ErrForbiddenAddress)
+
+	// A single internal address among public ones is enough to refuse the host
+	mixed := staticResolver{netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("169.254.169.254")}
+	assert.ErrorIs(t, CheckHost(ctx, mixed, "push.example"), ErrForbiddenAddress)
+
+	assert.Error(t, CheckHost(ctx, staticResolver{}, "push.example"))
+}
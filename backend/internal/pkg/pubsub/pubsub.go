@@ -0,0 +1,109 @@
+// Package pubsub provides an in-process publish/subscribe broker for fanning
+// out messages to subscribers of a topic.
+package pubsub
+
+import "sync"
+
+// Broker delivers messages published to a topic to its subscribers.
+// Publishing never blocks: a subscriber whose buffer is full is dropped and
+// its channel closed, so it can catch up from another source.
+type Broker[K comparable, T any] struct {
+	mu     sync.Mutex
+	topics map[K]map[*Subscription[K, T]]struct{}
+	buffer int
+	closed bool
+}
+
+// Subscription receives the messages of one topic
+type Subscription[K comparable, T any] struct {
+	broker *Broker[K, T]
+	topic  K
+	ch     chan T
+	once   sync.Once
+}
+
+// NewBroker creates a broker buffering up to buffer messages per subscriber
+func NewBroker[K comparable, T any](buffer int) *Broker[K, T] {
+	return &Broker[K, T]{
+		topics: make(map[K]map[*Subscription[K, T]]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe starts receiving messages of a topic. The subscription of a
+// closed broker is closed right away.
+func (b *Broker[K, T]) Subscribe(topic K) *Subscription[K, T] {
+	sub := &Subscription[K, T]{broker: b, topic: topic, ch: make(chan T, b.buffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		sub.once.Do(func() { close(sub.ch) })
+		return sub
+	}
+	if b.topics[topic] == nil {
+		b.topics[topic] = make(map[*Subscription[K, T]]struct{})
+	}
+	b.topics[topic][sub] = struct{}{}
+	return sub
+}
+
+// Publish sends a message to the subscribers of a topic and returns how many received it
+func (b *Broker[K, T]) Publish(topic K, msg T) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delivered := 0
+	for sub := range b.topics[topic] {
+		select {
+		case sub.ch <- msg:
+			delivered++
+		default:
+			b.remove(sub)
+		}
+	}
+	return delivered
+}
+
+// Subscribers returns the number of subscribers of a topic
+func (b *Broker[K, T]) Subscribers(topic K) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.topics[topic])
+}
+
+// Close closes every subscription; later subscriptions are closed immediately
+func (b *Broker[K, T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, subs := range b.topics {
+		for sub := range subs {
+			b.remove(sub)
+		}
+	}
+}
+
+// remove unregisters and closes a subscription, b.mu must be held
+func (b *Broker[K, T]) remove(sub *Subscription[K, T]) {
+	subs := b.topics[sub.topic]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.topics, sub.topic)
+	}
+	sub.once.Do(func() { close(sub.ch) })
+}
+
+// C returns the channel of messages, closed when the subscription ends
+func (s *Subscription[K, T]) C() <-chan T {
+	return s.ch
+}
+
+// Close stops the subscription
+func (s *Subscription[K, T]) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
@@ -0,0 +1,66 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_Publish(t *testing.T) {
+	b := NewBroker[int, string](4)
+	first := b.Subscribe(1)
+	second := b.Subscribe(1)
+	other := b.Subscribe(2)
+
+	assert.Equal(t, 2, b.Publish(1, "hello"))
+	assert.Equal(t, "hello", <-first.C())
+	assert.Equal(t, "hello", <-second.C())
+	assert.Empty(t, other.C())
+
+	assert.Zero(t, b.Publish(3, "nobody"))
+}
+
+func TestBroker_SlowSubscriberIsDropped(t *testing.T) {
+	b := NewBroker[int, int](2)
+	slow := b.Subscribe(1)
+
+	assert.Equal(t, 1, b.Publish(1, 1))
+	assert.Equal(t, 1, b.Publish(1, 2))
+	assert.Zero(t, b.Publish(1, 3))
+	assert.Zero(t, b.Subscribers(1))
+
+	// Buffered messages are still received before the channel closes
+	var got []int
+	for msg := range slow.C() {
+		got = append(got, msg)
+	}
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestSubscription_Close(t *testing.T) {
+	b := NewBroker[int, int](1)
+	sub := b.Subscribe(1)
+
+	sub.Close()
+	sub.Close()
+
+	_, ok := <-sub.C()
+	assert.False(t, ok)
+	assert.Zero(t, b.Subscribers(1))
+	assert.Zero(t, b.Publish(1, 1))
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker[int, int](1)
+	sub := b.Subscribe(1)
+
+	b.Close()
+	_, ok := <-sub.C()
+	assert.False(t, ok)
+
+	// Subscribing after close ends right away
+	_, ok = <-b.Subscribe(1).C()
+	assert.False(t, ok)
+
+	sub.Close()
+}
@@ -0,0 +1,166 @@
+// Package spam scores comments for signs of spam and flooding.
+package spam
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reasons a comment scored
+const (
+	ReasonRate       = "rate"        // the author posts too often
+	ReasonLinks      = "links"       // too many links
+	ReasonDuplicate  = "duplicate"   // the same text was posted recently
+	ReasonStopWords  = "stop_words"  // typical spam words
+	ReasonNewAccount = "new_account" // the account was just created
+)
+
+// Points added by each signal
+const (
+	rateScore       = 5 // flooding alone is enough to hold a comment
+	linkScore       = 2 // per link over the allowance
+	duplicateScore  = 3
+	stopWordScore   = 2 // per matched word or phrase
+	newAccountScore = 2
+)
+
+// minFingerprintLength is the shortest normalized text compared for
+// duplicates, short replies like "thanks" are repeated legitimately
+const minFingerprintLength = 20
+
+// DefaultStopWords are used for languages without a configured list
+var DefaultStopWords = map[string][]string{
+	"en": {
+		"viagra", "cialis", "casino", "online casino", "payday loan", "crypto signals",
+		"buy followers", "seo services", "work from home", "earn money fast", "click here",
+	},
+	"ru": {
+		"казино", "онлайн казино", "ставки на спорт", "займ без отказа", "быстрый заработок",
+		"заработок в интернете", "раскрутка сайта", "накрутка подписчиков", "виагра", "порно",
+	},
+}
+
+// linkPattern matches URLs and bare domains with common spam TLDs
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|ru|info|biz|xyz|top|io|me|shop|site|online)\b`)
+
+// Signals describe a new comment and the recent activity of its author
+type Signals struct {
+	Content        string
+	AccountAge     time.Duration
+	RecentComments int // comments by the author within the rate window
+	Duplicates     int // recent comments with the same fingerprint
+}
+
+// Rules are the thresholds signals are scored against
+type Rules struct {
+	RateLimit     int           // comments per rate window before flooding scores
+	MaxLinks      int           // links allowed without a penalty
+	NewAccountAge time.Duration // accounts younger than this score
+	StopWords     []string      // normalized words and phrases
+}
+
+// Result is the spam score of a comment with the signals that contributed
+type Result struct {
+	Score   int
+	Reasons []string
+}
+
+// Score scores a comment, higher is more suspicious
+func (r *Rules) Score(s Signals) Result {
+	var res Result
+	add := func(points int, reason string) {
+		res.Score += points
+		res.Reasons = append(res.Reasons, reason)
+	}
+
+	if r.RateLimit > 0 && s.RecentComments >= r.RateLimit {
+		add(rateScore, ReasonRate)
+	}
+	if links := CountLinks(s.Content); links > r.MaxLinks {
+		add(linkScore*(links-r.MaxLinks), ReasonLinks)
+	}
+	if s.Duplicates > 0 {
+		add(duplicateScore, ReasonDuplicate)
+	}
+	if words := r.matchStopWords(s.Content); words > 0 {
+		add(stopWordScore*words, ReasonStopWords)
+	}
+	if s.AccountAge < r.NewAccountAge {
+		add(newAccountScore, ReasonNewAccount)
+	}
+
+	return res
+}
+
+// matchStopWords counts the stop words and phrases found in the text
+func (r *Rules) matchStopWords(text string) int {
+	if len(r.StopWords) == 0 {
+		return 0
+	}
+
+	// Pad with spaces so that only whole words match
+	padded := " " + Normalize(text) + " "
+	matched := 0
+	for _, word := range r.StopWords {
+		if word != "" && strings.Contains(padded, " "+word+" ") {
+			matched++
+		}
+	}
+	return matched
+}
+
+// CountLinks counts URLs and domain names in the text
+func CountLinks(text string) int {
+	return len(linkPattern.FindAllStringIndex(text, -1))
+}
+
+// Normalize lowercases the text and keeps only letters and digits, separated
+// by single spaces, so that punctuation and formatting tricks do not matter
+func Normalize(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			// Cyrillic "ё" is commonly written as "е"
+			if r == 'ё' {
+				r = 'е'
+			}
+			b.WriteRune(r)
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// NormalizeWords normalizes a stop word list, dropping empty entries
+func NormalizeWords(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = Normalize(word); word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return normalized
+}
+
+// Fingerprint identifies comments with the same text. It is empty for
+// texts too short to compare.
+func Fingerprint(text string) string {
+	normalized := Normalize(text)
+	if utf8.RuneCountInString(normalized) < minFingerprintLength {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
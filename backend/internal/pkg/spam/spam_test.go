@@ -0,0 +1,102 @@
+package spam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "buy cheap watches", Normalize("  BUY!!! cheap...   watches "))
+	assert.Equal(t, "еще раз", Normalize("Ещё раз"))
+	assert.Equal(t, "", Normalize("👍 !!!"))
+}
+
+func TestCountLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "no links", text: "Thanks for the post", want: 0},
+		{name: "url", text: "see https://example.com/a?b=c", want: 1},
+		{name: "markdown link", text: "[docs](https://go.dev/doc)", want: 1},
+		{name: "www and bare domains", text: "www.shop.example cheap-pills.ru and best.xyz", want: 3},
+		{name: "file names are not links", text: "edit main.go and README.md", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CountLinks(tt.text))
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint("Great article, thank you very much!")
+	assert.NotEmpty(t, a)
+	assert.Equal(t, a, Fingerprint("great ARTICLE thank you   very much"))
+	assert.NotEqual(t, a, Fingerprint("Great article, thank you so much!"))
+
+	// Short replies are not compared
+	assert.Empty(t, Fingerprint("Спасибо!"))
+}
+
+func TestRules_Score(t *testing.T) {
+	rules := &Rules{
+		RateLimit:     5,
+		MaxLinks:      2,
+		NewAccountAge: 24 * time.Hour,
+		StopWords:     NormalizeWords(append(DefaultStopWords["en"], DefaultStopWords["ru"]...)),
+	}
+	old := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		signals     Signals
+		wantScore   int
+		wantReasons []string
+	}{
+		{
+			name:    "clean comment",
+			signals: Signals{Content: "Nice write-up, the second example helped", AccountAge: old},
+		},
+		{
+			name:        "flooding",
+			signals:     Signals{Content: "hi", AccountAge: old, RecentComments: 5},
+			wantScore:   5,
+			wantReasons: []string{ReasonRate},
+		},
+		{
+			name:        "links over the allowance",
+			signals:     Signals{Content: "a.com b.com c.com d.com", AccountAge: old},
+			wantScore:   4,
+			wantReasons: []string{ReasonLinks},
+		},
+		{
+			name:        "stop words are whole words in any case",
+			signals:     Signals{Content: "Лучшее ОНЛАЙН-казино! Online casino", AccountAge: old},
+			wantScore:   8,
+			wantReasons: []string{ReasonStopWords},
+		},
+		{
+			name:    "stop words inside other words do not match",
+			signals: Signals{Content: "casinos and cialisative", AccountAge: old},
+		},
+		{
+			name:        "duplicate from a new account",
+			signals:     Signals{Content: "hello", AccountAge: time.Hour, Duplicates: 2},
+			wantScore:   5,
+			wantReasons: []string{ReasonDuplicate, ReasonNewAccount},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := rules.Score(tt.signals)
+			assert.Equal(t, tt.wantScore, res.Score)
+			assert.Equal(t, tt.wantReasons, res.Reasons)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Package webmention implements the client side of the W3C Webmention protocol:
+// endpoint discovery, sending and verification of source documents
+package webmention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"personal-web-platform/internal/pkg/netguard"
+)
+
+// maxDocumentSize limits fetched HTML documents
+const maxDocumentSize = 1 << 20
+
+// ErrNoLink is returned when the source document doesn't link to the target
+var ErrNoLink = errors.New("source does not link to target")
+
+// StatusError is an unexpected HTTP response status
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.Code)
+}
+
+// IsTemporary reports whether a failed request is worth retrying:
+// network errors, rate limiting and server errors. Refused internal
+// addresses are permanent.
+func IsTemporary(err error) bool {
+	if errors.Is(err, netguard.ErrForbiddenAddress) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= 500 || statusErr.Code == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+var (
+	linkHeaderRe = regexp.MustCompile(`<([^>]*)>\s*;[^,]*rel\s*=\s*"?([^",]*)"?`)
+	tagRe        = regexp.MustCompile(`(?is)<(link|a)\b([^>]*)>`)
+	attrRe       = regexp.MustCompile(`(?is)\b(href|rel)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	hrefRe       = regexp.MustCompile(`(?is)<a\b[^>]*\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	titleRe      = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	// urlRe matches absolute links in Markdown or HTML content
+	urlRe = regexp.MustCompile(`https?://[^\s<>"'()\[\]]+`)
+)
+
+// Client discovers endpoints, sends and verifies webmentions
+type Client struct {
+	http      *http.Client
+	userAgent string
+}
+
+// NewClient creates a webmention client
+func NewClient(httpClient *http.Client, userAgent string) *Client {
+	return &Client{http: httpClient, userAgent: userAgent}
+}
+
+// fetch downloads an HTML document, base is the final URL after redirects
+func (c *Client) fetch(ctx context.Context, target string) (document string, header http.Header, base *url.URL, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to fetch %s: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, nil, &StatusError{Code: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize))
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+
+	return string(body), resp.Header, resp.Request.URL, nil
+}
+
+// DiscoverEndpoint finds the webmention endpoint of target.
+// Returns an empty string when the target doesn't accept webmentions.
+func (c *Client) DiscoverEndpoint(ctx context.Context, target string) (string, error) {
+	document, header, base, err := c.fetch(ctx, target)
+	if err != nil {
+		return "", err
+	}
+
+	// The Link header takes precedence over the document
+	for _, value := range header.Values("Link") {
+		for _, m := range linkHeaderRe.FindAllStringSubmatch(value, -1) {
+			if hasRel(m[2], "webmention") {
+				return resolve(base, m[1])
+			}
+		}
+	}
+
+	for _, tag := range tagRe.FindAllStringSubmatch(document, -1) {
+		var href, rel string
+		hasHref := false
+		for _, attr := range attrRe.FindAllStringSubmatch(tag[2], -1) {
+			value := attr[2] + attr[3] + attr[4]
+			if strings.EqualFold(attr[1], "href") {
+				href, hasHref = html.UnescapeString(value), true
+			} else {
+				rel = value
+			}
+		}
+		if hasHref && hasRel(rel, "webmention") {
+			// An empty href points to the document itself
+			return resolve(base, href)
+		}
+	}
+
+	return "", nil
+}
+
+// Send notifies endpoint that source mentions target
+func (c *Client) Send(ctx context.Context, endpoint, source, target string) error {
+	form := url.Values{"source": {source}, "target": {target}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webmention: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDocumentSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{Code: resp.StatusCode}
+	}
+	return nil
+}
+
+// Verify fetches source and checks that it links to target.
+// Returns the source document title on success.
+func (c *Client) Verify(ctx context.Context, source, target string) (string, error) {
+	document, _, base, err := c.fetch(ctx, source)
+	if err != nil {
+		return "", err
+	}
+	if !LinksTo(document, base, target) {
+		return "", ErrNoLink
+	}
+	return Title(document), nil
+}
+
+// LinksTo reports whether an HTML document has an <a href> pointing to target
+func LinksTo(document string, base *url.URL, target string) bool {
+	want := normalize(target)
+	for _, m := range hrefRe.FindAllStringSubmatch(document, -1) {
+		href, err := resolve(base, html.UnescapeString(m[1]+m[2]+m[3]))
+		if err == nil && normalize(href) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Title returns the text of the <title> element
+func Title(document string) string {
+	m := titleRe.FindStringSubmatch(document)
+	if m == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(m[1])), " ")
+}
+
+// ExtractLinks returns the unique absolute http(s) links found in content
+func ExtractLinks(content string) []string {
+	seen := make(map[string]bool)
+	links := make([]string, 0)
+	for _, link := range urlRe.FindAllString(content, -1) {
+		// Trailing punctuation belongs to the sentence, not to the link
+		link = strings.TrimRight(link, ".,;:!?*_")
+		if _, err := url.ParseRequestURI(link); err != nil || seen[link] {
+			continue
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+	return links
+}
+
+func hasRel(rel, value string) bool {
+	for _, r := range strings.Fields(rel) {
+		if strings.EqualFold(r, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolve(base *url.URL, ref string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", ref, err)
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	return u.String(), nil
+}
+
+// normalize makes URLs comparable: no fragment and no trailing slash
+func normalize(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	u.Fragment = ""
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
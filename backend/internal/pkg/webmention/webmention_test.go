@@ -0,0 +1,144 @@
+package webmention
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/pkg/netguard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DiscoverEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		document string
+		want     string
+	}{
+		{
+			name:   "Link header",
+			header: `<https://hooks.example/wm>; rel="webmention"`,
+			want:   "https://hooks.example/wm",
+		},
+		{
+			name:   "Relative Link header with several rels",
+			header: `</style.css>; rel=stylesheet, </endpoint>; rel="webmention other"`,
+			want:   "/endpoint",
+		},
+		{
+			name:     "Link element",
+			document: `<html><head><link href="/webmention?x=1&amp;y=2" rel="webmention"></head></html>`,
+			want:     "/webmention?x=1&y=2",
+		},
+		{
+			name:     "Anchor element with single quotes",
+			document: `<a rel='webmention' href='https://hooks.example/wm'>mentions</a>`,
+			want:     "https://hooks.example/wm",
+		},
+		{
+			name:     "Empty href is the page itself",
+			document: `<link rel="webmention" href="">`,
+			want:     "/post",
+		},
+		{
+			name:     "No endpoint",
+			document: `<html><link rel="stylesheet" href="/style.css"></html>`,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("Link", tt.header)
+				}
+				_, _ = w.Write([]byte(tt.document))
+			}))
+			defer server.Close()
+
+			client := NewClient(http.DefaultClient, "test")
+			endpoint, err := client.DiscoverEndpoint(context.Background(), server.URL+"/post")
+			require.NoError(t, err)
+
+			want := tt.want
+			if want != "" && want[0] == '/' {
+				want = server.URL + want
+			}
+			assert.Equal(t, want, endpoint)
+		})
+	}
+}
+
+func TestClient_SendAndVerify(t *testing.T) {
+	var received url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webmention", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		received = r.PostForm
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("GET /source", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html><title> Their  post </title><a href="https://blog.example/blog/hello/#comments">my post</a></html>`))
+	})
+	mux.HandleFunc("GET /gone", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("GET /broken", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(http.DefaultClient, "test")
+	ctx := context.Background()
+
+	require.NoError(t, client.Send(ctx, server.URL+"/webmention", "https://blog.example/blog/hello", "https://other.example/post"))
+	assert.Equal(t, "https://blog.example/blog/hello", received.Get("source"))
+	assert.Equal(t, "https://other.example/post", received.Get("target"))
+
+	title, err := client.Verify(ctx, server.URL+"/source", "https://blog.example/blog/hello")
+	require.NoError(t, err)
+	assert.Equal(t, "Their post", title)
+
+	_, err = client.Verify(ctx, server.URL+"/source", "https://blog.example/blog/other")
+	assert.ErrorIs(t, err, ErrNoLink)
+	assert.False(t, IsTemporary(err))
+
+	_, err = client.Verify(ctx, server.URL+"/gone", "https://blog.example/blog/hello")
+	assert.Error(t, err)
+	assert.False(t, IsTemporary(err))
+
+	_, err = client.Verify(ctx, server.URL+"/broken", "https://blog.example/blog/hello")
+	assert.True(t, IsTemporary(err))
+
+	err = client.Send(ctx, server.URL+"/missing", "https://blog.example/blog/hello", "https://other.example/post")
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusNotFound, statusErr.Code)
+
+	// Internal addresses won't become reachable by retrying
+	_, err = NewClient(netguard.NewClient(time.Second), "test").Verify(ctx, server.URL+"/source", "https://blog.example/blog/hello")
+	assert.ErrorIs(t, err, netguard.ErrForbiddenAddress)
+	assert.False(t, IsTemporary(err))
+}
+
+func TestExtractLinks(t *testing.T) {
+	content := "See [this](https://a.example/post) and https://b.example/page.\n" +
+		"<a href=\"https://c.example/x?y=1\">c</a>, again https://a.example/post, **https://d.example/bold**"
+
+	assert.Equal(t, []string{
+		"https://a.example/post",
+		"https://b.example/page",
+		"https://c.example/x?y=1",
+		"https://d.example/bold",
+	}, ExtractLinks(content))
+	assert.Empty(t, ExtractLinks("no links here"))
+}
@@ -0,0 +1,151 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// recordSize is the aes128gcm record size; a push message is a single record
+	recordSize = 4096
+	saltLength = 16
+	authLength = 16
+	// tagLength is the AES-GCM authentication tag size
+	tagLength = 16
+	// headerLength is salt, record size, key id length and a P-256 public key
+	headerLength = saltLength + 4 + 1 + 65
+
+	// MaxPayloadSize is the largest payload that fits in one record
+	MaxPayloadSize = recordSize - headerLength - tagLength - 1
+)
+
+// ErrPayloadTooLarge is returned for payloads over MaxPayloadSize
+var ErrPayloadTooLarge = errors.New("push payload too large")
+
+// Keys are the subscription keys the browser generated for the endpoint
+type Keys struct {
+	P256dh string `json:"p256dh"` // base64url P-256 public key of the user agent
+	Auth   string `json:"auth"`   // base64url 16-byte authentication secret
+}
+
+// decode parses and validates the subscription keys
+func (k Keys) decode() (*ecdh.PublicKey, []byte, error) {
+	rawKey, err := decodeBase64(k.P256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	publicKey, err := ecdh.P256().NewPublicKey(rawKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+
+	auth, err := decodeBase64(k.Auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+	if len(auth) != authLength {
+		return nil, nil, fmt.Errorf("invalid auth secret: want %d bytes, got %d", authLength, len(auth))
+	}
+
+	return publicKey, auth, nil
+}
+
+// Validate reports whether the keys can be used to encrypt messages
+func (k Keys) Validate() error {
+	_, _, err := k.decode()
+	return err
+}
+
+// Encrypt encrypts a push message for the subscription as specified in
+// RFC 8291 with the aes128gcm content coding of RFC 8188
+func Encrypt(keys Keys, payload []byte) ([]byte, error) {
+	uaPublic, auth, err := keys.decode()
+	if err != nil {
+		return nil, err
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return encrypt(uaPublic, auth, asPrivate, salt, payload)
+}
+
+func encrypt(uaPublic *ecdh.PublicKey, auth []byte, asPrivate *ecdh.PrivateKey, salt, payload []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	// RFC 8291 section 3.4: mix the auth secret into the shared secret
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic.Bytes()...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, auth, string(keyInfo), 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 8188 section 2.2 and 2.3: content encryption key and nonce
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// The only record is the last one: padding delimiter 0x02
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+
+	header := make([]byte, 0, headerLength)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// decodeBase64 accepts base64url with or without padding, as browsers produce both
+func decodeBase64(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(trimPadding(s))
+}
+
+func trimPadding(s string) string {
+	for len(s) > 0 && s[len(s)-1] == '=' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
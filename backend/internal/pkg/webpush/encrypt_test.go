@@ -0,0 +1,117 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := decodeBase64(s)
+	require.NoError(t, err)
+	return b
+}
+
+// RFC 8291 Appendix A
+func TestEncrypt_RFC8291Example(t *testing.T) {
+	uaPublic, err := ecdh.P256().NewPublicKey(mustDecode(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"))
+	require.NoError(t, err)
+	asPrivate, err := ecdh.P256().NewPrivateKey(mustDecode(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	require.NoError(t, err)
+	auth := mustDecode(t, "BTBZMqHH6r4Tts7J_aSIgg")
+	salt := mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlw")
+
+	body, err := encrypt(uaPublic, auth, asPrivate, salt, []byte("When I grow up, I want to be a watermelon"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN",
+		base64.RawURLEncoding.EncodeToString(body))
+}
+
+// decrypt is the user agent side of RFC 8291
+func decrypt(t *testing.T, uaPrivate *ecdh.PrivateKey, auth, body []byte) []byte {
+	t.Helper()
+	require.Greater(t, len(body), headerLength)
+
+	salt := body[:saltLength]
+	assert.Equal(t, uint32(recordSize), binary.BigEndian.Uint32(body[saltLength:saltLength+4]))
+	keyID := body[saltLength+5 : headerLength]
+	asPublic, err := ecdh.P256().NewPublicKey(keyID)
+	require.NoError(t, err)
+
+	sharedSecret, err := uaPrivate.ECDH(asPublic)
+	require.NoError(t, err)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPrivate.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, keyID...)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, auth, string(keyInfo), 32)
+	require.NoError(t, err)
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	require.NoError(t, err)
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	require.NoError(t, err)
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, body[headerLength:], nil)
+	require.NoError(t, err)
+
+	require.Equal(t, byte(0x02), plaintext[len(plaintext)-1], "last record delimiter")
+	return plaintext[:len(plaintext)-1]
+}
+
+// newUserAgentKeys creates subscription keys like a browser does
+func newUserAgentKeys(t *testing.T) (*ecdh.PrivateKey, []byte, Keys) {
+	t.Helper()
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, authLength)
+	_, err = rand.Read(auth)
+	require.NoError(t, err)
+
+	return uaPrivate, auth, Keys{
+		P256dh: base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		// Some browsers pad their base64
+		Auth: base64.URLEncoding.EncodeToString(auth),
+	}
+}
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	uaPrivate, auth, keys := newUserAgentKeys(t)
+
+	payload := []byte(`{"title":"Новый пост","url":"https://blog.example/blog/hello"}`)
+	body, err := Encrypt(keys, payload)
+	require.NoError(t, err)
+	assert.Equal(t, payload, decrypt(t, uaPrivate, auth, body))
+
+	// Every message uses a fresh key and salt
+	again, err := Encrypt(keys, payload)
+	require.NoError(t, err)
+	assert.NotEqual(t, body, again)
+}
+
+func TestEncrypt_Invalid(t *testing.T) {
+	_, _, keys := newUserAgentKeys(t)
+
+	_, err := Encrypt(keys, make([]byte, MaxPayloadSize+1))
+	assert.ErrorIs(t, err, ErrPayloadTooLarge)
+
+	_, err = Encrypt(Keys{P256dh: "bm90IGEga2V5", Auth: keys.Auth}, []byte("x"))
+	assert.ErrorContains(t, err, "p256dh")
+
+	_, err = Encrypt(Keys{P256dh: keys.P256dh, Auth: "c2hvcnQ"}, []byte("x"))
+	assert.ErrorContains(t, err, "auth")
+}
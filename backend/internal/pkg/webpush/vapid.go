@@ -0,0 +1,104 @@
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// vapidTokenTTL is the lifetime of a VAPID token, push services reject more than 24h
+const vapidTokenTTL = 12 * time.Hour
+
+// VAPID identifies the application server to push services (RFC 8292)
+type VAPID struct {
+	key       *ecdsa.PrivateKey
+	publicKey string
+	subject   string
+}
+
+// NewVAPID loads a key pair in the format used by browsers and web-push tools:
+// base64url uncompressed P-256 public key and raw 32-byte private key.
+// subject is a mailto: or https: contact for the push service operators.
+func NewVAPID(publicKey, privateKey, subject string) (*VAPID, error) {
+	rawPrivate, err := decodeBase64(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), rawPrivate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+
+	derived, err := key.PublicKey.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	rawPublic, err := decodeBase64(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid public key: %w", err)
+	}
+	if string(rawPublic) != string(derived) {
+		return nil, fmt.Errorf("vapid public key doesn't match the private key")
+	}
+
+	return &VAPID{
+		key:       key,
+		publicKey: base64.RawURLEncoding.EncodeToString(derived),
+		subject:   subject,
+	}, nil
+}
+
+// GenerateVAPIDKeys creates a new key pair for NewVAPID
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	rawPublic, err := key.PublicKey.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+	rawPrivate, err := key.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(rawPublic), base64.RawURLEncoding.EncodeToString(rawPrivate), nil
+}
+
+// PublicKey returns the key browsers pass to pushManager.subscribe as applicationServerKey
+func (v *VAPID) PublicKey() string {
+	return v.publicKey
+}
+
+// authorization builds the Authorization header for a push endpoint
+func (v *VAPID) authorization(endpoint *url.URL, now time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]any{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": v.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	// ES256 signatures are r || s, 32 bytes each
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, v.key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign vapid token: %w", err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + token + ", k=" + v.publicKey, nil
+}
@@ -0,0 +1,119 @@
+// Package webpush sends Web Push messages: payload encryption (RFC 8291)
+// and VAPID authentication (RFC 8292) for the push protocol (RFC 8030)
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrGone is returned when the subscription expired or was revoked by the user
+var ErrGone = errors.New("push subscription is gone")
+
+// StatusError is an unexpected response from the push service
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("push service returned status %d: %s", e.Code, e.Body)
+}
+
+// Urgency of a message, push services may delay low urgency messages to save battery
+const (
+	UrgencyLow    = "low"
+	UrgencyNormal = "normal"
+	UrgencyHigh   = "high"
+)
+
+// Subscription is a browser PushSubscription
+type Subscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     Keys   `json:"keys"`
+}
+
+// Options of a message
+type Options struct {
+	TTL     time.Duration // how long the push service keeps an undelivered message
+	Urgency string
+	// Topic replaces an undelivered message with the same topic
+	Topic string
+}
+
+// ValidateEndpoint checks that a push endpoint is an absolute https URL
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.New("push endpoint must be an https URL")
+	}
+	return nil
+}
+
+// Client delivers push messages
+type Client struct {
+	http  *http.Client
+	vapid *VAPID
+}
+
+// NewClient creates a client authenticating with vapid
+func NewClient(httpClient *http.Client, vapid *VAPID) *Client {
+	return &Client{http: httpClient, vapid: vapid}
+}
+
+// Send encrypts payload and delivers it to the subscription's push service
+func (c *Client) Send(ctx context.Context, sub *Subscription, payload []byte, opts Options) error {
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	body, err := Encrypt(sub.Keys, payload)
+	if err != nil {
+		return err
+	}
+	authorization, err := c.vapid.authorization(endpoint, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(opts.TTL.Seconds())))
+	if opts.Urgency != "" {
+		req.Header.Set("Urgency", opts.Urgency)
+	}
+	if opts.Topic != "" {
+		req.Header.Set("Topic", opts.Topic)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusNotFound:
+		return ErrGone
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &StatusError{Code: resp.StatusCode, Body: string(bytes.TrimSpace(text))}
+	}
+	return nil
+}
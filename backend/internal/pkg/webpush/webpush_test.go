@@ -0,0 +1,137 @@
+package webpush
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVAPID(t *testing.T) *VAPID {
+	t.Helper()
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	vapid, err := NewVAPID(publicKey, privateKey, "mailto:admin@blog.example")
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, vapid.PublicKey())
+	return vapid
+}
+
+// verifyAuthorization checks a "vapid t=..., k=..." header and returns the token claims
+func verifyAuthorization(t *testing.T, header string) map[string]any {
+	t.Helper()
+	require.True(t, strings.HasPrefix(header, "vapid t="), header)
+	token, publicKey, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	require.True(t, ok, header)
+
+	rawPublic := mustDecode(t, publicKey)
+	key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), rawPublic)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature := mustDecode(t, parts[2])
+	require.Len(t, signature, 64)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	assert.True(t, ecdsa.Verify(key, digest[:], r, s), "token signature")
+
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal(mustDecode(t, parts[1]), &claims))
+	return claims
+}
+
+func TestNewVAPID_Invalid(t *testing.T) {
+	publicKey, privateKey, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	otherPublic, _, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+
+	_, err = NewVAPID(otherPublic, privateKey, "mailto:admin@blog.example")
+	assert.ErrorContains(t, err, "doesn't match")
+
+	_, err = NewVAPID(publicKey, "not a key", "mailto:admin@blog.example")
+	assert.Error(t, err)
+}
+
+func TestClient_Send(t *testing.T) {
+	vapid := newTestVAPID(t)
+	uaPrivate, auth, keys := newUserAgentKeys(t)
+
+	var received *http.Request
+	var body []byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), vapid)
+	sub := &Subscription{Endpoint: server.URL + "/push/abc", Keys: keys}
+	err := client.Send(context.Background(), sub, []byte(`{"title":"Hi"}`), Options{TTL: time.Hour, Urgency: UrgencyNormal, Topic: "post-1"})
+	require.NoError(t, err)
+
+	require.NotNil(t, received)
+	assert.Equal(t, "/push/abc", received.URL.Path)
+	assert.Equal(t, "aes128gcm", received.Header.Get("Content-Encoding"))
+	assert.Equal(t, "3600", received.Header.Get("TTL"))
+	assert.Equal(t, "normal", received.Header.Get("Urgency"))
+	assert.Equal(t, "post-1", received.Header.Get("Topic"))
+	assert.Equal(t, `{"title":"Hi"}`, string(decrypt(t, uaPrivate, auth, body)))
+
+	claims := verifyAuthorization(t, received.Header.Get("Authorization"))
+	assert.Equal(t, server.URL, claims["aud"])
+	assert.Equal(t, "mailto:admin@blog.example", claims["sub"])
+	assert.InDelta(t, time.Now().Add(vapidTokenTTL).Unix(), claims["exp"], 60)
+}
+
+func TestClient_Send_Errors(t *testing.T) {
+	vapid := newTestVAPID(t)
+	_, _, keys := newUserAgentKeys(t)
+
+	status := http.StatusGone
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "nope", status)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), vapid)
+	sub := &Subscription{Endpoint: server.URL + "/push/abc", Keys: keys}
+
+	t.Run("Expired subscription", func(t *testing.T) {
+		status = http.StatusGone
+		assert.ErrorIs(t, client.Send(context.Background(), sub, []byte("x"), Options{}), ErrGone)
+
+		status = http.StatusNotFound
+		assert.ErrorIs(t, client.Send(context.Background(), sub, []byte("x"), Options{}), ErrGone)
+	})
+
+	t.Run("Push service error", func(t *testing.T) {
+		status = http.StatusTooManyRequests
+		err := client.Send(context.Background(), sub, []byte("x"), Options{})
+
+		var statusErr *StatusError
+		require.ErrorAs(t, err, &statusErr)
+		assert.Equal(t, http.StatusTooManyRequests, statusErr.Code)
+		assert.Equal(t, "nope", statusErr.Body)
+	})
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	assert.NoError(t, ValidateEndpoint("https://fcm.googleapis.com/fcm/send/abc"))
+	assert.Error(t, ValidateEndpoint("http://push.example/abc"))
+	assert.Error(t, ValidateEndpoint("/relative"))
+	assert.Error(t, ValidateEndpoint("https://"))
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ActivityPubRepository defines methods for federation data access
+type ActivityPubRepository interface {
+	GetKey(ctx context.Context) (*domain.ActivityPubKey, error)
+	SaveKey(ctx context.Context, key *domain.ActivityPubKey) (*domain.ActivityPubKey, error)
+	AddFollower(ctx context.Context, follower *domain.Follower) error
+	RemoveFollower(ctx context.Context, actorID string) (bool, error)
+	ListFollowers(ctx context.Context) ([]domain.Follower, error)
+	CountFollowers(ctx context.Context) (int, error)
+	GetCommentIDByObjectID(ctx context.Context, objectID string) (int, error)
+	LinkComment(ctx context.Context, objectID string, commentID int) error
+}
+
+type activityPubRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewActivityPubRepo creates a new ActivityPub repository implementation
+func NewActivityPubRepo(db *pgxpool.Pool) ActivityPubRepository {
+	return &activityPubRepo{db: db}
+}
+
+func (r *activityPubRepo) GetKey(ctx context.Context) (*domain.ActivityPubKey, error) {
+	var key domain.ActivityPubKey
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `SELECT private_key_pem, public_key_pem, created_at FROM activitypub_keys WHERE id = 1`
+	err := db.QueryRow(ctx, query).Scan(&key.PrivateKeyPEM, &key.PublicKeyPEM, &key.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get activitypub key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// SaveKey stores the key pair unless one already exists and returns the stored pair,
+// so concurrent first requests end up using the same key
+func (r *activityPubRepo) SaveKey(ctx context.Context, key *domain.ActivityPubKey) (*domain.ActivityPubKey, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO activitypub_keys (id, private_key_pem, public_key_pem)
+		VALUES (1, $1, $2)
+		ON CONFLICT (id) DO NOTHING
+	`
+	if _, err := db.Exec(ctx, query, key.PrivateKeyPEM, key.PublicKeyPEM); err != nil {
+		return nil, fmt.Errorf("failed to save activitypub key: %w", err)
+	}
+
+	return r.GetKey(ctx)
+}
+
+// AddFollower stores a follower or refreshes its inboxes
+func (r *activityPubRepo) AddFollower(ctx context.Context, follower *domain.Follower) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO activitypub_followers (actor_id, inbox, shared_inbox)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (actor_id) DO UPDATE SET
+			inbox = EXCLUDED.inbox,
+			shared_inbox = EXCLUDED.shared_inbox
+		RETURNING id, created_at
+	`
+	err := db.QueryRow(ctx, query, follower.ActorID, follower.Inbox, follower.SharedInbox).Scan(&follower.ID, &follower.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add follower: %w", err)
+	}
+
+	return nil
+}
+
+func (r *activityPubRepo) RemoveFollower(ctx context.Context, actorID string) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	result, err := db.Exec(ctx, `DELETE FROM activitypub_followers WHERE actor_id = $1`, actorID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove follower: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *activityPubRepo) ListFollowers(ctx context.Context) ([]domain.Follower, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `SELECT id, actor_id, inbox, shared_inbox, created_at FROM activitypub_followers ORDER BY id`
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list followers: %w", err)
+	}
+	defer rows.Close()
+
+	followers := make([]domain.Follower, 0)
+	for rows.Next() {
+		var f domain.Follower
+		if err := rows.Scan(&f.ID, &f.ActorID, &f.Inbox, &f.SharedInbox, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan follower: %w", err)
+		}
+		followers = append(followers, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate followers: %w", err)
+	}
+
+	return followers, nil
+}
+
+func (r *activityPubRepo) CountFollowers(ctx context.Context) (int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	var count int
+	if err := db.QueryRow(ctx, `SELECT COUNT(*) FROM activitypub_followers`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count followers: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetCommentIDByObjectID returns the comment created from a remote object, 0 if none
+func (r *activityPubRepo) GetCommentIDByObjectID(ctx context.Context, objectID string) (int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	var commentID int
+	err := db.QueryRow(ctx, `SELECT comment_id FROM activitypub_comments WHERE object_id = $1`, objectID).Scan(&commentID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get comment by object id: %w", err)
+	}
+
+	return commentID, nil
+}
+
+func (r *activityPubRepo) LinkComment(ctx context.Context, objectID string, commentID int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `INSERT INTO activitypub_comments (object_id, comment_id) VALUES ($1, $2)`
+	if _, err := db.Exec(ctx, query, objectID, commentID); err != nil {
+		return fmt.Errorf("failed to link comment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityPubRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	postRepo := NewPostRepo(testDB.Pool)
+	commentRepo := NewCommentRepo(testDB.Pool)
+	apRepo := NewActivityPubRepo(testDB.Pool)
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "activitypub_keys", "activitypub_followers", "activitypub_comments", "comments", "posts", "users")
+	require.NoError(t, err)
+
+	t.Run("Key is stored once", func(t *testing.T) {
+		key, err := apRepo.GetKey(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, key)
+
+		first, err := apRepo.SaveKey(ctx, &domain.ActivityPubKey{PrivateKeyPEM: "private-1", PublicKeyPEM: "public-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "public-1", first.PublicKeyPEM)
+
+		// A concurrent first use keeps the existing pair
+		second, err := apRepo.SaveKey(ctx, &domain.ActivityPubKey{PrivateKeyPEM: "private-2", PublicKeyPEM: "public-2"})
+		require.NoError(t, err)
+		assert.Equal(t, "private-1", second.PrivateKeyPEM)
+	})
+
+	t.Run("Followers", func(t *testing.T) {
+		follower := &domain.Follower{ActorID: "https://remote.example/users/alice", Inbox: "https://remote.example/users/alice/inbox"}
+		require.NoError(t, apRepo.AddFollower(ctx, follower))
+		assert.NotZero(t, follower.ID)
+
+		// Following again refreshes the inboxes
+		require.NoError(t, apRepo.AddFollower(ctx, &domain.Follower{
+			ActorID:     "https://remote.example/users/alice",
+			Inbox:       "https://remote.example/users/alice/inbox",
+			SharedInbox: "https://remote.example/inbox",
+		}))
+
+		followers, err := apRepo.ListFollowers(ctx)
+		require.NoError(t, err)
+		require.Len(t, followers, 1)
+		assert.Equal(t, "https://remote.example/inbox", followers[0].SharedInbox)
+
+		count, err := apRepo.CountFollowers(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		removed, err := apRepo.RemoveFollower(ctx, "https://remote.example/users/alice")
+		require.NoError(t, err)
+		assert.True(t, removed)
+
+		removed, err = apRepo.RemoveFollower(ctx, "https://remote.example/users/alice")
+		require.NoError(t, err)
+		assert.False(t, removed)
+	})
+
+	t.Run("Remote comments", func(t *testing.T) {
+		owner, err := authRepo.CreateUser(ctx, "owner@example.com", "Owner", "", domain.RoleAdmin)
+		require.NoError(t, err)
+		remote, err := authRepo.CreateUser(ctx, "alice@remote.example.invalid", "alice", "", domain.RoleUser)
+		require.NoError(t, err)
+
+		post, err := postRepo.Create(ctx, &domain.Post{
+			Title:     "Federated",
+			Slug:      "federated",
+			Content:   "Post to test federated replies",
+			AuthorID:  owner.ID,
+			Published: true,
+		})
+		require.NoError(t, err)
+
+		comment, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: remote.ID, Content: "Hello from the fediverse"})
+		require.NoError(t, err)
+
+		objectID := "https://remote.example/users/alice/statuses/1"
+		id, err := apRepo.GetCommentIDByObjectID(ctx, objectID)
+		require.NoError(t, err)
+		assert.Zero(t, id)
+
+		require.NoError(t, apRepo.LinkComment(ctx, objectID, comment.ID))
+		id, err = apRepo.GetCommentIDByObjectID(ctx, objectID)
+		require.NoError(t, err)
+		assert.Equal(t, comment.ID, id)
+
+		// The same note can't be linked twice
+		assert.Error(t, apRepo.LinkComment(ctx, objectID, comment.ID))
+
+		// Deleting the comment drops the link
+		require.NoError(t, commentRepo.HardDelete(ctx, comment.ID))
+		id, err = apRepo.GetCommentIDByObjectID(ctx, objectID)
+		require.NoError(t, err)
+		assert.Zero(t, id)
+	})
+}
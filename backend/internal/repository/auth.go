@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"personal-web-platform/internal/domain"
 
@@ -19,6 +20,15 @@ type AuthRepository interface {
 	GetUserByProviderID(ctx context.Context, providerName, providerUserID string) (*domain.User, error)
 	GetOAuthProvider(ctx context.Context, userID int, providerName string) (*domain.OAuthProvider, error)
 	UpdateOAuthProvider(ctx context.Context, provider *domain.OAuthProvider) error
+
+	// Admin user management
+	ListUsers(ctx context.Context, req *domain.ListUsersRequest) ([]domain.UserSummary, int, error)
+	GetUserSummary(ctx context.Context, id int) (*domain.UserSummary, error)
+	// ListOAuthProviders returns the providers linked to a user, without their tokens
+	ListOAuthProviders(ctx context.Context, userID int) ([]domain.OAuthProvider, error)
+	// UpdateUserRole returns the updated user, or nil when there is no such user
+	UpdateUserRole(ctx context.Context, id int, role domain.Role) (*domain.User, error)
+	DeleteUser(ctx context.Context, id int) error
 }
 
 type authRepo struct {
@@ -223,3 +233,177 @@ func (r *authRepo) UpdateOAuthProvider(ctx context.Context, provider *domain.OAu
 
 	return nil
 }
+
+// userSummaryColumns selects a user with the counts of domain.UserSummary
+const userSummaryColumns = `
+		u.id, u.email, u.name, u.avatar_url, u.role, u.created_at,
+		(SELECT COUNT(*) FROM comments c WHERE c.user_id = u.id AND c.deleted_at IS NULL) AS comments_count,
+		(SELECT COUNT(*) FROM post_reactions r WHERE r.user_id = u.id AND r.emoji = '` + domain.LikeEmoji + `')
+			+ (SELECT COUNT(*) FROM comment_reactions r WHERE r.user_id = u.id AND r.emoji = '` + domain.LikeEmoji + `') AS likes_count,
+		(SELECT COUNT(*) FROM sessions s WHERE s.user_id = u.id AND s.expires_at > NOW()) AS sessions_count`
+
+func scanUserSummary(row pgx.Row) (*domain.UserSummary, error) {
+	var user domain.UserSummary
+	err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.AvatarURL,
+		&user.Role,
+		&user.CreatedAt,
+		&user.CommentsCount,
+		&user.LikesCount,
+		&user.SessionsCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *authRepo) ListUsers(ctx context.Context, req *domain.ListUsersRequest) ([]domain.UserSummary, int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Search is a substring match, its wildcards are taken literally
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(req.Search) + "%"
+	where := `
+		WHERE ($1 = '' OR u.email ILIKE $2 OR u.name ILIKE $2)
+		  AND ($3 = '' OR u.role = $3)`
+
+	var totalCount int
+	err := db.QueryRow(ctx, "SELECT COUNT(*) FROM users u"+where, req.Search, pattern, req.Role).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := `SELECT ` + userSummaryColumns + `
+		FROM users u` + where + `
+		ORDER BY u.created_at DESC, u.id DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := db.Query(ctx, query, req.Search, pattern, req.Role, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []domain.UserSummary{}
+	for rows.Next() {
+		user, err := scanUserSummary(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, *user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, totalCount, nil
+}
+
+func (r *authRepo) GetUserSummary(ctx context.Context, id int) (*domain.UserSummary, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `SELECT ` + userSummaryColumns + `
+		FROM users u
+		WHERE u.id = $1
+	`
+
+	user, err := scanUserSummary(db.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // User not found
+		}
+		return nil, fmt.Errorf("failed to get user summary: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *authRepo) ListOAuthProviders(ctx context.Context, userID int) ([]domain.OAuthProvider, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT id, user_id, provider, provider_user_id, created_at, updated_at
+		FROM oauth_providers
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth providers: %w", err)
+	}
+	defer rows.Close()
+
+	providers := []domain.OAuthProvider{}
+	for rows.Next() {
+		var provider domain.OAuthProvider
+		err := rows.Scan(
+			&provider.ID,
+			&provider.UserID,
+			&provider.Provider,
+			&provider.ProviderUserID,
+			&provider.CreatedAt,
+			&provider.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan oauth provider: %w", err)
+		}
+		providers = append(providers, provider)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating oauth providers: %w", err)
+	}
+
+	return providers, nil
+}
+
+func (r *authRepo) UpdateUserRole(ctx context.Context, id int, role domain.Role) (*domain.User, error) {
+	var user domain.User
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE users SET role = $1
+		WHERE id = $2
+		RETURNING id, email, name, avatar_url, role, created_at
+	`
+
+	err := db.QueryRow(ctx, query, role, id).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.AvatarURL,
+		&user.Role,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // User not found
+		}
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	return &user, nil
+}
+
+// DeleteUser deletes a user along with everything that references it.
+// Comments, reactions and posts do so as well, callers move or remove them
+// first so that counts stay right.
+func (r *authRepo) DeleteUser(ctx context.Context, id int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	result, err := db.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
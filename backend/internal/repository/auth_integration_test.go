@@ -214,4 +214,168 @@ func TestAuthRepository_Integration(t *testing.T) {
 			assert.Nil(t, session)
 		}
 	})
+
+	t.Run("Session management", func(t *testing.T) {
+		user, err := repo.CreateUser(ctx, "sessions@example.com", "", "", domain.RoleUser)
+		require.NoError(t, err)
+		other, err := repo.CreateUser(ctx, "sessions-other@example.com", "", "", domain.RoleUser)
+		require.NoError(t, err)
+
+		current := &domain.Session{UserID: user.ID, Token: "current-token", UserAgent: "Firefox", IPAddress: "203.0.113.7", ExpiresAt: time.Now().Add(time.Hour)}
+		require.NoError(t, sessionRepo.CreateSession(ctx, current))
+		assert.False(t, current.LastSeenAt.IsZero())
+		phone := &domain.Session{UserID: user.ID, Token: "phone-token", UserAgent: "Safari", ExpiresAt: time.Now().Add(time.Hour)}
+		require.NoError(t, sessionRepo.CreateSession(ctx, phone))
+		require.NoError(t, sessionRepo.CreateSession(ctx, &domain.Session{UserID: user.ID, Token: "laptop-token", ExpiresAt: time.Now().Add(time.Hour)}))
+		require.NoError(t, sessionRepo.CreateSession(ctx, &domain.Session{UserID: other.ID, Token: "other-token", ExpiresAt: time.Now().Add(time.Hour)}))
+
+		retrieved, err := sessionRepo.GetSession(ctx, "current-token")
+		require.NoError(t, err)
+		require.NotNil(t, retrieved)
+		assert.Equal(t, "Firefox", retrieved.UserAgent)
+		assert.Equal(t, "203.0.113.7", retrieved.IPAddress)
+
+		_, err = testDB.Pool.Exec(ctx, `UPDATE sessions SET last_seen_at = NOW() - INTERVAL '1 day' WHERE id = $1`, current.ID)
+		require.NoError(t, err)
+		require.NoError(t, sessionRepo.TouchSession(ctx, current.ID))
+		retrieved, err = sessionRepo.GetSession(ctx, "current-token")
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), retrieved.LastSeenAt, time.Minute)
+
+		// Only the owner can delete a session
+		deleted, err := sessionRepo.DeleteUserSession(ctx, other.ID, phone.ID)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+		deleted, err = sessionRepo.DeleteUserSession(ctx, user.ID, phone.ID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		revoked, err := sessionRepo.DeleteOtherUserSessions(ctx, user.ID, "current-token")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), revoked)
+
+		sessions, err := sessionRepo.ListUserSessions(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, current.ID, sessions[0].ID)
+
+		kept, err := sessionRepo.GetSession(ctx, "other-token")
+		require.NoError(t, err)
+		assert.NotNil(t, kept)
+	})
+
+	t.Run("Admin user management", func(t *testing.T) {
+		err := testDB.TruncateTables(ctx, "oauth_providers", "sessions", "users")
+		require.NoError(t, err)
+
+		alice, err := repo.CreateUser(ctx, "alice@example.com", "Alice", "", domain.RoleAdmin)
+		require.NoError(t, err)
+		bob, err := repo.CreateUser(ctx, "bob@example.com", "Bob_Smith", "", domain.RoleUser)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.LinkOAuthProvider(ctx, &domain.OAuthProvider{
+			UserID: bob.ID, Provider: "github", ProviderUserID: "bob-gh", AccessToken: "secret",
+		}))
+		require.NoError(t, sessionRepo.CreateSession(ctx, &domain.Session{UserID: bob.ID, Token: "bob-token", ExpiresAt: time.Now().Add(time.Hour)}))
+		require.NoError(t, sessionRepo.CreateSession(ctx, &domain.Session{UserID: bob.ID, Token: "bob-old", ExpiresAt: time.Now().Add(-time.Hour)}))
+
+		users, total, err := repo.ListUsers(ctx, &domain.ListUsersRequest{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, users, 2)
+		assert.Equal(t, bob.ID, users[0].ID) // newest first
+		assert.Equal(t, 1, users[0].SessionsCount)
+
+		// "_" is matched literally
+		users, total, err = repo.ListUsers(ctx, &domain.ListUsersRequest{Search: "b_s", Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, users, 1)
+		assert.Equal(t, bob.ID, users[0].ID)
+		_, total, err = repo.ListUsers(ctx, &domain.ListUsersRequest{Search: "a_i", Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+
+		_, total, err = repo.ListUsers(ctx, &domain.ListUsersRequest{Role: domain.RoleAdmin, Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+
+		summary, err := repo.GetUserSummary(ctx, bob.ID)
+		require.NoError(t, err)
+		require.NotNil(t, summary)
+		assert.Equal(t, "Bob_Smith", summary.Name)
+
+		providers, err := repo.ListOAuthProviders(ctx, bob.ID)
+		require.NoError(t, err)
+		require.Len(t, providers, 1)
+		assert.Equal(t, "github", providers[0].Provider)
+		assert.Empty(t, providers[0].AccessToken)
+
+		sessions, err := sessionRepo.ListUserSessions(ctx, bob.ID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, "bob-token", sessions[0].Token)
+
+		promoted, err := repo.UpdateUserRole(ctx, bob.ID, domain.RoleAdmin)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, promoted.Role)
+		missing, err := repo.UpdateUserRole(ctx, 99999, domain.RoleAdmin)
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+
+		require.NoError(t, repo.DeleteUser(ctx, bob.ID))
+		assert.Error(t, repo.DeleteUser(ctx, bob.ID))
+		deleted, err := repo.GetUserByID(ctx, bob.ID)
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+		session, err := sessionRepo.GetSession(ctx, "bob-token")
+		require.NoError(t, err)
+		assert.Nil(t, session)
+
+		summary, err = repo.GetUserSummary(ctx, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, summary.Role)
+	})
+
+	t.Run("User bans", func(t *testing.T) {
+		banRepo := NewBanRepo(testDB.Pool)
+		admin, err := repo.CreateUser(ctx, "ban-admin@example.com", "", "", domain.RoleAdmin)
+		require.NoError(t, err)
+		user, err := repo.CreateUser(ctx, "banned@example.com", "", "", domain.RoleUser)
+		require.NoError(t, err)
+
+		// An expired ban is kept in the history but not in effect
+		past := time.Now().Add(-time.Hour)
+		require.NoError(t, banRepo.Create(ctx, &domain.UserBan{UserID: user.ID, Reason: "old", IssuedBy: &admin.ID, ExpiresAt: &past}))
+		active, err := banRepo.GetActive(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, active)
+
+		ban := &domain.UserBan{UserID: user.ID, Reason: "spam", IssuedBy: &admin.ID}
+		require.NoError(t, banRepo.Create(ctx, ban))
+		assert.NotZero(t, ban.ID)
+
+		active, err = banRepo.GetActive(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, active)
+		assert.Equal(t, ban.ID, active.ID)
+		assert.Equal(t, "spam", active.Reason)
+		assert.Nil(t, active.ExpiresAt)
+
+		lifted, err := banRepo.LiftActive(ctx, user.ID, admin.ID)
+		require.NoError(t, err)
+		require.NotNil(t, lifted)
+		assert.NotNil(t, lifted.LiftedAt)
+		assert.Equal(t, admin.ID, *lifted.LiftedBy)
+
+		lifted, err = banRepo.LiftActive(ctx, user.ID, admin.ID)
+		require.NoError(t, err)
+		assert.Nil(t, lifted)
+
+		bans, err := banRepo.ListByUser(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, bans, 2)
+		assert.Equal(t, ban.ID, bans[0].ID)
+		assert.Equal(t, "old", bans[1].Reason)
+	})
 }
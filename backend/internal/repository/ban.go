@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BanRepository defines methods for user ban data access
+type BanRepository interface {
+	// LockUser serializes ban changes of a user until the transaction ends
+	LockUser(ctx context.Context, userID int) error
+	Create(ctx context.Context, ban *domain.UserBan) error
+	// GetActive returns the ban in effect for a user, or nil
+	GetActive(ctx context.Context, userID int) (*domain.UserBan, error)
+	// LiftActive lifts the ban in effect for a user and returns it, or nil
+	// when there is none
+	LiftActive(ctx context.Context, userID, adminID int) (*domain.UserBan, error)
+	// ListByUser returns every ban of a user, newest first
+	ListByUser(ctx context.Context, userID int) ([]domain.UserBan, error)
+}
+
+type banRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewBanRepo creates a new ban repository implementation
+func NewBanRepo(db *pgxpool.Pool) BanRepository {
+	return &banRepo{db: db}
+}
+
+const banColumns = `id, user_id, reason, issued_by, created_at, expires_at, lifted_at, lifted_by`
+
+// activeBan matches bans that have not been lifted or expired
+const activeBan = `lifted_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())`
+
+func scanBan(row pgx.Row) (*domain.UserBan, error) {
+	var ban domain.UserBan
+	err := row.Scan(
+		&ban.ID,
+		&ban.UserID,
+		&ban.Reason,
+		&ban.IssuedBy,
+		&ban.CreatedAt,
+		&ban.ExpiresAt,
+		&ban.LiftedAt,
+		&ban.LiftedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+func (r *banRepo) LockUser(ctx context.Context, userID int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Bans reference the row, so a lock weaker than FOR UPDATE keeps them insertable
+	query := `SELECT id FROM users WHERE id = $1 FOR NO KEY UPDATE`
+	if _, err := db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *banRepo) Create(ctx context.Context, ban *domain.UserBan) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO user_bans (user_id, reason, issued_by, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := db.QueryRow(ctx, query, ban.UserID, ban.Reason, ban.IssuedBy, ban.ExpiresAt).Scan(&ban.ID, &ban.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ban: %w", err)
+	}
+
+	return nil
+}
+
+func (r *banRepo) GetActive(ctx context.Context, userID int) (*domain.UserBan, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT ` + banColumns + `
+		FROM user_bans
+		WHERE user_id = $1 AND ` + activeBan + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	ban, err := scanBan(db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Not banned
+		}
+		return nil, fmt.Errorf("failed to get active ban: %w", err)
+	}
+
+	return ban, nil
+}
+
+func (r *banRepo) LiftActive(ctx context.Context, userID, adminID int) (*domain.UserBan, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE user_bans
+		SET lifted_at = NOW(), lifted_by = $2
+		WHERE user_id = $1 AND ` + activeBan + `
+		RETURNING ` + banColumns
+
+	// There is at most one active ban, Create is only called without one
+	// while the user is locked
+	ban, err := scanBan(db.QueryRow(ctx, query, userID, adminID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Not banned
+		}
+		return nil, fmt.Errorf("failed to lift ban: %w", err)
+	}
+
+	return ban, nil
+}
+
+func (r *banRepo) ListByUser(ctx context.Context, userID int) ([]domain.UserBan, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT ` + banColumns + `
+		FROM user_bans
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bans: %w", err)
+	}
+	defer rows.Close()
+
+	bans := []domain.UserBan{}
+	for rows.Next() {
+		ban, err := scanBan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ban: %w", err)
+		}
+		bans = append(bans, *ban)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bans: %w", err)
+	}
+
+	return bans, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BookmarkRepository defines methods for reading list data access
+type BookmarkRepository interface {
+	Add(ctx context.Context, userID, postID int) (bool, error)
+	Remove(ctx context.Context, userID, postID int) (bool, error)
+}
+
+type bookmarkRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewBookmarkRepo creates a new bookmark repository implementation
+func NewBookmarkRepo(db *pgxpool.Pool) BookmarkRepository {
+	return &bookmarkRepo{db: db}
+}
+
+// Add bookmarks a post. Returns false if it was already bookmarked.
+func (r *bookmarkRepo) Add(ctx context.Context, userID, postID int) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+	query := `INSERT INTO bookmarks (user_id, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	result, err := db.Exec(ctx, query, userID, postID)
+	if err != nil {
+		return false, fmt.Errorf("failed to add bookmark: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// Remove deletes a bookmark. Returns false if the post was not bookmarked.
+func (r *bookmarkRepo) Remove(ctx context.Context, userID, postID int) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+	query := `DELETE FROM bookmarks WHERE user_id = $1 AND post_id = $2`
+
+	result, err := db.Exec(ctx, query, userID, postID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
@@ -11,15 +11,56 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// mentionsColumn selects the users mentioned in comment c as a JSON array
+const mentionsColumn = `COALESCE((
+		           SELECT json_agg(json_build_object('user_id', m.user_id, 'name', mu.name, 'handle', m.handle) ORDER BY m.position)
+		           FROM comment_mentions m JOIN users mu ON mu.id = m.user_id WHERE m.comment_id = c.id
+		       ), '[]'::json)`
+
 // CommentRepository defines methods for comment data access
 type CommentRepository interface {
 	Create(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
 	Update(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
 	SoftDelete(ctx context.Context, id int, placeholder string) error
 	HardDelete(ctx context.Context, id int) error
+	// Lock keeps replies from being added to a comment until the transaction ends
+	Lock(ctx context.Context, id int) error
 	HasReplies(ctx context.Context, id int) (bool, error)
+	// PurgeTombstones hard-deletes soft-deleted comments with no live comment
+	// below them, on one post or on all posts when postID is 0, and returns them.
+	// Approved and pending comments are live, rejected and spam ones go with
+	// their ancestors.
+	PurgeTombstones(ctx context.Context, postID int) ([]domain.Comment, error)
+	SetModerationStatus(ctx context.Context, id int, status string) error
 	GetByID(ctx context.Context, id int) (*domain.Comment, error)
-	GetByPostID(ctx context.Context, postID, userID int) ([]domain.Comment, error)
+	ListRevisions(ctx context.Context, commentID int) ([]domain.CommentRevision, error)
+	// ListCommenters returns the users with published comments on a post
+	ListCommenters(ctx context.Context, postID int) ([]domain.User, error)
+	// SetMentions replaces the users mentioned in a comment
+	SetMentions(ctx context.Context, commentID int, mentions []domain.CommentMention) error
+	// GetByPostID returns the comments of a post oldest first, replies are
+	// not nested. Pending comments are included for their author, and for
+	// admins when includePending is set.
+	GetByPostID(ctx context.Context, postID, userID int, includePending bool) ([]domain.Comment, error)
+	HasApproved(ctx context.Context, userID int) (bool, error)
+	// SoftDeleteByUser soft-deletes every live comment of a user and returns
+	// the posts they were on. Edit history, mentions and in-app notifications
+	// of the user's comments are deleted as they still hold the content.
+	SoftDeleteByUser(ctx context.Context, userID int, placeholder string) ([]int, error)
+	// ReassignUser moves every comment of a user to another user
+	ReassignUser(ctx context.Context, fromUserID, toUserID int) (int64, error)
+	CountByUserSince(ctx context.Context, userID int, since time.Time) (int, error)
+	CountByFingerprintSince(ctx context.Context, fingerprint string, since time.Time) (int, error)
+	ListByStatus(ctx context.Context, status string, limit, offset int) ([]domain.QueuedComment, int, error)
+
+	// CreateReport stores a report, it returns false when the user has
+	// reported the comment before
+	CreateReport(ctx context.Context, report *domain.CommentReport) (bool, error)
+	CountOpenReports(ctx context.Context, commentID int) (int, error)
+	// ListReported lists comments with reports in a status, with those reports
+	ListReported(ctx context.Context, status string, limit, offset int) ([]domain.ReportedComment, int, error)
+	// CloseReports sets the status of the open reports of a comment
+	CloseReports(ctx context.Context, commentID int, status string, adminID int) (int64, error)
 }
 
 type commentRepo struct {
@@ -35,17 +76,38 @@ func (r *commentRepo) Create(ctx context.Context, comment *domain.Comment) (*dom
 	var createdComment domain.Comment
 	db := GetQueryEngine(ctx, r.db)
 
+	// Only approved comments are counted, in the same statement
 	query := `
-		INSERT INTO comments (post_id, user_id, content, parent_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, NOW(), NOW())
-		RETURNING id, post_id, user_id, content, parent_id, likes_count, created_at, updated_at, deleted_at
+		WITH created AS (
+			INSERT INTO comments (post_id, user_id, content, parent_id, moderation_status, spam_score, spam_reasons, content_fingerprint, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NOW(), NOW())
+			RETURNING id, post_id, user_id, content, parent_id, likes_count, created_at, updated_at, deleted_at, moderation_status, edit_count
+		), counted AS (
+			UPDATE posts SET comments_count = comments_count + 1
+			FROM created
+			WHERE posts.id = created.post_id AND created.moderation_status = 'approved'
+		)
+		SELECT * FROM created
 	`
 
+	status := comment.ModerationStatus
+	if status == "" {
+		status = domain.CommentStatusApproved
+	}
+	reasons := comment.SpamReasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+
 	err := db.QueryRow(ctx, query,
 		comment.PostID,
 		comment.UserID,
 		comment.Content,
 		comment.ParentID,
+		status,
+		comment.SpamScore,
+		reasons,
+		comment.Fingerprint,
 	).Scan(
 		&createdComment.ID,
 		&createdComment.PostID,
@@ -56,16 +118,16 @@ func (r *commentRepo) Create(ctx context.Context, comment *domain.Comment) (*dom
 		&createdComment.CreatedAt,
 		&createdComment.UpdatedAt,
 		&createdComment.DeletedAt,
+		&createdComment.ModerationStatus,
+		&createdComment.EditCount,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
-	// Increment post comments count
-	_, err = db.Exec(ctx, "UPDATE posts SET comments_count = comments_count + 1 WHERE id = $1", comment.PostID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to increment comments count: %w", err)
-	}
+	createdComment.SpamScore = comment.SpamScore
+	createdComment.SpamReasons = comment.SpamReasons
+	createdComment.Fingerprint = comment.Fingerprint
 
 	return &createdComment, nil
 }
@@ -74,16 +136,33 @@ func (r *commentRepo) Update(ctx context.Context, comment *domain.Comment) (*dom
 	var updatedComment domain.Comment
 	db := GetQueryEngine(ctx, r.db)
 
+	// The replaced content is kept as a revision, the spam screening of the new one replaces the old
 	query := `
-		UPDATE comments
-		SET content = $1, updated_at = NOW()
-		WHERE id = $2 AND deleted_at IS NULL
-		RETURNING id, post_id, user_id, content, parent_id, likes_count, created_at, updated_at, deleted_at
+		WITH old AS (
+			SELECT id, content FROM comments WHERE id = $2 AND deleted_at IS NULL FOR UPDATE
+		), revision AS (
+			INSERT INTO comment_revisions (comment_id, content, created_at)
+			SELECT id, content, NOW() FROM old
+		)
+		UPDATE comments c
+		SET content = $1, content_fingerprint = NULLIF($3, ''), spam_score = $4, spam_reasons = $5,
+			updated_at = NOW(), edit_count = c.edit_count + 1
+		FROM old
+		WHERE c.id = old.id
+		RETURNING c.id, c.post_id, c.user_id, c.content, c.parent_id, c.likes_count, c.created_at, c.updated_at, c.deleted_at, c.moderation_status, c.edit_count
 	`
 
+	reasons := comment.SpamReasons
+	if reasons == nil {
+		reasons = []string{}
+	}
+
 	err := db.QueryRow(ctx, query,
 		comment.Content,
 		comment.ID,
+		comment.Fingerprint,
+		comment.SpamScore,
+		reasons,
 	).Scan(
 		&updatedComment.ID,
 		&updatedComment.PostID,
@@ -94,6 +173,8 @@ func (r *commentRepo) Update(ctx context.Context, comment *domain.Comment) (*dom
 		&updatedComment.CreatedAt,
 		&updatedComment.UpdatedAt,
 		&updatedComment.DeletedAt,
+		&updatedComment.ModerationStatus,
+		&updatedComment.EditCount,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -101,6 +182,7 @@ func (r *commentRepo) Update(ctx context.Context, comment *domain.Comment) (*dom
 		}
 		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
+	updatedComment.Edited = updatedComment.EditCount > 0
 
 	return &updatedComment, nil
 }
@@ -108,51 +190,155 @@ func (r *commentRepo) Update(ctx context.Context, comment *domain.Comment) (*dom
 func (r *commentRepo) SoftDelete(ctx context.Context, id int, placeholder string) error {
 	db := GetQueryEngine(ctx, r.db)
 
+	// Decrement post comments count in the same statement (held comments are not counted)
 	query := `
-		UPDATE comments
-		SET deleted_at = $1, content = $2
-		WHERE id = $3 AND deleted_at IS NULL
+		WITH deleted AS (
+			UPDATE comments
+			SET deleted_at = $1, content = $2
+			WHERE id = $3 AND deleted_at IS NULL
+			RETURNING post_id, moderation_status
+		), counted AS (
+			UPDATE posts SET comments_count = GREATEST(comments_count - 1, 0)
+			FROM deleted
+			WHERE posts.id = deleted.post_id AND deleted.moderation_status = 'approved'
+		)
+		SELECT COUNT(*) FROM deleted
 	`
 
-	result, err := db.Exec(ctx, query, time.Now(), placeholder, id)
-	if err != nil {
+	var deleted int
+	if err := db.QueryRow(ctx, query, time.Now(), placeholder, id).Scan(&deleted); err != nil {
 		return fmt.Errorf("failed to soft delete comment: %w", err)
 	}
-
-	if result.RowsAffected() == 0 {
+	if deleted == 0 {
 		return fmt.Errorf("comment not found or already deleted")
 	}
 
-	// Decrement post comments count
-	var postID int
-	if err := db.QueryRow(ctx, "SELECT post_id FROM comments WHERE id = $1", id).Scan(&postID); err == nil {
-		_, _ = db.Exec(ctx, "UPDATE posts SET comments_count = GREATEST(comments_count - 1, 0) WHERE id = $1", postID)
+	return nil
+}
+
+func (r *commentRepo) SoftDeleteByUser(ctx context.Context, userID int, placeholder string) ([]int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		WITH deleted AS (
+			UPDATE comments
+			SET deleted_at = $1, content = $2
+			WHERE user_id = $3 AND deleted_at IS NULL
+			RETURNING post_id, moderation_status
+		), revisions AS (
+			DELETE FROM comment_revisions WHERE comment_id IN (SELECT id FROM comments WHERE user_id = $3)
+		), mentions AS (
+			DELETE FROM comment_mentions WHERE comment_id IN (SELECT id FROM comments WHERE user_id = $3)
+		), notifications AS (
+			DELETE FROM notifications WHERE comment_id IN (SELECT id FROM comments WHERE user_id = $3)
+		), counted AS (
+			UPDATE posts SET comments_count = GREATEST(comments_count - d.n, 0)
+			FROM (SELECT post_id, COUNT(*) AS n FROM deleted WHERE moderation_status = 'approved' GROUP BY post_id) d
+			WHERE posts.id = d.post_id
+		)
+		SELECT DISTINCT post_id FROM deleted ORDER BY post_id
+	`
+
+	rows, err := db.Query(ctx, query, time.Now(), placeholder, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to soft delete user comments: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
+	postIDs := []int{}
+	for rows.Next() {
+		var postID int
+		if err := rows.Scan(&postID); err != nil {
+			return nil, fmt.Errorf("failed to scan post id: %w", err)
+		}
+		postIDs = append(postIDs, postID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted comments: %w", err)
+	}
+
+	return postIDs, nil
+}
+
+func (r *commentRepo) ReassignUser(ctx context.Context, fromUserID, toUserID int) (int64, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	result, err := db.Exec(ctx, `UPDATE comments SET user_id = $1 WHERE user_id = $2`, toUserID, fromUserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign comments: %w", err)
+	}
+
+	return result.RowsAffected(), nil
 }
 
 func (r *commentRepo) HardDelete(ctx context.Context, id int) error {
 	db := GetQueryEngine(ctx, r.db)
 
-	// Get post_id to decrement count before deletion
-	var postID int
-	// We ignore error here, if comment doesn't exist, DELETE will affect 0 rows
-	_ = db.QueryRow(ctx, "SELECT post_id FROM comments WHERE id = $1", id).Scan(&postID)
+	// Soft-deleted and held comments are already excluded from the count
+	query := `
+		WITH deleted AS (
+			DELETE FROM comments WHERE id = $1
+			RETURNING post_id, deleted_at IS NULL AND moderation_status = 'approved' AS counted
+		), counted AS (
+			UPDATE posts SET comments_count = GREATEST(comments_count - 1, 0)
+			FROM deleted
+			WHERE posts.id = deleted.post_id AND deleted.counted
+		)
+		SELECT COUNT(*) FROM deleted
+	`
 
-	query := `DELETE FROM comments WHERE id = $1`
-	result, err := db.Exec(ctx, query, id)
-	if err != nil {
+	var deleted int
+	if err := db.QueryRow(ctx, query, id).Scan(&deleted); err != nil {
 		return fmt.Errorf("failed to hard delete comment: %w", err)
 	}
-
-	if result.RowsAffected() == 0 {
+	if deleted == 0 {
 		return fmt.Errorf("comment not found")
 	}
 
-	// Get post_id to decrement count
-	if postID != 0 {
-		_, _ = db.Exec(ctx, "UPDATE posts SET comments_count = GREATEST(comments_count - 1, 0) WHERE id = $1", postID)
+	return nil
+}
+
+func (r *commentRepo) SetModerationStatus(ctx context.Context, id int, status string) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Only approved comments are counted, deleted ones were already subtracted.
+	// The count follows the status change in the same statement.
+	query := `
+		WITH old AS (
+			SELECT id, moderation_status FROM comments WHERE id = $2 FOR UPDATE
+		), changed AS (
+			UPDATE comments c
+			SET moderation_status = $1
+			FROM old
+			WHERE c.id = old.id AND old.moderation_status <> $1
+			RETURNING c.post_id, c.deleted_at IS NULL AS live, old.moderation_status = 'approved' AS was_approved
+		), counted AS (
+			UPDATE posts
+			SET comments_count = GREATEST(comments_count + CASE WHEN $1 = 'approved' THEN 1 ELSE -1 END, 0)
+			FROM changed
+			WHERE posts.id = changed.post_id AND changed.live AND changed.was_approved <> ($1 = 'approved')
+		)
+		SELECT COUNT(*) FROM changed
+	`
+	if _, err := db.Exec(ctx, query, status, id); err != nil {
+		return fmt.Errorf("failed to set comment moderation status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *commentRepo) Lock(ctx context.Context, id int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Inserting a reply takes a key share lock on the parent, FOR UPDATE blocks it
+	var locked int
+	err := db.QueryRow(ctx, `SELECT id FROM comments WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("comment not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock comment: %w", err)
 	}
 
 	return nil
@@ -169,14 +355,60 @@ func (r *commentRepo) HasReplies(ctx context.Context, id int) (bool, error) {
 	return exists, nil
 }
 
+func (r *commentRepo) PurgeTombstones(ctx context.Context, postID int) ([]domain.Comment, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Every ancestor of a live comment is kept to hold the thread together.
+	// Soft-deleted comments and the held replies deleted with them are not
+	// counted, so counts don't change.
+	query := `
+		WITH RECURSIVE kept AS (
+			SELECT parent_id AS id FROM comments
+			WHERE deleted_at IS NULL AND moderation_status IN ('approved', 'pending')
+			  AND parent_id IS NOT NULL AND ($1 = 0 OR post_id = $1)
+			UNION
+			SELECT c.parent_id FROM comments c
+			JOIN kept ON c.id = kept.id
+			WHERE c.parent_id IS NOT NULL
+		)
+		DELETE FROM comments c
+		WHERE c.deleted_at IS NOT NULL AND ($1 = 0 OR c.post_id = $1)
+		  AND c.id NOT IN (SELECT id FROM kept)
+		RETURNING c.id, c.post_id, c.parent_id, c.moderation_status
+	`
+
+	rows, err := db.Query(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted comments: %w", err)
+	}
+	defer rows.Close()
+
+	purged := []domain.Comment{}
+	for rows.Next() {
+		var comment domain.Comment
+		if err := rows.Scan(&comment.ID, &comment.PostID, &comment.ParentID, &comment.ModerationStatus); err != nil {
+			return nil, fmt.Errorf("failed to scan purged comment: %w", err)
+		}
+		purged = append(purged, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating purged comments: %w", err)
+	}
+
+	return purged, nil
+}
+
 func (r *commentRepo) GetByID(ctx context.Context, id int) (*domain.Comment, error) {
 	var comment domain.Comment
 	db := GetQueryEngine(ctx, r.db)
 
 	query := `
-		SELECT c.id, c.post_id, c.user_id, c.content, c.parent_id, c.likes_count, c.created_at, c.updated_at, c.deleted_at,
+		SELECT c.id, c.post_id, c.user_id, c.content, c.parent_id, c.likes_count, c.created_at, c.updated_at, c.deleted_at, c.moderation_status, c.edit_count,
 		       u.email, u.name, u.avatar_url, u.role,
-		       (SELECT photo_url FROM profile_info LIMIT 1) as profile_photo
+		       (SELECT photo_url FROM profile_info LIMIT 1) as profile_photo,
+		       ` + reactionsSummaryColumn(domain.ReactionTargetComment, "c.id", "0") + ` as reactions,
+		       ` + mentionsColumn + ` as mentions
 		FROM comments c
 		LEFT JOIN users u ON c.user_id = u.id
 		WHERE c.id = $1
@@ -198,11 +430,15 @@ func (r *commentRepo) GetByID(ctx context.Context, id int) (*domain.Comment, err
 		&comment.CreatedAt,
 		&comment.UpdatedAt,
 		&comment.DeletedAt,
+		&comment.ModerationStatus,
+		&comment.EditCount,
 		&userEmail,
 		&userName,
 		&userAvatar,
 		&userRole,
 		&profilePhoto,
+		&comment.Reactions,
+		&comment.Mentions,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -210,6 +446,7 @@ func (r *commentRepo) GetByID(ctx context.Context, id int) (*domain.Comment, err
 		}
 		return nil, fmt.Errorf("failed to get comment by id: %w", err)
 	}
+	comment.Edited = comment.EditCount > 0
 
 	// Set user if exists
 	if userEmail != "" {
@@ -230,29 +467,30 @@ func (r *commentRepo) GetByID(ctx context.Context, id int) (*domain.Comment, err
 	return &comment, nil
 }
 
-func (r *commentRepo) GetByPostID(ctx context.Context, postID, userID int) ([]domain.Comment, error) {
+func (r *commentRepo) GetByPostID(ctx context.Context, postID, userID int, includePending bool) ([]domain.Comment, error) {
 	db := GetQueryEngine(ctx, r.db)
 	query := `
-		SELECT c.id, c.post_id, c.user_id, c.content, c.parent_id, c.likes_count, c.created_at, c.updated_at, c.deleted_at,
+		SELECT c.id, c.post_id, c.user_id, c.content, c.parent_id, c.likes_count, c.created_at, c.updated_at, c.deleted_at, c.moderation_status, c.edit_count,
 		       u.email, u.name, u.avatar_url, u.role,
-		       EXISTS(SELECT 1 FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.user_id = $2) as is_liked,
+		       EXISTS(SELECT 1 FROM comment_reactions cr WHERE cr.comment_id = c.id AND cr.user_id = $2 AND cr.emoji = '👍') as is_liked,
+		       ` + reactionsSummaryColumn(domain.ReactionTargetComment, "c.id", "$2") + ` as reactions,
+		       ` + mentionsColumn + ` as mentions,
 		       (SELECT photo_url FROM profile_info LIMIT 1) as profile_photo
 		FROM comments c
 		LEFT JOIN users u ON c.user_id = u.id
 		WHERE c.post_id = $1
-		ORDER BY c.created_at ASC
+		  AND (c.moderation_status = 'approved'
+		       OR (c.moderation_status = 'pending' AND ($3 OR c.user_id = $2)))
+		ORDER BY c.created_at ASC, c.id ASC
 	`
 
-	rows, err := db.Query(ctx, query, postID, userID)
+	rows, err := db.Query(ctx, query, postID, userID, includePending)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments by post id: %w", err)
 	}
 	defer rows.Close()
 
-	var comments []domain.Comment
-	commentsMap := make(map[int]*domain.Comment)
-	var rawComments []*domain.Comment
-	// First pass: scan all comments
+	comments := []domain.Comment{}
 	for rows.Next() {
 		var comment domain.Comment
 		var userEmail string
@@ -271,16 +509,21 @@ func (r *commentRepo) GetByPostID(ctx context.Context, postID, userID int) ([]do
 			&comment.CreatedAt,
 			&comment.UpdatedAt,
 			&comment.DeletedAt,
+			&comment.ModerationStatus,
+			&comment.EditCount,
 			&userEmail,
 			&userName,
 			&userAvatar,
 			&userRole,
 			&comment.IsLiked,
+			&comment.Reactions,
+			&comment.Mentions,
 			&profilePhoto,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
+		comment.Edited = comment.EditCount > 0
 
 		// Set user if exists
 		if userEmail != "" {
@@ -298,35 +541,364 @@ func (r *commentRepo) GetByPostID(ctx context.Context, postID, userID int) ([]do
 			}
 		}
 
-		// Initialize replies slice
-		comment.Replies = []*domain.Comment{}
-
-		ptr := &comment
-		commentsMap[comment.ID] = ptr
-		rawComments = append(rawComments, ptr)
+		comments = append(comments, comment)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating comments: %w", err)
 	}
 
-	// Second pass: build tree structure
-	// We iterate over rawComments to preserve order
-	for _, comment := range rawComments {
-		if comment.ParentID != nil {
-			// Nested comment - add to parent's replies
-			if parent, ok := commentsMap[*comment.ParentID]; ok {
-				parent.Replies = append(parent.Replies, comment)
+	return comments, nil
+}
+
+func (r *commentRepo) HasApproved(ctx context.Context, userID int) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM comments WHERE user_id = $1 AND moderation_status = 'approved')`
+	err := db.QueryRow(ctx, query, userID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check approved comments: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *commentRepo) CountByUserSince(ctx context.Context, userID int, since time.Time) (int, error) {
+	db := GetQueryEngine(ctx, r.db)
+	var count int
+	query := `SELECT COUNT(*) FROM comments WHERE user_id = $1 AND created_at >= $2`
+	if err := db.QueryRow(ctx, query, userID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count user comments: %w", err)
+	}
+	return count, nil
+}
+
+func (r *commentRepo) CountByFingerprintSince(ctx context.Context, fingerprint string, since time.Time) (int, error) {
+	db := GetQueryEngine(ctx, r.db)
+	var count int
+	query := `SELECT COUNT(*) FROM comments WHERE content_fingerprint = $1 AND created_at >= $2`
+	if err := db.QueryRow(ctx, query, fingerprint, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count duplicate comments: %w", err)
+	}
+	return count, nil
+}
+
+// ListByStatus lists comments with a moderation status for the moderation
+// queue, pending ones oldest first and the rest newest first
+func (r *commentRepo) ListByStatus(ctx context.Context, status string, limit, offset int) ([]domain.QueuedComment, int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	var totalCount int
+	err := db.QueryRow(ctx,
+		"SELECT COUNT(*) FROM comments WHERE moderation_status = $1 AND deleted_at IS NULL", status,
+	).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	order := "DESC"
+	if status == domain.CommentStatusPending {
+		order = "ASC"
+	}
+	query := `
+		SELECT c.id, c.post_id, c.user_id, c.content, c.parent_id, c.likes_count, c.created_at, c.updated_at, c.deleted_at, c.moderation_status, c.edit_count,
+		       c.spam_score, c.spam_reasons,
+		       u.email, u.name, u.avatar_url, u.role,
+		       p.title, p.slug
+		FROM comments c
+		JOIN posts p ON p.id = c.post_id
+		LEFT JOIN users u ON c.user_id = u.id
+		WHERE c.moderation_status = $1 AND c.deleted_at IS NULL
+		ORDER BY c.created_at ` + order + `, c.id ` + order + `
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := db.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list comments by status: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []domain.QueuedComment{}
+	for rows.Next() {
+		var comment domain.QueuedComment
+		var userEmail string
+		var userName string
+		var userAvatar string
+		var userRole string
+
+		err := rows.Scan(
+			&comment.ID,
+			&comment.PostID,
+			&comment.UserID,
+			&comment.Content,
+			&comment.ParentID,
+			&comment.LikesCount,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.ModerationStatus,
+			&comment.EditCount,
+			&comment.SpamScore,
+			&comment.SpamReasons,
+			&userEmail,
+			&userName,
+			&userAvatar,
+			&userRole,
+			&comment.PostTitle,
+			&comment.PostSlug,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comment.Edited = comment.EditCount > 0
+
+		if userEmail != "" {
+			comment.User = &domain.User{
+				ID:        comment.UserID,
+				Email:     userEmail,
+				Name:      userName,
+				AvatarURL: userAvatar,
+				Role:      domain.Role(userRole),
 			}
 		}
+
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating comments: %w", err)
 	}
 
-	// Third pass: collect roots
-	for _, comment := range rawComments {
-		if comment.ParentID == nil {
-			comments = append(comments, *comment)
+	return comments, totalCount, nil
+}
+
+func (r *commentRepo) ListRevisions(ctx context.Context, commentID int) ([]domain.CommentRevision, error) {
+	db := GetQueryEngine(ctx, r.db)
+	query := `
+		SELECT id, comment_id, content, created_at
+		FROM comment_revisions
+		WHERE comment_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := db.Query(ctx, query, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []domain.CommentRevision{}
+	for rows.Next() {
+		var revision domain.CommentRevision
+		if err := rows.Scan(&revision.ID, &revision.CommentID, &revision.Content, &revision.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment revision: %w", err)
 		}
+		revisions = append(revisions, revision)
 	}
 
-	return comments, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comment revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *commentRepo) ListCommenters(ctx context.Context, postID int) ([]domain.User, error) {
+	db := GetQueryEngine(ctx, r.db)
+	query := `
+		SELECT DISTINCT u.id, u.email, u.name, u.avatar_url, u.role, u.created_at
+		FROM comments c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.post_id = $1 AND c.deleted_at IS NULL AND c.moderation_status = 'approved'
+	`
+
+	rows, err := db.Query(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commenters: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Email, &user.Name, &user.AvatarURL, &user.Role, &user.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan commenter: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating commenters: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *commentRepo) SetMentions(ctx context.Context, commentID int, mentions []domain.CommentMention) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	if _, err := db.Exec(ctx, "DELETE FROM comment_mentions WHERE comment_id = $1", commentID); err != nil {
+		return fmt.Errorf("failed to clear comment mentions: %w", err)
+	}
+
+	for i, m := range mentions {
+		_, err := db.Exec(ctx,
+			"INSERT INTO comment_mentions (comment_id, user_id, handle, position) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING",
+			commentID, m.UserID, m.Handle, i)
+		if err != nil {
+			return fmt.Errorf("failed to save comment mention: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *commentRepo) CreateReport(ctx context.Context, report *domain.CommentReport) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+	query := `
+		INSERT INTO comment_reports (comment_id, user_id, reason, details)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (comment_id, user_id) DO NOTHING
+		RETURNING id, status, created_at
+	`
+
+	err := db.QueryRow(ctx, query, report.CommentID, report.UserID, report.Reason, report.Details).
+		Scan(&report.ID, &report.Status, &report.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create comment report: %w", err)
+	}
+	return true, nil
+}
+
+func (r *commentRepo) CountOpenReports(ctx context.Context, commentID int) (int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	var count int
+	query := `SELECT COUNT(*) FROM comment_reports WHERE comment_id = $1 AND status = 'open'`
+	if err := db.QueryRow(ctx, query, commentID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count comment reports: %w", err)
+	}
+	return count, nil
+}
+
+// ListReported lists reported comments for the reports inbox, open ones
+// reported first on top and the rest most recently reported on top
+func (r *commentRepo) ListReported(ctx context.Context, status string, limit, offset int) ([]domain.ReportedComment, int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	var totalCount int
+	err := db.QueryRow(ctx,
+		"SELECT COUNT(DISTINCT comment_id) FROM comment_reports WHERE status = $1", status,
+	).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count reported comments: %w", err)
+	}
+
+	order := "r.last_reported_at DESC"
+	if status == domain.ReportStatusOpen {
+		order = "r.first_reported_at ASC"
+	}
+	query := `
+		WITH r AS (
+			SELECT comment_id,
+			       MIN(created_at) AS first_reported_at,
+			       MAX(created_at) AS last_reported_at,
+			       json_agg(json_build_object(
+			           'id', id, 'comment_id', comment_id, 'user_id', user_id, 'reason', reason, 'details', details,
+			           'status', status, 'created_at', created_at, 'resolved_at', resolved_at, 'resolved_by', resolved_by
+			       ) ORDER BY created_at, id) AS reports
+			FROM comment_reports
+			WHERE status = $1
+			GROUP BY comment_id
+		)
+		SELECT c.id, c.post_id, c.user_id, c.content, c.parent_id, c.likes_count, c.created_at, c.updated_at, c.deleted_at, c.moderation_status, c.edit_count,
+		       c.spam_score, c.spam_reasons,
+		       u.email, u.name, u.avatar_url, u.role,
+		       p.title, p.slug,
+		       r.reports
+		FROM r
+		JOIN comments c ON c.id = r.comment_id
+		JOIN posts p ON p.id = c.post_id
+		LEFT JOIN users u ON c.user_id = u.id
+		ORDER BY ` + order + `, c.id
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := db.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reported comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []domain.ReportedComment{}
+	for rows.Next() {
+		var comment domain.ReportedComment
+		var userEmail string
+		var userName string
+		var userAvatar string
+		var userRole string
+
+		err := rows.Scan(
+			&comment.ID,
+			&comment.PostID,
+			&comment.UserID,
+			&comment.Content,
+			&comment.ParentID,
+			&comment.LikesCount,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.DeletedAt,
+			&comment.ModerationStatus,
+			&comment.EditCount,
+			&comment.SpamScore,
+			&comment.SpamReasons,
+			&userEmail,
+			&userName,
+			&userAvatar,
+			&userRole,
+			&comment.PostTitle,
+			&comment.PostSlug,
+			&comment.Reports,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan reported comment: %w", err)
+		}
+		comment.Edited = comment.EditCount > 0
+
+		if userEmail != "" {
+			comment.User = &domain.User{
+				ID:        comment.UserID,
+				Email:     userEmail,
+				Name:      userName,
+				AvatarURL: userAvatar,
+				Role:      domain.Role(userRole),
+			}
+		}
+
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating reported comments: %w", err)
+	}
+
+	return comments, totalCount, nil
+}
+
+func (r *commentRepo) CloseReports(ctx context.Context, commentID int, status string, adminID int) (int64, error) {
+	db := GetQueryEngine(ctx, r.db)
+	query := `
+		UPDATE comment_reports
+		SET status = $2, resolved_at = NOW(), resolved_by = $3
+		WHERE comment_id = $1 AND status = 'open'
+	`
+
+	result, err := db.Exec(ctx, query, commentID, status, adminID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close comment reports: %w", err)
+	}
+	return result.RowsAffected(), nil
 }
@@ -107,6 +107,23 @@ func TestCommentRepository_Integration(t *testing.T) {
 		require.NotNil(t, updated)
 		assert.Equal(t, "Updated content", updated.Content)
 		assert.True(t, updated.UpdatedAt.After(updated.CreatedAt))
+		assert.True(t, updated.Edited)
+		assert.Equal(t, 1, updated.EditCount)
+
+		// Every edit keeps the replaced content
+		updated.Content = "Updated again"
+		_, err = commentRepo.Update(ctx, updated)
+		require.NoError(t, err)
+
+		revisions, err := commentRepo.ListRevisions(ctx, created.ID)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Equal(t, "Original content", revisions[0].Content)
+		assert.Equal(t, "Updated content", revisions[1].Content)
+
+		retrieved, err := commentRepo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, retrieved.EditCount)
 	})
 
 	t.Run("Delete comment (soft delete)", func(t *testing.T) {
@@ -144,7 +161,7 @@ func TestCommentRepository_Integration(t *testing.T) {
 		}
 
 		// Get all comments for the post
-		comments, err := commentRepo.GetByPostID(ctx, post.ID, 0)
+		comments, err := commentRepo.GetByPostID(ctx, post.ID, 0, false)
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(comments), 5)
 
@@ -168,7 +185,7 @@ func TestCommentRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Get comments - repository returns all comments (filtering is done at service layer)
-		comments, err := commentRepo.GetByPostID(ctx, post.ID, 0)
+		comments, err := commentRepo.GetByPostID(ctx, post.ID, 0, false)
 		require.NoError(t, err)
 
 		// Find the deleted comment and verify DeletedAt is set
@@ -222,6 +239,338 @@ func TestCommentRepository_Integration(t *testing.T) {
 		assert.Equal(t, reply1.ID, *reply2.ParentID)
 	})
 
+	t.Run("SetModerationStatus", func(t *testing.T) {
+		comment, err := commentRepo.Create(ctx, &domain.Comment{
+			PostID:  post.ID,
+			UserID:  user.ID,
+			Content: "Buy cheap watches",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusApproved, comment.ModerationStatus)
+
+		before, err := postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+
+		require.NoError(t, commentRepo.SetModerationStatus(ctx, comment.ID, domain.CommentStatusSpam))
+		// Setting the same status again is a no-op
+		require.NoError(t, commentRepo.SetModerationStatus(ctx, comment.ID, domain.CommentStatusSpam))
+
+		retrieved, err := commentRepo.GetByID(ctx, comment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusSpam, retrieved.ModerationStatus)
+
+		after, err := postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, before.CommentsCount-1, after.CommentsCount)
+
+		// Spam is hidden from the public thread
+		comments, err := commentRepo.GetByPostID(ctx, post.ID, 0, false)
+		require.NoError(t, err)
+		for _, c := range comments {
+			assert.NotEqual(t, comment.ID, c.ID)
+		}
+
+		require.NoError(t, commentRepo.SetModerationStatus(ctx, comment.ID, domain.CommentStatusApproved))
+		after, err = postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, before.CommentsCount, after.CommentsCount)
+	})
+
+	t.Run("Pending comments", func(t *testing.T) {
+		other, err := authRepo.CreateUser(ctx, "reader@example.com", "", "", domain.RoleUser)
+		require.NoError(t, err)
+
+		before, err := postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+
+		held, err := commentRepo.Create(ctx, &domain.Comment{
+			PostID:           post.ID,
+			UserID:           other.ID,
+			Content:          "First time here",
+			ModerationStatus: domain.CommentStatusPending,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.CommentStatusPending, held.ModerationStatus)
+
+		// Held comments are not counted
+		after, err := postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, before.CommentsCount, after.CommentsCount)
+
+		hasApproved, err := commentRepo.HasApproved(ctx, other.ID)
+		require.NoError(t, err)
+		assert.False(t, hasApproved)
+
+		// Visible to the author and admins only
+		contains := func(comments []domain.Comment) bool {
+			for _, c := range comments {
+				if c.ID == held.ID {
+					return true
+				}
+			}
+			return false
+		}
+		comments, err := commentRepo.GetByPostID(ctx, post.ID, 0, false)
+		require.NoError(t, err)
+		assert.False(t, contains(comments))
+		comments, err = commentRepo.GetByPostID(ctx, post.ID, user.ID, false)
+		require.NoError(t, err)
+		assert.False(t, contains(comments))
+		comments, err = commentRepo.GetByPostID(ctx, post.ID, other.ID, false)
+		require.NoError(t, err)
+		assert.True(t, contains(comments))
+		comments, err = commentRepo.GetByPostID(ctx, post.ID, user.ID, true)
+		require.NoError(t, err)
+		assert.True(t, contains(comments))
+
+		queue, total, err := commentRepo.ListByStatus(ctx, domain.CommentStatusPending, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, queue, 1)
+		assert.Equal(t, held.ID, queue[0].ID)
+		assert.Equal(t, post.Slug, queue[0].PostSlug)
+
+		// Rejecting a held comment does not touch the count, approving does
+		require.NoError(t, commentRepo.SetModerationStatus(ctx, held.ID, domain.CommentStatusRejected))
+		after, err = postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, before.CommentsCount, after.CommentsCount)
+
+		require.NoError(t, commentRepo.SetModerationStatus(ctx, held.ID, domain.CommentStatusApproved))
+		after, err = postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, before.CommentsCount+1, after.CommentsCount)
+
+		hasApproved, err = commentRepo.HasApproved(ctx, other.ID)
+		require.NoError(t, err)
+		assert.True(t, hasApproved)
+	})
+
+	t.Run("Spam signals", func(t *testing.T) {
+		since := time.Now().Add(-time.Minute)
+		before, err := commentRepo.CountByUserSince(ctx, user.ID, since)
+		require.NoError(t, err)
+
+		held, err := commentRepo.Create(ctx, &domain.Comment{
+			PostID:           post.ID,
+			UserID:           user.ID,
+			Content:          "Best online casino, visit now",
+			ModerationStatus: domain.CommentStatusPending,
+			SpamScore:        6,
+			SpamReasons:      []string{"stop_words", "new_account"},
+			Fingerprint:      "f1ngerprint",
+		})
+		require.NoError(t, err)
+
+		count, err := commentRepo.CountByUserSince(ctx, user.ID, since)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, count)
+
+		duplicates, err := commentRepo.CountByFingerprintSince(ctx, "f1ngerprint", since)
+		require.NoError(t, err)
+		assert.Equal(t, 1, duplicates)
+
+		// The score is shown in the moderation queue
+		queue, _, err := commentRepo.ListByStatus(ctx, domain.CommentStatusPending, 100, 0)
+		require.NoError(t, err)
+		var found bool
+		for _, c := range queue {
+			if c.ID == held.ID {
+				found = true
+				assert.Equal(t, 6, c.SpamScore)
+				assert.Equal(t, []string{"stop_words", "new_account"}, c.SpamReasons)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("Mentions", func(t *testing.T) {
+		ivan, err := authRepo.CreateUser(ctx, "ivan@example.com", "Ivan", "", domain.RoleUser)
+		require.NoError(t, err)
+		_, err = commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: ivan.ID, Content: "Hello"})
+		require.NoError(t, err)
+
+		commenters, err := commentRepo.ListCommenters(ctx, post.ID)
+		require.NoError(t, err)
+		var found bool
+		for _, u := range commenters {
+			found = found || u.ID == ivan.ID
+		}
+		assert.True(t, found)
+
+		comment, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: user.ID, Content: "@ivan hi"})
+		require.NoError(t, err)
+		require.NoError(t, commentRepo.SetMentions(ctx, comment.ID, []domain.CommentMention{
+			{UserID: ivan.ID, Handle: "ivan"},
+		}))
+
+		got, err := commentRepo.GetByID(ctx, comment.ID)
+		require.NoError(t, err)
+		require.Len(t, got.Mentions, 1)
+		assert.Equal(t, ivan.ID, got.Mentions[0].UserID)
+		assert.Equal(t, "Ivan", got.Mentions[0].Name)
+
+		// Replaced on edit
+		require.NoError(t, commentRepo.SetMentions(ctx, comment.ID, nil))
+		got, err = commentRepo.GetByID(ctx, comment.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got.Mentions)
+	})
+
+	t.Run("Reports", func(t *testing.T) {
+		reporter, err := authRepo.CreateUser(ctx, "reporter@example.com", "Reporter", "", domain.RoleUser)
+		require.NoError(t, err)
+		comment, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: user.ID, Content: "Rude words"})
+		require.NoError(t, err)
+
+		report := &domain.CommentReport{CommentID: comment.ID, UserID: reporter.ID, Reason: domain.ReportReasonAbuse}
+		created, err := commentRepo.CreateReport(ctx, report)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, domain.ReportStatusOpen, report.Status)
+
+		// One report per user
+		created, err = commentRepo.CreateReport(ctx, &domain.CommentReport{CommentID: comment.ID, UserID: reporter.ID, Reason: domain.ReportReasonSpam})
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		count, err := commentRepo.CountOpenReports(ctx, comment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		reported, total, err := commentRepo.ListReported(ctx, domain.ReportStatusOpen, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, reported, 1)
+		assert.Equal(t, comment.ID, reported[0].ID)
+		assert.Equal(t, post.Slug, reported[0].PostSlug)
+		require.Len(t, reported[0].Reports, 1)
+		assert.Equal(t, domain.ReportReasonAbuse, reported[0].Reports[0].Reason)
+
+		closed, err := commentRepo.CloseReports(ctx, comment.ID, domain.ReportStatusDismissed, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), closed)
+		closed, err = commentRepo.CloseReports(ctx, comment.ID, domain.ReportStatusResolved, user.ID)
+		require.NoError(t, err)
+		assert.Zero(t, closed)
+
+		reported, _, err = commentRepo.ListReported(ctx, domain.ReportStatusDismissed, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, reported, 1)
+		require.NotNil(t, reported[0].Reports[0].ResolvedBy)
+		assert.Equal(t, user.ID, *reported[0].Reports[0].ResolvedBy)
+	})
+
+	t.Run("PurgeTombstones", func(t *testing.T) {
+		other, err := postRepo.Create(ctx, &domain.Post{Title: "Tombstones", Slug: "tombstones", Content: "Post with deleted comments", AuthorID: user.ID, Published: true})
+		require.NoError(t, err)
+
+		createWithStatus := func(parentID *int, status string) *domain.Comment {
+			c, err := commentRepo.Create(ctx, &domain.Comment{PostID: other.ID, UserID: user.ID, Content: "Comment", ParentID: parentID, ModerationStatus: status})
+			require.NoError(t, err)
+			return c
+		}
+		create := func(parentID *int) *domain.Comment {
+			return createWithStatus(parentID, domain.CommentStatusApproved)
+		}
+
+		// root (deleted) <- middle (deleted) <- leaf, kept (deleted) <- live,
+		// held (deleted) <- pending and rejected (deleted) <- rejected reply
+		root := create(nil)
+		middle := create(&root.ID)
+		leaf := create(&middle.ID)
+		kept := create(nil)
+		create(&kept.ID)
+		held := create(nil)
+		createWithStatus(&held.ID, domain.CommentStatusPending)
+		rejected := create(nil)
+		rejectedReply := createWithStatus(&rejected.ID, domain.CommentStatusRejected)
+		require.NoError(t, commentRepo.SoftDelete(ctx, root.ID, "Deleted"))
+		require.NoError(t, commentRepo.SoftDelete(ctx, middle.ID, "Deleted"))
+		require.NoError(t, commentRepo.SoftDelete(ctx, kept.ID, "Deleted"))
+		require.NoError(t, commentRepo.SoftDelete(ctx, held.ID, "Deleted"))
+		require.NoError(t, commentRepo.SoftDelete(ctx, rejected.ID, "Deleted"))
+
+		// A rejected reply doesn't hold up its parent, a pending one does
+		purged, err := commentRepo.PurgeTombstones(ctx, other.ID)
+		require.NoError(t, err)
+		if assert.Len(t, purged, 1) {
+			assert.Equal(t, rejected.ID, purged[0].ID)
+		}
+		gone, err := commentRepo.GetByID(ctx, rejectedReply.ID)
+		require.NoError(t, err)
+		assert.Nil(t, gone)
+
+		require.NoError(t, commentRepo.HardDelete(ctx, leaf.ID))
+		purged, err = commentRepo.PurgeTombstones(ctx, other.ID)
+		require.NoError(t, err)
+		assert.Len(t, purged, 2)
+
+		gone, err = commentRepo.GetByID(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Nil(t, gone)
+		still, err := commentRepo.GetByID(ctx, kept.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, still)
+		still, err = commentRepo.GetByID(ctx, held.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, still)
+
+		// Only the live reply is counted
+		updated, err := postRepo.GetByID(ctx, other.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.CommentsCount)
+	})
+
+	t.Run("SoftDeleteByUser and ReassignUser", func(t *testing.T) {
+		leaving, err := authRepo.CreateUser(ctx, "leaving@example.com", "", "", domain.RoleUser)
+		require.NoError(t, err)
+		ghost, err := authRepo.CreateUser(ctx, "ghost@example.com", "", "", domain.RoleUser)
+		require.NoError(t, err)
+		other, err := postRepo.Create(ctx, &domain.Post{Title: "Leaving", Slug: "leaving", Content: "Post with comments of a leaving user", AuthorID: user.ID, Published: true})
+		require.NoError(t, err)
+
+		first, err := commentRepo.Create(ctx, &domain.Comment{PostID: other.ID, UserID: leaving.ID, Content: "First"})
+		require.NoError(t, err)
+		_, err = commentRepo.Create(ctx, &domain.Comment{PostID: other.ID, UserID: leaving.ID, Content: "Held", ModerationStatus: domain.CommentStatusPending})
+		require.NoError(t, err)
+		_, err = commentRepo.Create(ctx, &domain.Comment{PostID: other.ID, UserID: user.ID, Content: "Reply", ParentID: &first.ID})
+		require.NoError(t, err)
+
+		// An edit and a mention keep content of the comment around
+		_, err = commentRepo.Update(ctx, &domain.Comment{ID: first.ID, Content: "First, edited @user"})
+		require.NoError(t, err)
+		require.NoError(t, commentRepo.SetMentions(ctx, first.ID, []domain.CommentMention{{UserID: user.ID, Handle: "user"}}))
+
+		postIDs, err := commentRepo.SoftDeleteByUser(ctx, leaving.ID, "Deleted")
+		require.NoError(t, err)
+		assert.Equal(t, []int{other.ID}, postIDs)
+
+		revisions, err := commentRepo.ListRevisions(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+
+		var mentions int
+		err = testDB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM comment_mentions WHERE comment_id = $1", first.ID).Scan(&mentions)
+		require.NoError(t, err)
+		assert.Zero(t, mentions)
+
+		// Only the approved comment was counted
+		updated, err := postRepo.GetByID(ctx, other.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, updated.CommentsCount)
+
+		moved, err := commentRepo.ReassignUser(ctx, leaving.ID, ghost.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), moved)
+
+		tombstone, err := commentRepo.GetByID(ctx, first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ghost.ID, tombstone.UserID)
+		assert.Equal(t, "Deleted", tombstone.Content)
+		assert.NotNil(t, tombstone.DeletedAt)
+	})
+
 	t.Run("GetByID returns nil for non-existent comment", func(t *testing.T) {
 		comment, err := commentRepo.GetByID(ctx, 99999)
 		require.NoError(t, err)
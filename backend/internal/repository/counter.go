@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CounterRepository defines methods for checking denormalized counters
+// against the rows they count
+type CounterRepository interface {
+	// ListDrift returns every counter that differs from its actual value
+	ListDrift(ctx context.Context) ([]domain.CounterDrift, error)
+	// Recount locks the row holding a counter and sets it to its actual
+	// value, which is returned. It must run in a transaction.
+	Recount(ctx context.Context, counter string, id int) (int, error)
+}
+
+type counterRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCounterRepo creates a new counter repository implementation
+func NewCounterRepo(db *pgxpool.Pool) CounterRepository {
+	return &counterRepo{db: db}
+}
+
+// counterColumn describes where a counter is stored and how it is computed for row t
+type counterColumn struct {
+	table  string
+	column string
+	actual string
+}
+
+// counterColumns whitelists counters used to build queries
+var counterColumns = map[string]counterColumn{
+	domain.CounterPostLikes: {
+		table:  "posts",
+		column: "likes_count",
+		actual: "(SELECT COUNT(*) FROM post_reactions r WHERE r.post_id = t.id AND r.emoji = '" + domain.LikeEmoji + "')",
+	},
+	domain.CounterPostComments: {
+		table:  "posts",
+		column: "comments_count",
+		actual: "(SELECT COUNT(*) FROM comments c WHERE c.post_id = t.id AND c.deleted_at IS NULL AND c.moderation_status = 'approved')",
+	},
+	domain.CounterCommentLikes: {
+		table:  "comments",
+		column: "likes_count",
+		actual: "(SELECT COUNT(*) FROM comment_reactions r WHERE r.comment_id = t.id AND r.emoji = '" + domain.LikeEmoji + "')",
+	},
+}
+
+// counterOrder keeps drift reports stable
+var counterOrder = []string{domain.CounterPostLikes, domain.CounterPostComments, domain.CounterCommentLikes}
+
+func (r *counterRepo) ListDrift(ctx context.Context) ([]domain.CounterDrift, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	parts := make([]string, 0, len(counterOrder))
+	for _, name := range counterOrder {
+		c := counterColumns[name]
+		parts = append(parts, fmt.Sprintf(`
+			SELECT '%[1]s' AS counter, id, stored, actual FROM (
+				SELECT t.id, t.%[3]s AS stored, %[4]s AS actual FROM %[2]s t
+			) counts
+			WHERE stored <> actual`, name, c.table, c.column, c.actual))
+	}
+	query := strings.Join(parts, "\n\t\t\tUNION ALL") + "\n\t\tORDER BY counter, id"
+
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list counter drift: %w", err)
+	}
+	defer rows.Close()
+
+	drift := []domain.CounterDrift{}
+	for rows.Next() {
+		var d domain.CounterDrift
+		if err := rows.Scan(&d.Counter, &d.ID, &d.Stored, &d.Actual); err != nil {
+			return nil, fmt.Errorf("failed to scan counter drift: %w", err)
+		}
+		drift = append(drift, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating counter drift: %w", err)
+	}
+
+	return drift, nil
+}
+
+func (r *counterRepo) Recount(ctx context.Context, counter string, id int) (int, error) {
+	c, ok := counterColumns[counter]
+	if !ok {
+		return 0, fmt.Errorf("unknown counter: %s", counter)
+	}
+	db := GetQueryEngine(ctx, r.db)
+
+	// Likes and comments change their counter in the same statement, so once
+	// the row is locked the count in a new statement includes all of them
+	var locked int
+	lock := fmt.Sprintf("SELECT id FROM %s WHERE id = $1 FOR UPDATE", c.table)
+	if err := db.QueryRow(ctx, lock, id).Scan(&locked); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil // Deleted since the drift was found
+		}
+		return 0, fmt.Errorf("failed to lock %s: %w", c.table, err)
+	}
+
+	var actual int
+	query := fmt.Sprintf("UPDATE %[1]s t SET %[2]s = %[3]s WHERE t.id = $1 RETURNING t.%[2]s", c.table, c.column, c.actual)
+	if err := db.QueryRow(ctx, query, id).Scan(&actual); err != nil {
+		return 0, fmt.Errorf("failed to recount %s: %w", counter, err)
+	}
+	return actual, nil
+}
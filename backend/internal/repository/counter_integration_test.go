@@ -0,0 +1,102 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	postRepo := NewPostRepo(testDB.Pool)
+	likeRepo := NewLikeRepository(testDB.Pool)
+	counterRepo := NewCounterRepo(testDB.Pool)
+	transactor := &txManager{pool: testDB.Pool}
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "post_reactions", "comments", "posts", "users")
+	require.NoError(t, err)
+
+	author, err := authRepo.CreateUser(ctx, "counter-author@example.com", "", "", domain.RoleUser)
+	require.NoError(t, err)
+
+	post, err := postRepo.Create(ctx, &domain.Post{
+		Title:     "Counters",
+		Slug:      "counters",
+		Content:   "Post to test like and comment counters",
+		AuthorID:  author.ID,
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("Concurrent toggles keep the count", func(t *testing.T) {
+		users := make([]*domain.User, 10)
+		for i := range users {
+			users[i], err = authRepo.CreateUser(ctx, "liker"+string(rune('a'+i))+"@example.com", "", "", domain.RoleUser)
+			require.NoError(t, err)
+		}
+
+		// Every user toggles three times, ending up liking the post
+		var wg sync.WaitGroup
+		for _, user := range users {
+			wg.Add(1)
+			go func(userID int) {
+				defer wg.Done()
+				for range 3 {
+					_, err := likeRepo.TogglePostLike(ctx, userID, post.ID)
+					assert.NoError(t, err)
+				}
+			}(user.ID)
+		}
+		wg.Wait()
+
+		got, err := postRepo.GetByID(ctx, post.ID, 0)
+		require.NoError(t, err)
+		count, err := likeRepo.GetPostLikesCount(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, len(users), count)
+		assert.Equal(t, count, got.LikesCount)
+
+		drift, err := counterRepo.ListDrift(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, drift)
+	})
+
+	t.Run("Drift is found and recounted", func(t *testing.T) {
+		_, err := testDB.Pool.Exec(ctx, "UPDATE posts SET likes_count = 42, comments_count = 7 WHERE id = $1", post.ID)
+		require.NoError(t, err)
+
+		drift, err := counterRepo.ListDrift(ctx)
+		require.NoError(t, err)
+		require.Len(t, drift, 2)
+		assert.Equal(t, domain.CounterDrift{Counter: domain.CounterPostComments, ID: post.ID, Stored: 7, Actual: 0}, drift[0])
+		assert.Equal(t, domain.CounterPostLikes, drift[1].Counter)
+		assert.Equal(t, 42, drift[1].Stored)
+
+		for _, d := range drift {
+			err := transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+				actual, err := counterRepo.Recount(ctx, d.Counter, d.ID)
+				assert.Equal(t, d.Actual, actual)
+				return err
+			})
+			require.NoError(t, err)
+		}
+
+		drift, err = counterRepo.ListDrift(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, drift)
+	})
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postEventsChannel is the LISTEN/NOTIFY channel carrying "post_id:id" of new post events
+const postEventsChannel = "post_events"
+
+// EventRepository defines methods for post event data access
+type EventRepository interface {
+	// Create stores an event. With notifications enabled, listeners get its ID
+	// when the surrounding transaction commits.
+	Create(ctx context.Context, event *domain.PostEvent) error
+	GetByID(ctx context.Context, id int64) (*domain.PostEvent, error)
+	// ListAfter returns the events of a post after afterID, oldest first.
+	// IDs are assigned on insert, events committed out of order with a lower
+	// ID than afterID are not returned.
+	ListAfter(ctx context.Context, postID int, afterID int64, limit int) ([]domain.PostEvent, error)
+	// LatestID returns the ID of the newest event of a post, 0 if there are none
+	LatestID(ctx context.Context, postID int) (int64, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// Listen calls handle for every event created from now on, by any
+	// instance, until ctx is done or the connection fails
+	Listen(ctx context.Context, handle func(postID int, id int64)) error
+}
+
+type eventRepo struct {
+	db     *pgxpool.Pool
+	notify bool
+}
+
+// NewEventRepo creates a new post event repository implementation
+func NewEventRepo(db *pgxpool.Pool, notify bool) EventRepository {
+	return &eventRepo{db: db, notify: notify}
+}
+
+func (r *eventRepo) Create(ctx context.Context, event *domain.PostEvent) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO post_events (post_id, type, data)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	if r.notify {
+		query = `
+			WITH inserted AS (
+				INSERT INTO post_events (post_id, type, data)
+				VALUES ($1, $2, $3)
+				RETURNING id, post_id, created_at
+			)
+			SELECT id, created_at, pg_notify('` + postEventsChannel + `', post_id || ':' || id) FROM inserted
+		`
+	}
+
+	row := db.QueryRow(ctx, query, event.PostID, event.Type, event.Data)
+	var err error
+	if r.notify {
+		err = row.Scan(&event.ID, &event.CreatedAt, nil)
+	} else {
+		err = row.Scan(&event.ID, &event.CreatedAt)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create post event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *eventRepo) GetByID(ctx context.Context, id int64) (*domain.PostEvent, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `SELECT id, post_id, type, data, created_at FROM post_events WHERE id = $1`
+	var e domain.PostEvent
+	err := db.QueryRow(ctx, query, id).Scan(&e.ID, &e.PostID, &e.Type, &e.Data, &e.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get post event: %w", err)
+	}
+
+	return &e, nil
+}
+
+func (r *eventRepo) ListAfter(ctx context.Context, postID int, afterID int64, limit int) ([]domain.PostEvent, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT id, post_id, type, data, created_at
+		FROM post_events
+		WHERE post_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+	rows, err := db.Query(ctx, query, postID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.PostEvent, 0)
+	for rows.Next() {
+		var e domain.PostEvent
+		if err := rows.Scan(&e.ID, &e.PostID, &e.Type, &e.Data, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan post event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate post events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *eventRepo) LatestID(ctx context.Context, postID int) (int64, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	var id int64
+	err := db.QueryRow(ctx, `SELECT COALESCE(MAX(id), 0) FROM post_events WHERE post_id = $1`, postID).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest post event: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *eventRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	tag, err := db.Exec(ctx, `DELETE FROM post_events WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete post events: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *eventRepo) Listen(ctx context.Context, handle func(postID int, id int64)) error {
+	// LISTEN needs a connection of its own for as long as it runs
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+postEventsChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	defer func() {
+		// The connection goes back to the pool, stop listening first
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_, _ = conn.Exec(ctx, "UNLISTEN "+postEventsChannel)
+	}()
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+		post, event, ok := strings.Cut(n.Payload, ":")
+		if !ok {
+			continue
+		}
+		postID, err := strconv.Atoi(post)
+		if err != nil {
+			continue
+		}
+		id, err := strconv.ParseInt(event, 10, 64)
+		if err != nil {
+			continue
+		}
+		handle(postID, id)
+	}
+}
@@ -0,0 +1,114 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	postRepo := NewPostRepo(testDB.Pool)
+	repo := NewEventRepo(testDB.Pool, true)
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "post_events", "posts", "users")
+	require.NoError(t, err)
+
+	author, err := authRepo.CreateUser(ctx, "author@example.com", "Author", "", domain.RoleAdmin)
+	require.NoError(t, err)
+	post, err := postRepo.Create(ctx, &domain.Post{
+		Title:     "Live",
+		Slug:      "live",
+		Content:   "Post to test live events",
+		AuthorID:  author.ID,
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	create := func(eventType string) *domain.PostEvent {
+		event := &domain.PostEvent{PostID: post.ID, Type: eventType, Data: json.RawMessage(`{"likes_count":1}`)}
+		require.NoError(t, repo.Create(ctx, event))
+		return event
+	}
+
+	t.Run("Listen receives created events", func(t *testing.T) {
+		listenCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type notification struct {
+			postID int
+			id     int64
+		}
+		received := make(chan notification, 32)
+		done := make(chan error, 1)
+		go func() {
+			done <- repo.Listen(listenCtx, func(postID int, id int64) {
+				received <- notification{postID, id}
+			})
+		}()
+
+		// LISTEN starts in the background, retry until a notification arrives
+		var got notification
+		for attempt := 0; attempt < 25 && got.id == 0; attempt++ {
+			create(domain.PostEventLikesUpdated)
+			select {
+			case got = <-received:
+			case <-time.After(200 * time.Millisecond):
+			}
+		}
+		require.NotZero(t, got.id, "no notification received")
+		assert.Equal(t, post.ID, got.postID)
+
+		stored, err := repo.GetByID(ctx, got.id)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, domain.PostEventLikesUpdated, stored.Type)
+		assert.JSONEq(t, `{"likes_count":1}`, string(stored.Data))
+
+		cancel()
+		assert.Error(t, <-done)
+	})
+
+	t.Run("ListAfter and LatestID", func(t *testing.T) {
+		first := create(domain.PostEventCommentCreated)
+		second := create(domain.PostEventCommentDeleted)
+
+		events, err := repo.ListAfter(ctx, post.ID, first.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, second.ID, events[0].ID)
+
+		latest, err := repo.LatestID(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, latest)
+
+		latest, err = repo.LatestID(ctx, post.ID+1)
+		require.NoError(t, err)
+		assert.Zero(t, latest)
+	})
+
+	t.Run("DeleteBefore", func(t *testing.T) {
+		deleted, err := repo.DeleteBefore(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Positive(t, deleted)
+
+		missing, err := repo.GetByID(ctx, 1<<40)
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+}
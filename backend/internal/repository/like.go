@@ -3,71 +3,54 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"personal-web-platform/internal/domain"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // LikeRepository defines the interface for like operations.
+// Likes are stored as "👍" reactions.
 type LikeRepository interface {
 	// Post likes
 	TogglePostLike(ctx context.Context, userID, postID int) (bool, error)
 	GetPostLikesCount(ctx context.Context, postID int) (int, error)
 	IsPostLikedByUser(ctx context.Context, userID, postID int) (bool, error)
+	// SetPostLike likes or unlikes a post. Returns true if the state changed.
+	SetPostLike(ctx context.Context, userID, postID int, liked bool) (bool, error)
 
 	// Comment likes
 	ToggleCommentLike(ctx context.Context, userID, commentID int) (bool, error)
 	GetCommentLikesCount(ctx context.Context, commentID int) (int, error)
 	IsCommentLikedByUser(ctx context.Context, userID, commentID int) (bool, error)
+	// SetCommentLike likes or unlikes a comment. Returns true if the state changed.
+	SetCommentLike(ctx context.Context, userID, commentID int, liked bool) (bool, error)
+
+	// ListLikers returns the users who liked a post or comment, newest first
+	ListLikers(ctx context.Context, target domain.ReactionTarget, targetID, limit, offset int) ([]domain.Liker, int, error)
+	// ListLikedBy returns the published posts and comments a user liked, newest first
+	ListLikedBy(ctx context.Context, userID, limit, offset int) ([]domain.LikedItem, int, error)
 }
 
 type likeRepository struct {
-	db *pgxpool.Pool
+	db        *pgxpool.Pool
+	reactions ReactionRepository
 }
 
 // NewLikeRepository creates a new instance of LikeRepository.
 func NewLikeRepository(db *pgxpool.Pool) LikeRepository {
-	return &likeRepository{db: db}
+	return &likeRepository{db: db, reactions: NewReactionRepo(db)}
 }
 
 // TogglePostLike adds or removes a like for a post. Returns true if liked, false if unliked.
 func (r *likeRepository) TogglePostLike(ctx context.Context, userID, postID int) (bool, error) {
-	// Check if like exists
-	isLiked, err := r.IsPostLikedByUser(ctx, userID, postID)
-	if err != nil {
-		return false, fmt.Errorf("failed to check post like status: %w", err)
-	}
-
-	if isLiked {
-		// Unlike: delete the like
-		query := `DELETE FROM post_likes WHERE user_id = $1 AND post_id = $2`
-		_, err := r.db.Exec(ctx, query, userID, postID)
-		if err != nil {
-			return false, fmt.Errorf("failed to unlike post: %w", err)
-		}
-
-		// Decrement likes count
-		_, _ = r.db.Exec(ctx, "UPDATE posts SET likes_count = GREATEST(likes_count - 1, 0) WHERE id = $1", postID)
-		return false, nil
-	}
-
-	// Like: insert a new like
-	query := `INSERT INTO post_likes (user_id, post_id) VALUES ($1, $2)`
-	_, err = r.db.Exec(ctx, query, userID, postID)
-	if err != nil {
-		return false, fmt.Errorf("failed to like post: %w", err)
-	}
-
-	// Increment likes count
-	_, _ = r.db.Exec(ctx, "UPDATE posts SET likes_count = likes_count + 1 WHERE id = $1", postID)
-	return true, nil
+	return r.toggle(ctx, domain.ReactionTargetPost, postID, userID)
 }
 
 // GetPostLikesCount returns the total number of likes for a post.
 func (r *likeRepository) GetPostLikesCount(ctx context.Context, postID int) (int, error) {
-	query := `SELECT COUNT(*) FROM post_likes WHERE post_id = $1`
-	var count int
-	err := r.db.QueryRow(ctx, query, postID).Scan(&count)
+	count, err := r.reactions.Count(ctx, domain.ReactionTargetPost, postID, domain.LikeEmoji)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get post likes count: %w", err)
 	}
@@ -76,66 +59,188 @@ func (r *likeRepository) GetPostLikesCount(ctx context.Context, postID int) (int
 
 // IsPostLikedByUser checks if a user has liked a specific post.
 func (r *likeRepository) IsPostLikedByUser(ctx context.Context, userID, postID int) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM post_likes WHERE user_id = $1 AND post_id = $2)`
-	var exists bool
-	err := r.db.QueryRow(ctx, query, userID, postID).Scan(&exists)
+	exists, err := r.reactions.Exists(ctx, domain.ReactionTargetPost, postID, userID, domain.LikeEmoji)
 	if err != nil {
 		return false, fmt.Errorf("failed to check if post is liked: %w", err)
 	}
 	return exists, nil
 }
 
+// SetPostLike likes or unlikes a post. Returns true if the state changed.
+func (r *likeRepository) SetPostLike(ctx context.Context, userID, postID int, liked bool) (bool, error) {
+	return r.set(ctx, domain.ReactionTargetPost, postID, userID, liked)
+}
+
 // ToggleCommentLike adds or removes a like for a comment. Returns true if liked, false if unliked.
 func (r *likeRepository) ToggleCommentLike(ctx context.Context, userID, commentID int) (bool, error) {
-	// Check if like exists
-	isLiked, err := r.IsCommentLikedByUser(ctx, userID, commentID)
+	return r.toggle(ctx, domain.ReactionTargetComment, commentID, userID)
+}
+
+// GetCommentLikesCount returns the total number of likes for a comment.
+func (r *likeRepository) GetCommentLikesCount(ctx context.Context, commentID int) (int, error) {
+	count, err := r.reactions.Count(ctx, domain.ReactionTargetComment, commentID, domain.LikeEmoji)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get comment likes count: %w", err)
+	}
+	return count, nil
+}
+
+// IsCommentLikedByUser checks if a user has liked a specific comment.
+func (r *likeRepository) IsCommentLikedByUser(ctx context.Context, userID, commentID int) (bool, error) {
+	exists, err := r.reactions.Exists(ctx, domain.ReactionTargetComment, commentID, userID, domain.LikeEmoji)
 	if err != nil {
-		return false, fmt.Errorf("failed to check comment like status: %w", err)
+		return false, fmt.Errorf("failed to check if comment is liked: %w", err)
 	}
+	return exists, nil
+}
 
-	if isLiked {
-		// Unlike: delete the like
-		query := `DELETE FROM comment_likes WHERE user_id = $1 AND comment_id = $2`
-		_, err := r.db.Exec(ctx, query, userID, commentID)
+// SetCommentLike likes or unlikes a comment. Returns true if the state changed.
+func (r *likeRepository) SetCommentLike(ctx context.Context, userID, commentID int, liked bool) (bool, error) {
+	return r.set(ctx, domain.ReactionTargetComment, commentID, userID, liked)
+}
+
+// set adds or removes the "👍" reaction. Both are no-ops when the
+// reaction is already in the requested state.
+func (r *likeRepository) set(ctx context.Context, target domain.ReactionTarget, targetID, userID int, liked bool) (bool, error) {
+	if liked {
+		added, err := r.reactions.Add(ctx, target, targetID, userID, domain.LikeEmoji)
 		if err != nil {
-			return false, fmt.Errorf("failed to unlike comment: %w", err)
+			return false, fmt.Errorf("failed to like %s: %w", target, err)
 		}
+		return added, nil
+	}
 
-		// Decrement likes count
-		_, _ = r.db.Exec(ctx, "UPDATE comments SET likes_count = GREATEST(likes_count - 1, 0) WHERE id = $1", commentID)
-		return false, nil
+	removed, err := r.reactions.Remove(ctx, target, targetID, userID, domain.LikeEmoji)
+	if err != nil {
+		return false, fmt.Errorf("failed to unlike %s: %w", target, err)
 	}
+	return removed, nil
+}
 
-	// Like: insert a new like
-	query := `INSERT INTO comment_likes (user_id, comment_id) VALUES ($1, $2)`
-	_, err = r.db.Exec(ctx, query, userID, commentID)
+// toggle removes the "👍" reaction if present or adds it otherwise. Each
+// step is a single statement, there is no check that a concurrent toggle
+// could invalidate.
+func (r *likeRepository) toggle(ctx context.Context, target domain.ReactionTarget, targetID, userID int) (bool, error) {
+	removed, err := r.reactions.Remove(ctx, target, targetID, userID, domain.LikeEmoji)
 	if err != nil {
-		return false, fmt.Errorf("failed to like comment: %w", err)
+		return false, fmt.Errorf("failed to unlike %s: %w", target, err)
+	}
+	if removed {
+		return false, nil
 	}
 
-	// Increment likes count
-	_, _ = r.db.Exec(ctx, "UPDATE comments SET likes_count = likes_count + 1 WHERE id = $1", commentID)
+	// A concurrent toggle may have liked it first, it is liked either way
+	if _, err := r.reactions.Add(ctx, target, targetID, userID, domain.LikeEmoji); err != nil {
+		return false, fmt.Errorf("failed to like %s: %w", target, err)
+	}
 	return true, nil
 }
 
-// GetCommentLikesCount returns the total number of likes for a comment.
-func (r *likeRepository) GetCommentLikesCount(ctx context.Context, commentID int) (int, error) {
-	query := `SELECT COUNT(*) FROM comment_likes WHERE comment_id = $1`
-	var count int
-	err := r.db.QueryRow(ctx, query, commentID).Scan(&count)
+// ListLikers returns the users who liked a post or comment, newest first.
+func (r *likeRepository) ListLikers(ctx context.Context, target domain.ReactionTarget, targetID, limit, offset int) ([]domain.Liker, int, error) {
+	t, err := getReactionTable(target)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get comment likes count: %w", err)
+		return nil, 0, err
 	}
-	return count, nil
+
+	total, err := r.reactions.Count(ctx, target, targetID, domain.LikeEmoji)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count %s likes: %w", target, err)
+	}
+
+	db := GetQueryEngine(ctx, r.db)
+	query := fmt.Sprintf(`
+		SELECT u.id, u.name, u.avatar_url, u.role, r.created_at,
+		       (SELECT photo_url FROM profile_info LIMIT 1) as profile_photo
+		FROM %s r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.%s = $1 AND r.emoji = $2
+		ORDER BY r.created_at DESC, r.user_id DESC
+		LIMIT $3 OFFSET $4
+	`, t.table, t.column)
+
+	rows, err := db.Query(ctx, query, targetID, domain.LikeEmoji, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list %s likes: %w", target, err)
+	}
+	defer rows.Close()
+
+	likers := []domain.Liker{}
+	for rows.Next() {
+		var liker domain.Liker
+		var role string
+		var profilePhoto *string
+		var likedAt *time.Time
+		if err := rows.Scan(&liker.UserID, &liker.Name, &liker.AvatarURL, &role, &likedAt, &profilePhoto); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan %s like: %w", target, err)
+		}
+		if likedAt != nil {
+			liker.LikedAt = *likedAt
+		}
+		// The blog owner is shown with the profile photo
+		if role == string(domain.RoleAdmin) && profilePhoto != nil && *profilePhoto != "" {
+			liker.AvatarURL = *profilePhoto
+		}
+		likers = append(likers, liker)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating %s likes: %w", target, err)
+	}
+
+	return likers, total, nil
 }
 
-// IsCommentLikedByUser checks if a user has liked a specific comment.
-func (r *likeRepository) IsCommentLikedByUser(ctx context.Context, userID, commentID int) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM comment_likes WHERE user_id = $1 AND comment_id = $2)`
-	var exists bool
-	err := r.db.QueryRow(ctx, query, userID, commentID).Scan(&exists)
-	if err != nil && err != pgx.ErrNoRows {
-		return false, fmt.Errorf("failed to check if comment is liked: %w", err)
+// likedByQuery selects the published posts and comments liked by user $1,
+// like is the "👍" emoji in $2
+const likedByQuery = `
+		SELECT 'post' AS target, p.id AS post_id, p.slug, p.title, NULL::integer AS comment_id, '' AS content, r.created_at
+		FROM post_reactions r
+		JOIN posts p ON p.id = r.post_id
+		WHERE r.user_id = $1 AND r.emoji = $2 AND p.published AND p.deleted_at IS NULL
+		UNION ALL
+		SELECT 'comment', p.id, p.slug, p.title, c.id, c.content, r.created_at
+		FROM comment_reactions r
+		JOIN comments c ON c.id = r.comment_id
+		JOIN posts p ON p.id = c.post_id
+		WHERE r.user_id = $1 AND r.emoji = $2 AND c.deleted_at IS NULL AND c.moderation_status = 'approved'
+		  AND p.published AND p.deleted_at IS NULL`
+
+// ListLikedBy returns the published posts and comments a user liked, newest first.
+func (r *likeRepository) ListLikedBy(ctx context.Context, userID, limit, offset int) ([]domain.LikedItem, int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM (` + likedByQuery + `) liked`
+	if err := db.QueryRow(ctx, countQuery, userID, domain.LikeEmoji).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count liked items: %w", err)
 	}
-	return exists, nil
+
+	query := likedByQuery + `
+		ORDER BY created_at DESC, comment_id DESC NULLS LAST, post_id DESC
+		LIMIT $3 OFFSET $4`
+	rows, err := db.Query(ctx, query, userID, domain.LikeEmoji, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list liked items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []domain.LikedItem{}
+	for rows.Next() {
+		var item domain.LikedItem
+		var likedAt *time.Time
+		if err := rows.Scan(&item.Target, &item.PostID, &item.PostSlug, &item.PostTitle, &item.CommentID, &item.CommentContent, &likedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan liked item: %w", err)
+		}
+		if likedAt != nil {
+			item.LikedAt = *likedAt
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating liked items: %w", err)
+	}
+
+	return items, total, nil
 }
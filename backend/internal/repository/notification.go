@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NotificationRepository defines methods for notification settings and email queue data access
+type NotificationRepository interface {
+	// GetSettings returns the defaults for users who never changed their settings
+	GetSettings(ctx context.Context, userID int) (*domain.NotificationSettings, error)
+	UpsertSettings(ctx context.Context, settings *domain.NotificationSettings) error
+
+	// CreateNotification stores an in-app notification. A notification about
+	// a comment is stored once per user and type, repeats are ignored.
+	CreateNotification(ctx context.Context, notification *domain.Notification) error
+
+	// Email queue
+	EnqueueCommentReply(ctx context.Context, userID, postID, commentID int) error
+	EnqueueCommentMention(ctx context.Context, userID, postID, commentID int) error
+	// EnqueueNewPost queues a post for every user who wants new posts by email, except its author
+	EnqueueNewPost(ctx context.Context, postID, authorID int) (int64, error)
+	// ListDueUsers returns users with notifications queued before batchedBefore
+	// who were not emailed after sentBefore
+	ListDueUsers(ctx context.Context, batchedBefore, sentBefore time.Time, limit int) ([]int, error)
+	ListPending(ctx context.Context, userID int) ([]domain.EmailNotification, error)
+	MarkSent(ctx context.Context, ids []int, deliveryID int) error
+	Reschedule(ctx context.Context, ids []int, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, ids []int) error
+	MarkSkipped(ctx context.Context, ids []int) error
+
+	// RecordDelivery logs an attempt to send an email
+	RecordDelivery(ctx context.Context, delivery *domain.EmailDelivery) error
+}
+
+type notificationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewNotificationRepo creates a new notification repository implementation
+func NewNotificationRepo(db *pgxpool.Pool) NotificationRepository {
+	return &notificationRepo{db: db}
+}
+
+func (r *notificationRepo) GetSettings(ctx context.Context, userID int) (*domain.NotificationSettings, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT user_id, email_enabled, push_enabled, new_posts_enabled, language, created_at, updated_at
+		FROM notification_settings
+		WHERE user_id = $1
+	`
+	var s domain.NotificationSettings
+	err := db.QueryRow(ctx, query, userID).
+		Scan(&s.UserID, &s.EmailEnabled, &s.PushEnabled, &s.NewPostsEnabled, &s.Language, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Same defaults as the table
+			return &domain.NotificationSettings{
+				UserID:          userID,
+				EmailEnabled:    true,
+				PushEnabled:     true,
+				NewPostsEnabled: true,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	return &s, nil
+}
+
+func (r *notificationRepo) UpsertSettings(ctx context.Context, settings *domain.NotificationSettings) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO notification_settings (user_id, email_enabled, push_enabled, new_posts_enabled, language)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			email_enabled = EXCLUDED.email_enabled,
+			push_enabled = EXCLUDED.push_enabled,
+			new_posts_enabled = EXCLUDED.new_posts_enabled,
+			language = EXCLUDED.language,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+	err := db.QueryRow(ctx, query, settings.UserID, settings.EmailEnabled, settings.PushEnabled,
+		settings.NewPostsEnabled, settings.Language).Scan(&settings.CreatedAt, &settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save notification settings: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) CreateNotification(ctx context.Context, notification *domain.Notification) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO notifications (user_id, type, title, message, post_id, comment_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := db.Exec(ctx, query, notification.UserID, notification.Type, notification.Title,
+		notification.Message, notification.PostID, notification.CommentID)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) EnqueueCommentReply(ctx context.Context, userID, postID, commentID int) error {
+	return r.enqueueComment(ctx, domain.NotificationTypeCommentReply, userID, postID, commentID)
+}
+
+func (r *notificationRepo) EnqueueCommentMention(ctx context.Context, userID, postID, commentID int) error {
+	return r.enqueueComment(ctx, domain.NotificationTypeCommentMention, userID, postID, commentID)
+}
+
+// enqueueComment queues a notification about a comment, once per user, type and comment
+func (r *notificationRepo) enqueueComment(ctx context.Context, notificationType string, userID, postID, commentID int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO email_notifications (user_id, type, post_id, comment_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := db.Exec(ctx, query, userID, notificationType, postID, commentID); err != nil {
+		return fmt.Errorf("failed to enqueue notification: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) EnqueueNewPost(ctx context.Context, postID, authorID int) (int64, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Federated users have reserved .invalid addresses and can't receive email
+	query := `
+		INSERT INTO email_notifications (user_id, type, post_id)
+		SELECT u.id, $1, $2
+		FROM users u
+		LEFT JOIN notification_settings ns ON ns.user_id = u.id
+		WHERE u.id <> $3
+			AND u.email NOT LIKE '%.invalid'
+			AND COALESCE(ns.email_enabled, TRUE)
+			AND COALESCE(ns.new_posts_enabled, TRUE)
+		ON CONFLICT DO NOTHING
+	`
+	tag, err := db.Exec(ctx, query, domain.NotificationTypeNewPost, postID, authorID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue notifications: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *notificationRepo) ListDueUsers(ctx context.Context, batchedBefore, sentBefore time.Time, limit int) ([]int, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT n.user_id
+		FROM email_notifications n
+		WHERE n.status = 'pending' AND n.next_attempt_at <= NOW()
+			AND NOT EXISTS (
+				SELECT 1 FROM email_deliveries d
+				WHERE d.user_id = n.user_id AND d.status = 'sent' AND d.created_at > $2
+			)
+		GROUP BY n.user_id
+		HAVING MIN(n.created_at) <= $1
+		ORDER BY MIN(n.created_at)
+		LIMIT $3
+	`
+	rows, err := db.Query(ctx, query, batchedBefore, sentBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users with notifications: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+func (r *notificationRepo) ListPending(ctx context.Context, userID int) ([]domain.EmailNotification, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT n.id, n.user_id, n.type, n.post_id, n.comment_id, n.status, n.attempts, n.next_attempt_at, n.created_at,
+			p.title, p.slug, COALESCE(p.preview, ''), COALESCE(c.content, ''), COALESCE(u.name, ''),
+			(p.deleted_at IS NOT NULL OR NOT p.published
+				OR c.deleted_at IS NOT NULL OR COALESCE(c.moderation_status, 'approved') <> 'approved') AS withdrawn
+		FROM email_notifications n
+		JOIN posts p ON p.id = n.post_id
+		LEFT JOIN comments c ON c.id = n.comment_id
+		LEFT JOIN users u ON u.id = c.user_id
+		WHERE n.user_id = $1 AND n.status = 'pending' AND n.next_attempt_at <= NOW()
+		ORDER BY n.created_at, n.id
+	`
+	rows, err := db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]domain.EmailNotification, 0)
+	for rows.Next() {
+		var n domain.EmailNotification
+		err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.PostID, &n.CommentID, &n.Status, &n.Attempts, &n.NextAttemptAt, &n.CreatedAt,
+			&n.PostTitle, &n.PostSlug, &n.PostPreview, &n.CommentContent, &n.ActorName, &n.Withdrawn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepo) MarkSent(ctx context.Context, ids []int, deliveryID int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE email_notifications
+		SET status = 'sent', attempts = attempts + 1, delivery_id = $2, sent_at = NOW()
+		WHERE id = ANY($1)
+	`
+	if _, err := db.Exec(ctx, query, ids, deliveryID); err != nil {
+		return fmt.Errorf("failed to mark notifications sent: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) Reschedule(ctx context.Context, ids []int, nextAttemptAt time.Time) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE email_notifications
+		SET attempts = attempts + 1, next_attempt_at = $2
+		WHERE id = ANY($1)
+	`
+	if _, err := db.Exec(ctx, query, ids, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to reschedule notifications: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) MarkFailed(ctx context.Context, ids []int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `UPDATE email_notifications SET status = 'failed', attempts = attempts + 1 WHERE id = ANY($1)`
+	if _, err := db.Exec(ctx, query, ids); err != nil {
+		return fmt.Errorf("failed to mark notifications failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) MarkSkipped(ctx context.Context, ids []int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `UPDATE email_notifications SET status = 'skipped' WHERE id = ANY($1)`
+	if _, err := db.Exec(ctx, query, ids); err != nil {
+		return fmt.Errorf("failed to mark notifications skipped: %w", err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) RecordDelivery(ctx context.Context, delivery *domain.EmailDelivery) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO email_deliveries (user_id, recipient, template, subject, notifications, status, attempt, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	err := db.QueryRow(ctx, query, delivery.UserID, delivery.Recipient, delivery.Template, delivery.Subject,
+		delivery.Notifications, delivery.Status, delivery.Attempt, delivery.Error).Scan(&delivery.ID, &delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record email delivery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	postRepo := NewPostRepo(testDB.Pool)
+	commentRepo := NewCommentRepo(testDB.Pool)
+	repo := NewNotificationRepo(testDB.Pool)
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "notifications", "email_notifications", "email_deliveries", "notification_settings", "comments", "posts", "users")
+	require.NoError(t, err)
+
+	author, err := authRepo.CreateUser(ctx, "author@example.com", "Author", "", domain.RoleAdmin)
+	require.NoError(t, err)
+	reader, err := authRepo.CreateUser(ctx, "reader@example.com", "Reader", "", domain.RoleUser)
+	require.NoError(t, err)
+	quiet, err := authRepo.CreateUser(ctx, "quiet@example.com", "Quiet", "", domain.RoleUser)
+	require.NoError(t, err)
+	_, err = authRepo.CreateUser(ctx, "alice@mastodon.example.invalid", "Alice", "", domain.RoleUser)
+	require.NoError(t, err)
+
+	post, err := postRepo.Create(ctx, &domain.Post{
+		Title:     "Notified",
+		Slug:      "notified",
+		Content:   "Post to test notifications",
+		AuthorID:  author.ID,
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("Settings", func(t *testing.T) {
+		settings, err := repo.GetSettings(ctx, quiet.ID)
+		require.NoError(t, err)
+		assert.True(t, settings.EmailEnabled)
+		assert.True(t, settings.NewPostsEnabled)
+
+		settings.NewPostsEnabled = false
+		settings.Language = "en"
+		require.NoError(t, repo.UpsertSettings(ctx, settings))
+
+		settings, err = repo.GetSettings(ctx, quiet.ID)
+		require.NoError(t, err)
+		assert.False(t, settings.NewPostsEnabled)
+		assert.Equal(t, "en", settings.Language)
+	})
+
+	t.Run("New post is queued once for interested users", func(t *testing.T) {
+		queued, err := repo.EnqueueNewPost(ctx, post.ID, author.ID)
+		require.NoError(t, err)
+		// Not the author, the user who opted out or the federated user
+		assert.Equal(t, int64(1), queued)
+
+		queued, err = repo.EnqueueNewPost(ctx, post.ID, author.ID)
+		require.NoError(t, err)
+		assert.Zero(t, queued)
+	})
+
+	t.Run("Batching and throttling", func(t *testing.T) {
+		parent, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: reader.ID, Content: "First"})
+		require.NoError(t, err)
+		reply, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: author.ID, Content: "Thanks!", ParentID: &parent.ID})
+		require.NoError(t, err)
+		require.NoError(t, repo.EnqueueCommentReply(ctx, reader.ID, post.ID, reply.ID))
+
+		// Still within the batching window
+		due, err := repo.ListDueUsers(ctx, time.Now().Add(-time.Minute), time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+
+		due, err = repo.ListDueUsers(ctx, time.Now().Add(time.Minute), time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int{reader.ID}, due)
+
+		pending, err := repo.ListPending(ctx, reader.ID)
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, domain.NotificationTypeNewPost, pending[0].Type)
+		assert.Equal(t, "Notified", pending[0].PostTitle)
+		assert.Equal(t, domain.NotificationTypeCommentReply, pending[1].Type)
+		assert.Equal(t, "Thanks!", pending[1].CommentContent)
+		assert.Equal(t, "Author", pending[1].ActorName)
+		assert.False(t, pending[1].Withdrawn)
+
+		delivery := &domain.EmailDelivery{
+			UserID: reader.ID, Recipient: reader.Email, Template: "notification_digest",
+			Subject: "2 new notifications", Notifications: 2, Status: domain.EmailNotificationSent, Attempt: 1,
+		}
+		require.NoError(t, repo.RecordDelivery(ctx, delivery))
+		assert.NotZero(t, delivery.ID)
+		require.NoError(t, repo.MarkSent(ctx, []int{pending[0].ID, pending[1].ID}, delivery.ID))
+
+		pending, err = repo.ListPending(ctx, reader.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		// A new reply waits for the minimum interval since the last email
+		another, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: author.ID, Content: "More", ParentID: &parent.ID})
+		require.NoError(t, err)
+		require.NoError(t, repo.EnqueueCommentReply(ctx, reader.ID, post.ID, another.ID))
+
+		due, err = repo.ListDueUsers(ctx, time.Now().Add(time.Minute), time.Now().Add(-time.Hour), 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+
+		due, err = repo.ListDueUsers(ctx, time.Now().Add(time.Minute), time.Now().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int{reader.ID}, due)
+	})
+
+	t.Run("Retries", func(t *testing.T) {
+		pending, err := repo.ListPending(ctx, reader.ID)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+
+		require.NoError(t, repo.Reschedule(ctx, []int{pending[0].ID}, time.Now().Add(time.Hour)))
+		later, err := repo.ListPending(ctx, reader.ID)
+		require.NoError(t, err)
+		assert.Empty(t, later)
+
+		require.NoError(t, repo.MarkFailed(ctx, []int{pending[0].ID}))
+	})
+
+	t.Run("In-app notifications are stored once per comment", func(t *testing.T) {
+		comment, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: author.ID, Content: "Hi @Quiet"})
+		require.NoError(t, err)
+
+		notification := &domain.Notification{
+			UserID:    quiet.ID,
+			Type:      domain.NotificationTypeCommentMention,
+			Title:     "Author mentioned you",
+			Message:   "Notified: Hi @Quiet",
+			PostID:    &post.ID,
+			CommentID: &comment.ID,
+		}
+		require.NoError(t, repo.CreateNotification(ctx, notification))
+		require.NoError(t, repo.CreateNotification(ctx, notification))
+
+		var count int
+		err = testDB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM notifications WHERE user_id = $1", quiet.ID).Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
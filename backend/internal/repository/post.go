@@ -16,6 +16,10 @@ type PostRepository interface {
 	Create(ctx context.Context, post *domain.Post) (*domain.Post, error)
 	Update(ctx context.Context, post *domain.Post) (*domain.Post, error)
 	Delete(ctx context.Context, id int) error
+	SoftDelete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	SetPublished(ctx context.Context, id int, published bool) error
+	SetSeries(ctx context.Context, id int, series string) error
 	GetByID(ctx context.Context, id, userID int) (*domain.Post, error)
 	GetBySlug(ctx context.Context, slug string, userID int) (*domain.Post, error)
 	List(ctx context.Context, req *domain.ListPostsRequest) ([]domain.Post, int, error)
@@ -42,9 +46,11 @@ func (r *postRepo) Create(ctx context.Context, post *domain.Post) (*domain.Post,
 	}
 
 	query := `
-		INSERT INTO posts (title, slug, content, preview, author_id, published, published_at, cover_image, read_time_minutes, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
-		RETURNING id, title, slug, content, preview, author_id, published, published_at, cover_image, read_time_minutes, likes_count, comments_count, created_at, updated_at
+		INSERT INTO posts (title, slug, content, preview, language, author_id, published, published_at, cover_image, read_time_minutes,
+		                   comments_disabled, comments_close_after_days, comments_min_account_age_days, series, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
+		RETURNING id, title, slug, content, preview, language, author_id, published, published_at, cover_image, read_time_minutes, likes_count, comments_count,
+		          comments_disabled, comments_close_after_days, comments_min_account_age_days, series, created_at, updated_at
 	`
 
 	err := db.QueryRow(ctx, query,
@@ -52,17 +58,23 @@ func (r *postRepo) Create(ctx context.Context, post *domain.Post) (*domain.Post,
 		post.Slug,
 		post.Content,
 		post.Preview,
+		post.Language,
 		post.AuthorID,
 		post.Published,
 		publishedAt,
 		post.CoverImage,
 		post.ReadTimeMinutes,
+		post.CommentsDisabled,
+		post.CommentsCloseAfterDays,
+		post.CommentsMinAccountAgeDays,
+		post.Series,
 	).Scan(
 		&createdPost.ID,
 		&createdPost.Title,
 		&createdPost.Slug,
 		&createdPost.Content,
 		&createdPost.Preview,
+		&createdPost.Language,
 		&createdPost.AuthorID,
 		&createdPost.Published,
 		&publishedAtScan,
@@ -70,6 +82,10 @@ func (r *postRepo) Create(ctx context.Context, post *domain.Post) (*domain.Post,
 		&createdPost.ReadTimeMinutes,
 		&createdPost.LikesCount,
 		&createdPost.CommentsCount,
+		&createdPost.CommentsDisabled,
+		&createdPost.CommentsCloseAfterDays,
+		&createdPost.CommentsMinAccountAgeDays,
+		&createdPost.Series,
 		&createdPost.CreatedAt,
 		&createdPost.UpdatedAt,
 	)
@@ -100,9 +116,11 @@ func (r *postRepo) Update(ctx context.Context, post *domain.Post) (*domain.Post,
 
 	query := `
 		UPDATE posts
-		SET title = $1, slug = $2, content = $3, preview = $4, published = $5, published_at = $6, cover_image = $7, read_time_minutes = $8, updated_at = NOW()
-		WHERE id = $9
-		RETURNING id, title, slug, content, preview, author_id, published, published_at, cover_image, read_time_minutes, likes_count, comments_count, created_at, updated_at
+		SET title = $1, slug = $2, content = $3, preview = $4, language = $5, published = $6, published_at = $7, cover_image = $8, read_time_minutes = $9,
+		    comments_disabled = $10, comments_close_after_days = $11, comments_min_account_age_days = $12, series = $13, updated_at = NOW()
+		WHERE id = $14
+		RETURNING id, title, slug, content, preview, language, author_id, published, published_at, cover_image, read_time_minutes, likes_count, comments_count,
+		          comments_disabled, comments_close_after_days, comments_min_account_age_days, series, created_at, updated_at
 	`
 
 	err := db.QueryRow(ctx, query,
@@ -110,10 +128,15 @@ func (r *postRepo) Update(ctx context.Context, post *domain.Post) (*domain.Post,
 		post.Slug,
 		post.Content,
 		post.Preview,
+		post.Language,
 		post.Published,
 		publishedAt,
 		post.CoverImage,
 		post.ReadTimeMinutes,
+		post.CommentsDisabled,
+		post.CommentsCloseAfterDays,
+		post.CommentsMinAccountAgeDays,
+		post.Series,
 		post.ID,
 	).Scan(
 		&updatedPost.ID,
@@ -121,6 +144,7 @@ func (r *postRepo) Update(ctx context.Context, post *domain.Post) (*domain.Post,
 		&updatedPost.Slug,
 		&updatedPost.Content,
 		&updatedPost.Preview,
+		&updatedPost.Language,
 		&updatedPost.AuthorID,
 		&updatedPost.Published,
 		&publishedAtScan,
@@ -128,6 +152,10 @@ func (r *postRepo) Update(ctx context.Context, post *domain.Post) (*domain.Post,
 		&updatedPost.ReadTimeMinutes,
 		&updatedPost.LikesCount,
 		&updatedPost.CommentsCount,
+		&updatedPost.CommentsDisabled,
+		&updatedPost.CommentsCloseAfterDays,
+		&updatedPost.CommentsMinAccountAgeDays,
+		&updatedPost.Series,
 		&updatedPost.CreatedAt,
 		&updatedPost.UpdatedAt,
 	)
@@ -158,14 +186,89 @@ func (r *postRepo) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+func (r *postRepo) SoftDelete(ctx context.Context, id int) error {
+	db := GetQueryEngine(ctx, r.db)
+	query := `UPDATE posts SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete post: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("post not found or already deleted")
+	}
+
+	return nil
+}
+
+func (r *postRepo) Restore(ctx context.Context, id int) error {
+	db := GetQueryEngine(ctx, r.db)
+	query := `UPDATE posts SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore post: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("post not found or not deleted")
+	}
+
+	return nil
+}
+
+func (r *postRepo) SetPublished(ctx context.Context, id int, published bool) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Already published posts keep their publication date
+	query := `
+		UPDATE posts
+		SET published = $1,
+		    published_at = CASE WHEN $1 THEN COALESCE(published_at, NOW()) ELSE NULL END,
+		    updated_at = NOW()
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := db.Exec(ctx, query, published, id)
+	if err != nil {
+		return fmt.Errorf("failed to set post published status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("post not found")
+	}
+
+	return nil
+}
+
+func (r *postRepo) SetSeries(ctx context.Context, id int, series string) error {
+	db := GetQueryEngine(ctx, r.db)
+	query := `UPDATE posts SET series = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL`
+
+	result, err := db.Exec(ctx, query, series, id)
+	if err != nil {
+		return fmt.Errorf("failed to set post series: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("post not found")
+	}
+
+	return nil
+}
+
 func (r *postRepo) GetByID(ctx context.Context, id, userID int) (*domain.Post, error) { //nolint:dupl // similar to GetBySlug but different query
 	var post domain.Post
 	db := GetQueryEngine(ctx, r.db)
 
 	query := `
-		SELECT p.id, p.title, p.slug, p.content, p.preview, p.author_id, p.published, p.published_at,
-		       p.cover_image, p.read_time_minutes, p.likes_count, p.comments_count, p.created_at, p.updated_at,
-		       EXISTS(SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = $2) as is_liked,
+		SELECT p.id, p.title, p.slug, p.content, p.preview, p.language, p.author_id, p.published, p.published_at,
+		       p.cover_image, p.read_time_minutes, p.likes_count, p.comments_count, p.created_at, p.updated_at, p.deleted_at,
+		       p.comments_disabled, p.comments_close_after_days, p.comments_min_account_age_days, p.series,
+		       EXISTS(SELECT 1 FROM post_reactions pr WHERE pr.post_id = p.id AND pr.user_id = $2 AND pr.emoji = '👍') as is_liked,
+		       ` + reactionsSummaryColumn(domain.ReactionTargetPost, "p.id", "$2") + ` as reactions,
+		       EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $2) as is_bookmarked,
 		       u.email, u.name, u.avatar_url, u.role,
 		       (SELECT photo_url FROM profile_info LIMIT 1) as profile_photo
 		FROM posts p
@@ -186,6 +289,7 @@ func (r *postRepo) GetByID(ctx context.Context, id, userID int) (*domain.Post, e
 		&post.Slug,
 		&post.Content,
 		&post.Preview,
+		&post.Language,
 		&post.AuthorID,
 		&post.Published,
 		&publishedAt,
@@ -195,7 +299,14 @@ func (r *postRepo) GetByID(ctx context.Context, id, userID int) (*domain.Post, e
 		&post.CommentsCount,
 		&post.CreatedAt,
 		&post.UpdatedAt,
+		&post.DeletedAt,
+		&post.CommentsDisabled,
+		&post.CommentsCloseAfterDays,
+		&post.CommentsMinAccountAgeDays,
+		&post.Series,
 		&post.IsLiked,
+		&post.Reactions,
+		&post.IsBookmarked,
 		&authorEmail,
 		&authorName,
 		&authorAvatar,
@@ -237,14 +348,20 @@ func (r *postRepo) GetBySlug(ctx context.Context, slug string, userID int) (*dom
 	db := GetQueryEngine(ctx, r.db)
 
 	query := `
-		SELECT p.id, p.title, p.slug, p.content, p.preview, p.author_id, p.published, p.published_at,
-		       p.cover_image, p.read_time_minutes, p.likes_count, p.comments_count, p.created_at, p.updated_at,
-		       EXISTS(SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = $2) as is_liked,
+		SELECT p.id, p.title, p.slug, p.content, p.preview, p.language, p.author_id, p.published, p.published_at,
+		       p.cover_image, p.read_time_minutes, p.likes_count, p.comments_count, p.created_at, p.updated_at, p.deleted_at,
+		       p.comments_disabled, p.comments_close_after_days, p.comments_min_account_age_days, p.series,
+		       EXISTS(SELECT 1 FROM post_reactions pr WHERE pr.post_id = p.id AND pr.user_id = $2 AND pr.emoji = '👍') as is_liked,
+		       ` + reactionsSummaryColumn(domain.ReactionTargetPost, "p.id", "$2") + ` as reactions,
+		       EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $2) as is_bookmarked,
 		       u.email, u.name, u.avatar_url, u.role,
 		       (SELECT photo_url FROM profile_info LIMIT 1) as profile_photo
 		FROM posts p
 		LEFT JOIN users u ON p.author_id = u.id
 		WHERE p.slug = $1
+		   OR p.id = (SELECT pt.post_id FROM post_translations pt WHERE pt.slug = $1)
+		ORDER BY (p.slug = $1) DESC
+		LIMIT 1
 	`
 
 	var authorEmail string
@@ -260,6 +377,7 @@ func (r *postRepo) GetBySlug(ctx context.Context, slug string, userID int) (*dom
 		&post.Slug,
 		&post.Content,
 		&post.Preview,
+		&post.Language,
 		&post.AuthorID,
 		&post.Published,
 		&publishedAt,
@@ -269,7 +387,14 @@ func (r *postRepo) GetBySlug(ctx context.Context, slug string, userID int) (*dom
 		&post.CommentsCount,
 		&post.CreatedAt,
 		&post.UpdatedAt,
+		&post.DeletedAt,
+		&post.CommentsDisabled,
+		&post.CommentsCloseAfterDays,
+		&post.CommentsMinAccountAgeDays,
+		&post.Series,
 		&post.IsLiked,
+		&post.Reactions,
+		&post.IsBookmarked,
 		&authorEmail,
 		&authorName,
 		&authorAvatar,
@@ -325,9 +450,12 @@ func (r *postRepo) List(ctx context.Context, req *domain.ListPostsRequest) ([]do
 
 	// Build query with filters
 	baseQuery := `
-		SELECT p.id, p.title, p.slug, p.content, p.preview, p.author_id, p.published, p.published_at,
-		       p.cover_image, p.read_time_minutes, p.likes_count, p.comments_count, p.created_at, p.updated_at,
-		       EXISTS(SELECT 1 FROM post_likes pl WHERE pl.post_id = p.id AND pl.user_id = $1) as is_liked,
+		SELECT p.id, p.title, p.slug, p.content, p.preview, p.language, p.author_id, p.published, p.published_at,
+		       p.cover_image, p.read_time_minutes, p.likes_count, p.comments_count, p.created_at, p.updated_at, p.deleted_at,
+		       p.comments_disabled, p.comments_close_after_days, p.comments_min_account_age_days, p.series,
+		       EXISTS(SELECT 1 FROM post_reactions pr WHERE pr.post_id = p.id AND pr.user_id = $1 AND pr.emoji = '👍') as is_liked,
+		       ` + reactionsSummaryColumn(domain.ReactionTargetPost, "p.id", "$1") + ` as reactions,
+		       EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked,
 		       u.email, u.name, u.avatar_url, u.role,
 		       (SELECT photo_url FROM profile_info LIMIT 1) as profile_photo
 		FROM posts p
@@ -335,20 +463,41 @@ func (r *postRepo) List(ctx context.Context, req *domain.ListPostsRequest) ([]do
 	`
 	countQuery := `SELECT COUNT(*) FROM posts p`
 
-	var whereClause string
+	// Soft-deleted posts are only listed on request (admin trash view)
+	whereClause := " WHERE p.deleted_at IS NULL"
+	if req.Deleted {
+		whereClause = " WHERE p.deleted_at IS NOT NULL"
+	}
 	// $1 is always UserID
 	args := []interface{}{req.UserID}
 	argIndex := 2
 
 	// Filter by published status if specified
 	if req.Published != nil {
-		whereClause = fmt.Sprintf(" WHERE p.published = $%d", argIndex)
+		whereClause += fmt.Sprintf(" AND p.published = $%d", argIndex)
 		args = append(args, *req.Published)
 		argIndex++
 	}
 
+	// Filter by series if specified
+	if req.Series != "" {
+		whereClause += fmt.Sprintf(" AND p.series = $%d", argIndex)
+		args = append(args, req.Series)
+		argIndex++
+	}
+
+	// Filter by bookmarks of a user if specified
+	if req.BookmarkedBy != 0 {
+		whereClause += fmt.Sprintf(" AND EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $%d)", argIndex)
+		args = append(args, req.BookmarkedBy)
+		argIndex++
+	}
+
 	// Order by published_at desc for published posts, created_at desc for drafts
 	orderClause := " ORDER BY p.published_at DESC NULLS LAST, p.created_at DESC"
+	if req.BookmarkedBy != 0 {
+		orderClause = fmt.Sprintf(" ORDER BY (SELECT b.created_at FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $%d) DESC, p.id DESC", argIndex-1)
+	}
 
 	// Limit and offset
 	limitClause := fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
@@ -358,11 +507,22 @@ func (r *postRepo) List(ctx context.Context, req *domain.ListPostsRequest) ([]do
 	var totalCount int
 	// The where clause uses $2. If we run count query, we need to shift indices or prepare separate query.
 	// Let's just fix the indices in string builder.
-	countWhere := ""
+	countWhere := " WHERE p.deleted_at IS NULL"
+	if req.Deleted {
+		countWhere = " WHERE p.deleted_at IS NOT NULL"
+	}
 	countArgs := []interface{}{}
 	if req.Published != nil {
-		countWhere = " WHERE p.published = $1"
 		countArgs = append(countArgs, *req.Published)
+		countWhere += fmt.Sprintf(" AND p.published = $%d", len(countArgs))
+	}
+	if req.Series != "" {
+		countArgs = append(countArgs, req.Series)
+		countWhere += fmt.Sprintf(" AND p.series = $%d", len(countArgs))
+	}
+	if req.BookmarkedBy != 0 {
+		countArgs = append(countArgs, req.BookmarkedBy)
+		countWhere += fmt.Sprintf(" AND EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $%d)", len(countArgs))
 	}
 	err := db.QueryRow(ctx, countQuery+countWhere, countArgs...).Scan(&totalCount)
 	if err != nil {
@@ -392,6 +552,7 @@ func (r *postRepo) List(ctx context.Context, req *domain.ListPostsRequest) ([]do
 			&post.Slug,
 			&post.Content,
 			&post.Preview,
+			&post.Language,
 			&post.AuthorID,
 			&post.Published,
 			&publishedAt,
@@ -401,7 +562,14 @@ func (r *postRepo) List(ctx context.Context, req *domain.ListPostsRequest) ([]do
 			&post.CommentsCount,
 			&post.CreatedAt,
 			&post.UpdatedAt,
+			&post.DeletedAt,
+			&post.CommentsDisabled,
+			&post.CommentsCloseAfterDays,
+			&post.CommentsMinAccountAgeDays,
+			&post.Series,
 			&post.IsLiked,
+			&post.Reactions,
+			&post.IsBookmarked,
 			&authorEmail,
 			&authorName,
 			&authorAvatar,
@@ -108,6 +108,34 @@ func TestPostRepository_Integration(t *testing.T) {
 		assert.True(t, updated.UpdatedAt.After(updated.CreatedAt))
 	})
 
+	t.Run("Discussion settings", func(t *testing.T) {
+		created, err := postRepo.Create(ctx, &domain.Post{
+			Title:                     "Discussion",
+			Slug:                      "discussion",
+			Content:                   "Content with more than 10 characters",
+			AuthorID:                  author.ID,
+			Published:                 true,
+			CommentsCloseAfterDays:    30,
+			CommentsMinAccountAgeDays: 7,
+		})
+		require.NoError(t, err)
+		assert.False(t, created.CommentsDisabled)
+		assert.Equal(t, 30, created.CommentsCloseAfterDays)
+		assert.Equal(t, 7, created.CommentsMinAccountAgeDays)
+
+		created.CommentsDisabled = true
+		created.CommentsCloseAfterDays = 0
+		_, err = postRepo.Update(ctx, created)
+		require.NoError(t, err)
+
+		retrieved, err := postRepo.GetBySlug(ctx, "discussion", 0)
+		require.NoError(t, err)
+		require.NotNil(t, retrieved)
+		assert.True(t, retrieved.CommentsDisabled)
+		assert.Equal(t, 0, retrieved.CommentsCloseAfterDays)
+		assert.Equal(t, 7, retrieved.CommentsMinAccountAgeDays)
+	})
+
 	t.Run("Delete post", func(t *testing.T) {
 		post := &domain.Post{
 			Title:     "To Delete",
@@ -183,6 +211,129 @@ func TestPostRepository_Integration(t *testing.T) {
 		}
 	})
 
+	t.Run("SoftDelete, Restore and SetPublished", func(t *testing.T) {
+		created, err := postRepo.Create(ctx, &domain.Post{
+			Title:    "Trash Test",
+			Slug:     "trash-test",
+			Content:  "Content for soft delete test with more than 10 characters",
+			AuthorID: author.ID,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, postRepo.SetPublished(ctx, created.ID, true))
+		retrieved, err := postRepo.GetByID(ctx, created.ID, 0)
+		require.NoError(t, err)
+		assert.True(t, retrieved.Published)
+		assert.False(t, retrieved.PublishedAt.IsZero())
+
+		require.NoError(t, postRepo.SoftDelete(ctx, created.ID))
+		assert.Error(t, postRepo.SoftDelete(ctx, created.ID))
+		assert.Error(t, postRepo.SetPublished(ctx, created.ID, false))
+
+		// Deleted posts are still reachable by ID and slug, but not listed
+		retrieved, err = postRepo.GetByID(ctx, created.ID, 0)
+		require.NoError(t, err)
+		assert.NotNil(t, retrieved.DeletedAt)
+
+		retrieved, err = postRepo.GetBySlug(ctx, "trash-test", 0)
+		require.NoError(t, err)
+		assert.NotNil(t, retrieved.DeletedAt)
+
+		posts, _, err := postRepo.List(ctx, &domain.ListPostsRequest{Page: 1, Limit: 100})
+		require.NoError(t, err)
+		for _, post := range posts {
+			assert.NotEqual(t, created.ID, post.ID)
+		}
+
+		trash, total, err := postRepo.List(ctx, &domain.ListPostsRequest{Page: 1, Limit: 100, Deleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, trash, 1)
+		assert.Equal(t, created.ID, trash[0].ID)
+
+		require.NoError(t, postRepo.Restore(ctx, created.ID))
+		assert.Error(t, postRepo.Restore(ctx, created.ID))
+		retrieved, err = postRepo.GetByID(ctx, created.ID, 0)
+		require.NoError(t, err)
+		assert.Nil(t, retrieved.DeletedAt)
+	})
+
+	t.Run("Series", func(t *testing.T) {
+		inSeries, err := postRepo.Create(ctx, &domain.Post{
+			Title: "Series One", Slug: "series-one", Content: "Content of the first post of a series",
+			AuthorID: author.ID, Published: true, Series: "Go internals",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Go internals", inSeries.Series)
+
+		moved, err := postRepo.Create(ctx, &domain.Post{
+			Title: "Series Two", Slug: "series-two", Content: "Content of a post moved into the series",
+			AuthorID: author.ID, Published: true,
+		})
+		require.NoError(t, err)
+		require.NoError(t, postRepo.SetSeries(ctx, moved.ID, "Go internals"))
+
+		posts, total, err := postRepo.List(ctx, &domain.ListPostsRequest{Page: 1, Limit: 100, Series: "Go internals"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, posts, 2)
+		for _, post := range posts {
+			assert.Equal(t, "Go internals", post.Series)
+		}
+
+		require.NoError(t, postRepo.SetSeries(ctx, moved.ID, ""))
+		retrieved, err := postRepo.GetBySlug(ctx, "series-two", 0)
+		require.NoError(t, err)
+		assert.Empty(t, retrieved.Series)
+	})
+
+	t.Run("Bookmarks", func(t *testing.T) {
+		bookmarkRepo := NewBookmarkRepo(testDB.Pool)
+		reader, err := authRepo.CreateUser(ctx, "reader@example.com", "", "", domain.RoleUser)
+		require.NoError(t, err)
+
+		first, err := postRepo.Create(ctx, &domain.Post{
+			Title: "Bookmark One", Slug: "bookmark-one", Content: "Content of the first bookmarked post",
+			AuthorID: author.ID, Published: true,
+		})
+		require.NoError(t, err)
+		second, err := postRepo.Create(ctx, &domain.Post{
+			Title: "Bookmark Two", Slug: "bookmark-two", Content: "Content of the second bookmarked post",
+			AuthorID: author.ID, Published: true,
+		})
+		require.NoError(t, err)
+
+		added, err := bookmarkRepo.Add(ctx, reader.ID, second.ID)
+		require.NoError(t, err)
+		assert.True(t, added)
+		_, err = bookmarkRepo.Add(ctx, reader.ID, first.ID)
+		require.NoError(t, err)
+		added, err = bookmarkRepo.Add(ctx, reader.ID, first.ID)
+		require.NoError(t, err)
+		assert.False(t, added)
+
+		retrieved, err := postRepo.GetBySlug(ctx, "bookmark-one", reader.ID)
+		require.NoError(t, err)
+		assert.True(t, retrieved.IsBookmarked)
+
+		// Newest bookmark first
+		posts, total, err := postRepo.List(ctx, &domain.ListPostsRequest{Page: 1, Limit: 10, UserID: reader.ID, BookmarkedBy: reader.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, posts, 2)
+		assert.Equal(t, first.ID, posts[0].ID)
+		assert.Equal(t, second.ID, posts[1].ID)
+		assert.True(t, posts[0].IsBookmarked)
+
+		removed, err := bookmarkRepo.Remove(ctx, reader.ID, first.ID)
+		require.NoError(t, err)
+		assert.True(t, removed)
+
+		retrieved, err = postRepo.GetByID(ctx, first.ID, reader.ID)
+		require.NoError(t, err)
+		assert.False(t, retrieved.IsBookmarked)
+	})
+
 	t.Run("GetByID returns nil for non-existent post", func(t *testing.T) {
 		post, err := postRepo.GetByID(ctx, 99999, 0)
 		require.NoError(t, err)
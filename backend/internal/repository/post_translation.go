@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostTranslationRepository defines methods for post translation data access
+type PostTranslationRepository interface {
+	Upsert(ctx context.Context, translation *domain.PostTranslation) (*domain.PostTranslation, error)
+	Delete(ctx context.Context, postID int, language string) error
+	GetBySlug(ctx context.Context, slug string) (*domain.PostTranslation, error)
+	ListByPostID(ctx context.Context, postID int) ([]domain.PostTranslation, error)
+	ListByPostIDs(ctx context.Context, postIDs []int) ([]domain.PostTranslation, error)
+}
+
+type postTranslationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewPostTranslationRepo creates a new post translation repository implementation
+func NewPostTranslationRepo(db *pgxpool.Pool) PostTranslationRepository {
+	return &postTranslationRepo{db: db}
+}
+
+func (r *postTranslationRepo) Upsert(ctx context.Context, translation *domain.PostTranslation) (*domain.PostTranslation, error) {
+	var saved domain.PostTranslation
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO post_translations (post_id, language, title, slug, content, preview, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (post_id, language)
+		DO UPDATE SET
+			title = EXCLUDED.title,
+			slug = EXCLUDED.slug,
+			content = EXCLUDED.content,
+			preview = EXCLUDED.preview,
+			updated_at = NOW()
+		RETURNING id, post_id, language, title, slug, content, preview, created_at, updated_at
+	`
+
+	err := db.QueryRow(ctx, query,
+		translation.PostID,
+		translation.Language,
+		translation.Title,
+		translation.Slug,
+		translation.Content,
+		translation.Preview,
+	).Scan(
+		&saved.ID,
+		&saved.PostID,
+		&saved.Language,
+		&saved.Title,
+		&saved.Slug,
+		&saved.Content,
+		&saved.Preview,
+		&saved.CreatedAt,
+		&saved.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert post translation: %w", err)
+	}
+
+	return &saved, nil
+}
+
+func (r *postTranslationRepo) Delete(ctx context.Context, postID int, language string) error {
+	db := GetQueryEngine(ctx, r.db)
+	query := `DELETE FROM post_translations WHERE post_id = $1 AND language = $2`
+
+	result, err := db.Exec(ctx, query, postID, language)
+	if err != nil {
+		return fmt.Errorf("failed to delete post translation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("post translation not found")
+	}
+
+	return nil
+}
+
+func (r *postTranslationRepo) GetBySlug(ctx context.Context, slug string) (*domain.PostTranslation, error) {
+	var translation domain.PostTranslation
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT id, post_id, language, title, slug, content, preview, created_at, updated_at
+		FROM post_translations
+		WHERE slug = $1
+	`
+
+	err := db.QueryRow(ctx, query, slug).Scan(
+		&translation.ID,
+		&translation.PostID,
+		&translation.Language,
+		&translation.Title,
+		&translation.Slug,
+		&translation.Content,
+		&translation.Preview,
+		&translation.CreatedAt,
+		&translation.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil // Translation not found
+		}
+		return nil, fmt.Errorf("failed to get post translation by slug: %w", err)
+	}
+
+	return &translation, nil
+}
+
+func (r *postTranslationRepo) ListByPostID(ctx context.Context, postID int) ([]domain.PostTranslation, error) {
+	return r.list(ctx, `WHERE post_id = $1`, postID)
+}
+
+func (r *postTranslationRepo) ListByPostIDs(ctx context.Context, postIDs []int) ([]domain.PostTranslation, error) {
+	if len(postIDs) == 0 {
+		return []domain.PostTranslation{}, nil
+	}
+	return r.list(ctx, `WHERE post_id = ANY($1)`, postIDs)
+}
+
+// list fetches translations matching the given WHERE clause
+func (r *postTranslationRepo) list(ctx context.Context, where string, args ...any) ([]domain.PostTranslation, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT id, post_id, language, title, slug, content, preview, created_at, updated_at
+		FROM post_translations
+	` + where + ` ORDER BY post_id, language`
+
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list post translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := make([]domain.PostTranslation, 0)
+	for rows.Next() {
+		var translation domain.PostTranslation
+		if err := rows.Scan(
+			&translation.ID,
+			&translation.PostID,
+			&translation.Language,
+			&translation.Title,
+			&translation.Slug,
+			&translation.Content,
+			&translation.Preview,
+			&translation.CreatedAt,
+			&translation.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan post translation: %w", err)
+		}
+		translations = append(translations, translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating post translations: %w", err)
+	}
+
+	return translations, nil
+}
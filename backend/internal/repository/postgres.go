@@ -35,6 +35,15 @@ func GetQueryEngine(ctx context.Context, pool *pgxpool.Pool) QueryEngine {
 	return pool
 }
 
+// WithoutTransaction detaches ctx from its transaction, for work that
+// outlives it (e.g. background sending started inside a transaction)
+func WithoutTransaction(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, txKey{}, nil)
+}
+
 type txManager struct {
 	pool *pgxpool.Pool
 }
@@ -84,27 +93,49 @@ func (tm *txManager) RunInTransaction(ctx context.Context, fn func(ctx context.C
 
 // Repositories aggregates all repository interfaces
 type Repositories struct {
-	Profile    ProfileRepository
-	Auth       AuthRepository
-	Session    SessionRepository
-	Post       PostRepository
-	Comment    CommentRepository
-	Like       LikeRepository
-	Transactor Transactor
-	db         *pgxpool.Pool
+	Profile      ProfileRepository
+	Auth         AuthRepository
+	Session      SessionRepository
+	Ban          BanRepository
+	Post         PostRepository
+	Translation  PostTranslationRepository
+	Comment      CommentRepository
+	Like         LikeRepository
+	Reaction     ReactionRepository
+	Bookmark     BookmarkRepository
+	ActivityPub  ActivityPubRepository
+	Webmention   WebmentionRepository
+	Subscriber   SubscriberRepository
+	Notification NotificationRepository
+	Push         PushRepository
+	Event        EventRepository
+	Counter      CounterRepository
+	Transactor   Transactor
+	db           *pgxpool.Pool
 }
 
 // NewRepositories creates a new Repositories instance with all implementations
-func NewRepositories(db *pgxpool.Pool, _ *config.Config) *Repositories { //nolint:revive // cfg reserved for future use
+func NewRepositories(db *pgxpool.Pool, cfg *config.Config) *Repositories {
 	return &Repositories{
-		Profile:    NewProfileRepo(db),
-		Auth:       NewAuthRepo(db),
-		Session:    NewSessionRepo(db),
-		Post:       NewPostRepo(db),
-		Comment:    NewCommentRepo(db),
-		Like:       NewLikeRepository(db),
-		Transactor: &txManager{pool: db},
-		db:         db,
+		Profile:      NewProfileRepo(db),
+		Auth:         NewAuthRepo(db),
+		Session:      NewSessionRepo(db),
+		Ban:          NewBanRepo(db),
+		Post:         NewPostRepo(db),
+		Translation:  NewPostTranslationRepo(db),
+		Comment:      NewCommentRepo(db),
+		Like:         NewLikeRepository(db),
+		Reaction:     NewReactionRepo(db),
+		Bookmark:     NewBookmarkRepo(db),
+		ActivityPub:  NewActivityPubRepo(db),
+		Webmention:   NewWebmentionRepo(db),
+		Subscriber:   NewSubscriberRepo(db),
+		Notification: NewNotificationRepo(db),
+		Push:         NewPushRepo(db),
+		Event:        NewEventRepo(db, cfg.Events.Notify),
+		Counter:      NewCounterRepo(db),
+		Transactor:   &txManager{pool: db},
+		db:           db,
 	}
 }
 
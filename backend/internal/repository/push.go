@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PushRepository defines methods for Web Push subscription data access
+type PushRepository interface {
+	// Save registers a browser for a user, taking the endpoint over from another user if needed
+	Save(ctx context.Context, sub *domain.PushSubscription) error
+	// Delete removes a subscription of a user, reporting whether it existed
+	Delete(ctx context.Context, userID int, endpoint string) (bool, error)
+	// DeleteByEndpoint removes a subscription the push service reported as gone
+	DeleteByEndpoint(ctx context.Context, endpoint string) error
+
+	// ListForUser returns the subscriptions of a user who has push notifications enabled
+	ListForUser(ctx context.Context, userID int) ([]domain.PushSubscription, error)
+	// ListForNewPost returns the subscriptions of users who want push notifications about new posts
+	ListForNewPost(ctx context.Context, authorID int) ([]domain.PushSubscription, error)
+}
+
+type pushRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewPushRepo creates a new push subscription repository implementation
+func NewPushRepo(db *pgxpool.Pool) PushRepository {
+	return &pushRepo{db: db}
+}
+
+func (r *pushRepo) Save(ctx context.Context, sub *domain.PushSubscription) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO push_subscriptions (user_id, endpoint, p256dh, auth, user_agent)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (endpoint) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			p256dh = EXCLUDED.p256dh,
+			auth = EXCLUDED.auth,
+			user_agent = EXCLUDED.user_agent,
+			updated_at = NOW()
+		RETURNING id, created_at
+	`
+	err := db.QueryRow(ctx, query, sub.UserID, sub.Endpoint, sub.P256dh, sub.Auth, sub.UserAgent).Scan(&sub.ID, &sub.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save push subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pushRepo) Delete(ctx context.Context, userID int, endpoint string) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	tag, err := db.Exec(ctx, `DELETE FROM push_subscriptions WHERE user_id = $1 AND endpoint = $2`, userID, endpoint)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *pushRepo) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	if _, err := db.Exec(ctx, `DELETE FROM push_subscriptions WHERE endpoint = $1`, endpoint); err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pushRepo) ListForUser(ctx context.Context, userID int) ([]domain.PushSubscription, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT s.id, s.user_id, s.endpoint, s.p256dh, s.auth, s.user_agent, s.created_at, COALESCE(ns.language, '')
+		FROM push_subscriptions s
+		LEFT JOIN notification_settings ns ON ns.user_id = s.user_id
+		WHERE s.user_id = $1 AND COALESCE(ns.push_enabled, TRUE)
+		ORDER BY s.id
+	`
+	return r.query(ctx, db, query, userID)
+}
+
+func (r *pushRepo) ListForNewPost(ctx context.Context, authorID int) ([]domain.PushSubscription, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT s.id, s.user_id, s.endpoint, s.p256dh, s.auth, s.user_agent, s.created_at, COALESCE(ns.language, '')
+		FROM push_subscriptions s
+		LEFT JOIN notification_settings ns ON ns.user_id = s.user_id
+		WHERE s.user_id <> $1 AND COALESCE(ns.push_enabled, TRUE) AND COALESCE(ns.new_posts_enabled, TRUE)
+		ORDER BY s.id
+	`
+	return r.query(ctx, db, query, authorID)
+}
+
+func (r *pushRepo) query(ctx context.Context, db QueryEngine, query string, args ...any) ([]domain.PushSubscription, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list push subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]domain.PushSubscription, 0)
+	for rows.Next() {
+		var s domain.PushSubscription
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Endpoint, &s.P256dh, &s.Auth, &s.UserAgent, &s.CreatedAt, &s.Language); err != nil {
+			return nil, fmt.Errorf("failed to scan push subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate push subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
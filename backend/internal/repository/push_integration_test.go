@@ -0,0 +1,91 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	notificationRepo := NewNotificationRepo(testDB.Pool)
+	repo := NewPushRepo(testDB.Pool)
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "push_subscriptions", "notification_settings", "users")
+	require.NoError(t, err)
+
+	author, err := authRepo.CreateUser(ctx, "author@example.com", "Author", "", domain.RoleAdmin)
+	require.NoError(t, err)
+	reader, err := authRepo.CreateUser(ctx, "reader@example.com", "Reader", "", domain.RoleUser)
+	require.NoError(t, err)
+	quiet, err := authRepo.CreateUser(ctx, "quiet@example.com", "Quiet", "", domain.RoleUser)
+	require.NoError(t, err)
+
+	save := func(userID int, endpoint string) *domain.PushSubscription {
+		sub := &domain.PushSubscription{UserID: userID, Endpoint: endpoint, P256dh: "key", Auth: "auth", UserAgent: "Firefox"}
+		require.NoError(t, repo.Save(ctx, sub))
+		return sub
+	}
+
+	t.Run("Save takes the endpoint over", func(t *testing.T) {
+		first := save(author.ID, "https://push.example/shared")
+		second := save(reader.ID, "https://push.example/shared")
+		assert.Equal(t, first.ID, second.ID)
+
+		subs, err := repo.ListForUser(ctx, author.ID)
+		require.NoError(t, err)
+		assert.Empty(t, subs)
+
+		subs, err = repo.ListForUser(ctx, reader.ID)
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "Firefox", subs[0].UserAgent)
+	})
+
+	t.Run("New post subscriptions respect settings", func(t *testing.T) {
+		save(author.ID, "https://push.example/author")
+		save(quiet.ID, "https://push.example/quiet")
+		require.NoError(t, notificationRepo.UpsertSettings(ctx, &domain.NotificationSettings{
+			UserID: quiet.ID, EmailEnabled: true, PushEnabled: true, NewPostsEnabled: false, Language: "en",
+		}))
+
+		subs, err := repo.ListForNewPost(ctx, author.ID)
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, reader.ID, subs[0].UserID)
+
+		// Replies still reach the user, in their language
+		subs, err = repo.ListForUser(ctx, quiet.ID)
+		require.NoError(t, err)
+		require.Len(t, subs, 1)
+		assert.Equal(t, "en", subs[0].Language)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		deleted, err := repo.Delete(ctx, author.ID, "https://push.example/shared")
+		require.NoError(t, err)
+		assert.False(t, deleted, "endpoint belongs to another user")
+
+		deleted, err = repo.Delete(ctx, reader.ID, "https://push.example/shared")
+		require.NoError(t, err)
+		assert.True(t, deleted)
+
+		require.NoError(t, repo.DeleteByEndpoint(ctx, "https://push.example/quiet"))
+		subs, err := repo.ListForUser(ctx, quiet.ID)
+		require.NoError(t, err)
+		assert.Empty(t, subs)
+	})
+}
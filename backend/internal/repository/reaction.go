@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReactionRepository defines methods for emoji reaction data access
+type ReactionRepository interface {
+	Add(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error)
+	Remove(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error)
+	Exists(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error)
+	Count(ctx context.Context, target domain.ReactionTarget, targetID int, emoji string) (int, error)
+	Summary(ctx context.Context, target domain.ReactionTarget, targetID, userID int) ([]domain.ReactionSummary, error)
+	// RemoveByUser removes every reaction of a user on a target type and
+	// returns how many were removed
+	RemoveByUser(ctx context.Context, target domain.ReactionTarget, userID int) (int64, error)
+}
+
+type reactionRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewReactionRepo creates a new reaction repository implementation
+func NewReactionRepo(db *pgxpool.Pool) ReactionRepository {
+	return &reactionRepo{db: db}
+}
+
+// reactionTable describes where reactions of a target are stored
+type reactionTable struct {
+	table  string // reactions table
+	column string // foreign key column in the reactions table
+	parent string // table holding likes_count
+}
+
+// reactionTables whitelists table names used to build queries
+var reactionTables = map[domain.ReactionTarget]reactionTable{
+	domain.ReactionTargetPost:    {table: "post_reactions", column: "post_id", parent: "posts"},
+	domain.ReactionTargetComment: {table: "comment_reactions", column: "comment_id", parent: "comments"},
+}
+
+func getReactionTable(target domain.ReactionTarget) (reactionTable, error) {
+	t, ok := reactionTables[target]
+	if !ok {
+		return reactionTable{}, fmt.Errorf("unknown reaction target: %s", target)
+	}
+	return t, nil
+}
+
+// reactionsSummaryColumn returns a SELECT expression aggregating reactions
+// of the row referenced by ref as a JSON array of domain.ReactionSummary
+func reactionsSummaryColumn(target domain.ReactionTarget, ref, userArg string) string {
+	t := reactionTables[target]
+	return fmt.Sprintf(`COALESCE((
+		           SELECT json_agg(json_build_object('emoji', rs.emoji, 'count', rs.cnt, 'reacted', rs.reacted) ORDER BY rs.cnt DESC, rs.emoji)
+		           FROM (SELECT emoji, COUNT(*) AS cnt, BOOL_OR(user_id = %s) AS reacted FROM %s WHERE %s = %s GROUP BY emoji) rs
+		       ), '[]'::json)`, userArg, t.table, t.column, ref)
+}
+
+func (r *reactionRepo) Add(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error) {
+	t, err := getReactionTable(target)
+	if err != nil {
+		return false, err
+	}
+	db := GetQueryEngine(ctx, r.db)
+
+	// likes_count mirrors the number of "👍" reactions and changes in the
+	// same statement, so concurrent likes cannot make it drift
+	query := fmt.Sprintf(`
+		WITH added AS (
+			INSERT INTO %[1]s (%[2]s, user_id, emoji) VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+			RETURNING %[2]s
+		), counted AS (
+			UPDATE %[3]s SET likes_count = likes_count + 1
+			FROM added
+			WHERE %[3]s.id = added.%[2]s AND $3 = $4
+		)
+		SELECT COUNT(*) FROM added
+	`, t.table, t.column, t.parent)
+
+	var added int
+	if err := db.QueryRow(ctx, query, targetID, userID, emoji, domain.LikeEmoji).Scan(&added); err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+	return added > 0, nil // 0 when already reacted
+}
+
+func (r *reactionRepo) Remove(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error) {
+	t, err := getReactionTable(target)
+	if err != nil {
+		return false, err
+	}
+	db := GetQueryEngine(ctx, r.db)
+
+	query := fmt.Sprintf(`
+		WITH removed AS (
+			DELETE FROM %[1]s WHERE %[2]s = $1 AND user_id = $2 AND emoji = $3
+			RETURNING %[2]s
+		), counted AS (
+			UPDATE %[3]s SET likes_count = GREATEST(likes_count - 1, 0)
+			FROM removed
+			WHERE %[3]s.id = removed.%[2]s AND $3 = $4
+		)
+		SELECT COUNT(*) FROM removed
+	`, t.table, t.column, t.parent)
+
+	var removed int
+	if err := db.QueryRow(ctx, query, targetID, userID, emoji, domain.LikeEmoji).Scan(&removed); err != nil {
+		return false, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+	return removed > 0, nil // 0 when there was nothing to remove
+}
+
+func (r *reactionRepo) Exists(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error) {
+	t, err := getReactionTable(target)
+	if err != nil {
+		return false, err
+	}
+	db := GetQueryEngine(ctx, r.db)
+
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1 AND user_id = $2 AND emoji = $3)`, t.table, t.column)
+	if err := db.QueryRow(ctx, query, targetID, userID, emoji).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check reaction: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *reactionRepo) Count(ctx context.Context, target domain.ReactionTarget, targetID int, emoji string) (int, error) {
+	t, err := getReactionTable(target)
+	if err != nil {
+		return 0, err
+	}
+	db := GetQueryEngine(ctx, r.db)
+
+	var count int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s = $1 AND emoji = $2`, t.table, t.column)
+	if err := db.QueryRow(ctx, query, targetID, emoji).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	return count, nil
+}
+
+func (r *reactionRepo) Summary(ctx context.Context, target domain.ReactionTarget, targetID, userID int) ([]domain.ReactionSummary, error) {
+	if _, err := getReactionTable(target); err != nil {
+		return nil, err
+	}
+	db := GetQueryEngine(ctx, r.db)
+
+	var raw []byte
+	query := `SELECT ` + reactionsSummaryColumn(target, "$1", "$2")
+	if err := db.QueryRow(ctx, query, targetID, userID).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("failed to get reactions summary: %w", err)
+	}
+
+	summary := make([]domain.ReactionSummary, 0)
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode reactions summary: %w", err)
+	}
+	return summary, nil
+}
+
+func (r *reactionRepo) RemoveByUser(ctx context.Context, target domain.ReactionTarget, userID int) (int64, error) {
+	t, err := getReactionTable(target)
+	if err != nil {
+		return 0, err
+	}
+	db := GetQueryEngine(ctx, r.db)
+
+	// A user likes a row at most once, so each row loses one like at most
+	query := fmt.Sprintf(`
+		WITH removed AS (
+			DELETE FROM %[1]s WHERE user_id = $1
+			RETURNING %[2]s, emoji
+		), counted AS (
+			UPDATE %[3]s SET likes_count = GREATEST(likes_count - 1, 0)
+			FROM removed
+			WHERE %[3]s.id = removed.%[2]s AND removed.emoji = $2
+		)
+		SELECT COUNT(*) FROM removed
+	`, t.table, t.column, t.parent)
+
+	var removed int64
+	if err := db.QueryRow(ctx, query, userID, domain.LikeEmoji).Scan(&removed); err != nil {
+		return 0, fmt.Errorf("failed to remove user reactions: %w", err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,151 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReactionRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	postRepo := NewPostRepo(testDB.Pool)
+	reactionRepo := NewReactionRepo(testDB.Pool)
+	likeRepo := NewLikeRepository(testDB.Pool)
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "post_reactions", "comment_reactions", "comments", "posts", "users")
+	require.NoError(t, err)
+
+	alice, err := authRepo.CreateUser(ctx, "alice@example.com", "", "", domain.RoleUser)
+	require.NoError(t, err)
+	bob, err := authRepo.CreateUser(ctx, "bob@example.com", "", "", domain.RoleUser)
+	require.NoError(t, err)
+
+	post, err := postRepo.Create(ctx, &domain.Post{
+		Title:     "Reactions",
+		Slug:      "reactions",
+		Content:   "Post to test emoji reactions functionality",
+		AuthorID:  alice.ID,
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("Add is idempotent per emoji", func(t *testing.T) {
+		added, err := reactionRepo.Add(ctx, domain.ReactionTargetPost, post.ID, alice.ID, "🔥")
+		require.NoError(t, err)
+		assert.True(t, added)
+
+		added, err = reactionRepo.Add(ctx, domain.ReactionTargetPost, post.ID, alice.ID, "🔥")
+		require.NoError(t, err)
+		assert.False(t, added)
+
+		_, err = reactionRepo.Add(ctx, domain.ReactionTargetPost, post.ID, bob.ID, "🔥")
+		require.NoError(t, err)
+		_, err = reactionRepo.Add(ctx, domain.ReactionTargetPost, post.ID, alice.ID, "❤️")
+		require.NoError(t, err)
+
+		summary, err := reactionRepo.Summary(ctx, domain.ReactionTargetPost, post.ID, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ReactionSummary{
+			{Emoji: "🔥", Count: 2, Reacted: true},
+			{Emoji: "❤️", Count: 1, Reacted: false},
+		}, summary)
+	})
+
+	t.Run("Likes are thumbs up reactions", func(t *testing.T) {
+		liked, err := likeRepo.TogglePostLike(ctx, bob.ID, post.ID)
+		require.NoError(t, err)
+		assert.True(t, liked)
+
+		exists, err := reactionRepo.Exists(ctx, domain.ReactionTargetPost, post.ID, bob.ID, domain.LikeEmoji)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		retrieved, err := postRepo.GetByID(ctx, post.ID, bob.ID)
+		require.NoError(t, err)
+		assert.True(t, retrieved.IsLiked)
+		assert.Equal(t, 1, retrieved.LikesCount)
+		assert.Len(t, retrieved.Reactions, 3)
+
+		removed, err := reactionRepo.Remove(ctx, domain.ReactionTargetPost, post.ID, bob.ID, domain.LikeEmoji)
+		require.NoError(t, err)
+		assert.True(t, removed)
+
+		count, err := likeRepo.GetPostLikesCount(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		retrieved, err = postRepo.GetByID(ctx, post.ID, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, retrieved.LikesCount)
+	})
+
+	t.Run("Setting a like is idempotent", func(t *testing.T) {
+		changed, err := likeRepo.SetPostLike(ctx, alice.ID, post.ID, true)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		changed, err = likeRepo.SetPostLike(ctx, alice.ID, post.ID, true)
+		require.NoError(t, err)
+		assert.False(t, changed)
+
+		count, err := likeRepo.GetPostLikesCount(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		changed, err = likeRepo.SetPostLike(ctx, alice.ID, post.ID, false)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		changed, err = likeRepo.SetPostLike(ctx, alice.ID, post.ID, false)
+		require.NoError(t, err)
+		assert.False(t, changed)
+	})
+
+	t.Run("Liked by lists", func(t *testing.T) {
+		commentRepo := NewCommentRepo(testDB.Pool)
+		comment, err := commentRepo.Create(ctx, &domain.Comment{PostID: post.ID, UserID: alice.ID, Content: "Liked comment"})
+		require.NoError(t, err)
+
+		_, err = likeRepo.TogglePostLike(ctx, alice.ID, post.ID)
+		require.NoError(t, err)
+		_, err = likeRepo.TogglePostLike(ctx, bob.ID, post.ID)
+		require.NoError(t, err)
+		_, err = likeRepo.ToggleCommentLike(ctx, bob.ID, comment.ID)
+		require.NoError(t, err)
+
+		// Newest first
+		likers, total, err := likeRepo.ListLikers(ctx, domain.ReactionTargetPost, post.ID, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, likers, 1)
+		assert.Equal(t, bob.ID, likers[0].UserID)
+
+		items, total, err := likeRepo.ListLikedBy(ctx, bob.ID, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, items, 2)
+		assert.Equal(t, domain.ReactionTargetComment, items[0].Target)
+		require.NotNil(t, items[0].CommentID)
+		assert.Equal(t, comment.ID, *items[0].CommentID)
+		assert.Equal(t, "reactions", items[0].PostSlug)
+		assert.Equal(t, domain.ReactionTargetPost, items[1].Target)
+		assert.Nil(t, items[1].CommentID)
+
+		// Deleted comments drop out of the list
+		require.NoError(t, commentRepo.SoftDelete(ctx, comment.ID, "[deleted]"))
+		_, total, err = likeRepo.ListLikedBy(ctx, bob.ID, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+	})
+}
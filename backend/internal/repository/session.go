@@ -17,6 +17,15 @@ type SessionRepository interface {
 	GetSession(ctx context.Context, token string) (*domain.Session, error)
 	DeleteSession(ctx context.Context, token string) error
 	DeleteUserSessions(ctx context.Context, userID int) error
+	// DeleteUserSession deletes one session of a user and reports whether it existed
+	DeleteUserSession(ctx context.Context, userID, sessionID int) (bool, error)
+	// DeleteOtherUserSessions deletes every session of a user except the one with
+	// the given token and returns how many were deleted
+	DeleteOtherUserSessions(ctx context.Context, userID int, keepToken string) (int64, error)
+	// ListUserSessions returns the sessions of a user that have not expired, newest first
+	ListUserSessions(ctx context.Context, userID int) ([]domain.Session, error)
+	// TouchSession records that a session was just used
+	TouchSession(ctx context.Context, sessionID int) error
 	CleanupExpiredSessions(ctx context.Context) (int64, error)
 }
 
@@ -29,20 +38,42 @@ func NewSessionRepo(db *pgxpool.Pool) SessionRepository {
 	return &sessionRepo{db: db}
 }
 
+const sessionColumns = `id, user_id, token, user_agent, ip_address, expires_at, created_at, last_seen_at`
+
+func scanSession(row pgx.Row) (*domain.Session, error) {
+	var session domain.Session
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.Token,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.ExpiresAt,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
 func (r *sessionRepo) CreateSession(ctx context.Context, session *domain.Session) error {
 	db := GetQueryEngine(ctx, r.db)
 
 	query := `
-		INSERT INTO sessions (user_id, token, expires_at, created_at)
-		VALUES ($1, $2, $3, NOW())
-		RETURNING id, created_at
+		INSERT INTO sessions (user_id, token, user_agent, ip_address, expires_at, created_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, last_seen_at
 	`
 
 	err := db.QueryRow(ctx, query,
 		session.UserID,
 		session.Token,
+		session.UserAgent,
+		session.IPAddress,
 		session.ExpiresAt,
-	).Scan(&session.ID, &session.CreatedAt)
+	).Scan(&session.ID, &session.CreatedAt, &session.LastSeenAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
@@ -52,22 +83,15 @@ func (r *sessionRepo) CreateSession(ctx context.Context, session *domain.Session
 }
 
 func (r *sessionRepo) GetSession(ctx context.Context, token string) (*domain.Session, error) {
-	var session domain.Session
 	db := GetQueryEngine(ctx, r.db)
 
 	query := `
-		SELECT id, user_id, token, expires_at, created_at
+		SELECT ` + sessionColumns + `
 		FROM sessions
 		WHERE token = $1 AND expires_at > NOW()
 	`
 
-	err := db.QueryRow(ctx, query, token).Scan(
-		&session.ID,
-		&session.UserID,
-		&session.Token,
-		&session.ExpiresAt,
-		&session.CreatedAt,
-	)
+	session, err := scanSession(db.QueryRow(ctx, query, token))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil // Session not found or expired
@@ -75,7 +99,7 @@ func (r *sessionRepo) GetSession(ctx context.Context, token string) (*domain.Ses
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
-	return &session, nil
+	return session, nil
 }
 
 func (r *sessionRepo) DeleteSession(ctx context.Context, token string) error {
@@ -104,6 +128,77 @@ func (r *sessionRepo) DeleteUserSessions(ctx context.Context, userID int) error
 	return nil
 }
 
+func (r *sessionRepo) DeleteUserSession(ctx context.Context, userID, sessionID int) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `DELETE FROM sessions WHERE id = $1 AND user_id = $2`
+
+	result, err := db.Exec(ctx, query, sessionID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user session: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *sessionRepo) DeleteOtherUserSessions(ctx context.Context, userID int, keepToken string) (int64, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `DELETE FROM sessions WHERE user_id = $1 AND token <> $2`
+
+	result, err := db.Exec(ctx, query, userID, keepToken)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete other user sessions: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *sessionRepo) ListUserSessions(ctx context.Context, userID int) ([]domain.Session, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM sessions
+		WHERE user_id = $1 AND expires_at > NOW()
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []domain.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, *session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepo) TouchSession(ctx context.Context, sessionID int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `UPDATE sessions SET last_seen_at = NOW() WHERE id = $1`
+
+	_, err := db.Exec(ctx, query, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+
+	return nil
+}
+
 func (r *sessionRepo) CleanupExpiredSessions(ctx context.Context) (int64, error) {
 	db := GetQueryEngine(ctx, r.db)
 
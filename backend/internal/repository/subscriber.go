@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SubscriberRepository defines methods for newsletter subscriber data access
+type SubscriberRepository interface {
+	GetByEmail(ctx context.Context, email string) (*domain.Subscriber, error)
+	GetByConfirmToken(ctx context.Context, token string) (*domain.Subscriber, error)
+	GetByUnsubscribeToken(ctx context.Context, token string) (*domain.Subscriber, error)
+	// Save inserts a subscriber or updates the unconfirmed one with the same
+	// email, recording that a confirmation is sent. Returns false without
+	// saving when a confirmation was sent to the address within resendInterval.
+	Save(ctx context.Context, subscriber *domain.Subscriber, resendInterval time.Duration) (bool, error)
+	Confirm(ctx context.Context, id int) error
+	Unsubscribe(ctx context.Context, id int) error
+	ListConfirmed(ctx context.Context) ([]domain.Subscriber, error)
+
+	// Delivery queue
+	// EnqueuePost queues a post for every confirmed subscriber who didn't get it yet
+	EnqueuePost(ctx context.Context, postID int) (int64, error)
+	ListDueDeliveries(ctx context.Context, limit int) ([]domain.NewsletterDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.NewsletterDelivery) error
+}
+
+type subscriberRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewSubscriberRepo creates a new subscriber repository implementation
+func NewSubscriberRepo(db *pgxpool.Pool) SubscriberRepository {
+	return &subscriberRepo{db: db}
+}
+
+const subscriberColumns = `id, email, status, confirm_token, confirm_expires_at, confirm_sent_at, unsubscribe_token, confirmed_at, created_at`
+
+func scanSubscriber(row pgx.Row) (*domain.Subscriber, error) {
+	var s domain.Subscriber
+	var confirmToken *string
+	err := row.Scan(&s.ID, &s.Email, &s.Status, &confirmToken, &s.ConfirmExpiresAt, &s.ConfirmSentAt, &s.UnsubscribeToken, &s.ConfirmedAt, &s.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if confirmToken != nil {
+		s.ConfirmToken = *confirmToken
+	}
+	return &s, nil
+}
+
+func (r *subscriberRepo) getBy(ctx context.Context, column, value string) (*domain.Subscriber, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `SELECT ` + subscriberColumns + ` FROM subscribers WHERE ` + column + ` = $1`
+	subscriber, err := scanSubscriber(db.QueryRow(ctx, query, value))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get subscriber: %w", err)
+	}
+
+	return subscriber, nil
+}
+
+func (r *subscriberRepo) GetByEmail(ctx context.Context, email string) (*domain.Subscriber, error) {
+	return r.getBy(ctx, "email", email)
+}
+
+func (r *subscriberRepo) GetByConfirmToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	return r.getBy(ctx, "confirm_token", token)
+}
+
+func (r *subscriberRepo) GetByUnsubscribeToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	return r.getBy(ctx, "unsubscribe_token", token)
+}
+
+func (r *subscriberRepo) Save(ctx context.Context, subscriber *domain.Subscriber, resendInterval time.Duration) (bool, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	// The conditional update makes concurrent requests for one address send a single email
+	query := `
+		INSERT INTO subscribers (email, status, confirm_token, confirm_expires_at, confirm_sent_at, unsubscribe_token)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NOW(), $5)
+		ON CONFLICT (email) DO UPDATE SET
+			status = EXCLUDED.status,
+			confirm_token = EXCLUDED.confirm_token,
+			confirm_expires_at = EXCLUDED.confirm_expires_at,
+			confirm_sent_at = EXCLUDED.confirm_sent_at,
+			updated_at = NOW()
+		WHERE subscribers.status <> 'confirmed'
+			AND (subscribers.confirm_sent_at IS NULL OR subscribers.confirm_sent_at <= $6)
+		RETURNING id, confirm_sent_at, unsubscribe_token, created_at
+	`
+	err := db.QueryRow(ctx, query, subscriber.Email, subscriber.Status, subscriber.ConfirmToken,
+		subscriber.ConfirmExpiresAt, subscriber.UnsubscribeToken, time.Now().Add(-resendInterval)).
+		Scan(&subscriber.ID, &subscriber.ConfirmSentAt, &subscriber.UnsubscribeToken, &subscriber.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save subscriber: %w", err)
+	}
+
+	return true, nil
+}
+
+// Confirm activates a subscription and invalidates its confirmation token
+func (r *subscriberRepo) Confirm(ctx context.Context, id int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE subscribers
+		SET status = 'confirmed', confirm_token = NULL, confirm_expires_at = NULL, confirmed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to confirm subscriber: %w", err)
+	}
+
+	return nil
+}
+
+func (r *subscriberRepo) Unsubscribe(ctx context.Context, id int) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE subscribers
+		SET status = 'unsubscribed', confirm_token = NULL, confirm_expires_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+func (r *subscriberRepo) ListConfirmed(ctx context.Context) ([]domain.Subscriber, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `SELECT ` + subscriberColumns + ` FROM subscribers WHERE status = 'confirmed' ORDER BY id`
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	subscribers := make([]domain.Subscriber, 0)
+	for rows.Next() {
+		subscriber, err := scanSubscriber(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscriber: %w", err)
+		}
+		subscribers = append(subscribers, *subscriber)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate subscribers: %w", err)
+	}
+
+	return subscribers, nil
+}
+
+func (r *subscriberRepo) EnqueuePost(ctx context.Context, postID int) (int64, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	// Emails skipped because the post was unpublished or the subscriber left
+	// are queued again, sent and failed ones are not
+	query := `
+		INSERT INTO newsletter_deliveries (post_id, subscriber_id)
+		SELECT $1, id FROM subscribers WHERE status = 'confirmed'
+		ON CONFLICT (post_id, subscriber_id) DO UPDATE SET
+			status = 'pending',
+			attempts = 0,
+			next_attempt_at = NOW(),
+			last_error = ''
+		WHERE newsletter_deliveries.status = 'skipped'
+	`
+	tag, err := db.Exec(ctx, query, postID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue newsletter: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *subscriberRepo) ListDueDeliveries(ctx context.Context, limit int) ([]domain.NewsletterDelivery, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT d.id, d.post_id, d.subscriber_id, d.status, d.attempts, d.next_attempt_at, d.last_error, d.created_at,
+			s.email, s.unsubscribe_token, p.title, p.slug, COALESCE(p.preview, ''), COALESCE(p.cover_image, ''),
+			(p.deleted_at IS NOT NULL OR NOT p.published OR s.status <> 'confirmed') AS withdrawn
+		FROM newsletter_deliveries d
+		JOIN subscribers s ON s.id = d.subscriber_id
+		JOIN posts p ON p.id = d.post_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= NOW()
+		ORDER BY d.next_attempt_at, d.id
+		LIMIT $1
+	`
+	rows, err := db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list newsletter deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.NewsletterDelivery, 0)
+	for rows.Next() {
+		var d domain.NewsletterDelivery
+		err := rows.Scan(&d.ID, &d.PostID, &d.SubscriberID, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError, &d.CreatedAt,
+			&d.Email, &d.UnsubscribeToken, &d.PostTitle, &d.PostSlug, &d.PostPreview, &d.PostCoverImage, &d.Withdrawn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan newsletter delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate newsletter deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *subscriberRepo) UpdateDelivery(ctx context.Context, delivery *domain.NewsletterDelivery) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE newsletter_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5,
+			sent_at = CASE WHEN $2 = 'sent' THEN NOW() ELSE sent_at END
+		WHERE id = $1
+	`
+	_, err := db.Exec(ctx, query, delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastError)
+	if err != nil {
+		return fmt.Errorf("failed to update newsletter delivery: %w", err)
+	}
+
+	return nil
+}
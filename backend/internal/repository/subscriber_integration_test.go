@@ -0,0 +1,148 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriberRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	postRepo := NewPostRepo(testDB.Pool)
+	repo := NewSubscriberRepo(testDB.Pool)
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "newsletter_deliveries", "subscribers", "posts", "users")
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	subscriber := &domain.Subscriber{
+		Email:            "reader@example.com",
+		Status:           domain.SubscriberStatusPending,
+		ConfirmToken:     "confirm-1",
+		ConfirmExpiresAt: &expiresAt,
+		UnsubscribeToken: "unsubscribe-1",
+	}
+
+	t.Run("Save and confirm", func(t *testing.T) {
+		saved, err := repo.Save(ctx, subscriber, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, saved)
+		assert.NotZero(t, subscriber.ID)
+		assert.NotNil(t, subscriber.ConfirmSentAt)
+
+		// Another confirmation right away is refused
+		saved, err = repo.Save(ctx, &domain.Subscriber{
+			Email:            "reader@example.com",
+			Status:           domain.SubscriberStatusPending,
+			ConfirmToken:     "confirm-resent",
+			ConfirmExpiresAt: &expiresAt,
+			UnsubscribeToken: "ignored",
+		}, time.Minute)
+		require.NoError(t, err)
+		assert.False(t, saved)
+
+		found, err := repo.GetByConfirmToken(ctx, "confirm-1")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "reader@example.com", found.Email)
+
+		confirmed, err := repo.ListConfirmed(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, confirmed)
+
+		require.NoError(t, repo.Confirm(ctx, subscriber.ID))
+
+		// The confirmation link works once
+		found, err = repo.GetByConfirmToken(ctx, "confirm-1")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		confirmed, err = repo.ListConfirmed(ctx)
+		require.NoError(t, err)
+		require.Len(t, confirmed, 1)
+		assert.NotNil(t, confirmed[0].ConfirmedAt)
+	})
+
+	t.Run("Unsubscribe and subscribe again", func(t *testing.T) {
+		found, err := repo.GetByUnsubscribeToken(ctx, "unsubscribe-1")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+
+		require.NoError(t, repo.Unsubscribe(ctx, found.ID))
+		confirmed, err := repo.ListConfirmed(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, confirmed)
+
+		// Saving the same email updates the row and keeps the unsubscribe token
+		again := &domain.Subscriber{
+			Email:            "reader@example.com",
+			Status:           domain.SubscriberStatusPending,
+			ConfirmToken:     "confirm-2",
+			ConfirmExpiresAt: &expiresAt,
+			UnsubscribeToken: "ignored",
+		}
+		saved, err := repo.Save(ctx, again, 0)
+		require.NoError(t, err)
+		assert.True(t, saved)
+		assert.Equal(t, subscriber.ID, again.ID)
+		assert.Equal(t, "unsubscribe-1", again.UnsubscribeToken)
+
+		found, err = repo.GetByEmail(ctx, "reader@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, domain.SubscriberStatusPending, found.Status)
+		assert.Equal(t, "confirm-2", found.ConfirmToken)
+	})
+
+	t.Run("Post is queued once per subscriber", func(t *testing.T) {
+		require.NoError(t, repo.Confirm(ctx, subscriber.ID))
+
+		author, err := authRepo.CreateUser(ctx, "author@example.com", "Author", "", domain.RoleAdmin)
+		require.NoError(t, err)
+		post, err := postRepo.Create(ctx, &domain.Post{
+			Title:     "Newsletter",
+			Slug:      "newsletter",
+			Content:   "Post to test the newsletter",
+			AuthorID:  author.ID,
+			Published: true,
+		})
+		require.NoError(t, err)
+
+		queued, err := repo.EnqueuePost(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), queued)
+
+		due, err := repo.ListDueDeliveries(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, "reader@example.com", due[0].Email)
+		assert.Equal(t, "Newsletter", due[0].PostTitle)
+		assert.False(t, due[0].Withdrawn)
+
+		due[0].Status = domain.NewsletterDeliverySent
+		due[0].Attempts = 1
+		require.NoError(t, repo.UpdateDelivery(ctx, &due[0]))
+
+		// Publishing again doesn't resend the post
+		queued, err = repo.EnqueuePost(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Zero(t, queued)
+
+		due, err = repo.ListDueDeliveries(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, due)
+	})
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebmentionRepository defines methods for Webmention data access
+type WebmentionRepository interface {
+	// Received mentions
+	UpsertReceived(ctx context.Context, mention *domain.Webmention) error
+	ListDueReceived(ctx context.Context, limit int) ([]domain.Webmention, error)
+	UpdateReceived(ctx context.Context, mention *domain.Webmention) error
+	ListVerified(ctx context.Context, postID int) ([]domain.Webmention, error)
+
+	// Sent mentions
+	EnqueueOutgoing(ctx context.Context, postID int, source, target string) error
+	ListOutgoingTargets(ctx context.Context, postID int) ([]string, error)
+	ListDueOutgoing(ctx context.Context, limit int) ([]domain.OutgoingWebmention, error)
+	UpdateOutgoing(ctx context.Context, mention *domain.OutgoingWebmention) error
+}
+
+type webmentionRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewWebmentionRepo creates a new webmention repository implementation
+func NewWebmentionRepo(db *pgxpool.Pool) WebmentionRepository {
+	return &webmentionRepo{db: db}
+}
+
+// UpsertReceived queues a mention for verification. Sending the same
+// source and target again re-verifies it (the source may have changed).
+func (r *webmentionRepo) UpsertReceived(ctx context.Context, mention *domain.Webmention) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO webmentions (post_id, source, target)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (source, target) DO UPDATE SET
+			post_id = EXCLUDED.post_id,
+			status = 'pending',
+			attempts = 0,
+			next_attempt_at = NOW(),
+			last_error = '',
+			updated_at = NOW()
+		RETURNING id, status, created_at
+	`
+	err := db.QueryRow(ctx, query, mention.PostID, mention.Source, mention.Target).Scan(&mention.ID, &mention.Status, &mention.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save webmention: %w", err)
+	}
+
+	return nil
+}
+
+func (r *webmentionRepo) ListDueReceived(ctx context.Context, limit int) ([]domain.Webmention, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT id, post_id, source, target, title, status, attempts, next_attempt_at, last_error, verified_at, created_at
+		FROM webmentions
+		WHERE status = 'pending' AND next_attempt_at <= NOW()
+		ORDER BY next_attempt_at
+		LIMIT $1
+	`
+	return r.queryReceived(ctx, db, query, limit)
+}
+
+func (r *webmentionRepo) UpdateReceived(ctx context.Context, mention *domain.Webmention) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE webmentions
+		SET status = $2, title = $3, attempts = $4, next_attempt_at = $5, last_error = $6, verified_at = $7, updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := db.Exec(ctx, query, mention.ID, mention.Status, mention.Title, mention.Attempts,
+		mention.NextAttemptAt, mention.LastError, mention.VerifiedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webmention: %w", err)
+	}
+
+	return nil
+}
+
+func (r *webmentionRepo) ListVerified(ctx context.Context, postID int) ([]domain.Webmention, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT id, post_id, source, target, title, status, attempts, next_attempt_at, last_error, verified_at, created_at
+		FROM webmentions
+		WHERE post_id = $1 AND status = 'verified'
+		ORDER BY verified_at DESC, id DESC
+	`
+	return r.queryReceived(ctx, db, query, postID)
+}
+
+func (r *webmentionRepo) queryReceived(ctx context.Context, db QueryEngine, query string, args ...any) ([]domain.Webmention, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webmentions: %w", err)
+	}
+	defer rows.Close()
+
+	mentions := make([]domain.Webmention, 0)
+	for rows.Next() {
+		var m domain.Webmention
+		err := rows.Scan(&m.ID, &m.PostID, &m.Source, &m.Target, &m.Title, &m.Status, &m.Attempts,
+			&m.NextAttemptAt, &m.LastError, &m.VerifiedAt, &m.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webmention: %w", err)
+		}
+		mentions = append(mentions, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webmentions: %w", err)
+	}
+
+	return mentions, nil
+}
+
+// EnqueueOutgoing schedules a mention of target, resending it if it was sent before
+func (r *webmentionRepo) EnqueueOutgoing(ctx context.Context, postID int, source, target string) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		INSERT INTO webmentions_outgoing (post_id, source, target)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, target) DO UPDATE SET
+			source = EXCLUDED.source,
+			status = 'pending',
+			attempts = 0,
+			next_attempt_at = NOW(),
+			last_error = '',
+			updated_at = NOW()
+	`
+	if _, err := db.Exec(ctx, query, postID, source, target); err != nil {
+		return fmt.Errorf("failed to enqueue webmention: %w", err)
+	}
+
+	return nil
+}
+
+// ListOutgoingTargets returns every target ever mentioned by a post
+func (r *webmentionRepo) ListOutgoingTargets(ctx context.Context, postID int) ([]string, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	rows, err := db.Query(ctx, `SELECT target FROM webmentions_outgoing WHERE post_id = $1 ORDER BY id`, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webmention targets: %w", err)
+	}
+	defer rows.Close()
+
+	targets := make([]string, 0)
+	for rows.Next() {
+		var target string
+		if err := rows.Scan(&target); err != nil {
+			return nil, fmt.Errorf("failed to scan webmention target: %w", err)
+		}
+		targets = append(targets, target)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate webmention targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+func (r *webmentionRepo) ListDueOutgoing(ctx context.Context, limit int) ([]domain.OutgoingWebmention, error) {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		SELECT id, post_id, source, target, endpoint, status, attempts, next_attempt_at, last_error
+		FROM webmentions_outgoing
+		WHERE status = 'pending' AND next_attempt_at <= NOW()
+		ORDER BY next_attempt_at
+		LIMIT $1
+	`
+	rows, err := db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outgoing webmentions: %w", err)
+	}
+	defer rows.Close()
+
+	mentions := make([]domain.OutgoingWebmention, 0)
+	for rows.Next() {
+		var m domain.OutgoingWebmention
+		err := rows.Scan(&m.ID, &m.PostID, &m.Source, &m.Target, &m.Endpoint, &m.Status, &m.Attempts, &m.NextAttemptAt, &m.LastError)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan outgoing webmention: %w", err)
+		}
+		mentions = append(mentions, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outgoing webmentions: %w", err)
+	}
+
+	return mentions, nil
+}
+
+func (r *webmentionRepo) UpdateOutgoing(ctx context.Context, mention *domain.OutgoingWebmention) error {
+	db := GetQueryEngine(ctx, r.db)
+
+	query := `
+		UPDATE webmentions_outgoing
+		SET endpoint = $2, status = $3, attempts = $4, next_attempt_at = $5, last_error = $6, updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := db.Exec(ctx, query, mention.ID, mention.Endpoint, mention.Status, mention.Attempts, mention.NextAttemptAt, mention.LastError)
+	if err != nil {
+		return fmt.Errorf("failed to update outgoing webmention: %w", err)
+	}
+
+	return nil
+}
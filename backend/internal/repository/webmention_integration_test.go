@@ -0,0 +1,106 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebmentionRepository_Integration(t *testing.T) {
+	// Setup test database
+	testDB := testutil.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	authRepo := NewAuthRepo(testDB.Pool)
+	postRepo := NewPostRepo(testDB.Pool)
+	wmRepo := NewWebmentionRepo(testDB.Pool)
+	ctx := context.Background()
+
+	// Clean up tables at the start
+	err := testDB.TruncateTables(ctx, "webmentions", "webmentions_outgoing", "posts", "users")
+	require.NoError(t, err)
+
+	owner, err := authRepo.CreateUser(ctx, "owner@example.com", "Owner", "", domain.RoleAdmin)
+	require.NoError(t, err)
+	post, err := postRepo.Create(ctx, &domain.Post{
+		Title:     "Mentioned",
+		Slug:      "mentioned",
+		Content:   "Post to test webmentions",
+		AuthorID:  owner.ID,
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("Received mentions", func(t *testing.T) {
+		mention := &domain.Webmention{PostID: post.ID, Source: "https://other.example/post", Target: "https://blog.example/blog/mentioned"}
+		require.NoError(t, wmRepo.UpsertReceived(ctx, mention))
+		assert.NotZero(t, mention.ID)
+		assert.Equal(t, domain.WebmentionStatusPending, mention.Status)
+
+		due, err := wmRepo.ListDueReceived(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+
+		// Unverified mentions are not listed
+		verified, err := wmRepo.ListVerified(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Empty(t, verified)
+
+		now := time.Now()
+		m := due[0]
+		m.Status = domain.WebmentionStatusVerified
+		m.Title = "Their post"
+		m.Attempts = 1
+		m.VerifiedAt = &now
+		require.NoError(t, wmRepo.UpdateReceived(ctx, &m))
+
+		verified, err = wmRepo.ListVerified(ctx, post.ID)
+		require.NoError(t, err)
+		require.Len(t, verified, 1)
+		assert.Equal(t, "Their post", verified[0].Title)
+
+		// Sending the same mention again queues it for verification
+		again := &domain.Webmention{PostID: post.ID, Source: mention.Source, Target: mention.Target}
+		require.NoError(t, wmRepo.UpsertReceived(ctx, again))
+		assert.Equal(t, mention.ID, again.ID)
+		assert.Equal(t, domain.WebmentionStatusPending, again.Status)
+	})
+
+	t.Run("Outgoing mentions", func(t *testing.T) {
+		source := "https://blog.example/blog/mentioned"
+		require.NoError(t, wmRepo.EnqueueOutgoing(ctx, post.ID, source, "https://a.example/post"))
+		require.NoError(t, wmRepo.EnqueueOutgoing(ctx, post.ID, source, "https://b.example/post"))
+
+		due, err := wmRepo.ListDueOutgoing(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2)
+
+		m := due[0]
+		m.Status = domain.WebmentionStatusSent
+		m.Endpoint = "https://a.example/webmention"
+		m.Attempts = 1
+		require.NoError(t, wmRepo.UpdateOutgoing(ctx, &m))
+
+		due, err = wmRepo.ListDueOutgoing(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, due, 1)
+
+		// Re-enqueueing a sent mention resends it
+		require.NoError(t, wmRepo.EnqueueOutgoing(ctx, post.ID, source, m.Target))
+		due, err = wmRepo.ListDueOutgoing(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, due, 2)
+
+		targets, err := wmRepo.ListOutgoingTargets(ctx, post.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://a.example/post", "https://b.example/post"}, targets)
+	})
+}
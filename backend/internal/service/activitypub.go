@@ -0,0 +1,698 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/activitypub"
+	"personal-web-platform/internal/pkg/httpsig"
+	"personal-web-platform/internal/pkg/netguard"
+	"personal-web-platform/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// outboxPageSize is the number of activities per outbox page
+const outboxPageSize = 20
+
+// maxRemoteCommentLength matches the comment content limit
+const maxRemoteCommentLength = 5000
+
+// ActivityPubService defines methods for ActivityPub federation of the blog
+type ActivityPubService interface {
+	WebFinger(ctx context.Context, resource string) (*activitypub.WebFinger, error)
+	Actor(ctx context.Context) (*activitypub.Actor, error)
+	Outbox(ctx context.Context, page int) (*activitypub.OrderedCollection, error)
+	Followers(ctx context.Context) (*activitypub.OrderedCollection, error)
+	PostObject(ctx context.Context, postID int) (*activitypub.Object, error)
+	HandleInbox(ctx context.Context, r *http.Request, body []byte) error
+
+	// PostPublished delivers a Create activity to followers in the background
+	PostPublished(ctx context.Context, post *domain.Post)
+	// Wait blocks until background deliveries finish or ctx is done
+	Wait(ctx context.Context) error
+}
+
+type activityPubService struct {
+	apRepo      repository.ActivityPubRepository
+	postRepo    repository.PostRepository
+	commentRepo repository.CommentRepository
+	authRepo    repository.AuthRepository
+	profileRepo repository.ProfileRepository
+	transactor  repository.Transactor
+	comments    CommentService
+	httpClient  *http.Client
+	cfg         config.ActivityPub
+	frontendURL string
+	log         *slog.Logger
+
+	keyMu sync.Mutex
+	key   *domain.ActivityPubKey
+
+	// deliveries tracks background deliveries
+	deliveries sync.WaitGroup
+}
+
+// NewActivityPubService creates a new ActivityPub service implementation.
+// Remote replies are created through comments so they follow the same rules as local ones.
+func NewActivityPubService(
+	apRepo repository.ActivityPubRepository,
+	postRepo repository.PostRepository,
+	commentRepo repository.CommentRepository,
+	authRepo repository.AuthRepository,
+	profileRepo repository.ProfileRepository,
+	transactor repository.Transactor,
+	comments CommentService,
+	cfg *config.Config,
+	log *slog.Logger,
+) ActivityPubService {
+	return &activityPubService{
+		apRepo:      apRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		authRepo:    authRepo,
+		profileRepo: profileRepo,
+		transactor:  transactor,
+		comments:    comments,
+		httpClient:  netguard.NewClient(cfg.ActivityPub.DeliveryTimeout),
+		cfg:         cfg.ActivityPub,
+		frontendURL: strings.TrimSuffix(cfg.OAuth.FrontendURL, "/"),
+		log:         log,
+	}
+}
+
+func (s *activityPubService) actorID() string     { return s.cfg.BaseURL + "/ap/actor" }
+func (s *activityPubService) keyID() string       { return s.actorID() + "#main-key" }
+func (s *activityPubService) followersID() string { return s.cfg.BaseURL + "/ap/followers" }
+func (s *activityPubService) outboxID() string    { return s.cfg.BaseURL + "/ap/outbox" }
+func (s *activityPubService) postsPrefix() string { return s.cfg.BaseURL + "/ap/posts/" }
+
+func (s *activityPubService) WebFinger(_ context.Context, resource string) (*activitypub.WebFinger, error) {
+	if resource == "" {
+		return nil, fmt.Errorf("%w: resource is required", derr.ErrValidation)
+	}
+
+	base, err := url.Parse(s.cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid activitypub base url: %w", err)
+	}
+	subject := "acct:" + s.cfg.Username + "@" + base.Host
+
+	if !strings.EqualFold(resource, subject) && resource != s.actorID() {
+		return nil, fmt.Errorf("%w: unknown resource", derr.ErrNotFound)
+	}
+
+	return &activitypub.WebFinger{
+		Subject: subject,
+		Aliases: []string{s.actorID()},
+		Links: []activitypub.WebFingerLink{
+			{Rel: "self", Type: activitypub.ContentType, Href: s.actorID()},
+			{Rel: "http://webfinger.net/rel/profile-page", Type: "text/html", Href: s.frontendURL + "/"},
+		},
+	}, nil
+}
+
+func (s *activityPubService) Actor(ctx context.Context) (*activitypub.Actor, error) {
+	key, err := s.loadKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := &activitypub.Actor{
+		Context:           []string{activitypub.ActivityStreamsContext, activitypub.SecurityContext},
+		ID:                s.actorID(),
+		Type:              activitypub.TypePerson,
+		PreferredUsername: s.cfg.Username,
+		Name:              s.cfg.Username,
+		URL:               s.frontendURL + "/",
+		Inbox:             s.cfg.BaseURL + "/ap/inbox",
+		Outbox:            s.outboxID(),
+		Followers:         s.followersID(),
+		Endpoints:         &activitypub.Endpoints{SharedInbox: s.cfg.BaseURL + "/ap/inbox"},
+		PublicKey: activitypub.PublicKey{
+			ID:           s.keyID(),
+			Owner:        s.actorID(),
+			PublicKeyPem: key.PublicKeyPEM,
+		},
+	}
+
+	// The site owner profile gives the actor a name, bio and avatar
+	profile, err := s.profileRepo.GetProfile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	if profile != nil {
+		actor.Name = profile.Name
+		actor.Summary = "<p>" + html.EscapeString(profile.Description) + "</p>"
+		if profile.PhotoURL != "" {
+			actor.Icon = &activitypub.Image{Type: activitypub.TypeImage, URL: s.absoluteURL(profile.PhotoURL)}
+		}
+	}
+
+	return actor, nil
+}
+
+func (s *activityPubService) Outbox(ctx context.Context, page int) (*activitypub.OrderedCollection, error) {
+	published := true
+	limit := outboxPageSize
+	if page < 1 {
+		// The collection itself only links to the first page
+		limit = 1
+	}
+
+	posts, total, err := s.postRepo.List(ctx, &domain.ListPostsRequest{Page: max(page, 1), Limit: limit, Published: &published})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list posts: %w", err)
+	}
+
+	if page < 1 {
+		return &activitypub.OrderedCollection{
+			Context:    activitypub.ActivityStreamsContext,
+			ID:         s.outboxID(),
+			Type:       activitypub.TypeOrderedCollection,
+			TotalItems: total,
+			First:      s.outboxID() + "?page=1",
+		}, nil
+	}
+
+	collection := &activitypub.OrderedCollection{
+		Context:      activitypub.ActivityStreamsContext,
+		ID:           s.outboxID() + "?page=" + strconv.Itoa(page),
+		Type:         activitypub.TypeOrderedCollectionPage,
+		TotalItems:   total,
+		PartOf:       s.outboxID(),
+		OrderedItems: make([]*activitypub.Activity, 0, len(posts)),
+	}
+	if page*outboxPageSize < total {
+		collection.Next = s.outboxID() + "?page=" + strconv.Itoa(page+1)
+	}
+	for i := range posts {
+		activity, err := s.createActivity(&posts[i])
+		if err != nil {
+			return nil, err
+		}
+		collection.OrderedItems = append(collection.OrderedItems, activity)
+	}
+
+	return collection, nil
+}
+
+func (s *activityPubService) Followers(ctx context.Context) (*activitypub.OrderedCollection, error) {
+	count, err := s.apRepo.CountFollowers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count followers: %w", err)
+	}
+
+	// Only the size is public, like on Mastodon
+	return &activitypub.OrderedCollection{
+		Context:    activitypub.ActivityStreamsContext,
+		ID:         s.followersID(),
+		Type:       activitypub.TypeOrderedCollection,
+		TotalItems: count,
+	}, nil
+}
+
+func (s *activityPubService) PostObject(ctx context.Context, postID int) (*activitypub.Object, error) {
+	post, err := s.postRepo.GetByID(ctx, postID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil || !post.Published || post.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	object := s.postObject(post)
+	object.Context = activitypub.ActivityStreamsContext
+	return object, nil
+}
+
+func (s *activityPubService) PostPublished(ctx context.Context, post *domain.Post) {
+	if !post.Published || post.DeletedAt != nil {
+		return
+	}
+
+	activity, err := s.createActivity(post)
+	if err != nil {
+		s.log.Error("failed to build create activity", slog.Int("post_id", post.ID), slog.String("error", err.Error()))
+		return
+	}
+	activity.Context = activitypub.ActivityStreamsContext
+
+	s.deliverAsync(ctx, func(ctx context.Context, client *activitypub.Client) {
+		followers, err := s.apRepo.ListFollowers(ctx)
+		if err != nil {
+			s.log.Error("failed to list followers", slog.String("error", err.Error()))
+			return
+		}
+
+		// Servers with a shared inbox receive a single copy for all their followers
+		delivered := make(map[string]bool, len(followers))
+		for _, f := range followers {
+			inbox := f.Inbox
+			if f.SharedInbox != "" {
+				inbox = f.SharedInbox
+			}
+			if delivered[inbox] {
+				continue
+			}
+			delivered[inbox] = true
+
+			if err := client.Deliver(ctx, inbox, activity); err != nil {
+				s.log.Warn("failed to deliver activity", slog.String("inbox", inbox), slog.String("error", err.Error()))
+			}
+		}
+	})
+}
+
+func (s *activityPubService) HandleInbox(ctx context.Context, r *http.Request, body []byte) error {
+	var activity activitypub.Activity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		return fmt.Errorf("%w: invalid activity: %v", derr.ErrValidation, err)
+	}
+
+	switch activity.Type {
+	case activitypub.TypeFollow, activitypub.TypeUndo, activitypub.TypeCreate:
+	default:
+		// Everything else (likes, boosts, deletes of unknown accounts) is accepted and ignored
+		return nil
+	}
+
+	actor, err := s.verifySignature(ctx, r, body, activity.Actor)
+	if err != nil {
+		return err
+	}
+	if activity.Actor != actor.ID {
+		return fmt.Errorf("%w: activity actor does not match the signature", derr.ErrPermission)
+	}
+
+	switch activity.Type {
+	case activitypub.TypeFollow:
+		return s.handleFollow(ctx, actor, &activity, body)
+	case activitypub.TypeUndo:
+		return s.handleUndo(ctx, actor, &activity)
+	default:
+		return s.handleCreate(ctx, actor, &activity)
+	}
+}
+
+// verifySignature checks the HTTP signature of an inbox request and returns the signer.
+// The key is only fetched from the server of the activity actor.
+func (s *activityPubService) verifySignature(ctx context.Context, r *http.Request, body []byte, activityActor string) (*activitypub.Actor, error) {
+	sig, err := httpsig.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", derr.ErrPermission, err)
+	}
+
+	keyURL, err := parseRemoteURL(sig.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid key id: %v", derr.ErrPermission, err)
+	}
+	actorURL, err := parseRemoteURL(activityActor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid actor: %v", derr.ErrPermission, err)
+	}
+	if !strings.EqualFold(keyURL.Host, actorURL.Host) {
+		return nil, fmt.Errorf("%w: key is not hosted by the actor server", derr.ErrPermission)
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actorID, _, _ := strings.Cut(sig.KeyID, "#")
+	actor, err := client.FetchActor(ctx, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to fetch signer: %v", derr.ErrPermission, err)
+	}
+	if actor.PublicKey.ID != sig.KeyID {
+		return nil, fmt.Errorf("%w: unknown key %q", derr.ErrPermission, sig.KeyID)
+	}
+
+	publicKey, err := httpsig.ParsePublicKey(actor.PublicKey.PublicKeyPem)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", derr.ErrPermission, err)
+	}
+	if err := httpsig.Verify(r, body, sig, publicKey); err != nil {
+		return nil, fmt.Errorf("%w: %v", derr.ErrPermission, err)
+	}
+
+	return actor, nil
+}
+
+func (s *activityPubService) handleFollow(ctx context.Context, actor *activitypub.Actor, activity *activitypub.Activity, body []byte) error {
+	if activitypub.ObjectID(activity.Object) != s.actorID() {
+		return fmt.Errorf("%w: only the blog actor can be followed", derr.ErrValidation)
+	}
+
+	follower := &domain.Follower{ActorID: actor.ID, Inbox: actor.Inbox}
+	if actor.Endpoints != nil {
+		follower.SharedInbox = actor.Endpoints.SharedInbox
+	}
+	if _, err := parseRemoteURL(follower.Inbox); err != nil {
+		return fmt.Errorf("%w: invalid inbox: %v", derr.ErrValidation, err)
+	}
+	if follower.SharedInbox != "" {
+		if _, err := parseRemoteURL(follower.SharedInbox); err != nil {
+			return fmt.Errorf("%w: invalid shared inbox: %v", derr.ErrValidation, err)
+		}
+	}
+	if err := s.apRepo.AddFollower(ctx, follower); err != nil {
+		return fmt.Errorf("failed to add follower: %w", err)
+	}
+
+	accept := &activitypub.Activity{
+		Context: activitypub.ActivityStreamsContext,
+		ID:      s.actorID() + "#accepts/" + uuid.NewString(),
+		Type:    activitypub.TypeAccept,
+		Actor:   s.actorID(),
+		Object:  json.RawMessage(body),
+	}
+	s.deliverAsync(ctx, func(ctx context.Context, client *activitypub.Client) {
+		if err := client.Deliver(ctx, actor.Inbox, accept); err != nil {
+			s.log.Warn("failed to accept follow", slog.String("actor", actor.ID), slog.String("error", err.Error()))
+		}
+	})
+
+	return nil
+}
+
+func (s *activityPubService) handleUndo(ctx context.Context, actor *activitypub.Actor, activity *activitypub.Activity) error {
+	var undone activitypub.Activity
+	if err := json.Unmarshal(activity.Object, &undone); err != nil {
+		// A bare reference can't be matched to anything we store
+		return nil
+	}
+	if undone.Type != activitypub.TypeFollow || undone.Actor != actor.ID {
+		return nil
+	}
+
+	if _, err := s.apRepo.RemoveFollower(ctx, actor.ID); err != nil {
+		return fmt.Errorf("failed to remove follower: %w", err)
+	}
+	return nil
+}
+
+// handleCreate stores replies to blog posts (or to stored replies) as comments
+func (s *activityPubService) handleCreate(ctx context.Context, actor *activitypub.Actor, activity *activitypub.Activity) error {
+	var note activitypub.Object
+	if err := json.Unmarshal(activity.Object, &note); err != nil || note.Type != activitypub.TypeNote {
+		return nil
+	}
+	if note.ID == "" || note.InReplyTo == "" {
+		return nil
+	}
+	if note.AttributedTo != "" && note.AttributedTo != actor.ID {
+		return fmt.Errorf("%w: note is attributed to another actor", derr.ErrPermission)
+	}
+
+	content := htmlToText(note.Content)
+	if content == "" {
+		return nil
+	}
+	if runes := []rune(content); len(runes) > maxRemoteCommentLength {
+		content = string(runes[:maxRemoteCommentLength])
+	}
+
+	return s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		// Deliveries are retried by remote servers, store each note once
+		existing, err := s.apRepo.GetCommentIDByObjectID(ctx, note.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check remote comment: %w", err)
+		}
+		if existing != 0 {
+			return nil
+		}
+
+		postID, parentID, err := s.resolveReply(ctx, note.InReplyTo)
+		if err != nil || postID == 0 {
+			return err
+		}
+
+		user, err := s.remoteUser(ctx, actor)
+		if err != nil {
+			return err
+		}
+
+		comment, err := s.comments.CreateComment(ctx, postID, &domain.CreateCommentRequest{Content: content, ParentID: parentID}, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create remote comment: %w", err)
+		}
+
+		if err := s.apRepo.LinkComment(ctx, note.ID, comment.ID); err != nil {
+			return fmt.Errorf("failed to link remote comment: %w", err)
+		}
+		return nil
+	})
+}
+
+// resolveReply finds the post (and parent comment) a remote note replies to.
+// Returns a zero post ID when the note is not a reply to this blog.
+func (s *activityPubService) resolveReply(ctx context.Context, inReplyTo string) (int, *int, error) {
+	if rest, ok := strings.CutPrefix(inReplyTo, s.postsPrefix()); ok {
+		postID, err := strconv.Atoi(rest)
+		if err != nil {
+			return 0, nil, nil
+		}
+		post, err := s.postRepo.GetByID(ctx, postID, 0)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get post: %w", err)
+		}
+		if post == nil || !post.Published || post.DeletedAt != nil {
+			return 0, nil, nil
+		}
+		return post.ID, nil, nil
+	}
+
+	// Replies to replies thread under the stored remote comment
+	commentID, err := s.apRepo.GetCommentIDByObjectID(ctx, inReplyTo)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get parent comment: %w", err)
+	}
+	if commentID == 0 {
+		return 0, nil, nil
+	}
+	parent, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get parent comment: %w", err)
+	}
+	if parent == nil || parent.DeletedAt != nil {
+		return 0, nil, nil
+	}
+	return parent.PostID, &parent.ID, nil
+}
+
+// remoteUser returns the local shadow user of a remote actor, creating it on first reply
+func (s *activityPubService) remoteUser(ctx context.Context, actor *activitypub.Actor) (*domain.User, error) {
+	user, err := s.authRepo.GetUserByProviderID(ctx, domain.ActivityPubProvider, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote user: %w", err)
+	}
+	if user != nil {
+		return user, nil
+	}
+
+	actorURL, err := url.Parse(actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid actor id", derr.ErrValidation)
+	}
+
+	name := actor.Name
+	if name == "" {
+		name = actor.PreferredUsername
+	}
+	var avatarURL string
+	if actor.Icon != nil {
+		avatarURL = actor.Icon.URL
+	}
+
+	// Remote users have no address, the reserved .invalid TLD makes sure
+	// nothing is ever sent to it. preferredUsername is optional and chosen by
+	// the actor, the hash of the actor ID keeps the unique email column unique.
+	sum := sha256.Sum256([]byte(actor.ID))
+	email := fmt.Sprintf("%s@%s.invalid", hex.EncodeToString(sum[:16]), actorURL.Hostname())
+
+	user, err = s.authRepo.CreateUser(ctx, email, name, avatarURL, domain.RoleUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote user: %w", err)
+	}
+
+	err = s.authRepo.LinkOAuthProvider(ctx, &domain.OAuthProvider{
+		UserID:         user.ID,
+		Provider:       domain.ActivityPubProvider,
+		ProviderUserID: actor.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link remote user: %w", err)
+	}
+
+	return user, nil
+}
+
+// postObject renders a post as an Article linking to the blog page
+func (s *activityPubService) postObject(post *domain.Post) *activitypub.Object {
+	pageURL := s.frontendURL + "/blog/" + post.Slug
+
+	published := post.PublishedAt
+	if published.IsZero() {
+		published = post.CreatedAt
+	}
+
+	summary := post.Preview
+	if summary == "" {
+		summary = post.Title
+	}
+
+	object := &activitypub.Object{
+		ID:           s.postsPrefix() + strconv.Itoa(post.ID),
+		Type:         activitypub.TypeArticle,
+		AttributedTo: s.actorID(),
+		Name:         post.Title,
+		Content: fmt.Sprintf(`<p>%s</p><p><a href="%s">%s</a></p>`,
+			html.EscapeString(summary), html.EscapeString(pageURL), html.EscapeString(pageURL)),
+		URL:       pageURL,
+		Published: &published,
+		To:        []string{activitypub.PublicCollection},
+		Cc:        []string{s.followersID()},
+	}
+	if post.CoverImage != "" {
+		object.Image = &activitypub.Image{Type: activitypub.TypeImage, URL: s.absoluteURL(post.CoverImage)}
+	}
+
+	return object
+}
+
+func (s *activityPubService) createActivity(post *domain.Post) (*activitypub.Activity, error) {
+	object := s.postObject(post)
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode post object: %w", err)
+	}
+
+	return &activitypub.Activity{
+		ID:        object.ID + "#create",
+		Type:      activitypub.TypeCreate,
+		Actor:     s.actorID(),
+		Object:    raw,
+		Published: object.Published,
+		To:        object.To,
+		Cc:        object.Cc,
+	}, nil
+}
+
+func (s *activityPubService) Wait(ctx context.Context) error {
+	return waitGroup(ctx, &s.deliveries)
+}
+
+// deliverAsync runs fn in the background, detached from the request context
+func (s *activityPubService) deliverAsync(ctx context.Context, fn func(ctx context.Context, client *activitypub.Client)) {
+	ctx = context.WithoutCancel(ctx)
+
+	s.deliveries.Add(1)
+	go func() {
+		defer s.deliveries.Done()
+
+		client, err := s.client(ctx)
+		if err != nil {
+			s.log.Error("failed to create activitypub client", slog.String("error", err.Error()))
+			return
+		}
+		fn(ctx, client)
+	}()
+}
+
+func (s *activityPubService) client(ctx context.Context) (*activitypub.Client, error) {
+	key, err := s.loadKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := httpsig.ParsePrivateKey(key.PrivateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse actor key: %w", err)
+	}
+	return activitypub.NewClient(s.httpClient, s.keyID(), privateKey), nil
+}
+
+// loadKey returns the actor key pair, generating it on first use
+func (s *activityPubService) loadKey(ctx context.Context) (*domain.ActivityPubKey, error) {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+
+	if s.key != nil {
+		return s.key, nil
+	}
+
+	key, err := s.apRepo.GetKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get actor key: %w", err)
+	}
+
+	if key == nil {
+		privatePEM, publicPEM, err := httpsig.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		key, err = s.apRepo.SaveKey(ctx, &domain.ActivityPubKey{PrivateKeyPEM: privatePEM, PublicKeyPEM: publicPEM})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save actor key: %w", err)
+		}
+		if key == nil {
+			return nil, errors.New("failed to save actor key")
+		}
+	}
+
+	s.key = key
+	return key, nil
+}
+
+// absoluteURL resolves site-relative links such as uploaded images
+func (s *activityPubService) absoluteURL(link string) string {
+	if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+		return link
+	}
+	return s.frontendURL + "/" + strings.TrimPrefix(link, "/")
+}
+
+// parseRemoteURL accepts absolute https URLs only, remote servers are never
+// contacted over plain http
+func parseRemoteURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return nil, errors.New("must be an absolute https url")
+	}
+	return u, nil
+}
+
+var (
+	htmlBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</p>`)
+	htmlTagRe   = regexp.MustCompile(`<[^>]*>`)
+	blankLineRe = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText converts the HTML content of a remote note to plain comment text
+func htmlToText(content string) string {
+	content = htmlBreakRe.ReplaceAllString(content, "\n")
+	content = htmlTagRe.ReplaceAllString(content, "")
+	content = html.UnescapeString(content)
+	content = blankLineRe.ReplaceAllString(content, "\n\n")
+	return strings.TrimSpace(content)
+}
@@ -0,0 +1,536 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/activitypub"
+	"personal-web-platform/internal/pkg/httpsig"
+	"personal-web-platform/internal/pkg/netguard"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockActivityPubRepository is a mock implementation of ActivityPubRepository
+type MockActivityPubRepository struct {
+	mock.Mock
+}
+
+func (m *MockActivityPubRepository) GetKey(ctx context.Context) (*domain.ActivityPubKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.ActivityPubKey), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockActivityPubRepository) SaveKey(ctx context.Context, key *domain.ActivityPubKey) (*domain.ActivityPubKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.ActivityPubKey), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockActivityPubRepository) AddFollower(ctx context.Context, follower *domain.Follower) error {
+	args := m.Called(ctx, follower)
+	return args.Error(0)
+}
+
+func (m *MockActivityPubRepository) RemoveFollower(ctx context.Context, actorID string) (bool, error) {
+	args := m.Called(ctx, actorID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockActivityPubRepository) ListFollowers(ctx context.Context) ([]domain.Follower, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Follower), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockActivityPubRepository) CountFollowers(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockActivityPubRepository) GetCommentIDByObjectID(ctx context.Context, objectID string) (int, error) {
+	args := m.Called(ctx, objectID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockActivityPubRepository) LinkComment(ctx context.Context, objectID string, commentID int) error {
+	args := m.Called(ctx, objectID, commentID)
+	return args.Error(0)
+}
+
+// MockProfileRepository is a mock implementation of ProfileRepository
+type MockProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockProfileRepository) GetProfile(ctx context.Context) (*domain.Profile, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.Profile), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockProfileRepository) UpdateProfile(ctx context.Context, req *domain.UpdateProfileRequest) (*domain.Profile, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.Profile), args.Error(1) //nolint:errcheck // mock method
+}
+
+const testBlogURL = "https://blog.example"
+
+type activityPubFixture struct {
+	service     *activityPubService
+	apRepo      *MockActivityPubRepository
+	postRepo    *MockPostRepository
+	commentRepo *MockCommentRepository
+	authRepo    *MockAuthRepository
+	profileRepo *MockProfileRepository
+	key         *domain.ActivityPubKey
+}
+
+func newActivityPubFixture(t *testing.T) *activityPubFixture {
+	t.Helper()
+
+	privatePEM, publicPEM, err := httpsig.GenerateKey()
+	require.NoError(t, err)
+
+	f := &activityPubFixture{
+		apRepo:      new(MockActivityPubRepository),
+		postRepo:    new(MockPostRepository),
+		commentRepo: new(MockCommentRepository),
+		authRepo:    new(MockAuthRepository),
+		profileRepo: new(MockProfileRepository),
+		key:         &domain.ActivityPubKey{PrivateKeyPEM: privatePEM, PublicKeyPEM: publicPEM},
+	}
+	f.apRepo.On("GetKey", mock.Anything).Return(f.key, nil).Maybe()
+
+	mockTx := new(MockTransactor)
+	mockTx.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	cfg := &config.Config{
+		OAuth:       config.OAuth{FrontendURL: testBlogURL},
+		ActivityPub: config.ActivityPub{Enabled: true, BaseURL: testBlogURL, Username: "blog", DeliveryTimeout: 5 * time.Second},
+	}
+	comments := NewCommentService(f.commentRepo, f.postRepo, f.authRepo, noBans(), mockTx, config.Comments{})
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	f.service = NewActivityPubService(f.apRepo, f.postRepo, f.commentRepo, f.authRepo, f.profileRepo, mockTx, comments, cfg, log).(*activityPubService)
+	f.service.httpClient = fakeInstanceClient(t)
+	return f
+}
+
+// fakeInstanceClient trusts the certificate shared by all httptest TLS servers
+// and, unlike the production client, connects to loopback fake instances
+func fakeInstanceClient(t *testing.T) *http.Client {
+	t.Helper()
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	return server.Client()
+}
+
+// verifyDelivery checks a delivery was signed by the blog actor
+func (f *activityPubFixture) verifyDelivery(t *testing.T, d testutil.Delivery) {
+	t.Helper()
+	publicKey, err := httpsig.ParsePublicKey(f.key.PublicKeyPEM)
+	require.NoError(t, err)
+	sig, err := httpsig.Parse(d.Request)
+	require.NoError(t, err)
+	assert.Equal(t, testBlogURL+"/ap/actor#main-key", sig.KeyID)
+	assert.NoError(t, httpsig.Verify(d.Request, d.Body, sig, publicKey))
+}
+
+func TestActivityPubService_WebFinger(t *testing.T) {
+	f := newActivityPubFixture(t)
+
+	tests := []struct {
+		name     string
+		resource string
+		wantErr  error
+	}{
+		{name: "acct resource", resource: "acct:blog@blog.example"},
+		{name: "case insensitive", resource: "acct:Blog@Blog.Example"},
+		{name: "actor url", resource: testBlogURL + "/ap/actor"},
+		{name: "unknown user", resource: "acct:someone@blog.example", wantErr: derr.ErrNotFound},
+		{name: "other host", resource: "acct:blog@other.example", wantErr: derr.ErrNotFound},
+		{name: "missing resource", resource: "", wantErr: derr.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jrd, err := f.service.WebFinger(context.Background(), tt.resource)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "acct:blog@blog.example", jrd.Subject)
+			assert.Equal(t, testBlogURL+"/ap/actor", jrd.Links[0].Href)
+		})
+	}
+}
+
+func TestActivityPubService_Actor(t *testing.T) {
+	f := newActivityPubFixture(t)
+	f.profileRepo.On("GetProfile", mock.Anything).Return(&domain.Profile{
+		Name:        "Site Owner",
+		Description: "Go & distributed systems",
+		PhotoURL:    "/uploads/me.png",
+	}, nil)
+
+	actor, err := f.service.Actor(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, testBlogURL+"/ap/actor", actor.ID)
+	assert.Equal(t, "blog", actor.PreferredUsername)
+	assert.Equal(t, "Site Owner", actor.Name)
+	assert.Equal(t, "<p>Go &amp; distributed systems</p>", actor.Summary)
+	assert.Equal(t, testBlogURL+"/uploads/me.png", actor.Icon.URL)
+	assert.Equal(t, testBlogURL+"/ap/inbox", actor.Inbox)
+	assert.Equal(t, f.key.PublicKeyPEM, actor.PublicKey.PublicKeyPem)
+}
+
+func TestActivityPubService_GeneratesKeyOnFirstUse(t *testing.T) {
+	f := newActivityPubFixture(t)
+	f.apRepo.ExpectedCalls = nil
+
+	stored := &domain.ActivityPubKey{}
+	f.apRepo.On("GetKey", mock.Anything).Return(nil, nil).Once()
+	f.apRepo.On("SaveKey", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*stored = *args.Get(1).(*domain.ActivityPubKey) //nolint:errcheck // mock method
+	}).Return(stored, nil).Once()
+	f.profileRepo.On("GetProfile", mock.Anything).Return(nil, nil)
+
+	actor, err := f.service.Actor(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, actor.PublicKey.PublicKeyPem, "PUBLIC KEY")
+
+	// The key is cached afterwards
+	_, err = f.service.Actor(context.Background())
+	require.NoError(t, err)
+	f.apRepo.AssertExpectations(t)
+}
+
+func TestActivityPubService_Outbox(t *testing.T) {
+	publishedAt := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC)
+	post := domain.Post{ID: 7, Title: "Hello <Fediverse>", Slug: "hello-fediverse", Preview: "First post", Published: true, PublishedAt: publishedAt}
+
+	t.Run("collection", func(t *testing.T) {
+		f := newActivityPubFixture(t)
+		f.postRepo.On("List", mock.Anything, mock.MatchedBy(func(req *domain.ListPostsRequest) bool {
+			return req.Published != nil && *req.Published && req.Page == 1
+		})).Return([]domain.Post{post}, 25, nil)
+
+		outbox, err := f.service.Outbox(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, activitypub.TypeOrderedCollection, outbox.Type)
+		assert.Equal(t, 25, outbox.TotalItems)
+		assert.Equal(t, testBlogURL+"/ap/outbox?page=1", outbox.First)
+		assert.Empty(t, outbox.OrderedItems)
+	})
+
+	t.Run("page", func(t *testing.T) {
+		f := newActivityPubFixture(t)
+		f.postRepo.On("List", mock.Anything, mock.Anything).Return([]domain.Post{post}, 25, nil)
+
+		outbox, err := f.service.Outbox(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, activitypub.TypeOrderedCollectionPage, outbox.Type)
+		assert.Equal(t, testBlogURL+"/ap/outbox?page=2", outbox.Next)
+		require.Len(t, outbox.OrderedItems, 1)
+
+		create := outbox.OrderedItems[0]
+		assert.Equal(t, activitypub.TypeCreate, create.Type)
+
+		var object activitypub.Object
+		require.NoError(t, json.Unmarshal(create.Object, &object))
+		assert.Equal(t, testBlogURL+"/ap/posts/7", object.ID)
+		assert.Equal(t, activitypub.TypeArticle, object.Type)
+		assert.Equal(t, "Hello <Fediverse>", object.Name)
+		assert.Equal(t, testBlogURL+"/blog/hello-fediverse", object.URL)
+		assert.Equal(t, publishedAt, *object.Published)
+		assert.Contains(t, object.To, activitypub.PublicCollection)
+	})
+}
+
+func TestActivityPubService_PostObject(t *testing.T) {
+	f := newActivityPubFixture(t)
+	f.postRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Title: "Public", Slug: "public", Published: true}, nil)
+	f.postRepo.On("GetByID", mock.Anything, 2, 0).Return(&domain.Post{ID: 2, Title: "Draft", Slug: "draft"}, nil)
+	f.postRepo.On("GetByID", mock.Anything, 3, 0).Return(nil, nil)
+
+	object, err := f.service.PostObject(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, testBlogURL+"/ap/posts/1", object.ID)
+
+	_, err = f.service.PostObject(context.Background(), 2)
+	assert.ErrorIs(t, err, derr.ErrNotFound)
+	_, err = f.service.PostObject(context.Background(), 3)
+	assert.ErrorIs(t, err, derr.ErrNotFound)
+}
+
+func TestActivityPubService_HandleInbox_FollowAndUndo(t *testing.T) {
+	f := newActivityPubFixture(t)
+	remote := testutil.NewFakeInstance(t, "alice")
+
+	follow := map[string]any{
+		"@context": activitypub.ActivityStreamsContext,
+		"id":       remote.ActorID() + "#follows/1",
+		"type":     activitypub.TypeFollow,
+		"actor":    remote.ActorID(),
+		"object":   testBlogURL + "/ap/actor",
+	}
+
+	f.apRepo.On("AddFollower", mock.Anything, mock.MatchedBy(func(follower *domain.Follower) bool {
+		return follower.ActorID == remote.ActorID() &&
+			follower.Inbox == remote.ActorID()+"/inbox" &&
+			follower.SharedInbox == remote.Server.URL+"/inbox"
+	})).Return(nil).Once()
+
+	req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", follow)
+	require.NoError(t, f.service.HandleInbox(context.Background(), req, body))
+
+	// The follow is accepted with a signed Accept sent to the follower inbox
+	deliveries := remote.WaitForDeliveries(t, 1)
+	accept := deliveries[0]
+	assert.Equal(t, "/users/alice/inbox", accept.Request.URL.Path)
+	assert.Equal(t, activitypub.TypeAccept, accept.Activity.Type)
+	assert.Equal(t, remote.ActorID()+"#follows/1", activitypub.ObjectID(accept.Activity.Object))
+	f.verifyDelivery(t, accept)
+
+	undo := map[string]any{
+		"id":     remote.ActorID() + "#follows/1/undo",
+		"type":   activitypub.TypeUndo,
+		"actor":  remote.ActorID(),
+		"object": follow,
+	}
+	f.apRepo.On("RemoveFollower", mock.Anything, remote.ActorID()).Return(true, nil).Once()
+
+	req, body = remote.SignedRequest(t, testBlogURL+"/ap/inbox", undo)
+	require.NoError(t, f.service.HandleInbox(context.Background(), req, body))
+
+	require.NoError(t, f.service.Wait(context.Background()))
+	f.apRepo.AssertExpectations(t)
+}
+
+func TestActivityPubService_HandleInbox_Rejects(t *testing.T) {
+	f := newActivityPubFixture(t)
+	remote := testutil.NewFakeInstance(t, "alice")
+	impostor := testutil.NewFakeInstance(t, "mallory")
+
+	follow := map[string]any{
+		"id":     remote.ActorID() + "#follows/1",
+		"type":   activitypub.TypeFollow,
+		"actor":  remote.ActorID(),
+		"object": testBlogURL + "/ap/actor",
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", follow)
+		req.Header.Del("Signature")
+		assert.ErrorIs(t, f.service.HandleInbox(context.Background(), req, body), derr.ErrPermission)
+	})
+
+	t.Run("signed by another actor", func(t *testing.T) {
+		req, body := impostor.SignedRequest(t, testBlogURL+"/ap/inbox", follow)
+		assert.ErrorIs(t, f.service.HandleInbox(context.Background(), req, body), derr.ErrPermission)
+	})
+
+	t.Run("plain http actor", func(t *testing.T) {
+		insecure := map[string]any{"type": activitypub.TypeFollow, "actor": "http" + strings.TrimPrefix(remote.ActorID(), "https"), "object": testBlogURL + "/ap/actor"}
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", insecure)
+		assert.ErrorIs(t, f.service.HandleInbox(context.Background(), req, body), derr.ErrPermission)
+	})
+
+	t.Run("key hosted by another server", func(t *testing.T) {
+		elsewhere := map[string]any{"type": activitypub.TypeFollow, "actor": "https://elsewhere.example/users/alice", "object": testBlogURL + "/ap/actor"}
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", elsewhere)
+		assert.ErrorIs(t, f.service.HandleInbox(context.Background(), req, body), derr.ErrPermission)
+	})
+
+	t.Run("internal addresses are not fetched", func(t *testing.T) {
+		guarded := newActivityPubFixture(t)
+		guarded.service.httpClient = netguard.NewClient(time.Second)
+
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", follow)
+		assert.ErrorIs(t, guarded.service.HandleInbox(context.Background(), req, body), derr.ErrPermission)
+		guarded.apRepo.AssertNotCalled(t, "AddFollower", mock.Anything, mock.Anything)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		req, _ := remote.SignedRequest(t, testBlogURL+"/ap/inbox", follow)
+		tampered, err := json.Marshal(map[string]any{"type": activitypub.TypeFollow, "actor": remote.ActorID(), "object": "https://elsewhere.example/actor"})
+		require.NoError(t, err)
+		assert.ErrorIs(t, f.service.HandleInbox(context.Background(), req, tampered), derr.ErrPermission)
+	})
+
+	t.Run("malformed json", func(t *testing.T) {
+		req, _ := remote.SignedRequest(t, testBlogURL+"/ap/inbox", follow)
+		assert.ErrorIs(t, f.service.HandleInbox(context.Background(), req, []byte("{")), derr.ErrValidation)
+	})
+
+	t.Run("unsupported activities are ignored", func(t *testing.T) {
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", map[string]any{"type": "Like", "actor": remote.ActorID()})
+		req.Header.Del("Signature")
+		assert.NoError(t, f.service.HandleInbox(context.Background(), req, body))
+	})
+
+	f.apRepo.AssertNotCalled(t, "AddFollower", mock.Anything, mock.Anything)
+}
+
+func TestActivityPubService_HandleInbox_Reply(t *testing.T) {
+	remoteUser := &domain.User{ID: 42, Name: "alice (remote)"}
+	parentID := 10
+
+	newReply := func(remote *testutil.FakeInstance, id, inReplyTo string) map[string]any {
+		return map[string]any{
+			"id":    id + "/activity",
+			"type":  activitypub.TypeCreate,
+			"actor": remote.ActorID(),
+			"object": map[string]any{
+				"id":           id,
+				"type":         activitypub.TypeNote,
+				"attributedTo": remote.ActorID(),
+				"inReplyTo":    inReplyTo,
+				"content":      `<p><span class="h-card"><a href="https://blog.example/ap/actor">@blog</a></span> Great post &amp; thanks!</p><p>Second line</p>`,
+			},
+		}
+	}
+
+	t.Run("reply to a post creates a comment and a shadow user", func(t *testing.T) {
+		f := newActivityPubFixture(t)
+		remote := testutil.NewFakeInstance(t, "alice")
+		noteID := remote.ActorID() + "/statuses/1"
+
+		f.apRepo.On("GetCommentIDByObjectID", mock.Anything, noteID).Return(0, nil)
+		f.postRepo.On("GetByID", mock.Anything, 5, 0).Return(&domain.Post{ID: 5, Published: true}, nil)
+		f.authRepo.On("GetUserByProviderID", mock.Anything, domain.ActivityPubProvider, remote.ActorID()).Return(nil, nil)
+		sum := sha256.Sum256([]byte(remote.ActorID()))
+		f.authRepo.On("CreateUser", mock.Anything, hex.EncodeToString(sum[:16])+"@127.0.0.1.invalid", "alice (remote)", remote.Server.URL+"/avatars/alice.png", domain.RoleUser).Return(remoteUser, nil)
+		f.authRepo.On("LinkOAuthProvider", mock.Anything, mock.MatchedBy(func(p *domain.OAuthProvider) bool {
+			return p.UserID == 42 && p.Provider == domain.ActivityPubProvider && p.ProviderUserID == remote.ActorID()
+		})).Return(nil)
+		f.commentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.PostID == 5 && c.UserID == 42 && c.ParentID == nil &&
+				c.Content == "@blog Great post & thanks!\nSecond line"
+		})).Return(&domain.Comment{ID: 99, PostID: 5, UserID: 42}, nil)
+		f.apRepo.On("LinkComment", mock.Anything, noteID, 99).Return(nil)
+
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", newReply(remote, noteID, testBlogURL+"/ap/posts/5"))
+		require.NoError(t, f.service.HandleInbox(context.Background(), req, body))
+
+		f.commentRepo.AssertExpectations(t)
+		f.apRepo.AssertExpectations(t)
+	})
+
+	t.Run("reply to a remote reply is threaded under it", func(t *testing.T) {
+		f := newActivityPubFixture(t)
+		remote := testutil.NewFakeInstance(t, "alice")
+		parentNote := remote.ActorID() + "/statuses/1"
+		noteID := remote.ActorID() + "/statuses/2"
+
+		f.apRepo.On("GetCommentIDByObjectID", mock.Anything, noteID).Return(0, nil)
+		f.apRepo.On("GetCommentIDByObjectID", mock.Anything, parentNote).Return(parentID, nil)
+		f.commentRepo.On("GetByID", mock.Anything, parentID).Return(&domain.Comment{ID: parentID, PostID: 5, ModerationStatus: domain.CommentStatusApproved}, nil)
+		f.postRepo.On("GetByID", mock.Anything, 5, 0).Return(&domain.Post{ID: 5, Published: true}, nil)
+		f.authRepo.On("GetUserByProviderID", mock.Anything, domain.ActivityPubProvider, remote.ActorID()).Return(remoteUser, nil)
+		f.commentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.PostID == 5 && c.ParentID != nil && *c.ParentID == parentID
+		})).Return(&domain.Comment{ID: 100}, nil)
+		f.apRepo.On("LinkComment", mock.Anything, noteID, 100).Return(nil)
+
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", newReply(remote, noteID, parentNote))
+		require.NoError(t, f.service.HandleInbox(context.Background(), req, body))
+
+		f.authRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		f.apRepo.AssertExpectations(t)
+	})
+
+	t.Run("redelivered note is stored once", func(t *testing.T) {
+		f := newActivityPubFixture(t)
+		remote := testutil.NewFakeInstance(t, "alice")
+		noteID := remote.ActorID() + "/statuses/1"
+
+		f.apRepo.On("GetCommentIDByObjectID", mock.Anything, noteID).Return(99, nil)
+
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", newReply(remote, noteID, testBlogURL+"/ap/posts/5"))
+		require.NoError(t, f.service.HandleInbox(context.Background(), req, body))
+
+		f.commentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("notes that are not replies to the blog are ignored", func(t *testing.T) {
+		f := newActivityPubFixture(t)
+		remote := testutil.NewFakeInstance(t, "alice")
+		noteID := remote.ActorID() + "/statuses/1"
+		unknown := "https://elsewhere.example/statuses/9"
+
+		f.apRepo.On("GetCommentIDByObjectID", mock.Anything, noteID).Return(0, nil)
+		f.apRepo.On("GetCommentIDByObjectID", mock.Anything, unknown).Return(0, nil)
+
+		req, body := remote.SignedRequest(t, testBlogURL+"/ap/inbox", newReply(remote, noteID, unknown))
+		require.NoError(t, f.service.HandleInbox(context.Background(), req, body))
+
+		f.commentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestActivityPubService_PostPublished(t *testing.T) {
+	f := newActivityPubFixture(t)
+	mastodon := testutil.NewFakeInstance(t, "alice")
+	other := testutil.NewFakeInstance(t, "bob")
+
+	// Two followers share an instance inbox, the third has no shared inbox
+	f.apRepo.On("ListFollowers", mock.Anything).Return([]domain.Follower{
+		{ActorID: mastodon.ActorID(), Inbox: mastodon.ActorID() + "/inbox", SharedInbox: mastodon.Server.URL + "/inbox"},
+		{ActorID: mastodon.Server.URL + "/users/carol", Inbox: mastodon.Server.URL + "/users/carol/inbox", SharedInbox: mastodon.Server.URL + "/inbox"},
+		{ActorID: other.ActorID(), Inbox: other.ActorID() + "/inbox"},
+	}, nil)
+
+	f.service.PostPublished(context.Background(), &domain.Post{ID: 3, Title: "Hello", Slug: "hello", Published: true})
+	require.NoError(t, f.service.Wait(context.Background()))
+
+	mastodonDeliveries := mastodon.Deliveries()
+	require.Len(t, mastodonDeliveries, 1)
+	assert.Equal(t, "/inbox", mastodonDeliveries[0].Request.URL.Path)
+	assert.Equal(t, activitypub.TypeCreate, mastodonDeliveries[0].Activity.Type)
+	assert.Equal(t, testBlogURL+"/ap/posts/3", activitypub.ObjectID(mastodonDeliveries[0].Activity.Object))
+	f.verifyDelivery(t, mastodonDeliveries[0])
+
+	otherDeliveries := other.Deliveries()
+	require.Len(t, otherDeliveries, 1)
+	assert.Equal(t, "/users/bob/inbox", otherDeliveries[0].Request.URL.Path)
+
+	t.Run("drafts are not delivered", func(t *testing.T) {
+		f.service.PostPublished(context.Background(), &domain.Post{ID: 4, Published: false})
+		require.NoError(t, f.service.Wait(context.Background()))
+		assert.Len(t, mastodon.Deliveries(), 1)
+	})
+}
+
+func TestHTMLToText(t *testing.T) {
+	assert.Equal(t, "Hello\nworld & more", htmlToText("<p>Hello<br/>world &amp; more</p>"))
+	assert.Equal(t, "a\n\nb", htmlToText("<p>a</p><p></p><p></p><p>b</p>"))
+	assert.Equal(t, "", htmlToText("<p> </p>"))
+}
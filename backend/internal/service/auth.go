@@ -11,6 +11,7 @@ import (
 
 	"personal-web-platform/config"
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 	"personal-web-platform/internal/repository"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -19,32 +20,44 @@ import (
 
 // AuthService defines methods for authentication business logic
 type AuthService interface {
-	LoginWithOAuth(ctx context.Context, gothUser goth.User) (*domain.User, *domain.Session, error)
+	LoginWithOAuth(ctx context.Context, gothUser goth.User, client domain.SessionClient) (*domain.User, *domain.Session, error)
 	Logout(ctx context.Context, sessionToken string) error
 	ValidateSession(ctx context.Context, sessionToken string) (*domain.User, error)
 	GetUserByID(ctx context.Context, userID int) (*domain.User, error)
+	// ListSessions returns the active sessions of a user, marking the one with currentToken
+	ListSessions(ctx context.Context, userID int, currentToken string) ([]domain.Session, error)
+	// RevokeSession signs a user out of one of their other sessions
+	RevokeSession(ctx context.Context, userID, sessionID int, currentToken string) error
+	// RevokeOtherSessions signs a user out everywhere but the current session
+	RevokeOtherSessions(ctx context.Context, userID int, currentToken string) (int64, error)
 }
 
+// sessionTouchInterval limits how often last_seen_at is written, one request
+// in a burst is enough to tell when a session was last used
+const sessionTouchInterval = time.Minute
+
 type authService struct {
 	authRepo    repository.AuthRepository
 	sessionRepo repository.SessionRepository
+	banRepo     repository.BanRepository
 	transactor  repository.Transactor
 	cfg         *config.Config
 	log         *slog.Logger
 }
 
 // NewAuthService creates a new auth service implementation
-func NewAuthService(authRepo repository.AuthRepository, sessionRepo repository.SessionRepository, transactor repository.Transactor, cfg *config.Config, log *slog.Logger) AuthService {
+func NewAuthService(authRepo repository.AuthRepository, sessionRepo repository.SessionRepository, banRepo repository.BanRepository, transactor repository.Transactor, cfg *config.Config, log *slog.Logger) AuthService {
 	return &authService{
 		authRepo:    authRepo,
 		sessionRepo: sessionRepo,
+		banRepo:     banRepo,
 		transactor:  transactor,
 		cfg:         cfg,
 		log:         log,
 	}
 }
 
-func (s *authService) LoginWithOAuth(ctx context.Context, gothUser goth.User) (*domain.User, *domain.Session, error) {
+func (s *authService) LoginWithOAuth(ctx context.Context, gothUser goth.User, client domain.SessionClient) (*domain.User, *domain.Session, error) {
 	s.log.Info("auth_service: processing OAuth login",
 		"provider", gothUser.Provider,
 		"provider_user_id", gothUser.UserID,
@@ -172,7 +185,7 @@ func (s *authService) LoginWithOAuth(ctx context.Context, gothUser goth.User) (*
 	}
 
 	// Step 5: Create session (outside transaction)
-	session, err := s.createSession(ctx, user.ID)
+	session, err := s.createSession(ctx, user.ID, client)
 	if err != nil {
 		s.log.Error("auth_service: failed to create session",
 			"error", err,
@@ -208,11 +221,27 @@ func (s *authService) ValidateSession(ctx context.Context, sessionToken string)
 		return nil, nil // Session not found or expired
 	}
 
+	if time.Since(session.LastSeenAt) > sessionTouchInterval {
+		// Not worth failing the request over
+		if err := s.sessionRepo.TouchSession(ctx, session.ID); err != nil {
+			s.log.Warn("auth_service: failed to touch session", "error", err, "session_id", session.ID)
+		}
+	}
+
 	// Get user
 	user, err := s.authRepo.GetUserByID(ctx, session.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
+	if user == nil {
+		return nil, nil
+	}
+
+	// Banned users stay signed in, read-only
+	user.Ban, err = s.banRepo.GetActive(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active ban: %w", err)
+	}
 
 	return user, nil
 }
@@ -225,16 +254,64 @@ func (s *authService) GetUserByID(ctx context.Context, userID int) (*domain.User
 	return user, nil
 }
 
-func (s *authService) createSession(ctx context.Context, userID int) (*domain.Session, error) {
+func (s *authService) ListSessions(ctx context.Context, userID int, currentToken string) ([]domain.Session, error) {
+	sessions, err := s.sessionRepo.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].Token == currentToken
+	}
+
+	return sessions, nil
+}
+
+func (s *authService) RevokeSession(ctx context.Context, userID, sessionID int, currentToken string) error {
+	current, err := s.sessionRepo.GetSession(ctx, currentToken)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if current != nil && current.ID == sessionID {
+		return fmt.Errorf("%w: log out to end the current session", derr.ErrConflict)
+	}
+
+	deleted, err := s.sessionRepo.DeleteUserSession(ctx, userID, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	if !deleted {
+		return fmt.Errorf("%w: session not found", derr.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (s *authService) RevokeOtherSessions(ctx context.Context, userID int, currentToken string) (int64, error) {
+	revoked, err := s.sessionRepo.DeleteOtherUserSessions(ctx, userID, currentToken)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return revoked, nil
+}
+
+func (s *authService) createSession(ctx context.Context, userID int, client domain.SessionClient) (*domain.Session, error) {
 	// Generate random session token
 	token, err := generateSecureToken(32)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate session token: %w", err)
 	}
 
+	userAgent := client.UserAgent
+	if runes := []rune(userAgent); len(runes) > maxUserAgentLength {
+		userAgent = string(runes[:maxUserAgentLength])
+	}
+
 	session := &domain.Session{
 		UserID:    userID,
 		Token:     token,
+		UserAgent: userAgent,
+		IPAddress: client.IPAddress,
 		ExpiresAt: time.Now().Add(s.cfg.Auth.SessionMaxAge),
 	}
 
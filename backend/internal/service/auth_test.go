@@ -10,10 +10,12 @@ import (
 
 	"personal-web-platform/config"
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 
 	"github.com/markbates/goth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockAuthRepository is a mock implementation of AuthRepository
@@ -71,6 +73,43 @@ func (m *MockAuthRepository) UpdateOAuthProvider(ctx context.Context, provider *
 	return args.Error(0)
 }
 
+func (m *MockAuthRepository) ListUsers(ctx context.Context, req *domain.ListUsersRequest) ([]domain.UserSummary, int, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2) //nolint:errcheck // mock method
+	}
+	return args.Get(0).([]domain.UserSummary), args.Int(1), args.Error(2) //nolint:errcheck // mock method
+}
+
+func (m *MockAuthRepository) GetUserSummary(ctx context.Context, id int) (*domain.UserSummary, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.UserSummary), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockAuthRepository) ListOAuthProviders(ctx context.Context, userID int) ([]domain.OAuthProvider, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).([]domain.OAuthProvider), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockAuthRepository) UpdateUserRole(ctx context.Context, id int, role domain.Role) (*domain.User, error) {
+	args := m.Called(ctx, id, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.User), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockAuthRepository) DeleteUser(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 // MockSessionRepository is a mock implementation of SessionRepository
 type MockSessionRepository struct {
 	mock.Mock
@@ -104,6 +143,29 @@ func (m *MockSessionRepository) DeleteUserSessions(ctx context.Context, userID i
 	return args.Error(0)
 }
 
+func (m *MockSessionRepository) ListUserSessions(ctx context.Context, userID int) ([]domain.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).([]domain.Session), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSessionRepository) DeleteUserSession(ctx context.Context, userID, sessionID int) (bool, error) {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) DeleteOtherUserSessions(ctx context.Context, userID int, keepToken string) (int64, error) {
+	args := m.Called(ctx, userID, keepToken)
+	return args.Get(0).(int64), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSessionRepository) TouchSession(ctx context.Context, sessionID int) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
+}
+
 // MockTransactor is a mock implementation of Transactor
 type MockTransactor struct {
 	mock.Mock
@@ -157,8 +219,9 @@ func TestAuthService_LoginWithOAuth_ExistingUser(t *testing.T) {
 		Return(nil)
 
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
-	user, session, err := service.LoginWithOAuth(context.Background(), gothUser)
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
+	client := domain.SessionClient{UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}
+	user, session, err := service.LoginWithOAuth(context.Background(), gothUser, client)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -166,6 +229,8 @@ func TestAuthService_LoginWithOAuth_ExistingUser(t *testing.T) {
 	assert.Equal(t, existingUser.ID, user.ID)
 	assert.Equal(t, existingUser.Email, user.Email)
 	assert.NotEmpty(t, session.Token)
+	assert.Equal(t, "Mozilla/5.0", session.UserAgent)
+	assert.Equal(t, "203.0.113.7", session.IPAddress)
 
 	mockAuthRepo.AssertExpectations(t)
 	mockSessionRepo.AssertExpectations(t)
@@ -207,8 +272,8 @@ func TestAuthService_LoginWithOAuth_NewUser(t *testing.T) {
 		Return(nil)
 
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
-	service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
-	user, session, err := service.LoginWithOAuth(context.Background(), gothUser)
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
+	user, session, err := service.LoginWithOAuth(context.Background(), gothUser, domain.SessionClient{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -241,8 +306,8 @@ func TestAuthService_LoginWithOAuth_CreateUserError(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 	mockTransactor := new(MockTransactor)
 	mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
-	service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
-	user, session, err := service.LoginWithOAuth(context.Background(), gothUser)
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
+	user, session, err := service.LoginWithOAuth(context.Background(), gothUser, domain.SessionClient{})
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create user")
@@ -275,11 +340,14 @@ func TestAuthService_ValidateSession_Success(t *testing.T) {
 		Return(storedSession, nil)
 	mockAuthRepo.On("GetUserByID", mock.Anything, 1).
 		Return(user, nil)
+	// Last seen long ago, so the session is touched
+	mockSessionRepo.On("TouchSession", mock.Anything, 1).
+		Return(nil)
 
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 	mockTransactor := new(MockTransactor)
 	mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
-	service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
 	result, err := service.ValidateSession(context.Background(), sessionToken)
 
 	assert.NoError(t, err)
@@ -291,6 +359,24 @@ func TestAuthService_ValidateSession_Success(t *testing.T) {
 	mockSessionRepo.AssertExpectations(t)
 }
 
+func TestAuthService_ValidateSession_RecentlySeen(t *testing.T) {
+	mockAuthRepo := new(MockAuthRepository)
+	mockSessionRepo := new(MockSessionRepository)
+
+	mockSessionRepo.On("GetSession", mock.Anything, "token").
+		Return(&domain.Session{ID: 1, UserID: 1, LastSeenAt: time.Now().Add(-10 * time.Second)}, nil)
+	mockAuthRepo.On("GetUserByID", mock.Anything, 1).
+		Return(&domain.User{ID: 1}, nil)
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), passthroughTransactor(), getTestConfig(), log)
+	result, err := service.ValidateSession(context.Background(), "token")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockSessionRepo.AssertNotCalled(t, "TouchSession", mock.Anything, mock.Anything)
+}
+
 func TestAuthService_ValidateSession_NotFound(t *testing.T) {
 	mockAuthRepo := new(MockAuthRepository)
 	mockSessionRepo := new(MockSessionRepository)
@@ -304,7 +390,7 @@ func TestAuthService_ValidateSession_NotFound(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 	mockTransactor := new(MockTransactor)
 	mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
-	service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
 	result, err := service.ValidateSession(context.Background(), sessionToken)
 
 	assert.NoError(t, err)
@@ -326,7 +412,7 @@ func TestAuthService_Logout_Success(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 	mockTransactor := new(MockTransactor)
 	mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
-	service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
 	err := service.Logout(context.Background(), sessionToken)
 
 	assert.NoError(t, err)
@@ -346,7 +432,7 @@ func TestAuthService_Logout_Error(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 	mockTransactor := new(MockTransactor)
 	mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
-	service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
+	service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
 	err := service.Logout(context.Background(), sessionToken)
 
 	assert.Error(t, err)
@@ -394,7 +480,7 @@ func TestAuthService_GetUserByID(t *testing.T) {
 			log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 			mockTransactor := new(MockTransactor)
 			mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
-			service := NewAuthService(mockAuthRepo, mockSessionRepo, mockTransactor, cfg, log)
+			service := NewAuthService(mockAuthRepo, mockSessionRepo, noBans(), mockTransactor, cfg, log)
 			user, err := service.GetUserByID(context.Background(), tt.userID)
 
 			if tt.wantErr {
@@ -410,3 +496,69 @@ func TestAuthService_GetUserByID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_ListSessions(t *testing.T) {
+	mockSessionRepo := new(MockSessionRepository)
+	mockSessionRepo.On("ListUserSessions", mock.Anything, 1).
+		Return([]domain.Session{{ID: 3, Token: "other"}, {ID: 2, Token: "mine"}}, nil)
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewAuthService(new(MockAuthRepository), mockSessionRepo, noBans(), passthroughTransactor(), getTestConfig(), log)
+	sessions, err := service.ListSessions(context.Background(), 1, "mine")
+
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.False(t, sessions[0].Current)
+	assert.True(t, sessions[1].Current)
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	current := &domain.Session{ID: 2, UserID: 1, Token: "mine"}
+
+	t.Run("Other session", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockSessionRepo.On("GetSession", ctx, "mine").Return(current, nil)
+		mockSessionRepo.On("DeleteUserSession", ctx, 1, 3).Return(true, nil)
+
+		service := NewAuthService(new(MockAuthRepository), mockSessionRepo, noBans(), passthroughTransactor(), getTestConfig(), log)
+
+		require.NoError(t, service.RevokeSession(ctx, 1, 3, "mine"))
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Current session", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockSessionRepo.On("GetSession", ctx, "mine").Return(current, nil)
+
+		service := NewAuthService(new(MockAuthRepository), mockSessionRepo, noBans(), passthroughTransactor(), getTestConfig(), log)
+
+		err := service.RevokeSession(ctx, 1, 2, "mine")
+		assert.ErrorIs(t, err, derr.ErrConflict)
+		mockSessionRepo.AssertNotCalled(t, "DeleteUserSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Someone else's session", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockSessionRepo.On("GetSession", ctx, "mine").Return(current, nil)
+		mockSessionRepo.On("DeleteUserSession", ctx, 1, 9).Return(false, nil)
+
+		service := NewAuthService(new(MockAuthRepository), mockSessionRepo, noBans(), passthroughTransactor(), getTestConfig(), log)
+
+		err := service.RevokeSession(ctx, 1, 9, "mine")
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+}
+
+func TestAuthService_RevokeOtherSessions(t *testing.T) {
+	mockSessionRepo := new(MockSessionRepository)
+	mockSessionRepo.On("DeleteOtherUserSessions", mock.Anything, 1, "mine").Return(int64(2), nil)
+
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	service := NewAuthService(new(MockAuthRepository), mockSessionRepo, noBans(), passthroughTransactor(), getTestConfig(), log)
+	revoked, err := service.RevokeOtherSessions(context.Background(), 1, "mine")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), revoked)
+}
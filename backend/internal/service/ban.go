@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/repository"
+)
+
+// checkNotBanned fails with ErrPermission while the user is banned, banned
+// users can only read
+func checkNotBanned(ctx context.Context, bans repository.BanRepository, userID int) error {
+	ban, err := bans.GetActive(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check ban: %w", err)
+	}
+	if ban != nil {
+		return fmt.Errorf("%w: account is banned: %s", derr.ErrPermission, ban.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBanRepository is a mock implementation of BanRepository
+type MockBanRepository struct {
+	mock.Mock
+}
+
+// noBans returns a ban repository for which nobody is banned
+func noBans() *MockBanRepository {
+	bans := new(MockBanRepository)
+	bans.On("GetActive", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return bans
+}
+
+func (m *MockBanRepository) LockUser(ctx context.Context, userID int) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockBanRepository) Create(ctx context.Context, ban *domain.UserBan) error {
+	args := m.Called(ctx, ban)
+	return args.Error(0)
+}
+
+func (m *MockBanRepository) GetActive(ctx context.Context, userID int) (*domain.UserBan, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserBan), args.Error(1)
+}
+
+func (m *MockBanRepository) LiftActive(ctx context.Context, userID, adminID int) (*domain.UserBan, error) {
+	args := m.Called(ctx, userID, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserBan), args.Error(1)
+}
+
+func (m *MockBanRepository) ListByUser(ctx context.Context, userID int) ([]domain.UserBan, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.UserBan), args.Error(1)
+}
+
+func TestCheckNotBanned(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Not banned", func(t *testing.T) {
+		assert.NoError(t, checkNotBanned(ctx, noBans(), 1))
+	})
+
+	t.Run("Banned", func(t *testing.T) {
+		bans := new(MockBanRepository)
+		bans.On("GetActive", ctx, 1).Return(&domain.UserBan{ID: 3, UserID: 1, Reason: "spam"}, nil)
+
+		err := checkNotBanned(ctx, bans, 1)
+		assert.ErrorIs(t, err, derr.ErrPermission)
+		assert.Contains(t, err.Error(), "spam")
+	})
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/repository"
+)
+
+// BookmarkService defines methods for the reading list of signed-in users
+type BookmarkService interface {
+	AddBookmark(ctx context.Context, userID, postID int) error
+	RemoveBookmark(ctx context.Context, userID, postID int) error
+}
+
+type bookmarkService struct {
+	bookmarkRepo repository.BookmarkRepository
+	postRepo     repository.PostRepository
+}
+
+// NewBookmarkService creates a new bookmark service implementation
+func NewBookmarkService(bookmarkRepo repository.BookmarkRepository, postRepo repository.PostRepository) BookmarkService {
+	return &bookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		postRepo:     postRepo,
+	}
+}
+
+// AddBookmark adds a published post to the user's reading list. Bookmarking twice is a no-op.
+func (s *bookmarkService) AddBookmark(ctx context.Context, userID, postID int) error {
+	post, err := s.postRepo.GetByID(ctx, postID, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil || post.DeletedAt != nil || !post.Published {
+		return fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	if _, err := s.bookmarkRepo.Add(ctx, userID, postID); err != nil {
+		return fmt.Errorf("failed to add bookmark: %w", err)
+	}
+	return nil
+}
+
+// RemoveBookmark removes a post from the user's reading list. Removing a missing bookmark is a no-op.
+func (s *bookmarkService) RemoveBookmark(ctx context.Context, userID, postID int) error {
+	if _, err := s.bookmarkRepo.Remove(ctx, userID, postID); err != nil {
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBookmarkRepository is a mock implementation of BookmarkRepository
+type MockBookmarkRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookmarkRepository) Add(ctx context.Context, userID, postID int) (bool, error) {
+	args := m.Called(ctx, userID, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBookmarkRepository) Remove(ctx context.Context, userID, postID int) (bool, error) {
+	args := m.Called(ctx, userID, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestBookmarkService_AddBookmark(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		setupMocks func(*MockBookmarkRepository, *MockPostRepository)
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{
+			name: "success",
+			setupMocks: func(b *MockBookmarkRepository, p *MockPostRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+				b.On("Add", mock.Anything, 5, 1).Return(true, nil)
+			},
+		},
+		{
+			name: "success - already bookmarked",
+			setupMocks: func(b *MockBookmarkRepository, p *MockPostRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+				b.On("Add", mock.Anything, 5, 1).Return(false, nil)
+			},
+		},
+		{
+			name: "error - post not found",
+			setupMocks: func(_ *MockBookmarkRepository, p *MockPostRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(nil, nil)
+			},
+			wantErr: derr.ErrNotFound,
+		},
+		{
+			name: "error - draft",
+			setupMocks: func(_ *MockBookmarkRepository, p *MockPostRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+			},
+			wantErr: derr.ErrNotFound,
+		},
+		{
+			name: "error - deleted post",
+			setupMocks: func(_ *MockBookmarkRepository, p *MockPostRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true, DeletedAt: &deletedAt}, nil)
+			},
+			wantErr: derr.ErrNotFound,
+		},
+		{
+			name: "error - repository failure",
+			setupMocks: func(b *MockBookmarkRepository, p *MockPostRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+				b.On("Add", mock.Anything, 5, 1).Return(false, errors.New("db error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookmarkRepo := new(MockBookmarkRepository)
+			postRepo := new(MockPostRepository)
+			tt.setupMocks(bookmarkRepo, postRepo)
+
+			service := NewBookmarkService(bookmarkRepo, postRepo)
+			err := service.AddBookmark(context.Background(), 5, 1)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+
+			bookmarkRepo.AssertExpectations(t)
+			postRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBookmarkService_RemoveBookmark(t *testing.T) {
+	bookmarkRepo := new(MockBookmarkRepository)
+	bookmarkRepo.On("Remove", mock.Anything, 5, 1).Return(false, nil)
+
+	service := NewBookmarkService(bookmarkRepo, new(MockPostRepository))
+
+	assert.NoError(t, service.RemoveBookmark(context.Background(), 5, 1))
+	bookmarkRepo.AssertExpectations(t)
+}
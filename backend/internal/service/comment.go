@@ -3,8 +3,12 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"personal-web-platform/config"
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/spam"
 	"personal-web-platform/internal/pkg/validator"
 	"personal-web-platform/internal/repository"
 )
@@ -14,20 +18,80 @@ type CommentService interface {
 	CreateComment(ctx context.Context, postID int, req *domain.CreateCommentRequest, userID int) (*domain.Comment, error)
 	UpdateComment(ctx context.Context, commentID int, req *domain.UpdateCommentRequest, userID int, isAdmin bool) (*domain.Comment, error)
 	DeleteComment(ctx context.Context, commentID int, userID int, isAdmin bool) error
+	// PurgeTombstones removes deleted comments left without live replies on
+	// any post, it returns how many were removed
+	PurgeTombstones(ctx context.Context) (int, error)
 	GetCommentByID(ctx context.Context, id int) (*domain.Comment, error)
-	GetCommentsByPostSlug(ctx context.Context, slug string, userID int) ([]domain.Comment, error)
+	// GetCommentRevisions returns the earlier versions of an edited comment, oldest first (admin)
+	GetCommentRevisions(ctx context.Context, id int) ([]domain.CommentRevision, error)
+	// GetCommentsByPostSlug returns the comment threads of a post in the
+	// given order: approved comments, and pending ones to their author and admins
+	GetCommentsByPostSlug(ctx context.Context, slug string, userID int, isAdmin bool, order string) ([]domain.Comment, error)
+	// GetCommentPermalink returns a comment the viewer can see with its
+	// ancestors, first replies and where its thread is on the post page
+	GetCommentPermalink(ctx context.Context, id int, userID int, isAdmin bool) (*domain.CommentPermalink, error)
+
+	// Moderation (admin)
+	BulkModerateComments(ctx context.Context, req *domain.BulkCommentsRequest) (*domain.BulkResponse, error)
+	ListModerationQueue(ctx context.Context, req *domain.ModerationQueueRequest) (*domain.ModerationQueueResponse, error)
+
+	// Reports
+	ReportComment(ctx context.Context, commentID int, req *domain.ReportCommentRequest, userID int) error
+	ListReports(ctx context.Context, req *domain.ReportsRequest) (*domain.ReportsResponse, error)
+	ResolveReports(ctx context.Context, commentID, adminID int) error
+	DismissReports(ctx context.Context, commentID, adminID int) error
+}
+
+// CommentCreatedHook is notified after a comment is published, when it is
+// created or approved by a moderator; parent is nil for top-level comments.
+// The hook may run inside the caller's transaction.
+type CommentCreatedHook interface {
+	CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment)
+}
+
+// CommentUpdatedHook can be implemented by a comment hook that also wants to
+// know about edited comments.
+type CommentUpdatedHook interface {
+	CommentUpdated(ctx context.Context, comment *domain.Comment)
+}
+
+// CommentDeletedHook can be implemented by a comment hook that also wants to
+// know about deleted comments, including comments hidden by a moderator.
+// removed is false when the comment has replies and only its content was
+// replaced.
+type CommentDeletedHook interface {
+	CommentDeleted(ctx context.Context, comment *domain.Comment, removed bool)
+}
+
+// CommentRestoredHook can be implemented by a comment hook that wants to know
+// when a comment hidden by reader reports is shown again. Readers saw it
+// before, so it is not announced as a new comment.
+type CommentRestoredHook interface {
+	CommentRestored(ctx context.Context, comment *domain.Comment)
 }
 
 type commentService struct {
 	commentRepo repository.CommentRepository
 	postRepo    repository.PostRepository
+	authRepo    repository.AuthRepository
+	banRepo     repository.BanRepository
+	transactor  repository.Transactor
+	cfg         config.Comments
+	spamRules   *spam.Rules
+	hooks       []CommentCreatedHook
 }
 
 // NewCommentService creates a new comment service implementation
-func NewCommentService(commentRepo repository.CommentRepository, postRepo repository.PostRepository) CommentService {
+func NewCommentService(commentRepo repository.CommentRepository, postRepo repository.PostRepository, authRepo repository.AuthRepository, banRepo repository.BanRepository, transactor repository.Transactor, cfg config.Comments, hooks ...CommentCreatedHook) CommentService {
 	return &commentService{
 		commentRepo: commentRepo,
 		postRepo:    postRepo,
+		authRepo:    authRepo,
+		banRepo:     banRepo,
+		transactor:  transactor,
+		cfg:         cfg,
+		spamRules:   newSpamRules(cfg.Spam),
+		hooks:       hooks,
 	}
 }
 
@@ -36,19 +100,26 @@ func (s *commentService) CreateComment(ctx context.Context, postID int, req *dom
 	if err := validator.Validate(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return nil, err
+	}
 
 	// Check if post exists
 	post, err := s.postRepo.GetByID(ctx, postID, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
-	if post == nil {
+	if post == nil || post.DeletedAt != nil {
 		return nil, fmt.Errorf("post not found")
 	}
+	if err := s.checkCommentingOpen(ctx, post, userID); err != nil {
+		return nil, err
+	}
 
 	// If parent_id is specified, check if parent comment exists
+	var parent *domain.Comment
 	if req.ParentID != nil {
-		parent, err := s.commentRepo.GetByID(ctx, *req.ParentID)
+		parent, err = s.commentRepo.GetByID(ctx, *req.ParentID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get parent comment: %w", err)
 		}
@@ -63,29 +134,80 @@ func (s *commentService) CreateComment(ctx context.Context, postID int, req *dom
 		if parent.DeletedAt != nil {
 			return nil, fmt.Errorf("cannot reply to deleted comment")
 		}
+		// Held comments are not part of the discussion yet
+		if parent.ModerationStatus != domain.CommentStatusApproved {
+			return nil, fmt.Errorf("parent comment not found")
+		}
 	}
 
 	// Create comment
 	comment := &domain.Comment{
-		PostID:   postID,
-		UserID:   userID,
-		Content:  req.Content,
-		ParentID: req.ParentID,
+		PostID:      postID,
+		UserID:      userID,
+		Content:     req.Content,
+		ParentID:    req.ParentID,
+		Fingerprint: spam.Fingerprint(req.Content),
 	}
 
-	createdComment, err := s.commentRepo.Create(ctx, comment)
+	// Decide whether it is published or held for moderation
+	if err := s.screen(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	var createdComment *domain.Comment
+	err = s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdComment, err = s.commentRepo.Create(ctx, comment)
+		if err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+		return s.saveMentions(ctx, createdComment, nil)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+		return nil, err
+	}
+
+	// Held comments are announced once approved
+	if createdComment.ModerationStatus == domain.CommentStatusApproved {
+		for _, hook := range s.hooks {
+			hook.CommentCreated(ctx, post, createdComment, parent)
+		}
 	}
 
 	return createdComment, nil
 }
 
+// checkCommentingOpen applies the post's discussion settings to a new comment
+func (s *commentService) checkCommentingOpen(ctx context.Context, post *domain.Post, userID int) error {
+	now := time.Now()
+	reason := post.CommentingClosed(nil, now)
+
+	// The account only matters when the post asks for its age
+	if reason == "" && post.CommentsMinAccountAgeDays > 0 {
+		user, err := s.authRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user == nil {
+			return fmt.Errorf("user not found")
+		}
+		reason = post.CommentingClosed(user, now)
+	}
+
+	if reason != "" {
+		return fmt.Errorf("%w: comments are closed: %s", derr.ErrPermission, reason)
+	}
+	return nil
+}
+
 func (s *commentService) UpdateComment(ctx context.Context, commentID int, req *domain.UpdateCommentRequest, userID int, _ bool) (*domain.Comment, error) { //nolint:revive // isAdmin reserved for future permission checks
 	// Validate request
 	if err := validator.Validate(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return nil, err
+	}
 
 	// Get existing comment
 	comment, err := s.commentRepo.GetByID(ctx, commentID)
@@ -106,12 +228,59 @@ func (s *commentService) UpdateComment(ctx context.Context, commentID int, req *
 		return nil, fmt.Errorf("permission denied: you can only edit your own comments")
 	}
 
+	// Rewriting a comment after others replied to it would change the discussion
+	if s.cfg.EditWindow > 0 && time.Since(comment.CreatedAt) > s.cfg.EditWindow {
+		return nil, fmt.Errorf("%w: comments can only be edited within %s of posting", derr.ErrPermission, s.cfg.EditWindow)
+	}
+
+	// Nothing to keep a revision of
+	if comment.Content == req.Content {
+		return comment, nil
+	}
+
+	// The new content is screened like a new comment. A published comment
+	// that would be held goes back to moderation, an edit never publishes one.
+	screened := *comment
+	screened.Content = req.Content
+	screened.Fingerprint = spam.Fingerprint(req.Content)
+	if err := s.screen(ctx, &screened); err != nil {
+		return nil, err
+	}
+	held := comment.ModerationStatus == domain.CommentStatusApproved && screened.ModerationStatus == domain.CommentStatusPending
+
 	// Update comment
 	comment.Content = req.Content
+	comment.Fingerprint = screened.Fingerprint
+	comment.SpamScore, comment.SpamReasons = screened.SpamScore, screened.SpamReasons
 
-	updatedComment, err := s.commentRepo.Update(ctx, comment)
+	var updatedComment *domain.Comment
+	err = s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updatedComment, err = s.commentRepo.Update(ctx, comment)
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		if held {
+			if err := s.commentRepo.SetModerationStatus(ctx, comment.ID, domain.CommentStatusPending); err != nil {
+				return fmt.Errorf("failed to set comment moderation status: %w", err)
+			}
+			updatedComment.ModerationStatus = domain.CommentStatusPending
+		}
+		return s.saveMentions(ctx, updatedComment, comment.Mentions)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update comment: %w", err)
+		return nil, err
+	}
+
+	// A held comment disappears for readers until it is approved again
+	if held {
+		s.commentDeleted(ctx, updatedComment, true)
+		return updatedComment, nil
+	}
+	for _, hook := range s.hooks {
+		if h, ok := hook.(CommentUpdatedHook); ok {
+			h.CommentUpdated(ctx, updatedComment)
+		}
 	}
 
 	return updatedComment, nil
@@ -137,27 +306,71 @@ func (s *commentService) DeleteComment(ctx context.Context, commentID int, userI
 		return fmt.Errorf("permission denied: you can only delete your own comments")
 	}
 
-	// Check if comment has replies
-	hasReplies, err := s.commentRepo.HasReplies(ctx, commentID)
-	if err != nil {
-		return fmt.Errorf("failed to check for replies: %w", err)
-	}
+	return s.removeComment(ctx, comment)
+}
 
-	if hasReplies {
-		// If has replies, soft delete and replace content
-		if err := s.commentRepo.SoftDelete(ctx, commentID, "Содержимое удалено."); err != nil {
-			return fmt.Errorf("failed to soft delete comment: %w", err)
+// deletedCommentContent replaces the content of deleted comments kept for their replies
+const deletedCommentContent = "Содержимое удалено."
+
+// removeComment soft deletes a comment with replies to keep the thread, otherwise deletes it
+func (s *commentService) removeComment(ctx context.Context, comment *domain.Comment) error {
+	commentID := comment.ID
+
+	var hasReplies bool
+	var purged []domain.Comment
+	err := s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		// A reply added after the check would be deleted with its parent
+		if err := s.commentRepo.Lock(ctx, commentID); err != nil {
+			return err
 		}
-	} else {
-		// If no replies, hard delete
-		if err := s.commentRepo.HardDelete(ctx, commentID); err != nil {
-			return fmt.Errorf("failed to delete comment: %w", err)
+
+		var err error
+		if hasReplies, err = s.commentRepo.HasReplies(ctx, commentID); err != nil {
+			return fmt.Errorf("failed to check for replies: %w", err)
+		}
+
+		if hasReplies {
+			// If has replies, soft delete and replace content
+			if err := s.commentRepo.SoftDelete(ctx, commentID, deletedCommentContent); err != nil {
+				return fmt.Errorf("failed to soft delete comment: %w", err)
+			}
+		} else {
+			// If no replies, hard delete
+			if err := s.commentRepo.HardDelete(ctx, commentID); err != nil {
+				return fmt.Errorf("failed to delete comment: %w", err)
+			}
 		}
+
+		// Deleted ancestors, or replies that are all deleted, may no longer
+		// hold up anything
+		if hasReplies || comment.ParentID != nil {
+			if purged, err = s.commentRepo.PurgeTombstones(ctx, comment.PostID); err != nil {
+				return fmt.Errorf("failed to purge deleted comments: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Readers never saw held comments
+	if comment.ModerationStatus == domain.CommentStatusApproved {
+		s.commentDeleted(ctx, comment, !hasReplies)
 	}
+	s.tombstonesPurged(ctx, purged)
 
 	return nil
 }
 
+func (s *commentService) commentDeleted(ctx context.Context, comment *domain.Comment, removed bool) {
+	for _, hook := range s.hooks {
+		if h, ok := hook.(CommentDeletedHook); ok {
+			h.CommentDeleted(ctx, comment, removed)
+		}
+	}
+}
+
 func (s *commentService) GetCommentByID(ctx context.Context, id int) (*domain.Comment, error) {
 	comment, err := s.commentRepo.GetByID(ctx, id)
 	if err != nil {
@@ -169,21 +382,44 @@ func (s *commentService) GetCommentByID(ctx context.Context, id int) (*domain.Co
 	return comment, nil
 }
 
-func (s *commentService) GetCommentsByPostSlug(ctx context.Context, slug string, userID int) ([]domain.Comment, error) {
+func (s *commentService) GetCommentRevisions(ctx context.Context, id int) ([]domain.CommentRevision, error) {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil {
+		return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+
+	revisions, err := s.commentRepo.ListRevisions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comment revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (s *commentService) GetCommentsByPostSlug(ctx context.Context, slug string, userID int, isAdmin bool, order string) ([]domain.Comment, error) {
+	if order == "" {
+		order = domain.CommentSortOldest
+	}
+	if !domain.IsValidCommentSort(order) {
+		return nil, fmt.Errorf("%w: unknown sort %q", derr.ErrValidation, order)
+	}
+
 	// Get post by slug
 	post, err := s.postRepo.GetBySlug(ctx, slug, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
-	if post == nil {
+	if post == nil || post.DeletedAt != nil {
 		return nil, fmt.Errorf("post not found")
 	}
 
 	// Get comments
-	comments, err := s.commentRepo.GetByPostID(ctx, post.ID, userID)
+	comments, err := s.commentRepo.GetByPostID(ctx, post.ID, userID, isAdmin)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
 
-	return comments, nil
+	return commentTree(comments, order, s.cfg.MaxDepth, s.cfg.CollapseAfter), nil
 }
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/validator"
+)
+
+func (s *commentService) BulkModerateComments(ctx context.Context, req *domain.BulkCommentsRequest) (*domain.BulkResponse, error) {
+	// Validate request
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", derr.ErrValidation, err)
+	}
+
+	response := &domain.BulkResponse{Action: req.Action, Results: make([]domain.BulkItemResult, 0, len(req.IDs))}
+	err := s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range uniqueIDs(req.IDs) {
+			err := s.applyCommentAction(ctx, id, req.Action)
+			if err != nil && !errors.Is(err, errBulkItem) {
+				return err
+			}
+			addBulkResult(response, id, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run bulk comment action: %w", err)
+	}
+
+	return response, nil
+}
+
+// applyCommentAction applies a single bulk action. Logical failures are wrapped
+// with errBulkItem, any other error aborts the whole transaction.
+func (s *commentService) applyCommentAction(ctx context.Context, id int, action domain.BulkAction) error {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil {
+		return fmt.Errorf("%w: comment not found", errBulkItem)
+	}
+	if comment.DeletedAt != nil {
+		return fmt.Errorf("%w: comment already deleted", errBulkItem)
+	}
+
+	switch action {
+	case domain.BulkActionDelete:
+		return s.removeComment(ctx, comment)
+	case domain.BulkActionApprove:
+		return s.moderate(ctx, comment, domain.CommentStatusApproved)
+	case domain.BulkActionMarkSpam:
+		return s.moderate(ctx, comment, domain.CommentStatusSpam)
+	case domain.BulkActionReject:
+		return s.moderate(ctx, comment, domain.CommentStatusRejected)
+	default:
+		return fmt.Errorf("%w: unsupported action %q", errBulkItem, action)
+	}
+}
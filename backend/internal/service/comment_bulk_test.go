@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentService_BulkModerateComments(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		request       *domain.BulkCommentsRequest
+		setupMocks    func(*MockCommentRepository)
+		wantErr       error
+		wantAnyErr    bool
+		wantResults   []domain.BulkItemResult
+		wantSucceeded int
+	}{
+		{
+			name:    "delete - soft with replies, hard without",
+			request: &domain.BulkCommentsRequest{IDs: []int{1, 2, 3}, Action: domain.BulkActionDelete},
+			setupMocks: func(m *MockCommentRepository) {
+				m.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1}, nil)
+				m.On("GetByID", mock.Anything, 2).Return(&domain.Comment{ID: 2}, nil)
+				m.On("GetByID", mock.Anything, 3).Return(&domain.Comment{ID: 3, DeletedAt: &deletedAt}, nil)
+				m.On("Lock", mock.Anything, 1).Return(nil)
+				m.On("HasReplies", mock.Anything, 1).Return(true, nil)
+				m.On("Lock", mock.Anything, 2).Return(nil)
+				m.On("HasReplies", mock.Anything, 2).Return(false, nil)
+				m.On("SoftDelete", mock.Anything, 1, "Содержимое удалено.").Return(nil)
+				m.On("PurgeTombstones", mock.Anything, 0).Return([]domain.Comment{}, nil)
+				m.On("HardDelete", mock.Anything, 2).Return(nil)
+			},
+			wantResults: []domain.BulkItemResult{
+				{ID: 1, Success: true},
+				{ID: 2, Success: true},
+				{ID: 3, Error: "comment already deleted"},
+			},
+			wantSucceeded: 2,
+		},
+		{
+			name:    "mark spam",
+			request: &domain.BulkCommentsRequest{IDs: []int{1, 2}, Action: domain.BulkActionMarkSpam},
+			setupMocks: func(m *MockCommentRepository) {
+				m.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1}, nil)
+				m.On("GetByID", mock.Anything, 2).Return(nil, nil)
+				m.On("SetModerationStatus", mock.Anything, 1, domain.CommentStatusSpam).Return(nil)
+			},
+			wantResults: []domain.BulkItemResult{
+				{ID: 1, Success: true},
+				{ID: 2, Error: "comment not found"},
+			},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "approve",
+			request: &domain.BulkCommentsRequest{IDs: []int{1}, Action: domain.BulkActionApprove},
+			setupMocks: func(m *MockCommentRepository) {
+				m.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, ModerationStatus: domain.CommentStatusSpam}, nil)
+				m.On("SetModerationStatus", mock.Anything, 1, domain.CommentStatusApproved).Return(nil)
+			},
+			wantResults:   []domain.BulkItemResult{{ID: 1, Success: true}},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "reject",
+			request: &domain.BulkCommentsRequest{IDs: []int{1}, Action: domain.BulkActionReject},
+			setupMocks: func(m *MockCommentRepository) {
+				m.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, ModerationStatus: domain.CommentStatusPending}, nil)
+				m.On("SetModerationStatus", mock.Anything, 1, domain.CommentStatusRejected).Return(nil)
+			},
+			wantResults:   []domain.BulkItemResult{{ID: 1, Success: true}},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "error - repository failure aborts the batch",
+			request: &domain.BulkCommentsRequest{IDs: []int{1}, Action: domain.BulkActionApprove},
+			setupMocks: func(m *MockCommentRepository) {
+				m.On("GetByID", mock.Anything, 1).Return(nil, errors.New("db error"))
+			},
+			wantAnyErr: true,
+		},
+		{
+			name:       "error - post-only action",
+			request:    &domain.BulkCommentsRequest{IDs: []int{1}, Action: domain.BulkActionPublish},
+			setupMocks: func(_ *MockCommentRepository) {},
+			wantErr:    derr.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCommentRepo := new(MockCommentRepository)
+			mockTransactor := new(MockTransactor)
+			mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil).Maybe()
+			tt.setupMocks(mockCommentRepo)
+
+			service := NewCommentService(mockCommentRepo, new(MockPostRepository), new(MockAuthRepository), noBans(), mockTransactor, config.Comments{})
+			response, err := service.BulkModerateComments(context.Background(), tt.request)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, response)
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+				assert.Nil(t, response)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantResults, response.Results)
+				assert.Equal(t, tt.wantSucceeded, response.Succeeded)
+				assert.Equal(t, len(tt.wantResults)-tt.wantSucceeded, response.Failed)
+			}
+
+			mockCommentRepo.AssertExpectations(t)
+		})
+	}
+}
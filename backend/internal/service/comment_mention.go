@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/pkg/mention"
+)
+
+// saveMentions resolves the @mentions of a comment against the people taking
+// part in the discussion of the post, stores them and sets comment.Mentions.
+// previous are the mentions before an edit, they are replaced.
+func (s *commentService) saveMentions(ctx context.Context, comment *domain.Comment, previous []domain.CommentMention) error {
+	handles := mention.Parse(comment.Content)
+	if len(handles) == 0 && len(previous) == 0 {
+		return nil
+	}
+
+	var mentions []domain.CommentMention
+	if len(handles) > 0 {
+		commenters, err := s.commentRepo.ListCommenters(ctx, comment.PostID)
+		if err != nil {
+			return fmt.Errorf("failed to list commenters: %w", err)
+		}
+		mentions = resolveMentions(handles, commenters, comment.UserID)
+	}
+
+	if err := s.commentRepo.SetMentions(ctx, comment.ID, mentions); err != nil {
+		return fmt.Errorf("failed to save mentions: %w", err)
+	}
+	comment.Mentions = mentions
+	return nil
+}
+
+// resolveMentions matches handles to users by name. A handle matching several
+// users is ambiguous and is skipped, authors do not mention themselves.
+func resolveMentions(handles []string, users []domain.User, authorID int) []domain.CommentMention {
+	byKey := make(map[string][]domain.User)
+	for _, user := range users {
+		key := mention.Key(user.Name)
+		byKey[key] = append(byKey[key], user)
+	}
+
+	var mentions []domain.CommentMention
+	for _, handle := range handles {
+		matched := byKey[mention.Key(handle)]
+		if len(matched) != 1 || matched[0].ID == authorID {
+			continue
+		}
+		mentions = append(mentions, domain.CommentMention{
+			UserID: matched[0].ID,
+			Name:   matched[0].Name,
+			Handle: handle,
+		})
+	}
+	return mentions
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveMentions(t *testing.T) {
+	users := []domain.User{
+		{ID: 1, Name: "Anna Smith"},
+		{ID: 2, Name: "Ivan"},
+		{ID: 3, Name: "Alex"},
+		{ID: 4, Name: "alex"},
+	}
+
+	mentions := resolveMentions([]string{"anna_smith", "Ivan", "alex", "nobody"}, users, 5)
+	assert.Equal(t, []domain.CommentMention{
+		{UserID: 1, Name: "Anna Smith", Handle: "anna_smith"},
+		{UserID: 2, Name: "Ivan", Handle: "Ivan"},
+	}, mentions)
+
+	assert.Empty(t, resolveMentions([]string{"Ivan"}, users, 2), "authors do not mention themselves")
+}
+
+func TestCommentService_saveMentions(t *testing.T) {
+	ctx := context.Background()
+	commenters := []domain.User{{ID: 2, Name: "Ivan"}}
+
+	t.Run("Mentions are resolved and stored", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		service := &commentService{commentRepo: repo}
+		comment := &domain.Comment{ID: 11, PostID: 1, UserID: 3, Content: "@ivan agreed"}
+
+		want := []domain.CommentMention{{UserID: 2, Name: "Ivan", Handle: "ivan"}}
+		repo.On("ListCommenters", mock.Anything, 1).Return(commenters, nil)
+		repo.On("SetMentions", mock.Anything, 11, want).Return(nil)
+
+		require.NoError(t, service.saveMentions(ctx, comment, nil))
+		assert.Equal(t, want, comment.Mentions)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("No mentions skips the lookup", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		service := &commentService{commentRepo: repo}
+
+		require.NoError(t, service.saveMentions(ctx, &domain.Comment{ID: 11, PostID: 1, Content: "agreed"}, nil))
+		repo.AssertNotCalled(t, "ListCommenters", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "SetMentions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Edit removing mentions clears them", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		service := &commentService{commentRepo: repo}
+		comment := &domain.Comment{ID: 11, PostID: 1, UserID: 3, Content: "agreed"}
+
+		repo.On("SetMentions", mock.Anything, 11, []domain.CommentMention(nil)).Return(nil)
+
+		previous := []domain.CommentMention{{UserID: 2, Name: "Ivan", Handle: "ivan"}}
+		require.NoError(t, service.saveMentions(ctx, comment, previous))
+		assert.Empty(t, comment.Mentions)
+		repo.AssertExpectations(t)
+	})
+}
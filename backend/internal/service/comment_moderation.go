@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/validator"
+)
+
+func (s *commentService) ListModerationQueue(ctx context.Context, req *domain.ModerationQueueRequest) (*domain.ModerationQueueResponse, error) {
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	// Set defaults
+	if req.Status == "" {
+		req.Status = domain.CommentStatusPending
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+
+	comments, totalCount, err := s.commentRepo.ListByStatus(ctx, req.Status, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation queue: %w", err)
+	}
+
+	return &domain.ModerationQueueResponse{
+		Comments:   comments,
+		TotalCount: totalCount,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: (totalCount + req.Limit - 1) / req.Limit,
+	}, nil
+}
+
+// moderate sets the moderation status of a comment and tells the hooks when
+// a held comment gets published or a published one gets hidden
+func (s *commentService) moderate(ctx context.Context, comment *domain.Comment, status string) error {
+	if err := s.commentRepo.SetModerationStatus(ctx, comment.ID, status); err != nil {
+		return fmt.Errorf("failed to set comment moderation status: %w", err)
+	}
+
+	switch {
+	case comment.ModerationStatus == domain.CommentStatusPending && status == domain.CommentStatusApproved:
+		return s.commentApproved(ctx, comment)
+	case comment.ModerationStatus == domain.CommentStatusApproved && status != domain.CommentStatusApproved:
+		s.commentDeleted(ctx, comment, true)
+	}
+
+	return nil
+}
+
+// commentApproved announces a held comment as if it was created just now
+func (s *commentService) commentApproved(ctx context.Context, comment *domain.Comment) error {
+	if len(s.hooks) == 0 {
+		return nil
+	}
+
+	post, err := s.postRepo.GetByID(ctx, comment.PostID, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return nil
+	}
+
+	var parent *domain.Comment
+	if comment.ParentID != nil {
+		if parent, err = s.commentRepo.GetByID(ctx, *comment.ParentID); err != nil {
+			return fmt.Errorf("failed to get parent comment: %w", err)
+		}
+	}
+
+	comment.ModerationStatus = domain.CommentStatusApproved
+	for _, hook := range s.hooks {
+		hook.CommentCreated(ctx, post, comment, parent)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentService_ListModerationQueue(t *testing.T) {
+	t.Run("pending by default", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("ListByStatus", mock.Anything, domain.CommentStatusPending, 20, 20).
+			Return([]domain.QueuedComment{{Comment: domain.Comment{ID: 1}, PostSlug: "hello"}}, 21, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+		response, err := service.ListModerationQueue(context.Background(), &domain.ModerationQueueRequest{Page: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 21, response.TotalCount)
+		assert.Equal(t, 2, response.TotalPages)
+		assert.Len(t, response.Comments, 1)
+	})
+
+	t.Run("approved comments are not a queue", func(t *testing.T) {
+		service := NewCommentService(new(MockCommentRepository), new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+		_, err := service.ListModerationQueue(context.Background(), &domain.ModerationQueueRequest{Status: domain.CommentStatusApproved})
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+}
+
+func TestCommentService_ModerationHooks(t *testing.T) {
+	newService := func(repo *MockCommentRepository, postRepo *MockPostRepository, hook CommentCreatedHook) CommentService {
+		tx := new(MockTransactor)
+		tx.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
+		return NewCommentService(repo, postRepo, new(MockAuthRepository), noBans(), tx, config.Comments{}, hook)
+	}
+
+	t.Run("approving a held reply announces it", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		postRepo := new(MockPostRepository)
+		repo.On("GetByID", mock.Anything, 2).Return(&domain.Comment{ID: 2, PostID: 1, ParentID: intPtr(1), ModerationStatus: domain.CommentStatusPending}, nil)
+		repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, PostID: 1, UserID: 3, ModerationStatus: domain.CommentStatusApproved}, nil)
+		repo.On("SetModerationStatus", mock.Anything, 2, domain.CommentStatusApproved).Return(nil)
+		postRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+
+		hook := &recordingCommentHook{}
+		_, err := newService(repo, postRepo, hook).BulkModerateComments(context.Background(),
+			&domain.BulkCommentsRequest{IDs: []int{2}, Action: domain.BulkActionApprove})
+		assert.NoError(t, err)
+		if assert.NotNil(t, hook.comment) {
+			assert.Equal(t, domain.CommentStatusApproved, hook.comment.ModerationStatus)
+		}
+		if assert.NotNil(t, hook.parent) {
+			assert.Equal(t, 3, hook.parent.UserID)
+		}
+	})
+
+	t.Run("hiding a published comment removes it", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 2).Return(&domain.Comment{ID: 2, PostID: 1, ModerationStatus: domain.CommentStatusApproved}, nil)
+		repo.On("SetModerationStatus", mock.Anything, 2, domain.CommentStatusSpam).Return(nil)
+
+		hook := &recordingCommentHook{}
+		_, err := newService(repo, new(MockPostRepository), hook).BulkModerateComments(context.Background(),
+			&domain.BulkCommentsRequest{IDs: []int{2}, Action: domain.BulkActionMarkSpam})
+		assert.NoError(t, err)
+		assert.NotNil(t, hook.deleted)
+		assert.True(t, hook.removed)
+	})
+
+	t.Run("rejecting a held comment is silent", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 2).Return(&domain.Comment{ID: 2, PostID: 1, ModerationStatus: domain.CommentStatusPending}, nil)
+		repo.On("SetModerationStatus", mock.Anything, 2, domain.CommentStatusRejected).Return(nil)
+
+		hook := &recordingCommentHook{}
+		_, err := newService(repo, new(MockPostRepository), hook).BulkModerateComments(context.Background(),
+			&domain.BulkCommentsRequest{IDs: []int{2}, Action: domain.BulkActionReject})
+		assert.NoError(t, err)
+		assert.Nil(t, hook.comment)
+		assert.Nil(t, hook.deleted)
+	})
+}
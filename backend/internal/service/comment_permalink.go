@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+)
+
+// permalinkReplies is how many replies a permalink shows, the rest are
+// loaded with the thread
+const permalinkReplies = 10
+
+func (s *commentService) GetCommentPermalink(ctx context.Context, id int, userID int, isAdmin bool) (*domain.CommentPermalink, error) {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil {
+		return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+
+	post, err := s.postRepo.GetByID(ctx, comment.PostID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil || post.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+
+	// Build the thread the way the post page shows it, so that the comment
+	// is only found when the viewer can see it
+	comments, err := s.commentRepo.GetByPostID(ctx, post.ID, userID, isAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	threads := commentTree(comments, domain.CommentSortOldest, s.cfg.MaxDepth, s.cfg.CollapseAfter)
+
+	nodes := make(map[int]*domain.Comment, len(comments))
+	for i := range comments {
+		nodes[comments[i].ID] = &comments[i]
+	}
+	node, ok := nodes[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+
+	// Walk up to the top-level comment, replies to hidden comments are not shown
+	ancestors := []domain.Comment{}
+	root := node
+	for root.ParentID != nil {
+		parent, ok := nodes[*root.ParentID]
+		if !ok {
+			return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+		}
+		ancestor := *parent
+		ancestor.Replies = nil
+		ancestors = append([]domain.Comment{ancestor}, ancestors...)
+		root = parent
+	}
+
+	position := -1
+	for i := range threads {
+		if threads[i].ID == root.ID {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+
+	// The first page of replies, without their own replies
+	shown := node.Replies
+	if len(shown) > permalinkReplies {
+		shown = shown[:permalinkReplies]
+	}
+	result := *node
+	result.Replies = make([]*domain.Comment, 0, len(shown))
+	for _, r := range shown {
+		reply := *r
+		reply.Replies = []*domain.Comment{}
+		result.Replies = append(result.Replies, &reply)
+	}
+
+	return &domain.CommentPermalink{
+		Comment:        &result,
+		Ancestors:      ancestors,
+		PostID:         post.ID,
+		PostSlug:       post.Slug,
+		PostTitle:      post.Title,
+		ThreadID:       root.ID,
+		ThreadPosition: position,
+		Anchor:         domain.CommentAnchor(id),
+	}, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentService_GetCommentPermalink(t *testing.T) {
+	post := &domain.Post{ID: 1, Slug: "hello", Title: "Hello"}
+	comments := func() []domain.Comment {
+		list := []domain.Comment{
+			{ID: 1, PostID: 1, Content: "First thread"},
+			{ID: 2, PostID: 1, Content: "Second thread"},
+			{ID: 3, PostID: 1, Content: "Reply", ParentID: intPtr(2)},
+			{ID: 4, PostID: 1, Content: "Nested reply", ParentID: intPtr(3)},
+		}
+		for i := 0; i < 12; i++ {
+			list = append(list, domain.Comment{ID: 10 + i, PostID: 1, Content: fmt.Sprintf("Answer %d", i), ParentID: intPtr(4)})
+		}
+		list = append(list, domain.Comment{ID: 30, PostID: 1, Content: "Deep", ParentID: intPtr(10)})
+		return list
+	}
+
+	setup := func(comment *domain.Comment) (CommentService, *MockCommentRepository, *MockPostRepository) {
+		commentRepo := new(MockCommentRepository)
+		postRepo := new(MockPostRepository)
+		commentRepo.On("GetByID", mock.Anything, comment.ID).Return(comment, nil)
+		postRepo.On("GetByID", mock.Anything, 1, 0).Return(post, nil).Maybe()
+		commentRepo.On("GetByPostID", mock.Anything, 1, 5, false).Return(comments(), nil).Maybe()
+		service := NewCommentService(commentRepo, postRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+		return service, commentRepo, postRepo
+	}
+
+	t.Run("Reply with its thread context", func(t *testing.T) {
+		service, _, _ := setup(&domain.Comment{ID: 4, PostID: 1})
+
+		permalink, err := service.GetCommentPermalink(context.Background(), 4, 5, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, permalink.Comment.ID)
+		require.Len(t, permalink.Ancestors, 2)
+		assert.Equal(t, 2, permalink.Ancestors[0].ID)
+		assert.Equal(t, 3, permalink.Ancestors[1].ID)
+		assert.Nil(t, permalink.Ancestors[0].Replies)
+		assert.Equal(t, 2, permalink.ThreadID)
+		assert.Equal(t, 1, permalink.ThreadPosition)
+		assert.Equal(t, "hello", permalink.PostSlug)
+		assert.Equal(t, "Hello", permalink.PostTitle)
+		assert.Equal(t, "comment-4", permalink.Anchor)
+
+		// The first page of replies, without theirs, all of them still counted
+		require.Len(t, permalink.Comment.Replies, permalinkReplies)
+		assert.Equal(t, 10, permalink.Comment.Replies[0].ID)
+		assert.Empty(t, permalink.Comment.Replies[0].Replies)
+		assert.Equal(t, 13, permalink.Comment.RepliesCount)
+	})
+
+	t.Run("Top-level comment", func(t *testing.T) {
+		service, _, _ := setup(&domain.Comment{ID: 1, PostID: 1})
+
+		permalink, err := service.GetCommentPermalink(context.Background(), 1, 5, false)
+		require.NoError(t, err)
+		assert.Empty(t, permalink.Ancestors)
+		assert.Equal(t, 1, permalink.ThreadID)
+		assert.Equal(t, 0, permalink.ThreadPosition)
+	})
+
+	t.Run("Comment not visible to the viewer", func(t *testing.T) {
+		service, _, _ := setup(&domain.Comment{ID: 99, PostID: 1, ModerationStatus: domain.CommentStatusPending})
+
+		_, err := service.GetCommentPermalink(context.Background(), 99, 5, false)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Comment not found", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		commentRepo.On("GetByID", mock.Anything, 7).Return(nil, nil)
+		service := NewCommentService(commentRepo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+
+		_, err := service.GetCommentPermalink(context.Background(), 7, 5, false)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/validator"
+)
+
+// ReportComment files a reader's report. Reporting a comment again is not an
+// error, the first report stands. Enough reports hide the comment until an
+// admin reviews them.
+func (s *commentService) ReportComment(ctx context.Context, commentID int, req *domain.ReportCommentRequest, userID int) error {
+	if err := validator.Validate(req); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return err
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+	// Readers only see published comments
+	if comment == nil || comment.DeletedAt != nil || comment.ModerationStatus != domain.CommentStatusApproved {
+		return fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+	if comment.UserID == userID {
+		return fmt.Errorf("%w: cannot report your own comment", derr.ErrValidation)
+	}
+
+	return s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		// Concurrent reports of the comment wait here, so each one counts the
+		// reports filed before it
+		if err := s.commentRepo.Lock(ctx, comment.ID); err != nil {
+			return err
+		}
+
+		created, err := s.commentRepo.CreateReport(ctx, &domain.CommentReport{
+			CommentID: comment.ID,
+			UserID:    userID,
+			Reason:    req.Reason,
+			Details:   req.Details,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save report: %w", err)
+		}
+		if !created || s.cfg.ReportThreshold <= 0 {
+			return nil
+		}
+		// Readers cannot hide what the site owner says
+		if comment.User != nil && comment.User.Role == domain.RoleAdmin {
+			return nil
+		}
+
+		reports, err := s.commentRepo.CountOpenReports(ctx, comment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count reports: %w", err)
+		}
+		if reports < s.cfg.ReportThreshold {
+			return nil
+		}
+
+		// An earlier report may have hidden it already
+		current, err := s.commentRepo.GetByID(ctx, comment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+		if current == nil || current.DeletedAt != nil || current.ModerationStatus != domain.CommentStatusApproved {
+			return nil
+		}
+		return s.moderate(ctx, current, domain.CommentStatusPending)
+	})
+}
+
+func (s *commentService) ListReports(ctx context.Context, req *domain.ReportsRequest) (*domain.ReportsResponse, error) {
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	// Set defaults
+	if req.Status == "" {
+		req.Status = domain.ReportStatusOpen
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+
+	comments, totalCount, err := s.commentRepo.ListReported(ctx, req.Status, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reported comments: %w", err)
+	}
+
+	return &domain.ReportsResponse{
+		Comments:   comments,
+		TotalCount: totalCount,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: (totalCount + req.Limit - 1) / req.Limit,
+	}, nil
+}
+
+// ResolveReports upholds the open reports of a comment and rejects it
+func (s *commentService) ResolveReports(ctx context.Context, commentID, adminID int) error {
+	return s.closeReports(ctx, commentID, adminID, domain.ReportStatusResolved)
+}
+
+// DismissReports dismisses the open reports of a comment and publishes it
+// again if the reports hid it
+func (s *commentService) DismissReports(ctx context.Context, commentID, adminID int) error {
+	return s.closeReports(ctx, commentID, adminID, domain.ReportStatusDismissed)
+}
+
+func (s *commentService) closeReports(ctx context.Context, commentID, adminID int, status string) error {
+	return s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		comment, err := s.commentRepo.GetByID(ctx, commentID)
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+		if comment == nil {
+			return fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+		}
+
+		closed, err := s.commentRepo.CloseReports(ctx, comment.ID, status, adminID)
+		if err != nil {
+			return fmt.Errorf("failed to close reports: %w", err)
+		}
+		if closed == 0 {
+			return fmt.Errorf("%w: comment has no open reports", derr.ErrNotFound)
+		}
+		if comment.DeletedAt != nil {
+			return nil
+		}
+
+		// Only published comments can be reported, a pending one was hidden by its reports
+		switch {
+		case status == domain.ReportStatusResolved && (comment.ModerationStatus == domain.CommentStatusApproved || comment.ModerationStatus == domain.CommentStatusPending):
+			return s.moderate(ctx, comment, domain.CommentStatusRejected)
+		case status == domain.ReportStatusDismissed && comment.ModerationStatus == domain.CommentStatusPending:
+			return s.restore(ctx, comment)
+		}
+		return nil
+	})
+}
+
+// restore publishes a comment hidden by its reports again. Unlike approving a
+// held comment this notifies nobody, readers have seen it before.
+func (s *commentService) restore(ctx context.Context, comment *domain.Comment) error {
+	if err := s.commentRepo.SetModerationStatus(ctx, comment.ID, domain.CommentStatusApproved); err != nil {
+		return fmt.Errorf("failed to set comment moderation status: %w", err)
+	}
+
+	comment.ModerationStatus = domain.CommentStatusApproved
+	for _, hook := range s.hooks {
+		if h, ok := hook.(CommentRestoredHook); ok {
+			h.CommentRestored(ctx, comment)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentService_ReportComment(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Comments{ReportThreshold: 3}
+	req := &domain.ReportCommentRequest{Reason: domain.ReportReasonAbuse}
+	published := func() *domain.Comment {
+		return &domain.Comment{ID: 5, PostID: 1, UserID: 2, ModerationStatus: domain.CommentStatusApproved}
+	}
+
+	t.Run("below the threshold the comment stays", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(published(), nil)
+		repo.On("Lock", mock.Anything, 5).Return(nil)
+		repo.On("CreateReport", mock.Anything, mock.MatchedBy(func(r *domain.CommentReport) bool {
+			return r.CommentID == 5 && r.UserID == 3 && r.Reason == domain.ReportReasonAbuse
+		})).Return(true, nil)
+		repo.On("CountOpenReports", mock.Anything, 5).Return(2, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg)
+		assert.NoError(t, service.ReportComment(ctx, 5, req, 3))
+		repo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reaching the threshold hides the comment", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(published(), nil)
+		repo.On("Lock", mock.Anything, 5).Return(nil)
+		repo.On("CreateReport", mock.Anything, mock.Anything).Return(true, nil)
+		repo.On("CountOpenReports", mock.Anything, 5).Return(3, nil)
+		repo.On("SetModerationStatus", mock.Anything, 5, domain.CommentStatusPending).Return(nil)
+
+		hook := &recordingCommentHook{}
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg, hook)
+		assert.NoError(t, service.ReportComment(ctx, 5, req, 3))
+		repo.AssertExpectations(t)
+		assert.NotNil(t, hook.deleted)
+	})
+
+	t.Run("a repeated report is not counted", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(published(), nil)
+		repo.On("Lock", mock.Anything, 5).Return(nil)
+		repo.On("CreateReport", mock.Anything, mock.Anything).Return(false, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg)
+		assert.NoError(t, service.ReportComment(ctx, 5, req, 3))
+		repo.AssertNotCalled(t, "CountOpenReports", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a comment already hidden by an earlier report is left alone", func(t *testing.T) {
+		hidden := published()
+		hidden.ModerationStatus = domain.CommentStatusPending
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(published(), nil).Once()
+		repo.On("GetByID", mock.Anything, 5).Return(hidden, nil).Once()
+		repo.On("Lock", mock.Anything, 5).Return(nil)
+		repo.On("CreateReport", mock.Anything, mock.Anything).Return(true, nil)
+		repo.On("CountOpenReports", mock.Anything, 5).Return(4, nil)
+
+		hook := &recordingCommentHook{}
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg, hook)
+		assert.NoError(t, service.ReportComment(ctx, 5, req, 3))
+		repo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+		assert.Nil(t, hook.deleted)
+	})
+
+	t.Run("admin comments are never hidden", func(t *testing.T) {
+		comment := published()
+		comment.User = &domain.User{ID: 2, Role: domain.RoleAdmin}
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(comment, nil)
+		repo.On("Lock", mock.Anything, 5).Return(nil)
+		repo.On("CreateReport", mock.Anything, mock.Anything).Return(true, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg)
+		assert.NoError(t, service.ReportComment(ctx, 5, req, 3))
+		repo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("own and hidden comments cannot be reported", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(published(), nil)
+		repo.On("GetByID", mock.Anything, 6).Return(&domain.Comment{ID: 6, UserID: 2, ModerationStatus: domain.CommentStatusSpam}, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg)
+		assert.ErrorIs(t, service.ReportComment(ctx, 5, req, 2), derr.ErrValidation)
+		assert.ErrorIs(t, service.ReportComment(ctx, 6, req, 3), derr.ErrNotFound)
+		assert.ErrorIs(t, service.ReportComment(ctx, 5, &domain.ReportCommentRequest{Reason: "boring"}, 3), derr.ErrValidation)
+	})
+}
+
+func TestCommentService_CloseReports(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resolving rejects the comment", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(&domain.Comment{ID: 5, ModerationStatus: domain.CommentStatusPending}, nil)
+		repo.On("CloseReports", mock.Anything, 5, domain.ReportStatusResolved, 1).Return(int64(3), nil)
+		repo.On("SetModerationStatus", mock.Anything, 5, domain.CommentStatusRejected).Return(nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+		assert.NoError(t, service.ResolveReports(ctx, 5, 1))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("dismissing publishes a hidden comment again", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(&domain.Comment{ID: 5, PostID: 1, ModerationStatus: domain.CommentStatusPending}, nil)
+		repo.On("CloseReports", mock.Anything, 5, domain.ReportStatusDismissed, 1).Return(int64(3), nil)
+		repo.On("SetModerationStatus", mock.Anything, 5, domain.CommentStatusApproved).Return(nil)
+
+		hook := &recordingCommentHook{}
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{}, hook)
+		assert.NoError(t, service.DismissReports(ctx, 5, 1))
+		repo.AssertExpectations(t)
+
+		// Readers saw it before, it is not announced as a new comment
+		assert.Nil(t, hook.comment)
+		assert.Nil(t, hook.updated)
+		if assert.NotNil(t, hook.restored) {
+			assert.Equal(t, domain.CommentStatusApproved, hook.restored.ModerationStatus)
+		}
+	})
+
+	t.Run("dismissing keeps a published comment as is", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(&domain.Comment{ID: 5, ModerationStatus: domain.CommentStatusApproved}, nil)
+		repo.On("CloseReports", mock.Anything, 5, domain.ReportStatusDismissed, 1).Return(int64(1), nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+		assert.NoError(t, service.DismissReports(ctx, 5, 1))
+		repo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("no open reports", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 5).Return(&domain.Comment{ID: 5, ModerationStatus: domain.CommentStatusApproved}, nil)
+		repo.On("CloseReports", mock.Anything, 5, domain.ReportStatusResolved, 1).Return(int64(0), nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+		assert.ErrorIs(t, service.ResolveReports(ctx, 5, 1), derr.ErrNotFound)
+	})
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/pkg/spam"
+)
+
+// newSpamRules builds the scoring rules, configured stop word lists replace
+// the built-in list of their language
+func newSpamRules(cfg config.Spam) *spam.Rules {
+	lists := maps.Clone(spam.DefaultStopWords)
+	maps.Copy(lists, cfg.StopWords)
+
+	var stopWords []string
+	for _, lang := range slices.Sorted(maps.Keys(lists)) {
+		stopWords = append(stopWords, spam.NormalizeWords(lists[lang])...)
+	}
+
+	return &spam.Rules{
+		RateLimit:     cfg.RateLimit,
+		MaxLinks:      cfg.MaxLinks,
+		NewAccountAge: cfg.NewAccountAge,
+		StopWords:     stopWords,
+	}
+}
+
+// screen decides whether a new or edited comment is published or held for
+// moderation, by the moderation policy and its spam score. Admins are never held.
+func (s *commentService) screen(ctx context.Context, comment *domain.Comment) error {
+	comment.ModerationStatus = domain.CommentStatusApproved
+
+	policy := s.cfg.Moderation == domain.ModerationPolicyAll || s.cfg.Moderation == domain.ModerationPolicyFirstComment
+	if !policy && !s.cfg.Spam.Enabled {
+		return nil
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, comment.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user != nil && user.Role == domain.RoleAdmin {
+		return nil
+	}
+
+	held, err := s.heldByPolicy(ctx, comment.UserID)
+	if err != nil {
+		return err
+	}
+
+	if s.cfg.Spam.Enabled {
+		result, err := s.spamScore(ctx, user, comment)
+		if err != nil {
+			return err
+		}
+		comment.SpamScore, comment.SpamReasons = result.Score, result.Reasons
+		if result.Score >= s.cfg.Spam.HoldScore {
+			held = true
+		}
+	}
+
+	if held {
+		comment.ModerationStatus = domain.CommentStatusPending
+	}
+	return nil
+}
+
+// heldByPolicy tells whether the moderation policy holds a comment of the user
+func (s *commentService) heldByPolicy(ctx context.Context, userID int) (bool, error) {
+	switch s.cfg.Moderation {
+	case domain.ModerationPolicyAll:
+		return true, nil
+	case domain.ModerationPolicyFirstComment:
+		trusted, err := s.commentRepo.HasApproved(ctx, userID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check approved comments: %w", err)
+		}
+		return !trusted, nil
+	default:
+		return false, nil
+	}
+}
+
+// spamScore collects the signals of a new or edited comment and scores it
+func (s *commentService) spamScore(ctx context.Context, user *domain.User, comment *domain.Comment) (spam.Result, error) {
+	now := time.Now()
+	signals := spam.Signals{Content: comment.Content}
+
+	if user != nil {
+		signals.AccountAge = now.Sub(user.CreatedAt)
+	}
+
+	since := now.Add(-s.cfg.Spam.RateWindow)
+	recent, err := s.commentRepo.CountByUserSince(ctx, comment.UserID, since)
+	if err != nil {
+		return spam.Result{}, fmt.Errorf("failed to count recent comments: %w", err)
+	}
+	// An edited comment is among them, it isn't posting once more
+	if comment.ID != 0 && comment.CreatedAt.After(since) {
+		recent--
+	}
+	signals.RecentComments = recent
+
+	if comment.Fingerprint != "" {
+		duplicates, err := s.commentRepo.CountByFingerprintSince(ctx, comment.Fingerprint, now.Add(-s.cfg.Spam.DuplicateWindow))
+		if err != nil {
+			return spam.Result{}, fmt.Errorf("failed to count duplicate comments: %w", err)
+		}
+		signals.Duplicates = duplicates
+	}
+
+	return s.spamRules.Score(signals), nil
+}
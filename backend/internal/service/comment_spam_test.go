@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/pkg/spam"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentService_CreateComment_Spam(t *testing.T) {
+	cfg := config.Comments{
+		Moderation: domain.ModerationPolicyNone,
+		Spam: config.Spam{
+			Enabled:         true,
+			HoldScore:       5,
+			RateWindow:      10 * time.Minute,
+			RateLimit:       5,
+			MaxLinks:        2,
+			DuplicateWindow: 24 * time.Hour,
+			NewAccountAge:   24 * time.Hour,
+			StopWords:       map[string][]string{"en": {"payday loan"}},
+		},
+	}
+	reader := &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}
+
+	tests := []struct {
+		name        string
+		user        *domain.User
+		content     string
+		recent      int
+		duplicates  int
+		wantStatus  string
+		wantReasons []string
+	}{
+		{
+			name:       "clean comment is published",
+			user:       reader,
+			content:    "Thanks, this fixed my build",
+			wantStatus: domain.CommentStatusApproved,
+		},
+		{
+			name:        "flooding is held",
+			user:        reader,
+			content:     "Thanks, this fixed my build",
+			recent:      5,
+			wantStatus:  domain.CommentStatusPending,
+			wantReasons: []string{spam.ReasonRate},
+		},
+		{
+			name:        "configured stop words replace the built-in list",
+			user:        &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now()},
+			content:     "Get a payday loan, no online casino needed",
+			wantStatus:  domain.CommentStatusApproved,
+			wantReasons: []string{spam.ReasonStopWords, spam.ReasonNewAccount},
+		},
+		{
+			name:        "duplicate from a new account is held",
+			user:        &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now()},
+			content:     "Check out my profile for more great content",
+			duplicates:  3,
+			wantStatus:  domain.CommentStatusPending,
+			wantReasons: []string{spam.ReasonDuplicate, spam.ReasonNewAccount},
+		},
+		{
+			name:       "admins are not scored",
+			user:       &domain.User{ID: 2, Role: domain.RoleAdmin, CreatedAt: time.Now()},
+			content:    "a.com b.com c.com d.com e.com",
+			wantStatus: domain.CommentStatusApproved,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := new(MockPostRepository)
+			commentRepo := new(MockCommentRepository)
+			authRepo := new(MockAuthRepository)
+			postRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+			authRepo.On("GetUserByID", mock.Anything, 2).Return(tt.user, nil)
+			commentRepo.On("CountByUserSince", mock.Anything, 2, mock.Anything).Return(tt.recent, nil).Maybe()
+			commentRepo.On("CountByFingerprintSince", mock.Anything, spam.Fingerprint(tt.content), mock.Anything).Return(tt.duplicates, nil).Maybe()
+
+			var created *domain.Comment
+			commentRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				created = args.Get(1).(*domain.Comment)
+			}).Return(&domain.Comment{ID: 3}, nil)
+
+			service := NewCommentService(commentRepo, postRepo, authRepo, noBans(), passthroughTransactor(), cfg)
+			_, err := service.CreateComment(context.Background(), 1, &domain.CreateCommentRequest{Content: tt.content}, 2)
+			assert.NoError(t, err)
+
+			if assert.NotNil(t, created) {
+				assert.Equal(t, tt.wantStatus, created.ModerationStatus)
+				assert.Equal(t, tt.wantReasons, created.SpamReasons)
+			}
+		})
+	}
+}
+
+func TestCommentService_UpdateComment_Spam(t *testing.T) {
+	cfg := config.Comments{
+		Moderation: domain.ModerationPolicyNone,
+		Spam: config.Spam{
+			Enabled:         true,
+			HoldScore:       5,
+			RateWindow:      10 * time.Minute,
+			RateLimit:       5,
+			MaxLinks:        2,
+			DuplicateWindow: 24 * time.Hour,
+			NewAccountAge:   24 * time.Hour,
+		},
+	}
+	reader := &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}
+	content := "Thanks, this fixed my build"
+
+	tests := []struct {
+		name       string
+		status     string
+		recent     int
+		wantHeld   bool
+		wantStatus string
+	}{
+		{
+			// The edited comment itself is one of the recent ones
+			name:       "clean edit stays published",
+			status:     domain.CommentStatusApproved,
+			recent:     5,
+			wantStatus: domain.CommentStatusApproved,
+		},
+		{
+			name:       "edit that would be held goes back to moderation",
+			status:     domain.CommentStatusApproved,
+			recent:     6,
+			wantHeld:   true,
+			wantStatus: domain.CommentStatusPending,
+		},
+		{
+			name:       "held comment stays held",
+			status:     domain.CommentStatusPending,
+			recent:     6,
+			wantStatus: domain.CommentStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commentRepo := new(MockCommentRepository)
+			authRepo := new(MockAuthRepository)
+			hook := &recordingCommentHook{}
+			authRepo.On("GetUserByID", mock.Anything, 2).Return(reader, nil)
+			commentRepo.On("GetByID", mock.Anything, 3).Return(&domain.Comment{
+				ID: 3, PostID: 1, UserID: 2, Content: "Old", ModerationStatus: tt.status, CreatedAt: time.Now(),
+			}, nil)
+			commentRepo.On("CountByUserSince", mock.Anything, 2, mock.Anything).Return(tt.recent, nil)
+			commentRepo.On("CountByFingerprintSince", mock.Anything, spam.Fingerprint(content), mock.Anything).Return(0, nil)
+			commentRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool {
+				return c.Content == content && c.Fingerprint == spam.Fingerprint(content)
+			})).Return(&domain.Comment{ID: 3, PostID: 1, UserID: 2, Content: content, ModerationStatus: tt.status}, nil)
+			if tt.wantHeld {
+				commentRepo.On("SetModerationStatus", mock.Anything, 3, domain.CommentStatusPending).Return(nil).Once()
+			}
+
+			service := NewCommentService(commentRepo, new(MockPostRepository), authRepo, noBans(), passthroughTransactor(), cfg, hook)
+			updated, err := service.UpdateComment(context.Background(), 3, &domain.UpdateCommentRequest{Content: content}, 2, false)
+			assert.NoError(t, err)
+			if assert.NotNil(t, updated) {
+				assert.Equal(t, tt.wantStatus, updated.ModerationStatus)
+			}
+			commentRepo.AssertExpectations(t)
+
+			if tt.wantHeld {
+				// Readers stop seeing it
+				assert.Nil(t, hook.updated)
+				if assert.NotNil(t, hook.deleted) {
+					assert.True(t, hook.removed)
+				}
+			} else {
+				commentRepo.AssertNotCalled(t, "SetModerationStatus", mock.Anything, mock.Anything, mock.Anything)
+				assert.NotNil(t, hook.updated)
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"personal-web-platform/config"
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,6 +19,13 @@ type MockCommentRepository struct {
 	mock.Mock
 }
 
+// passthroughTransactor runs transactions without a database
+func passthroughTransactor() *MockTransactor {
+	tx := new(MockTransactor)
+	tx.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return tx
+}
+
 func (m *MockCommentRepository) Create(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
 	args := m.Called(ctx, comment)
 	if args.Get(0) == nil {
@@ -43,11 +52,29 @@ func (m *MockCommentRepository) HardDelete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockCommentRepository) Lock(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockCommentRepository) HasReplies(ctx context.Context, id int) (bool, error) {
 	args := m.Called(ctx, id)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCommentRepository) PurgeTombstones(ctx context.Context, postID int) ([]domain.Comment, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) SetModerationStatus(ctx context.Context, id int, status string) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
 func (m *MockCommentRepository) GetByID(ctx context.Context, id int) (*domain.Comment, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -56,11 +83,91 @@ func (m *MockCommentRepository) GetByID(ctx context.Context, id int) (*domain.Co
 	return args.Get(0).(*domain.Comment), args.Error(1) //nolint:errcheck // mock method
 }
 
-func (m *MockCommentRepository) GetByPostID(ctx context.Context, postID, userID int) ([]domain.Comment, error) {
-	args := m.Called(ctx, postID, userID)
+func (m *MockCommentRepository) GetByPostID(ctx context.Context, postID, userID int, includePending bool) ([]domain.Comment, error) {
+	args := m.Called(ctx, postID, userID, includePending)
 	return args.Get(0).([]domain.Comment), args.Error(1) //nolint:errcheck // mock method
 }
 
+func (m *MockCommentRepository) ListRevisions(ctx context.Context, commentID int) ([]domain.CommentRevision, error) {
+	args := m.Called(ctx, commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CommentRevision), args.Error(1)
+}
+
+func (m *MockCommentRepository) ListCommenters(ctx context.Context, postID int) ([]domain.User, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (m *MockCommentRepository) SetMentions(ctx context.Context, commentID int, mentions []domain.CommentMention) error {
+	args := m.Called(ctx, commentID, mentions)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) HasApproved(ctx context.Context, userID int) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) SoftDeleteByUser(ctx context.Context, userID int, placeholder string) ([]int, error) {
+	args := m.Called(ctx, userID, placeholder)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockCommentRepository) ReassignUser(ctx context.Context, fromUserID, toUserID int) (int64, error) {
+	args := m.Called(ctx, fromUserID, toUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCommentRepository) CountByUserSince(ctx context.Context, userID int, since time.Time) (int, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) CountByFingerprintSince(ctx context.Context, fingerprint string, since time.Time) (int, error) {
+	args := m.Called(ctx, fingerprint, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]domain.QueuedComment, int, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.QueuedComment), args.Int(1), args.Error(2)
+}
+
+func (m *MockCommentRepository) CreateReport(ctx context.Context, report *domain.CommentReport) (bool, error) {
+	args := m.Called(ctx, report)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) CountOpenReports(ctx context.Context, commentID int) (int, error) {
+	args := m.Called(ctx, commentID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) ListReported(ctx context.Context, status string, limit, offset int) ([]domain.ReportedComment, int, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.ReportedComment), args.Int(1), args.Error(2)
+}
+
+func (m *MockCommentRepository) CloseReports(ctx context.Context, commentID int, status string, adminID int) (int64, error) {
+	args := m.Called(ctx, commentID, status, adminID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestCommentService_CreateComment(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -109,8 +216,9 @@ func TestCommentService_CreateComment(t *testing.T) {
 			},
 			setupCommentMock: func(m *MockCommentRepository) {
 				m.On("GetByID", mock.Anything, 10).Return(&domain.Comment{
-					ID:     10,
-					PostID: 1,
+					ID:               10,
+					PostID:           1,
+					ModerationStatus: domain.CommentStatusApproved,
 				}, nil)
 				m.On("Create", mock.Anything, mock.Anything).Return(&domain.Comment{
 					ID:       2,
@@ -188,7 +296,7 @@ func TestCommentService_CreateComment(t *testing.T) {
 			tt.setupPostMock(mockPostRepo)
 			tt.setupCommentMock(mockCommentRepo)
 
-			service := NewCommentService(mockCommentRepo, mockPostRepo)
+			service := NewCommentService(mockCommentRepo, mockPostRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
 			comment, err := service.CreateComment(context.Background(), tt.postID, tt.request, tt.userID)
 
 			if tt.wantErr {
@@ -208,6 +316,315 @@ func TestCommentService_CreateComment(t *testing.T) {
 	}
 }
 
+func TestCommentService_CreateComment_Hooks(t *testing.T) {
+	mockPostRepo := new(MockPostRepository)
+	mockCommentRepo := new(MockCommentRepository)
+	mockPostRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+	mockCommentRepo.On("GetByID", mock.Anything, 10).Return(&domain.Comment{ID: 10, PostID: 1, UserID: 3, ModerationStatus: domain.CommentStatusApproved}, nil)
+	mockCommentRepo.On("Create", mock.Anything, mock.Anything).Return(&domain.Comment{ID: 2, PostID: 1, UserID: 2, ParentID: intPtr(10), ModerationStatus: domain.CommentStatusApproved}, nil)
+
+	hook := &recordingCommentHook{}
+	service := NewCommentService(mockCommentRepo, mockPostRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{}, hook)
+	_, err := service.CreateComment(context.Background(), 1, &domain.CreateCommentRequest{Content: "I agree!", ParentID: intPtr(10)}, 2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, hook.post.ID)
+	assert.Equal(t, 2, hook.comment.ID)
+	if assert.NotNil(t, hook.parent) {
+		assert.Equal(t, 3, hook.parent.UserID)
+	}
+}
+
+func TestCommentService_CreateComment_DiscussionSettings(t *testing.T) {
+	published := time.Now().AddDate(0, 0, -10)
+	newUser := &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now().AddDate(0, 0, -1)}
+	oldUser := &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now().AddDate(-1, 0, 0)}
+	newAdmin := &domain.User{ID: 2, Role: domain.RoleAdmin, CreatedAt: time.Now()}
+
+	tests := []struct {
+		name    string
+		post    *domain.Post
+		user    *domain.User
+		wantErr bool
+	}{
+		{name: "comments disabled", post: &domain.Post{ID: 1, CommentsDisabled: true}, wantErr: true},
+		{name: "closed after publication", post: &domain.Post{ID: 1, PublishedAt: published, CommentsCloseAfterDays: 7}, wantErr: true},
+		{name: "still open after publication", post: &domain.Post{ID: 1, PublishedAt: published, CommentsCloseAfterDays: 30}},
+		{name: "account too new", post: &domain.Post{ID: 1, CommentsMinAccountAgeDays: 7}, user: newUser, wantErr: true},
+		{name: "account old enough", post: &domain.Post{ID: 1, CommentsMinAccountAgeDays: 7}, user: oldUser},
+		{name: "admins are not held to the account age", post: &domain.Post{ID: 1, CommentsMinAccountAgeDays: 7}, user: newAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := new(MockPostRepository)
+			commentRepo := new(MockCommentRepository)
+			authRepo := new(MockAuthRepository)
+			postRepo.On("GetByID", mock.Anything, 1, 0).Return(tt.post, nil)
+			if tt.user != nil {
+				authRepo.On("GetUserByID", mock.Anything, 2).Return(tt.user, nil)
+			}
+			commentRepo.On("Create", mock.Anything, mock.Anything).Return(&domain.Comment{ID: 1, PostID: 1, UserID: 2}, nil).Maybe()
+
+			service := NewCommentService(commentRepo, postRepo, authRepo, noBans(), passthroughTransactor(), config.Comments{})
+			_, err := service.CreateComment(context.Background(), 1, &domain.CreateCommentRequest{Content: "Hello"}, 2)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, derr.ErrPermission)
+				commentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			authRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCommentService_BannedUser(t *testing.T) {
+	ctx := context.Background()
+	bans := new(MockBanRepository)
+	bans.On("GetActive", mock.Anything, 1).Return(&domain.UserBan{ID: 3, UserID: 1, Reason: "spam"}, nil)
+	repo := new(MockCommentRepository)
+	postRepo := new(MockPostRepository)
+	service := NewCommentService(repo, postRepo, new(MockAuthRepository), bans, passthroughTransactor(), config.Comments{})
+
+	t.Run("Create", func(t *testing.T) {
+		_, err := service.CreateComment(ctx, 1, &domain.CreateCommentRequest{Content: "Hi"}, 1)
+		assert.ErrorIs(t, err, derr.ErrPermission)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		_, err := service.UpdateComment(ctx, 5, &domain.UpdateCommentRequest{Content: "Edited"}, 1, false)
+		assert.ErrorIs(t, err, derr.ErrPermission)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCommentService_PurgeTombstones(t *testing.T) {
+	repo := new(MockCommentRepository)
+	hook := &recordingCommentHook{}
+	service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{}, hook)
+
+	repo.On("PurgeTombstones", mock.Anything, 0).Return([]domain.Comment{
+		{ID: 4, PostID: 1, ModerationStatus: domain.CommentStatusApproved},
+		{ID: 7, PostID: 2, ModerationStatus: domain.CommentStatusPending},
+	}, nil)
+
+	purged, err := service.PurgeTombstones(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	// Readers never saw the held one
+	if assert.NotNil(t, hook.deleted) {
+		assert.Equal(t, 4, hook.deleted.ID)
+	}
+	assert.True(t, hook.removed)
+}
+
+// recordingCommentHook records the last call of each comment hook
+type recordingCommentHook struct {
+	post            *domain.Post
+	comment, parent *domain.Comment
+	updated         *domain.Comment
+	deleted         *domain.Comment
+	removed         bool
+	restored        *domain.Comment
+}
+
+func (h *recordingCommentHook) CommentCreated(_ context.Context, post *domain.Post, comment, parent *domain.Comment) {
+	h.post, h.comment, h.parent = post, comment, parent
+}
+
+func (h *recordingCommentHook) CommentUpdated(_ context.Context, comment *domain.Comment) {
+	h.updated = comment
+}
+
+func (h *recordingCommentHook) CommentDeleted(_ context.Context, comment *domain.Comment, removed bool) {
+	h.deleted, h.removed = comment, removed
+}
+
+func (h *recordingCommentHook) CommentRestored(_ context.Context, comment *domain.Comment) {
+	h.restored = comment
+}
+
+func TestCommentService_UpdateAndDeleteHooks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Update", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		hook := &recordingCommentHook{}
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{}, hook)
+
+		repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, PostID: 5, UserID: 2, Content: "Old"}, nil)
+		repo.On("Update", mock.Anything, mock.Anything).Return(&domain.Comment{ID: 1, PostID: 5, UserID: 2, Content: "New"}, nil)
+
+		_, err := service.UpdateComment(ctx, 1, &domain.UpdateCommentRequest{Content: "New"}, 2, false)
+		assert.NoError(t, err)
+		if assert.NotNil(t, hook.updated) {
+			assert.Equal(t, "New", hook.updated.Content)
+		}
+	})
+
+	t.Run("Delete keeps comments with replies", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		hook := &recordingCommentHook{}
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{}, hook)
+
+		repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, PostID: 5, UserID: 2, ModerationStatus: domain.CommentStatusApproved}, nil)
+		repo.On("Lock", mock.Anything, 1).Return(nil)
+		repo.On("HasReplies", mock.Anything, 1).Return(true, nil)
+		repo.On("SoftDelete", mock.Anything, 1, mock.Anything).Return(nil)
+		repo.On("PurgeTombstones", mock.Anything, 5).Return([]domain.Comment{}, nil)
+
+		assert.NoError(t, service.DeleteComment(ctx, 1, 2, false))
+		if assert.NotNil(t, hook.deleted) {
+			assert.Equal(t, 5, hook.deleted.PostID)
+		}
+		assert.False(t, hook.removed)
+	})
+
+	t.Run("Delete removes comments without replies", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		hook := &recordingCommentHook{}
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{}, hook)
+
+		repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, PostID: 5, UserID: 2, ModerationStatus: domain.CommentStatusApproved}, nil)
+		repo.On("Lock", mock.Anything, 1).Return(nil)
+		repo.On("HasReplies", mock.Anything, 1).Return(false, nil)
+		repo.On("HardDelete", mock.Anything, 1).Return(nil)
+
+		assert.NoError(t, service.DeleteComment(ctx, 1, 2, false))
+		assert.NotNil(t, hook.deleted)
+		assert.True(t, hook.removed)
+	})
+
+	t.Run("Delete purges ancestors left without live replies", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		hook := &recordingCommentHook{}
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{}, hook)
+
+		repo.On("GetByID", mock.Anything, 3).Return(&domain.Comment{ID: 3, PostID: 5, UserID: 2, ParentID: intPtr(2), ModerationStatus: domain.CommentStatusApproved}, nil)
+		repo.On("Lock", mock.Anything, 3).Return(nil)
+		repo.On("HasReplies", mock.Anything, 3).Return(false, nil)
+		repo.On("HardDelete", mock.Anything, 3).Return(nil)
+		repo.On("PurgeTombstones", mock.Anything, 5).Return([]domain.Comment{{ID: 2, PostID: 5, ModerationStatus: domain.CommentStatusApproved}}, nil)
+
+		assert.NoError(t, service.DeleteComment(ctx, 3, 2, false))
+		repo.AssertExpectations(t)
+		if assert.NotNil(t, hook.deleted) {
+			assert.Equal(t, 2, hook.deleted.ID)
+		}
+		assert.True(t, hook.removed)
+	})
+}
+
+func TestCommentService_CreateComment_Moderation(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		user       *domain.User
+		approved   bool // whether the user already has an approved comment
+		wantStatus string
+	}{
+		{name: "none publishes right away", policy: domain.ModerationPolicyNone, wantStatus: domain.CommentStatusApproved},
+		{name: "all holds readers", policy: domain.ModerationPolicyAll, user: &domain.User{ID: 2, Role: domain.RoleUser}, wantStatus: domain.CommentStatusPending},
+		{name: "all never holds admins", policy: domain.ModerationPolicyAll, user: &domain.User{ID: 2, Role: domain.RoleAdmin}, wantStatus: domain.CommentStatusApproved},
+		{name: "first comment is held", policy: domain.ModerationPolicyFirstComment, user: &domain.User{ID: 2, Role: domain.RoleUser}, wantStatus: domain.CommentStatusPending},
+		{name: "known author is trusted", policy: domain.ModerationPolicyFirstComment, user: &domain.User{ID: 2, Role: domain.RoleUser}, approved: true, wantStatus: domain.CommentStatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := new(MockPostRepository)
+			commentRepo := new(MockCommentRepository)
+			authRepo := new(MockAuthRepository)
+			postRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+			authRepo.On("GetUserByID", mock.Anything, 2).Return(tt.user, nil).Maybe()
+			commentRepo.On("HasApproved", mock.Anything, 2).Return(tt.approved, nil).Maybe()
+			commentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool {
+				return c.ModerationStatus == tt.wantStatus
+			})).Return(&domain.Comment{ID: 3, PostID: 1, UserID: 2, ModerationStatus: tt.wantStatus}, nil)
+
+			hook := &recordingCommentHook{}
+			service := NewCommentService(commentRepo, postRepo, authRepo, noBans(), passthroughTransactor(), config.Comments{Moderation: tt.policy}, hook)
+			comment, err := service.CreateComment(context.Background(), 1, &domain.CreateCommentRequest{Content: "Hello"}, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, comment.ModerationStatus)
+
+			// Held comments are announced once approved
+			assert.Equal(t, tt.wantStatus == domain.CommentStatusApproved, hook.comment != nil)
+			commentRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("cannot reply to a held comment", func(t *testing.T) {
+		postRepo := new(MockPostRepository)
+		commentRepo := new(MockCommentRepository)
+		postRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+		commentRepo.On("GetByID", mock.Anything, 10).Return(&domain.Comment{ID: 10, PostID: 1, ModerationStatus: domain.CommentStatusPending}, nil)
+
+		service := NewCommentService(commentRepo, postRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+		_, err := service.CreateComment(context.Background(), 1, &domain.CreateCommentRequest{Content: "Hi", ParentID: intPtr(10)}, 2)
+		assert.ErrorContains(t, err, "parent comment not found")
+		commentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCommentService_UpdateComment_EditWindow(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Comments{EditWindow: time.Hour}
+
+	t.Run("refused after the window", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, UserID: 2, Content: "Old", CreatedAt: time.Now().Add(-2 * time.Hour)}, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg)
+		_, err := service.UpdateComment(ctx, 1, &domain.UpdateCommentRequest{Content: "New"}, 2, false)
+		assert.ErrorIs(t, err, derr.ErrPermission)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("allowed within the window", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, UserID: 2, Content: "Old", CreatedAt: time.Now().Add(-time.Minute)}, nil)
+		repo.On("Update", mock.Anything, mock.Anything).Return(&domain.Comment{ID: 1, UserID: 2, Content: "New", Edited: true, EditCount: 1}, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg)
+		comment, err := service.UpdateComment(ctx, 1, &domain.UpdateCommentRequest{Content: "New"}, 2, false)
+		assert.NoError(t, err)
+		assert.True(t, comment.Edited)
+	})
+
+	t.Run("unchanged content is not an edit", func(t *testing.T) {
+		repo := new(MockCommentRepository)
+		repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, UserID: 2, Content: "Same", CreatedAt: time.Now()}, nil)
+
+		service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), cfg)
+		comment, err := service.UpdateComment(ctx, 1, &domain.UpdateCommentRequest{Content: "Same"}, 2, false)
+		assert.NoError(t, err)
+		assert.False(t, comment.Edited)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCommentService_GetCommentRevisions(t *testing.T) {
+	ctx := context.Background()
+
+	repo := new(MockCommentRepository)
+	repo.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1, EditCount: 1}, nil)
+	repo.On("GetByID", mock.Anything, 2).Return(nil, nil)
+	repo.On("ListRevisions", mock.Anything, 1).Return([]domain.CommentRevision{{ID: 5, CommentID: 1, Content: "First version"}}, nil)
+
+	service := NewCommentService(repo, new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+	revisions, err := service.GetCommentRevisions(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+
+	_, err = service.GetCommentRevisions(ctx, 2)
+	assert.ErrorIs(t, err, derr.ErrNotFound)
+}
+
 func TestCommentService_UpdateComment(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -285,7 +702,7 @@ func TestCommentService_UpdateComment(t *testing.T) {
 			mockPostRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewCommentService(mockRepo, mockPostRepo)
+			service := NewCommentService(mockRepo, mockPostRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
 			comment, err := service.UpdateComment(context.Background(), tt.commentID, tt.request, tt.userID, tt.isAdmin)
 
 			if tt.wantErr {
@@ -326,6 +743,7 @@ func TestCommentService_DeleteComment(t *testing.T) {
 					ID:     1,
 					UserID: 1,
 				}, nil)
+				m.On("Lock", mock.Anything, 1).Return(nil)
 				m.On("HasReplies", mock.Anything, 1).Return(false, nil)
 				m.On("HardDelete", mock.Anything, 1).Return(nil)
 			},
@@ -342,6 +760,7 @@ func TestCommentService_DeleteComment(t *testing.T) {
 					ID:     1,
 					UserID: 1,
 				}, nil)
+				m.On("Lock", mock.Anything, 1).Return(nil)
 				m.On("HasReplies", mock.Anything, 1).Return(false, nil)
 				m.On("HardDelete", mock.Anything, 1).Return(nil)
 			},
@@ -358,8 +777,10 @@ func TestCommentService_DeleteComment(t *testing.T) {
 					ID:     1,
 					UserID: 1,
 				}, nil)
+				m.On("Lock", mock.Anything, 1).Return(nil)
 				m.On("HasReplies", mock.Anything, 1).Return(true, nil)
 				m.On("SoftDelete", mock.Anything, 1, "Содержимое удалено.").Return(nil)
+				m.On("PurgeTombstones", mock.Anything, 0).Return([]domain.Comment{}, nil)
 			},
 			wantErr: false,
 		},
@@ -412,7 +833,7 @@ func TestCommentService_DeleteComment(t *testing.T) {
 			mockPostRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewCommentService(mockRepo, mockPostRepo)
+			service := NewCommentService(mockRepo, mockPostRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
 			err := service.DeleteComment(context.Background(), tt.commentID, tt.userID, tt.isAdmin)
 
 			if tt.wantErr {
@@ -476,7 +897,7 @@ func TestCommentService_GetCommentByID(t *testing.T) {
 			mockPostRepo := new(MockPostRepository)
 			tt.setupMock(mockCommentRepo)
 
-			service := NewCommentService(mockCommentRepo, mockPostRepo)
+			service := NewCommentService(mockCommentRepo, mockPostRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
 			comment, err := service.GetCommentByID(context.Background(), tt.commentID)
 
 			if tt.wantErr {
@@ -496,6 +917,12 @@ func TestCommentService_GetCommentByID(t *testing.T) {
 	}
 }
 
+func TestCommentService_GetCommentsByPostSlug_UnknownSort(t *testing.T) {
+	service := NewCommentService(new(MockCommentRepository), new(MockPostRepository), new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+	_, err := service.GetCommentsByPostSlug(context.Background(), "test-post", 0, false, "random")
+	assert.ErrorIs(t, err, derr.ErrValidation)
+}
+
 func TestCommentService_GetCommentsByPostSlug(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -516,14 +943,14 @@ func TestCommentService_GetCommentsByPostSlug(t *testing.T) {
 				}, nil)
 			},
 			setupCommentMock: func(m *MockCommentRepository) {
-				m.On("GetByPostID", mock.Anything, 1, 0).Return([]domain.Comment{
+				m.On("GetByPostID", mock.Anything, 1, 0, false).Return([]domain.Comment{
 					{ID: 1, PostID: 1, UserID: 1, Content: "First comment"},
 					{ID: 2, PostID: 1, UserID: 2, Content: "Second comment"},
 					{ID: 3, PostID: 1, UserID: 1, Content: "Reply to first", ParentID: intPtr(1)},
 				}, nil)
 			},
 			wantErr:       false,
-			expectedCount: 3,
+			expectedCount: 2, // the reply is in the first thread
 		},
 		{
 			name:     "success - no comments for post",
@@ -535,7 +962,7 @@ func TestCommentService_GetCommentsByPostSlug(t *testing.T) {
 				}, nil)
 			},
 			setupCommentMock: func(m *MockCommentRepository) {
-				m.On("GetByPostID", mock.Anything, 2, 0).Return([]domain.Comment{}, nil)
+				m.On("GetByPostID", mock.Anything, 2, 0, false).Return([]domain.Comment{}, nil)
 			},
 			wantErr:       false,
 			expectedCount: 0,
@@ -570,7 +997,7 @@ func TestCommentService_GetCommentsByPostSlug(t *testing.T) {
 				}, nil)
 			},
 			setupCommentMock: func(m *MockCommentRepository) {
-				m.On("GetByPostID", mock.Anything, 1, 0).Return([]domain.Comment{}, errors.New("database error"))
+				m.On("GetByPostID", mock.Anything, 1, 0, false).Return([]domain.Comment{}, errors.New("database error"))
 			},
 			wantErr:     true,
 			errContains: "failed to get comments",
@@ -584,8 +1011,8 @@ func TestCommentService_GetCommentsByPostSlug(t *testing.T) {
 			tt.setupPostMock(mockPostRepo)
 			tt.setupCommentMock(mockCommentRepo)
 
-			service := NewCommentService(mockCommentRepo, mockPostRepo)
-			comments, err := service.GetCommentsByPostSlug(context.Background(), tt.postSlug, 0)
+			service := NewCommentService(mockCommentRepo, mockPostRepo, new(MockAuthRepository), noBans(), passthroughTransactor(), config.Comments{})
+			comments, err := service.GetCommentsByPostSlug(context.Background(), tt.postSlug, 0, false, "")
 
 			if tt.wantErr {
 				assert.Error(t, err)
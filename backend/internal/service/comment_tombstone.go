@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+)
+
+func (s *commentService) PurgeTombstones(ctx context.Context) (int, error) {
+	purged, err := s.commentRepo.PurgeTombstones(ctx, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted comments: %w", err)
+	}
+
+	s.tombstonesPurged(ctx, purged)
+	return len(purged), nil
+}
+
+// tombstonesPurged tells hooks that deleted comments are gone from their threads
+func (s *commentService) tombstonesPurged(ctx context.Context, purged []domain.Comment) {
+	for i := range purged {
+		// Readers never saw held comments
+		if purged[i].ModerationStatus == domain.CommentStatusApproved {
+			s.commentDeleted(ctx, &purged[i], true)
+		}
+	}
+}
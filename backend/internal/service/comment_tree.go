@@ -0,0 +1,88 @@
+package service
+
+import (
+	"sort"
+
+	"personal-web-platform/internal/domain"
+)
+
+// commentTree arranges the comments of a post, oldest first, into threads.
+// Replies nested deeper than maxDepth are listed under their deepest allowed
+// ancestor, replies to a comment beyond collapseAfter are marked collapsed.
+// Zero limits mean no limit. Replies whose parent is not shown are dropped.
+func commentTree(comments []domain.Comment, order string, maxDepth, collapseAfter int) []domain.Comment {
+	nodes := make(map[int]*domain.Comment, len(comments))
+	for i := range comments {
+		comment := &comments[i]
+		comment.Replies = []*domain.Comment{}
+		nodes[comment.ID] = comment
+	}
+
+	// Where each reply is shown and at which depth, -1 when its thread is not shown
+	parents := make(map[int]*domain.Comment, len(comments))
+	depths := make(map[int]int, len(comments))
+	var place func(comment *domain.Comment) int
+	place = func(comment *domain.Comment) int {
+		if depth, ok := depths[comment.ID]; ok {
+			return depth
+		}
+		depths[comment.ID] = -1 // also guards against cycles
+		if comment.ParentID == nil {
+			depths[comment.ID] = 0
+			return 0
+		}
+
+		parent, ok := nodes[*comment.ParentID]
+		if !ok || place(parent) < 0 {
+			return -1
+		}
+		// Too deep, reply to the parent's parent instead
+		if maxDepth > 0 && depths[parent.ID] >= maxDepth {
+			parent = parents[parent.ID]
+		}
+		parents[comment.ID] = parent
+		depths[comment.ID] = depths[parent.ID] + 1
+		return depths[comment.ID]
+	}
+
+	// Replies are appended in creation order
+	var roots []*domain.Comment
+	for i := range comments {
+		comment := &comments[i]
+		switch {
+		case place(comment) < 0:
+			// The parent is not shown
+		case comment.ParentID == nil:
+			roots = append(roots, comment)
+		default:
+			parent := parents[comment.ID]
+			parent.Replies = append(parent.Replies, comment)
+		}
+	}
+
+	threads := make([]domain.Comment, 0, len(roots))
+	for _, root := range roots {
+		countReplies(root, collapseAfter)
+		threads = append(threads, *root)
+	}
+
+	switch order {
+	case domain.CommentSortNewest:
+		sort.SliceStable(threads, func(i, j int) bool { return threads[i].CreatedAt.After(threads[j].CreatedAt) })
+	case domain.CommentSortTop:
+		sort.SliceStable(threads, func(i, j int) bool { return threads[i].LikesCount > threads[j].LikesCount })
+	}
+
+	return threads
+}
+
+// countReplies sets RepliesCount through a thread and collapses replies
+// beyond collapseAfter, it returns the number of replies
+func countReplies(comment *domain.Comment, collapseAfter int) int {
+	comment.RepliesCount = 0
+	for i, reply := range comment.Replies {
+		reply.Collapsed = collapseAfter > 0 && i >= collapseAfter
+		comment.RepliesCount += 1 + countReplies(reply, collapseAfter)
+	}
+	return comment.RepliesCount
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// threadFixture is a post discussion, oldest first:
+//
+//	1 (2 likes)
+//	├── 3
+//	│   └── 4
+//	│       └── 5
+//	├── 6
+//	└── 7
+//	2 (5 likes)
+//	8 reply to a hidden comment
+func threadFixture() []domain.Comment {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	comment := func(id int, parentID *int, likes int) domain.Comment {
+		return domain.Comment{ID: id, ParentID: parentID, LikesCount: likes, CreatedAt: start.Add(time.Duration(id) * time.Minute)}
+	}
+	return []domain.Comment{
+		comment(1, nil, 2),
+		comment(2, nil, 5),
+		comment(3, intPtr(1), 0),
+		comment(4, intPtr(3), 0),
+		comment(5, intPtr(4), 0),
+		comment(6, intPtr(1), 0),
+		comment(7, intPtr(1), 0),
+		comment(8, intPtr(99), 0),
+	}
+}
+
+func replyIDs(comment *domain.Comment) []int {
+	ids := make([]int, 0, len(comment.Replies))
+	for _, reply := range comment.Replies {
+		ids = append(ids, reply.ID)
+	}
+	return ids
+}
+
+func TestCommentTree(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		threads := commentTree(threadFixture(), domain.CommentSortOldest, 0, 0)
+		require.Len(t, threads, 2)
+		assert.Equal(t, 1, threads[0].ID)
+		assert.Equal(t, []int{3, 6, 7}, replyIDs(&threads[0]))
+		assert.Equal(t, []int{5}, replyIDs(threads[0].Replies[0].Replies[0]))
+		assert.Equal(t, 5, threads[0].RepliesCount)
+		assert.Equal(t, 2, threads[0].Replies[0].RepliesCount)
+		assert.Zero(t, threads[1].RepliesCount)
+	})
+
+	t.Run("deep replies are flattened", func(t *testing.T) {
+		threads := commentTree(threadFixture(), domain.CommentSortOldest, 2, 0)
+		reply := threads[0].Replies[0]
+		assert.Equal(t, []int{4, 5}, replyIDs(reply))
+		assert.Equal(t, 4, *reply.Replies[1].ParentID, "the reply keeps its real parent")
+		assert.Equal(t, 5, threads[0].RepliesCount)
+	})
+
+	t.Run("many replies are collapsed", func(t *testing.T) {
+		threads := commentTree(threadFixture(), domain.CommentSortOldest, 0, 2)
+		replies := threads[0].Replies
+		assert.False(t, replies[0].Collapsed)
+		assert.False(t, replies[1].Collapsed)
+		assert.True(t, replies[2].Collapsed)
+	})
+
+	t.Run("order", func(t *testing.T) {
+		assert.Equal(t, 2, commentTree(threadFixture(), domain.CommentSortNewest, 0, 0)[0].ID)
+		assert.Equal(t, 2, commentTree(threadFixture(), domain.CommentSortTop, 0, 0)[0].ID)
+		assert.Empty(t, commentTree(nil, domain.CommentSortOldest, 0, 0))
+	})
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/repository"
+)
+
+// CounterService defines methods for keeping like and comment counts right
+type CounterService interface {
+	// Reconcile finds counts that differ from the likes and comments they
+	// count and, unless dryRun is set, recomputes them
+	Reconcile(ctx context.Context, dryRun bool) (*domain.CounterReport, error)
+}
+
+type counterService struct {
+	counterRepo repository.CounterRepository
+	transactor  repository.Transactor
+	log         *slog.Logger
+}
+
+// NewCounterService creates a new counter service implementation
+func NewCounterService(counterRepo repository.CounterRepository, transactor repository.Transactor, log *slog.Logger) CounterService {
+	return &counterService{
+		counterRepo: counterRepo,
+		transactor:  transactor,
+		log:         log,
+	}
+}
+
+func (s *counterService) Reconcile(ctx context.Context, dryRun bool) (*domain.CounterReport, error) {
+	drift, err := s.counterRepo.ListDrift(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find counter drift: %w", err)
+	}
+
+	report := &domain.CounterReport{Drift: drift}
+	if dryRun {
+		return report, nil
+	}
+
+	// One row at a time, so that likes and comments on other rows are not held up
+	for i := range report.Drift {
+		d := &report.Drift[i]
+		err := s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+			actual, err := s.counterRepo.Recount(ctx, d.Counter, d.ID)
+			if err != nil {
+				return err
+			}
+			d.Actual = actual
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fix counter drift: %w", err)
+		}
+
+		s.log.Warn("fixed counter drift",
+			slog.String("counter", d.Counter), slog.Int("id", d.ID), slog.Int("stored", d.Stored), slog.Int("actual", d.Actual))
+	}
+	report.Fixed = true
+
+	return report, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockCounterRepository struct {
+	mock.Mock
+}
+
+func (m *MockCounterRepository) ListDrift(ctx context.Context) ([]domain.CounterDrift, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CounterDrift), args.Error(1)
+}
+
+func (m *MockCounterRepository) Recount(ctx context.Context, counter string, id int) (int, error) {
+	args := m.Called(ctx, counter, id)
+	return args.Int(0), args.Error(1)
+}
+
+func TestCounterService_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	drift := func() []domain.CounterDrift {
+		return []domain.CounterDrift{
+			{Counter: domain.CounterPostLikes, ID: 1, Stored: 5, Actual: 4},
+			{Counter: domain.CounterCommentLikes, ID: 7, Stored: 0, Actual: 2},
+		}
+	}
+
+	t.Run("fixes every drifted counter", func(t *testing.T) {
+		repo := new(MockCounterRepository)
+		repo.On("ListDrift", mock.Anything).Return(drift(), nil)
+		repo.On("Recount", mock.Anything, domain.CounterPostLikes, 1).Return(4, nil)
+		// A like arrived since the drift was found
+		repo.On("Recount", mock.Anything, domain.CounterCommentLikes, 7).Return(3, nil)
+
+		report, err := NewCounterService(repo, passthroughTransactor(), log).Reconcile(ctx, false)
+		require.NoError(t, err)
+		assert.True(t, report.Fixed)
+		assert.Equal(t, 3, report.Drift[1].Actual)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("dry run only reports", func(t *testing.T) {
+		repo := new(MockCounterRepository)
+		repo.On("ListDrift", mock.Anything).Return(drift(), nil)
+
+		report, err := NewCounterService(repo, passthroughTransactor(), log).Reconcile(ctx, true)
+		require.NoError(t, err)
+		assert.False(t, report.Fixed)
+		assert.Len(t, report.Drift, 2)
+		repo.AssertNotCalled(t, "Recount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("recount failure", func(t *testing.T) {
+		repo := new(MockCounterRepository)
+		repo.On("ListDrift", mock.Anything).Return(drift(), nil)
+		repo.On("Recount", mock.Anything, domain.CounterPostLikes, 1).Return(0, errors.New("db down"))
+
+		_, err := NewCounterService(repo, passthroughTransactor(), log).Reconcile(ctx, false)
+		assert.Error(t, err)
+	})
+}
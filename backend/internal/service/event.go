@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/pubsub"
+	"personal-web-platform/internal/repository"
+)
+
+const (
+	// eventBacklogLimit is the most events replayed to a resuming reader, more means reset
+	eventBacklogLimit = 100
+	// eventSubscriberBuffer is how far a reader may fall behind before its stream is closed
+	eventSubscriberBuffer = 64
+	// eventListenMaxBackoff caps the delay between LISTEN reconnects
+	eventListenMaxBackoff = 30 * time.Second
+)
+
+// EventService defines methods for live updates of post discussions
+type EventService interface {
+	// Subscribe streams the events of a post. A reader resuming after
+	// lastEventID first gets what it missed. The channel is closed when ctx is
+	// done or the reader falls too far behind.
+	Subscribe(ctx context.Context, slug string, lastEventID int64) (<-chan domain.PostEvent, error)
+	// Listen feeds events published by every instance to this instance's
+	// streams using LISTEN/NOTIFY, until ctx is done
+	Listen(ctx context.Context)
+	// Cleanup deletes events older than the retention period
+	Cleanup(ctx context.Context) (int64, error)
+	// Close ends all streams, on server shutdown
+	Close()
+
+	// Comment and like hooks publish the change
+	CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment)
+	CommentUpdated(ctx context.Context, comment *domain.Comment)
+	CommentDeleted(ctx context.Context, comment *domain.Comment, removed bool)
+	CommentRestored(ctx context.Context, comment *domain.Comment)
+	PostLikesChanged(ctx context.Context, postID, count int)
+	CommentLikesChanged(ctx context.Context, comment *domain.Comment, count int)
+}
+
+type eventService struct {
+	eventRepo   repository.EventRepository
+	postRepo    repository.PostRepository
+	commentRepo repository.CommentRepository
+	broker      *pubsub.Broker[int, domain.PostEvent]
+	cfg         config.Events
+	log         *slog.Logger
+}
+
+// NewEventService creates a new event service implementation
+func NewEventService(eventRepo repository.EventRepository, postRepo repository.PostRepository, commentRepo repository.CommentRepository, cfg config.Events, log *slog.Logger) EventService {
+	return &eventService{
+		eventRepo:   eventRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		broker:      pubsub.NewBroker[int, domain.PostEvent](eventSubscriberBuffer),
+		cfg:         cfg,
+		log:         log,
+	}
+}
+
+func (s *eventService) Subscribe(ctx context.Context, slug string, lastEventID int64) (<-chan domain.PostEvent, error) {
+	post, err := s.postRepo.GetBySlug(ctx, slug, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	// Drafts are not public, neither are their discussions
+	if post == nil || post.DeletedAt != nil || !post.Published {
+		return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	// Subscribe before reading the backlog so that nothing is missed in between
+	sub := s.broker.Subscribe(post.ID)
+
+	var backlog []domain.PostEvent
+	if lastEventID > 0 {
+		backlog, err = s.backlog(ctx, post.ID, lastEventID)
+		if err != nil {
+			sub.Close()
+			return nil, err
+		}
+	}
+
+	events := make(chan domain.PostEvent)
+	go s.stream(ctx, sub, backlog, lastEventID, events)
+	return events, nil
+}
+
+// backlog returns the events a resuming reader missed, or a reset event
+// when there are too many of them
+func (s *eventService) backlog(ctx context.Context, postID int, lastEventID int64) ([]domain.PostEvent, error) {
+	missed, err := s.eventRepo.ListAfter(ctx, postID, lastEventID, eventBacklogLimit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missed events: %w", err)
+	}
+	if len(missed) <= eventBacklogLimit {
+		return missed, nil
+	}
+
+	latest, err := s.eventRepo.LatestID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest event: %w", err)
+	}
+	return []domain.PostEvent{{
+		ID:        latest,
+		PostID:    postID,
+		Type:      domain.PostEventReset,
+		Data:      json.RawMessage(`{}`),
+		CreatedAt: time.Now(),
+	}}, nil
+}
+
+// stream sends the backlog and then live events, skipping live events
+// already sent as part of the backlog.
+//
+// Event IDs come from a sequence, so they follow insertion order, not commit
+// order: an event stored by a longer transaction may become visible after
+// one with a higher ID. Such an event is skipped here and is not replayed on
+// resume either. This is accepted since events only refresh what the page
+// shows; the reader catches up with the next change or a reload.
+func (s *eventService) stream(ctx context.Context, sub *pubsub.Subscription[int, domain.PostEvent], backlog []domain.PostEvent, lastID int64, out chan<- domain.PostEvent) {
+	defer close(out)
+	defer sub.Close()
+
+	send := func(event domain.PostEvent) bool {
+		select {
+		case out <- event:
+			lastID = event.ID
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for _, event := range backlog {
+		if !send(event) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.C():
+			if !ok {
+				return
+			}
+			if event.ID <= lastID {
+				continue
+			}
+			if !send(event) {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *eventService) Listen(ctx context.Context) {
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := s.eventRepo.Listen(ctx, func(postID int, id int64) {
+			// Most posts have nobody reading them live
+			if s.broker.Subscribers(postID) == 0 {
+				return
+			}
+			event, err := s.eventRepo.GetByID(ctx, id)
+			if err != nil {
+				s.log.Error("failed to get post event", slog.Int64("event_id", id), slog.String("error", err.Error()))
+				return
+			}
+			if event != nil {
+				s.broker.Publish(event.PostID, *event)
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Events published while reconnecting are replayed when readers reconnect with Last-Event-ID
+		if time.Since(started) > eventListenMaxBackoff {
+			backoff = time.Second
+		}
+		s.log.Error("post events listener stopped, reconnecting",
+			slog.String("error", err.Error()), slog.Duration("backoff", backoff))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, eventListenMaxBackoff)
+	}
+}
+
+func (s *eventService) Cleanup(ctx context.Context) (int64, error) {
+	deleted, err := s.eventRepo.DeleteBefore(ctx, time.Now().Add(-s.cfg.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old events: %w", err)
+	}
+	return deleted, nil
+}
+
+func (s *eventService) Close() {
+	s.broker.Close()
+}
+
+func (s *eventService) CommentCreated(ctx context.Context, _ *domain.Post, comment, _ *domain.Comment) {
+	s.publishComment(ctx, domain.PostEventCommentCreated, comment.ID)
+}
+
+func (s *eventService) CommentUpdated(ctx context.Context, comment *domain.Comment) {
+	s.publishComment(ctx, domain.PostEventCommentUpdated, comment.ID)
+}
+
+func (s *eventService) CommentRestored(ctx context.Context, comment *domain.Comment) {
+	s.publishComment(ctx, domain.PostEventCommentUpdated, comment.ID)
+}
+
+func (s *eventService) CommentDeleted(ctx context.Context, comment *domain.Comment, removed bool) {
+	s.publish(ctx, comment.PostID, domain.PostEventCommentDeleted, domain.CommentDeletedEvent{
+		ID:      comment.ID,
+		PostID:  comment.PostID,
+		Removed: removed,
+	})
+}
+
+func (s *eventService) PostLikesChanged(ctx context.Context, postID, count int) {
+	s.publish(ctx, postID, domain.PostEventLikesUpdated, domain.LikesUpdatedEvent{
+		PostID:     postID,
+		LikesCount: count,
+	})
+}
+
+func (s *eventService) CommentLikesChanged(ctx context.Context, comment *domain.Comment, count int) {
+	s.publish(ctx, comment.PostID, domain.PostEventLikesUpdated, domain.LikesUpdatedEvent{
+		PostID:     comment.PostID,
+		CommentID:  &comment.ID,
+		LikesCount: count,
+	})
+}
+
+// publishComment publishes a comment as the comment list shows it, with its author
+func (s *eventService) publishComment(ctx context.Context, eventType string, commentID int) {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		s.log.Error("failed to get comment for event", slog.Int("comment_id", commentID), slog.String("error", err.Error()))
+		return
+	}
+	// Comments held back by moderation are not shown to readers
+	if comment == nil || comment.ModerationStatus != domain.CommentStatusApproved {
+		return
+	}
+	s.publish(ctx, comment.PostID, eventType, comment)
+}
+
+// publish stores an event and delivers it to this instance's readers, or
+// with LISTEN/NOTIFY to the readers of every instance once the surrounding
+// transaction commits
+func (s *eventService) publish(ctx context.Context, postID int, eventType string, data any) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		s.log.Error("failed to encode post event", slog.String("type", eventType), slog.String("error", err.Error()))
+		return
+	}
+
+	event := &domain.PostEvent{PostID: postID, Type: eventType, Data: raw}
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		s.log.Error("failed to store post event",
+			slog.Int("post_id", postID), slog.String("type", eventType), slog.String("error", err.Error()))
+		return
+	}
+
+	if !s.cfg.Notify {
+		s.broker.Publish(postID, *event)
+	}
+}
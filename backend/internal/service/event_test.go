@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockEventRepository is a mock implementation of EventRepository
+type MockEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockEventRepository) Create(ctx context.Context, event *domain.PostEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockEventRepository) GetByID(ctx context.Context, id int64) (*domain.PostEvent, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.PostEvent), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockEventRepository) ListAfter(ctx context.Context, postID int, afterID int64, limit int) ([]domain.PostEvent, error) {
+	args := m.Called(ctx, postID, afterID, limit)
+	return args.Get(0).([]domain.PostEvent), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockEventRepository) LatestID(ctx context.Context, postID int) (int64, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(int64), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockEventRepository) Listen(ctx context.Context, handle func(postID int, id int64)) error {
+	args := m.Called(ctx, handle)
+	return args.Error(0)
+}
+
+type eventFixture struct {
+	service     *eventService
+	eventRepo   *MockEventRepository
+	postRepo    *MockPostRepository
+	commentRepo *MockCommentRepository
+}
+
+func newEventFixture(notify bool) *eventFixture {
+	f := &eventFixture{
+		eventRepo:   new(MockEventRepository),
+		postRepo:    new(MockPostRepository),
+		commentRepo: new(MockCommentRepository),
+	}
+	cfg := config.Events{Enabled: true, Notify: notify, Retention: 24 * time.Hour}
+	f.service = NewEventService(f.eventRepo, f.postRepo, f.commentRepo, cfg, slog.New(slog.NewTextHandler(io.Discard, nil))).(*eventService)
+	f.postRepo.On("GetBySlug", mock.Anything, "hello", 0).Return(&domain.Post{ID: 1, Slug: "hello", Published: true}, nil)
+	return f
+}
+
+// expectCreate numbers stored events from firstID on
+func (f *eventFixture) expectCreate(firstID int64) {
+	next := firstID
+	f.eventRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.PostEvent).ID = next
+		next++
+	}).Return(nil)
+}
+
+func receive(t *testing.T, events <-chan domain.PostEvent) domain.PostEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "stream closed")
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+		return domain.PostEvent{}
+	}
+}
+
+func TestEventService_Subscribe(t *testing.T) {
+	t.Run("Live events", func(t *testing.T) {
+		f := newEventFixture(false)
+		f.expectCreate(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := f.service.Subscribe(ctx, "hello", 0)
+		require.NoError(t, err)
+
+		f.service.PostLikesChanged(context.Background(), 1, 3)
+
+		event := receive(t, events)
+		assert.Equal(t, int64(10), event.ID)
+		assert.Equal(t, domain.PostEventLikesUpdated, event.Type)
+		assert.JSONEq(t, `{"post_id":1,"likes_count":3}`, string(event.Data))
+
+		cancel()
+		for range events {
+		}
+	})
+
+	t.Run("Resume replays missed events once", func(t *testing.T) {
+		f := newEventFixture(false)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		f.eventRepo.On("ListAfter", mock.Anything, 1, int64(4), eventBacklogLimit+1).Return([]domain.PostEvent{
+			{ID: 5, PostID: 1, Type: domain.PostEventCommentCreated},
+			{ID: 6, PostID: 1, Type: domain.PostEventCommentUpdated},
+		}, nil)
+
+		events, err := f.service.Subscribe(ctx, "hello", 4)
+		require.NoError(t, err)
+
+		// Published while the backlog was read, already part of it
+		f.service.broker.Publish(1, domain.PostEvent{ID: 6, PostID: 1})
+		f.service.broker.Publish(1, domain.PostEvent{ID: 7, PostID: 1})
+
+		assert.Equal(t, int64(5), receive(t, events).ID)
+		assert.Equal(t, int64(6), receive(t, events).ID)
+		assert.Equal(t, int64(7), receive(t, events).ID)
+	})
+
+	t.Run("Too many missed events reset the reader", func(t *testing.T) {
+		f := newEventFixture(false)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		missed := make([]domain.PostEvent, eventBacklogLimit+1)
+		f.eventRepo.On("ListAfter", mock.Anything, 1, int64(4), eventBacklogLimit+1).Return(missed, nil)
+		f.eventRepo.On("LatestID", mock.Anything, 1).Return(int64(300), nil)
+
+		events, err := f.service.Subscribe(ctx, "hello", 4)
+		require.NoError(t, err)
+
+		event := receive(t, events)
+		assert.Equal(t, domain.PostEventReset, event.Type)
+		assert.Equal(t, int64(300), event.ID)
+	})
+
+	t.Run("Post Not Found", func(t *testing.T) {
+		f := newEventFixture(false)
+		f.postRepo.On("GetBySlug", mock.Anything, "missing", 0).Return(nil, nil)
+
+		_, err := f.service.Subscribe(context.Background(), "missing", 0)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Draft post", func(t *testing.T) {
+		f := newEventFixture(false)
+		f.postRepo.On("GetBySlug", mock.Anything, "draft", 0).Return(&domain.Post{ID: 2, Slug: "draft"}, nil)
+
+		_, err := f.service.Subscribe(context.Background(), "draft", 0)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Close ends streams", func(t *testing.T) {
+		f := newEventFixture(false)
+
+		events, err := f.service.Subscribe(context.Background(), "hello", 0)
+		require.NoError(t, err)
+
+		f.service.Close()
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+}
+
+func TestEventService_Publish(t *testing.T) {
+	t.Run("Notify mode leaves delivery to the listener", func(t *testing.T) {
+		f := newEventFixture(true)
+		f.expectCreate(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := f.service.Subscribe(ctx, "hello", 0)
+		require.NoError(t, err)
+
+		f.service.CommentDeleted(context.Background(), &domain.Comment{ID: 3, PostID: 1}, true)
+		f.service.broker.Publish(1, domain.PostEvent{ID: 11, PostID: 1})
+
+		assert.Equal(t, int64(11), receive(t, events).ID)
+		f.eventRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("Comments carry their author", func(t *testing.T) {
+		f := newEventFixture(false)
+		f.expectCreate(10)
+
+		f.commentRepo.On("GetByID", mock.Anything, 3).Return(&domain.Comment{
+			ID: 3, PostID: 1, Content: "Hi", ModerationStatus: domain.CommentStatusApproved,
+			User: &domain.User{ID: 2, Name: "Bob"},
+		}, nil)
+
+		f.service.CommentCreated(context.Background(), &domain.Post{ID: 1}, &domain.Comment{ID: 3, PostID: 1}, nil)
+
+		event := f.eventRepo.Calls[0].Arguments.Get(1).(*domain.PostEvent)
+		assert.Equal(t, domain.PostEventCommentCreated, event.Type)
+		var comment domain.Comment
+		require.NoError(t, json.Unmarshal(event.Data, &comment))
+		assert.Equal(t, "Bob", comment.User.Name)
+	})
+
+	t.Run("Restored comments are published as updated", func(t *testing.T) {
+		f := newEventFixture(false)
+		f.expectCreate(10)
+
+		f.commentRepo.On("GetByID", mock.Anything, 3).Return(&domain.Comment{
+			ID: 3, PostID: 1, ModerationStatus: domain.CommentStatusApproved,
+		}, nil)
+
+		f.service.CommentRestored(context.Background(), &domain.Comment{ID: 3, PostID: 1})
+
+		event := f.eventRepo.Calls[0].Arguments.Get(1).(*domain.PostEvent)
+		assert.Equal(t, domain.PostEventCommentUpdated, event.Type)
+	})
+
+	t.Run("Held back comments are not published", func(t *testing.T) {
+		f := newEventFixture(false)
+
+		f.commentRepo.On("GetByID", mock.Anything, 3).Return(&domain.Comment{
+			ID: 3, PostID: 1, ModerationStatus: domain.CommentStatusSpam,
+		}, nil)
+
+		f.service.CommentUpdated(context.Background(), &domain.Comment{ID: 3, PostID: 1})
+
+		f.eventRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEventService_Listen(t *testing.T) {
+	f := newEventFixture(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := f.service.Subscribe(context.Background(), "hello", 0)
+	require.NoError(t, err)
+	defer f.service.Close()
+
+	f.eventRepo.On("GetByID", mock.Anything, int64(12)).Return(&domain.PostEvent{ID: 12, PostID: 1}, nil)
+	f.eventRepo.On("Listen", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		handle := args.Get(1).(func(postID int, id int64))
+		// Nobody reads post 2 here, its event is not loaded
+		handle(2, 11)
+		handle(1, 12)
+		cancel()
+	}).Return(context.Canceled)
+
+	f.service.Listen(ctx)
+
+	assert.Equal(t, int64(12), receive(t, events).ID)
+	f.eventRepo.AssertNotCalled(t, "GetByID", mock.Anything, int64(11))
+}
+
+func TestEventService_Cleanup(t *testing.T) {
+	f := newEventFixture(false)
+	f.eventRepo.On("DeleteBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour
+	})).Return(int64(5), nil)
+
+	deleted, err := f.service.Cleanup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/validator"
 	"personal-web-platform/internal/repository"
 )
 
@@ -13,30 +16,51 @@ type LikeService interface {
 	TogglePostLike(ctx context.Context, userID, postID int) (bool, error)
 	GetPostLikesCount(ctx context.Context, postID int) (int, error)
 	IsPostLikedByUser(ctx context.Context, userID, postID int) (bool, error)
+	SetPostLike(ctx context.Context, userID, postID int, liked bool) (*domain.LikeStatus, error)
 
 	// Comment likes
 	ToggleCommentLike(ctx context.Context, userID, commentID int) (bool, error)
 	GetCommentLikesCount(ctx context.Context, commentID int) (int, error)
 	IsCommentLikedByUser(ctx context.Context, userID, commentID int) (bool, error)
+	SetCommentLike(ctx context.Context, userID, commentID int, liked bool) (*domain.LikeStatus, error)
+
+	// Liked by lists
+	ListPostLikers(ctx context.Context, postID int, req *domain.LikesRequest) (*domain.LikersResponse, error)
+	ListCommentLikers(ctx context.Context, commentID int, req *domain.LikesRequest) (*domain.LikersResponse, error)
+	ListUserLikes(ctx context.Context, userID int, req *domain.LikesRequest) (*domain.LikedItemsResponse, error)
+}
+
+// LikesChangedHook is notified with the new like count after a like is toggled.
+type LikesChangedHook interface {
+	PostLikesChanged(ctx context.Context, postID, count int)
+	CommentLikesChanged(ctx context.Context, comment *domain.Comment, count int)
 }
 
 type likeService struct {
 	repo     repository.LikeRepository
 	postRepo repository.PostRepository
 	commRepo repository.CommentRepository
+	banRepo  repository.BanRepository
+	hooks    []LikesChangedHook
 }
 
 // NewLikeService creates a new LikeService instance.
-func NewLikeService(repo repository.LikeRepository, postRepo repository.PostRepository, commRepo repository.CommentRepository) LikeService {
+func NewLikeService(repo repository.LikeRepository, postRepo repository.PostRepository, commRepo repository.CommentRepository, banRepo repository.BanRepository, hooks ...LikesChangedHook) LikeService {
 	return &likeService{
 		repo:     repo,
 		postRepo: postRepo,
 		commRepo: commRepo,
+		banRepo:  banRepo,
+		hooks:    hooks,
 	}
 }
 
 // TogglePostLike toggles a like for a post. Returns true if liked, false if unliked.
 func (s *likeService) TogglePostLike(ctx context.Context, userID, postID int) (bool, error) {
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return false, err
+	}
+
 	// Verify post exists
 	_, err := s.postRepo.GetByID(ctx, postID, 0)
 	if err != nil {
@@ -48,6 +72,16 @@ func (s *likeService) TogglePostLike(ctx context.Context, userID, postID int) (b
 		return false, fmt.Errorf("failed to toggle post like: %w", err)
 	}
 
+	if len(s.hooks) > 0 {
+		count, err := s.repo.GetPostLikesCount(ctx, postID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get post likes count: %w", err)
+		}
+		for _, hook := range s.hooks {
+			hook.PostLikesChanged(ctx, postID, count)
+		}
+	}
+
 	return liked, nil
 }
 
@@ -69,10 +103,48 @@ func (s *likeService) IsPostLikedByUser(ctx context.Context, userID, postID int)
 	return liked, nil
 }
 
+// SetPostLike likes or unlikes a post. Repeating the call leaves the like
+// as it is, so retries are safe.
+func (s *likeService) SetPostLike(ctx context.Context, userID, postID int, liked bool) (*domain.LikeStatus, error) {
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.GetByID(ctx, postID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	// Drafts are not public, neither are their likes
+	if post == nil || post.DeletedAt != nil || !post.Published {
+		return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	changed, err := s.repo.SetPostLike(ctx, userID, postID, liked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set post like: %w", err)
+	}
+
+	count, err := s.repo.GetPostLikesCount(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post likes count: %w", err)
+	}
+	if changed {
+		for _, hook := range s.hooks {
+			hook.PostLikesChanged(ctx, postID, count)
+		}
+	}
+
+	return &domain.LikeStatus{IsLiked: liked, LikesCount: count}, nil
+}
+
 // ToggleCommentLike toggles a like for a comment. Returns true if liked, false if unliked.
 func (s *likeService) ToggleCommentLike(ctx context.Context, userID, commentID int) (bool, error) {
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return false, err
+	}
+
 	// Verify comment exists
-	_, err := s.commRepo.GetByID(ctx, commentID)
+	comment, err := s.commRepo.GetByID(ctx, commentID)
 	if err != nil {
 		return false, fmt.Errorf("failed to get comment: %w", err)
 	}
@@ -82,6 +154,16 @@ func (s *likeService) ToggleCommentLike(ctx context.Context, userID, commentID i
 		return false, fmt.Errorf("failed to toggle comment like: %w", err)
 	}
 
+	if len(s.hooks) > 0 && comment != nil {
+		count, err := s.repo.GetCommentLikesCount(ctx, commentID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get comment likes count: %w", err)
+		}
+		for _, hook := range s.hooks {
+			hook.CommentLikesChanged(ctx, comment, count)
+		}
+	}
+
 	return liked, nil
 }
 
@@ -102,3 +184,121 @@ func (s *likeService) IsCommentLikedByUser(ctx context.Context, userID, commentI
 	}
 	return liked, nil
 }
+
+// SetCommentLike likes or unlikes a comment. Repeating the call leaves the
+// like as it is, so retries are safe.
+func (s *likeService) SetCommentLike(ctx context.Context, userID, commentID int, liked bool) (*domain.LikeStatus, error) {
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil || comment.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+
+	changed, err := s.repo.SetCommentLike(ctx, userID, commentID, liked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set comment like: %w", err)
+	}
+
+	count, err := s.repo.GetCommentLikesCount(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment likes count: %w", err)
+	}
+	if changed {
+		for _, hook := range s.hooks {
+			hook.CommentLikesChanged(ctx, comment, count)
+		}
+	}
+
+	return &domain.LikeStatus{IsLiked: liked, LikesCount: count}, nil
+}
+
+// ListPostLikers returns a page of the users who liked a post, newest first.
+func (s *likeService) ListPostLikers(ctx context.Context, postID int, req *domain.LikesRequest) (*domain.LikersResponse, error) {
+	if err := likesPage(req); err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.GetByID(ctx, postID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	// Drafts are not public, neither are their likes
+	if post == nil || post.DeletedAt != nil || !post.Published {
+		return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	return s.listLikers(ctx, domain.ReactionTargetPost, postID, req)
+}
+
+// ListCommentLikers returns a page of the users who liked a comment, newest first.
+func (s *likeService) ListCommentLikers(ctx context.Context, commentID int, req *domain.LikesRequest) (*domain.LikersResponse, error) {
+	if err := likesPage(req); err != nil {
+		return nil, err
+	}
+
+	// Only comments readers can see
+	comment, err := s.commRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment == nil || comment.DeletedAt != nil || comment.ModerationStatus != domain.CommentStatusApproved {
+		return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+	}
+
+	return s.listLikers(ctx, domain.ReactionTargetComment, commentID, req)
+}
+
+func (s *likeService) listLikers(ctx context.Context, target domain.ReactionTarget, targetID int, req *domain.LikesRequest) (*domain.LikersResponse, error) {
+	likers, totalCount, err := s.repo.ListLikers(ctx, target, targetID, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s likes: %w", target, err)
+	}
+
+	return &domain.LikersResponse{
+		Users:      likers,
+		TotalCount: totalCount,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: (totalCount + req.Limit - 1) / req.Limit,
+	}, nil
+}
+
+// ListUserLikes returns a page of the posts and comments a user liked, newest first.
+func (s *likeService) ListUserLikes(ctx context.Context, userID int, req *domain.LikesRequest) (*domain.LikedItemsResponse, error) {
+	if err := likesPage(req); err != nil {
+		return nil, err
+	}
+
+	items, totalCount, err := s.repo.ListLikedBy(ctx, userID, req.Limit, (req.Page-1)*req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list liked items: %w", err)
+	}
+
+	return &domain.LikedItemsResponse{
+		Items:      items,
+		TotalCount: totalCount,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: (totalCount + req.Limit - 1) / req.Limit,
+	}, nil
+}
+
+// likesPage validates a like list request and sets its defaults
+func likesPage(req *domain.LikesRequest) error {
+	if err := validator.Validate(req); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+	return nil
+}
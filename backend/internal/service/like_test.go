@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockLikeRepository is a mock implementation of LikeRepository
@@ -46,13 +48,39 @@ func (m *MockLikeRepository) IsCommentLikedByUser(ctx context.Context, userID, c
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockLikeRepository) SetPostLike(ctx context.Context, userID, postID int, liked bool) (bool, error) {
+	args := m.Called(ctx, userID, postID, liked)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLikeRepository) SetCommentLike(ctx context.Context, userID, commentID int, liked bool) (bool, error) {
+	args := m.Called(ctx, userID, commentID, liked)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLikeRepository) ListLikers(ctx context.Context, target domain.ReactionTarget, targetID, limit, offset int) ([]domain.Liker, int, error) {
+	args := m.Called(ctx, target, targetID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Liker), args.Int(1), args.Error(2)
+}
+
+func (m *MockLikeRepository) ListLikedBy(ctx context.Context, userID, limit, offset int) ([]domain.LikedItem, int, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.LikedItem), args.Int(1), args.Error(2)
+}
+
 func TestLikeService_TogglePostLike(t *testing.T) {
 	ctx := context.Background()
 	mockLikeRepo := new(MockLikeRepository)
 	mockPostRepo := new(MockPostRepository)
 	mockCommentRepo := new(MockCommentRepository)
 
-	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo)
+	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo, noBans())
 
 	t.Run("successfully like post", func(t *testing.T) {
 		post := &domain.Post{
@@ -123,13 +151,30 @@ func TestLikeService_TogglePostLike(t *testing.T) {
 	})
 }
 
+func TestLikeService_BannedUser(t *testing.T) {
+	ctx := context.Background()
+	bans := new(MockBanRepository)
+	bans.On("GetActive", ctx, 1).Return(&domain.UserBan{ID: 3, UserID: 1, Reason: "spam"}, nil)
+	likeRepo := new(MockLikeRepository)
+	service := NewLikeService(likeRepo, new(MockPostRepository), new(MockCommentRepository), bans)
+
+	_, err := service.TogglePostLike(ctx, 1, 1)
+	assert.ErrorIs(t, err, derr.ErrPermission)
+
+	_, err = service.SetCommentLike(ctx, 1, 2, true)
+	assert.ErrorIs(t, err, derr.ErrPermission)
+
+	likeRepo.AssertNotCalled(t, "TogglePostLike", mock.Anything, mock.Anything, mock.Anything)
+	likeRepo.AssertNotCalled(t, "SetCommentLike", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestLikeService_GetPostLikesCount(t *testing.T) {
 	ctx := context.Background()
 	mockLikeRepo := new(MockLikeRepository)
 	mockPostRepo := new(MockPostRepository)
 	mockCommentRepo := new(MockCommentRepository)
 
-	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo)
+	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo, noBans())
 
 	t.Run("successful count", func(t *testing.T) {
 		mockLikeRepo.On("GetPostLikesCount", ctx, 1).Return(5, nil).Once()
@@ -171,7 +216,7 @@ func TestLikeService_IsPostLikedByUser(t *testing.T) { //nolint:dupl // Similar
 	mockPostRepo := new(MockPostRepository)
 	mockCommentRepo := new(MockCommentRepository)
 
-	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo)
+	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo, noBans())
 
 	t.Run("post is liked", func(t *testing.T) {
 		mockLikeRepo.On("IsPostLikedByUser", ctx, 1, 1).Return(true, nil).Once()
@@ -202,7 +247,7 @@ func TestLikeService_ToggleCommentLike(t *testing.T) {
 	mockPostRepo := new(MockPostRepository)
 	mockCommentRepo := new(MockCommentRepository)
 
-	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo)
+	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo, noBans())
 
 	t.Run("successfully like comment", func(t *testing.T) {
 		comment := &domain.Comment{
@@ -264,7 +309,7 @@ func TestLikeService_GetCommentLikesCount(t *testing.T) {
 	mockPostRepo := new(MockPostRepository)
 	mockCommentRepo := new(MockCommentRepository)
 
-	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo)
+	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo, noBans())
 
 	t.Run("successful count", func(t *testing.T) {
 		mockLikeRepo.On("GetCommentLikesCount", ctx, 1).Return(3, nil).Once()
@@ -295,7 +340,7 @@ func TestLikeService_IsCommentLikedByUser(t *testing.T) { //nolint:dupl // Simil
 	mockPostRepo := new(MockPostRepository)
 	mockCommentRepo := new(MockCommentRepository)
 
-	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo)
+	service := NewLikeService(mockLikeRepo, mockPostRepo, mockCommentRepo, noBans())
 
 	t.Run("comment is liked", func(t *testing.T) {
 		mockLikeRepo.On("IsCommentLikedByUser", ctx, 1, 1).Return(true, nil).Once()
@@ -319,3 +364,208 @@ func TestLikeService_IsCommentLikedByUser(t *testing.T) { //nolint:dupl // Simil
 		mockLikeRepo.AssertExpectations(t)
 	})
 }
+
+// recordingLikesHook records like count changes
+type recordingLikesHook struct {
+	postID, commentID, count int
+}
+
+func (h *recordingLikesHook) PostLikesChanged(_ context.Context, postID, count int) {
+	h.postID, h.count = postID, count
+}
+
+func (h *recordingLikesHook) CommentLikesChanged(_ context.Context, comment *domain.Comment, count int) {
+	h.postID, h.commentID, h.count = comment.PostID, comment.ID, count
+}
+
+func TestLikeService_LikesChangedHook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Post like", func(t *testing.T) {
+		likeRepo := new(MockLikeRepository)
+		postRepo := new(MockPostRepository)
+		hook := &recordingLikesHook{}
+		service := NewLikeService(likeRepo, postRepo, new(MockCommentRepository), noBans(), hook)
+
+		postRepo.On("GetByID", ctx, 1, 0).Return(&domain.Post{ID: 1}, nil)
+		likeRepo.On("TogglePostLike", ctx, 2, 1).Return(true, nil)
+		likeRepo.On("GetPostLikesCount", ctx, 1).Return(4, nil)
+
+		_, err := service.TogglePostLike(ctx, 2, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, recordingLikesHook{postID: 1, count: 4}, *hook)
+	})
+
+	t.Run("Comment like", func(t *testing.T) {
+		likeRepo := new(MockLikeRepository)
+		commentRepo := new(MockCommentRepository)
+		hook := &recordingLikesHook{}
+		service := NewLikeService(likeRepo, new(MockPostRepository), commentRepo, noBans(), hook)
+
+		commentRepo.On("GetByID", ctx, 3).Return(&domain.Comment{ID: 3, PostID: 1}, nil)
+		likeRepo.On("ToggleCommentLike", ctx, 2, 3).Return(false, nil)
+		likeRepo.On("GetCommentLikesCount", ctx, 3).Return(0, nil)
+
+		_, err := service.ToggleCommentLike(ctx, 2, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, recordingLikesHook{postID: 1, commentID: 3, count: 0}, *hook)
+	})
+}
+
+func TestLikeService_ListLikers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Post likers with default paging", func(t *testing.T) {
+		likeRepo := new(MockLikeRepository)
+		postRepo := new(MockPostRepository)
+		service := NewLikeService(likeRepo, postRepo, new(MockCommentRepository), noBans())
+
+		likers := []domain.Liker{{UserID: 2, Name: "Bob"}, {UserID: 3, Name: "Carol"}}
+		postRepo.On("GetByID", ctx, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+		likeRepo.On("ListLikers", ctx, domain.ReactionTargetPost, 1, 20, 0).Return(likers, 42, nil)
+
+		resp, err := service.ListPostLikers(ctx, 1, &domain.LikesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, likers, resp.Users)
+		assert.Equal(t, 42, resp.TotalCount)
+		assert.Equal(t, 1, resp.Page)
+		assert.Equal(t, 20, resp.Limit)
+		assert.Equal(t, 3, resp.TotalPages)
+	})
+
+	t.Run("Deleted post", func(t *testing.T) {
+		postRepo := new(MockPostRepository)
+		service := NewLikeService(new(MockLikeRepository), postRepo, new(MockCommentRepository), noBans())
+
+		deletedAt := time.Now()
+		postRepo.On("GetByID", ctx, 1, 0).Return(&domain.Post{ID: 1, Published: true, DeletedAt: &deletedAt}, nil)
+
+		_, err := service.ListPostLikers(ctx, 1, &domain.LikesRequest{})
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Draft post", func(t *testing.T) {
+		postRepo := new(MockPostRepository)
+		service := NewLikeService(new(MockLikeRepository), postRepo, new(MockCommentRepository), noBans())
+
+		postRepo.On("GetByID", ctx, 1, 0).Return(&domain.Post{ID: 1}, nil)
+
+		_, err := service.ListPostLikers(ctx, 1, &domain.LikesRequest{})
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Comment likers page", func(t *testing.T) {
+		likeRepo := new(MockLikeRepository)
+		commentRepo := new(MockCommentRepository)
+		service := NewLikeService(likeRepo, new(MockPostRepository), commentRepo, noBans())
+
+		commentRepo.On("GetByID", ctx, 3).Return(&domain.Comment{ID: 3, ModerationStatus: domain.CommentStatusApproved}, nil)
+		likeRepo.On("ListLikers", ctx, domain.ReactionTargetComment, 3, 10, 10).Return([]domain.Liker{}, 11, nil)
+
+		resp, err := service.ListCommentLikers(ctx, 3, &domain.LikesRequest{Page: 2, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Users)
+		assert.Equal(t, 2, resp.TotalPages)
+	})
+
+	t.Run("Comment awaiting moderation", func(t *testing.T) {
+		commentRepo := new(MockCommentRepository)
+		service := NewLikeService(new(MockLikeRepository), new(MockPostRepository), commentRepo, noBans())
+
+		commentRepo.On("GetByID", ctx, 3).Return(&domain.Comment{ID: 3, ModerationStatus: domain.CommentStatusPending}, nil)
+
+		_, err := service.ListCommentLikers(ctx, 3, &domain.LikesRequest{})
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Limit too large", func(t *testing.T) {
+		service := NewLikeService(new(MockLikeRepository), new(MockPostRepository), new(MockCommentRepository), noBans())
+
+		_, err := service.ListPostLikers(ctx, 1, &domain.LikesRequest{Limit: 500})
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+}
+
+func TestLikeService_ListUserLikes(t *testing.T) {
+	ctx := context.Background()
+	likeRepo := new(MockLikeRepository)
+	service := NewLikeService(likeRepo, new(MockPostRepository), new(MockCommentRepository), noBans())
+
+	items := []domain.LikedItem{
+		{Target: domain.ReactionTargetComment, PostID: 1, CommentID: intPtr(3)},
+		{Target: domain.ReactionTargetPost, PostID: 1, PostSlug: "hello"},
+	}
+	likeRepo.On("ListLikedBy", ctx, 2, 20, 0).Return(items, 2, nil)
+
+	resp, err := service.ListUserLikes(ctx, 2, &domain.LikesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, items, resp.Items)
+	assert.Equal(t, 2, resp.TotalCount)
+	assert.Equal(t, 1, resp.TotalPages)
+}
+
+func TestLikeService_SetLike(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Liking twice notifies once", func(t *testing.T) {
+		likeRepo := new(MockLikeRepository)
+		postRepo := new(MockPostRepository)
+		hook := &recordingLikesHook{}
+		service := NewLikeService(likeRepo, postRepo, new(MockCommentRepository), noBans(), hook)
+
+		postRepo.On("GetByID", ctx, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+		likeRepo.On("SetPostLike", ctx, 2, 1, true).Return(true, nil).Once()
+		likeRepo.On("SetPostLike", ctx, 2, 1, true).Return(false, nil).Once()
+		likeRepo.On("GetPostLikesCount", ctx, 1).Return(3, nil)
+
+		status, err := service.SetPostLike(ctx, 2, 1, true)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.LikeStatus{IsLiked: true, LikesCount: 3}, status)
+		assert.Equal(t, recordingLikesHook{postID: 1, count: 3}, *hook)
+
+		*hook = recordingLikesHook{}
+		status, err = service.SetPostLike(ctx, 2, 1, true)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.LikeStatus{IsLiked: true, LikesCount: 3}, status)
+		assert.Equal(t, recordingLikesHook{}, *hook)
+		likeRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unlike comment", func(t *testing.T) {
+		likeRepo := new(MockLikeRepository)
+		commentRepo := new(MockCommentRepository)
+		hook := &recordingLikesHook{}
+		service := NewLikeService(likeRepo, new(MockPostRepository), commentRepo, noBans(), hook)
+
+		commentRepo.On("GetByID", ctx, 3).Return(&domain.Comment{ID: 3, PostID: 1}, nil)
+		likeRepo.On("SetCommentLike", ctx, 2, 3, false).Return(true, nil)
+		likeRepo.On("GetCommentLikesCount", ctx, 3).Return(0, nil)
+
+		status, err := service.SetCommentLike(ctx, 2, 3, false)
+		require.NoError(t, err)
+		assert.False(t, status.IsLiked)
+		assert.Equal(t, recordingLikesHook{postID: 1, commentID: 3, count: 0}, *hook)
+	})
+
+	t.Run("Post not found", func(t *testing.T) {
+		postRepo := new(MockPostRepository)
+		service := NewLikeService(new(MockLikeRepository), postRepo, new(MockCommentRepository), noBans())
+
+		postRepo.On("GetByID", ctx, 9, 0).Return(nil, nil)
+
+		_, err := service.SetPostLike(ctx, 2, 9, true)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Draft post", func(t *testing.T) {
+		likeRepo := new(MockLikeRepository)
+		postRepo := new(MockPostRepository)
+		service := NewLikeService(likeRepo, postRepo, new(MockCommentRepository), noBans())
+
+		postRepo.On("GetByID", ctx, 1, 0).Return(&domain.Post{ID: 1}, nil)
+
+		_, err := service.SetPostLike(ctx, 2, 1, true)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+		likeRepo.AssertNotCalled(t, "SetPostLike", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"embed"
+	"io/fs"
+	"log/slog"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/pkg/mailer"
+)
+
+//go:embed templates/*.tmpl
+var emailTemplateFS embed.FS
+
+// Mail transports
+const (
+	MailTransportSMTP = "smtp"
+	MailTransportFile = "file"
+	MailTransportLog  = "log"
+)
+
+// NewMailSender creates the email transport selected in the config
+func NewMailSender(cfg *config.Config, log *slog.Logger) mailer.Sender {
+	switch cfg.Mail.Transport {
+	case MailTransportSMTP:
+		return mailer.NewSMTPSender(mailer.SMTPOptions{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			Timeout:  cfg.SMTP.Timeout,
+		})
+	case MailTransportFile:
+		return mailer.NewFileSender(cfg.Mail.Dir)
+	default:
+		return mailer.NewLogSender(log)
+	}
+}
+
+// emailTemplates parses the embedded email templates. They are part of the
+// binary, so a parse error is a programming error.
+func emailTemplates(fallbackLanguage string) *mailer.Templates {
+	dir, err := fs.Sub(emailTemplateFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	templates, err := mailer.ParseTemplates(dir, fallbackLanguage)
+	if err != nil {
+		panic(err)
+	}
+	return templates
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/mailer"
+	"personal-web-platform/internal/pkg/validator"
+	"personal-web-platform/internal/repository"
+)
+
+// NewsletterService defines methods for email subscriptions to new posts
+type NewsletterService interface {
+	// Subscribe starts a double opt-in: the address gets a confirmation link
+	Subscribe(ctx context.Context, req *domain.SubscribeRequest) error
+	Confirm(ctx context.Context, token string) error
+	Unsubscribe(ctx context.Context, token string) error
+
+	// PostPublished queues a published post for confirmed subscribers
+	PostPublished(ctx context.Context, post *domain.Post)
+	// ProcessQueue emails queued posts that are due
+	ProcessQueue(ctx context.Context) error
+	// Wait blocks until background confirmation emails are sent or ctx is done
+	Wait(ctx context.Context) error
+}
+
+type newsletterService struct {
+	subscriberRepo repository.SubscriberRepository
+	sender         mailer.Sender
+	templates      *mailer.Templates
+	cfg            config.Newsletter
+	language       string
+	from           string
+	siteName       string
+	baseURL        string
+	frontendURL    string
+	log            *slog.Logger
+
+	// deliveries tracks background sending of confirmations
+	deliveries sync.WaitGroup
+}
+
+// NewNewsletterService creates a new newsletter service implementation.
+// Emails are written in the default content language.
+func NewNewsletterService(subscriberRepo repository.SubscriberRepository, sender mailer.Sender, cfg *config.Config, log *slog.Logger) NewsletterService {
+	return &newsletterService{
+		subscriberRepo: subscriberRepo,
+		sender:         sender,
+		templates:      emailTemplates(cfg.Languages.Default),
+		cfg:            cfg.Newsletter,
+		language:       cfg.Languages.Default,
+		from:           cfg.SMTP.From,
+		siteName:       cfg.Profile.Name,
+		baseURL:        strings.TrimSuffix(cfg.OAuth.BaseURL, "/"),
+		frontendURL:    strings.TrimSuffix(cfg.OAuth.FrontendURL, "/"),
+		log:            log,
+	}
+}
+
+func (s *newsletterService) Subscribe(ctx context.Context, req *domain.SubscribeRequest) error {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if err := validator.Validate(req); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	existing, err := s.subscriberRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return fmt.Errorf("failed to get subscriber: %w", err)
+	}
+	// Confirmed addresses get no email, the response doesn't reveal who is subscribed
+	if existing != nil && existing.Status == domain.SubscriberStatusConfirmed {
+		return nil
+	}
+
+	confirmToken, err := generateSecureToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+	expiresAt := time.Now().Add(s.cfg.ConfirmationTTL)
+
+	subscriber := &domain.Subscriber{
+		Email:            req.Email,
+		Status:           domain.SubscriberStatusPending,
+		ConfirmToken:     confirmToken,
+		ConfirmExpiresAt: &expiresAt,
+	}
+	if existing != nil {
+		subscriber.UnsubscribeToken = existing.UnsubscribeToken
+	} else if subscriber.UnsubscribeToken, err = generateSecureToken(32); err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	// Repeated requests don't flood the address, the response stays the same
+	saved, err := s.subscriberRepo.Save(ctx, subscriber, s.cfg.ResendInterval)
+	if err != nil {
+		return fmt.Errorf("failed to save subscriber: %w", err)
+	}
+	if !saved {
+		return nil
+	}
+
+	msg, err := s.render("newsletter_confirm", subscriber.Email, map[string]any{
+		"SiteName":   s.siteName,
+		"SiteURL":    s.frontendURL + "/",
+		"ConfirmURL": s.baseURL + "/api/v1/subscribe/confirm?token=" + url.QueryEscape(confirmToken),
+		"ValidHours": int(s.cfg.ConfirmationTTL.Hours()),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.sendAsync(ctx, func(ctx context.Context) {
+		if err := s.sender.Send(ctx, msg); err != nil {
+			s.log.Error("failed to send subscription confirmation", slog.Int("subscriber_id", subscriber.ID), slog.String("error", err.Error()))
+		}
+	})
+
+	return nil
+}
+
+func (s *newsletterService) Confirm(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("%w: token is required", derr.ErrValidation)
+	}
+
+	subscriber, err := s.subscriberRepo.GetByConfirmToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to get subscriber: %w", err)
+	}
+	if subscriber == nil || subscriber.ConfirmExpiresAt == nil || time.Now().After(*subscriber.ConfirmExpiresAt) {
+		return fmt.Errorf("%w: invalid or expired confirmation link", derr.ErrNotFound)
+	}
+
+	if err := s.subscriberRepo.Confirm(ctx, subscriber.ID); err != nil {
+		return fmt.Errorf("failed to confirm subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (s *newsletterService) Unsubscribe(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("%w: token is required", derr.ErrValidation)
+	}
+
+	subscriber, err := s.subscriberRepo.GetByUnsubscribeToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to get subscriber: %w", err)
+	}
+	if subscriber == nil {
+		return fmt.Errorf("%w: invalid unsubscribe link", derr.ErrNotFound)
+	}
+	if subscriber.Status == domain.SubscriberStatusUnsubscribed {
+		return nil
+	}
+
+	if err := s.subscriberRepo.Unsubscribe(ctx, subscriber.ID); err != nil {
+		return fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	return nil
+}
+
+func (s *newsletterService) PostPublished(ctx context.Context, post *domain.Post) {
+	if !post.Published || post.DeletedAt != nil {
+		return
+	}
+
+	queued, err := s.subscriberRepo.EnqueuePost(ctx, post.ID)
+	if err != nil {
+		s.log.Error("failed to queue newsletter", slog.Int("post_id", post.ID), slog.String("error", err.Error()))
+		return
+	}
+	s.log.Info("newsletter queued", slog.Int("post_id", post.ID), slog.Int64("count", queued))
+}
+
+func (s *newsletterService) ProcessQueue(ctx context.Context) error {
+	deliveries, err := s.subscriberRepo.ListDueDeliveries(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list newsletter deliveries: %w", err)
+	}
+
+	for i := range deliveries {
+		if err := s.deliver(ctx, &deliveries[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliver emails a queued post, retrying failures with a growing delay
+func (s *newsletterService) deliver(ctx context.Context, d *domain.NewsletterDelivery) error {
+	if d.Withdrawn {
+		d.Status = domain.NewsletterDeliverySkipped
+	} else {
+		d.Attempts++
+		err := s.sendPost(ctx, d)
+
+		switch {
+		case err == nil:
+			d.Status = domain.NewsletterDeliverySent
+			d.LastError = ""
+		case d.Attempts < s.cfg.MaxAttempts:
+			d.NextAttemptAt = time.Now().Add(s.cfg.RetryInterval << (d.Attempts - 1))
+			d.LastError = err.Error()
+		default:
+			d.Status = domain.NewsletterDeliveryFailed
+			d.LastError = err.Error()
+			s.log.Warn("failed to send post to subscriber",
+				slog.Int("post_id", d.PostID), slog.Int("subscriber_id", d.SubscriberID), slog.String("error", err.Error()))
+		}
+	}
+
+	if err := s.subscriberRepo.UpdateDelivery(ctx, d); err != nil {
+		return fmt.Errorf("failed to update newsletter delivery: %w", err)
+	}
+	return nil
+}
+
+func (s *newsletterService) sendPost(ctx context.Context, d *domain.NewsletterDelivery) error {
+	unsubscribeURL := s.baseURL + "/api/v1/unsubscribe?token=" + url.QueryEscape(d.UnsubscribeToken)
+
+	coverURL := d.PostCoverImage
+	if coverURL != "" && !strings.HasPrefix(coverURL, "http://") && !strings.HasPrefix(coverURL, "https://") {
+		coverURL = s.frontendURL + "/" + strings.TrimPrefix(coverURL, "/")
+	}
+
+	msg, err := s.render("newsletter_post", d.Email, map[string]any{
+		"SiteName":       s.siteName,
+		"SiteURL":        s.frontendURL + "/",
+		"Post":           &domain.Post{ID: d.PostID, Title: d.PostTitle, Slug: d.PostSlug, Preview: d.PostPreview},
+		"PostURL":        s.frontendURL + "/blog/" + d.PostSlug,
+		"CoverURL":       coverURL,
+		"UnsubscribeURL": unsubscribeURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	// RFC 8058 one-click unsubscribe from the mail client
+	msg.Headers = map[string]string{
+		"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+
+	return s.sender.Send(ctx, msg)
+}
+
+// render builds an email to a subscriber
+func (s *newsletterService) render(name, to string, data map[string]any) (*mailer.Message, error) {
+	msg, err := s.templates.Render(name, s.language, data)
+	if err != nil {
+		return nil, err
+	}
+	msg.From = s.from
+	msg.To = to
+	return msg, nil
+}
+
+func (s *newsletterService) Wait(ctx context.Context) error {
+	return waitGroup(ctx, &s.deliveries)
+}
+
+// sendAsync sends email in the background, outliving the request
+func (s *newsletterService) sendAsync(ctx context.Context, fn func(ctx context.Context)) {
+	ctx = context.WithoutCancel(ctx)
+
+	s.deliveries.Add(1)
+	go func() {
+		defer s.deliveries.Done()
+		fn(ctx)
+	}()
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/mailer"
+	"personal-web-platform/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSubscriberRepository is a mock implementation of SubscriberRepository
+type MockSubscriberRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriberRepository) GetByEmail(ctx context.Context, email string) (*domain.Subscriber, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.Subscriber), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSubscriberRepository) GetByConfirmToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.Subscriber), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSubscriberRepository) GetByUnsubscribeToken(ctx context.Context, token string) (*domain.Subscriber, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.Subscriber), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSubscriberRepository) Save(ctx context.Context, subscriber *domain.Subscriber, resendInterval time.Duration) (bool, error) {
+	args := m.Called(ctx, subscriber, resendInterval)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSubscriberRepository) Confirm(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSubscriberRepository) Unsubscribe(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSubscriberRepository) ListConfirmed(ctx context.Context) ([]domain.Subscriber, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Subscriber), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSubscriberRepository) EnqueuePost(ctx context.Context, postID int) (int64, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(int64), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSubscriberRepository) ListDueDeliveries(ctx context.Context, limit int) ([]domain.NewsletterDelivery, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]domain.NewsletterDelivery), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockSubscriberRepository) UpdateDelivery(ctx context.Context, delivery *domain.NewsletterDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+// newTestNewsletterService sends email to a fake SMTP server
+func newTestNewsletterService(t *testing.T, repo *MockSubscriberRepository) (NewsletterService, *testutil.FakeSMTPServer) {
+	server := testutil.NewFakeSMTPServer(t)
+	sender := mailer.NewSMTPSender(mailer.SMTPOptions{Host: server.Host, Port: server.Port, Timeout: 5 * time.Second})
+	return newTestNewsletterServiceWithSender(repo, sender), server
+}
+
+func newTestNewsletterServiceWithSender(repo *MockSubscriberRepository, sender mailer.Sender) NewsletterService {
+	cfg := &config.Config{
+		Profile:   config.ProfileConfig{Name: "Test Blog"},
+		OAuth:     config.OAuth{BaseURL: "https://blog.example", FrontendURL: "https://blog.example"},
+		SMTP:      config.SMTP{From: "Test Blog <blog@blog.example>"},
+		Languages: config.Languages{Default: "en", Supported: []string{"en", "ru"}},
+		Newsletter: config.Newsletter{
+			Enabled:         true,
+			ConfirmationTTL: 48 * time.Hour,
+			ResendInterval:  10 * time.Minute,
+			BatchSize:       100,
+			MaxAttempts:     3,
+			RetryInterval:   5 * time.Minute,
+		},
+	}
+	return NewNewsletterService(repo, sender, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+var confirmLinkRe = regexp.MustCompile(`https://blog\.example/api/v1/subscribe/confirm\?token=\w+`)
+
+func TestNewsletterService_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("New address gets a confirmation email", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, server := newTestNewsletterService(t, repo)
+
+		var saved *domain.Subscriber
+		repo.On("GetByEmail", mock.Anything, "reader@example.com").Return(nil, nil)
+		repo.On("Save", mock.Anything, mock.Anything, 10*time.Minute).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(*domain.Subscriber)
+			saved.ID = 1
+		}).Return(true, nil)
+
+		err := service.Subscribe(ctx, &domain.SubscribeRequest{Email: " Reader@Example.com "})
+		require.NoError(t, err)
+
+		require.NotNil(t, saved)
+		assert.Equal(t, domain.SubscriberStatusPending, saved.Status)
+		assert.NotEmpty(t, saved.ConfirmToken)
+		assert.NotEmpty(t, saved.UnsubscribeToken)
+		assert.WithinDuration(t, time.Now().Add(48*time.Hour), *saved.ConfirmExpiresAt, time.Minute)
+
+		email := server.WaitForEmails(t, 1)[0]
+		assert.Equal(t, []string{"reader@example.com"}, email.To)
+		assert.Equal(t, "Confirm your subscription to Test Blog", email.Message.Header.Get("Subject"))
+
+		link := confirmLinkRe.FindString(email.Text)
+		require.NotEmpty(t, link)
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+		assert.Equal(t, saved.ConfirmToken, u.Query().Get("token"))
+		assert.Contains(t, email.HTML, link)
+	})
+
+	t.Run("Unsubscribed address keeps its unsubscribe token", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, server := newTestNewsletterService(t, repo)
+
+		repo.On("GetByEmail", mock.Anything, "reader@example.com").Return(&domain.Subscriber{
+			ID: 1, Email: "reader@example.com", Status: domain.SubscriberStatusUnsubscribed, UnsubscribeToken: "old-token",
+		}, nil)
+		repo.On("Save", mock.Anything, mock.MatchedBy(func(s *domain.Subscriber) bool {
+			return s.Status == domain.SubscriberStatusPending && s.UnsubscribeToken == "old-token"
+		}), mock.Anything).Return(true, nil)
+
+		require.NoError(t, service.Subscribe(ctx, &domain.SubscribeRequest{Email: "reader@example.com"}))
+		require.NoError(t, service.Wait(ctx))
+		server.WaitForEmails(t, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Confirmed address is not emailed again", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, server := newTestNewsletterService(t, repo)
+
+		repo.On("GetByEmail", mock.Anything, "reader@example.com").Return(&domain.Subscriber{ID: 1, Status: domain.SubscriberStatusConfirmed}, nil)
+
+		require.NoError(t, service.Subscribe(ctx, &domain.SubscribeRequest{Email: "reader@example.com"}))
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+		assert.Empty(t, server.Emails())
+	})
+
+	t.Run("Confirmation is not resent within the interval", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, server := newTestNewsletterService(t, repo)
+
+		repo.On("GetByEmail", mock.Anything, "reader@example.com").Return(&domain.Subscriber{ID: 1, Status: domain.SubscriberStatusPending}, nil)
+		repo.On("Save", mock.Anything, mock.Anything, 10*time.Minute).Return(false, nil)
+
+		require.NoError(t, service.Subscribe(ctx, &domain.SubscribeRequest{Email: "reader@example.com"}))
+		repo.AssertExpectations(t)
+		assert.Empty(t, server.Emails())
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		service, _ := newTestNewsletterService(t, new(MockSubscriberRepository))
+
+		err := service.Subscribe(ctx, &domain.SubscribeRequest{Email: "not-an-email"})
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+}
+
+func TestNewsletterService_Confirm(t *testing.T) {
+	ctx := context.Background()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, _ := newTestNewsletterService(t, repo)
+
+		repo.On("GetByConfirmToken", mock.Anything, "token").Return(&domain.Subscriber{ID: 1, ConfirmExpiresAt: &future}, nil)
+		repo.On("Confirm", mock.Anything, 1).Return(nil)
+
+		require.NoError(t, service.Confirm(ctx, "token"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, _ := newTestNewsletterService(t, repo)
+
+		repo.On("GetByConfirmToken", mock.Anything, "token").Return(&domain.Subscriber{ID: 1, ConfirmExpiresAt: &past}, nil)
+
+		assert.ErrorIs(t, service.Confirm(ctx, "token"), derr.ErrNotFound)
+		repo.AssertNotCalled(t, "Confirm", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, _ := newTestNewsletterService(t, repo)
+
+		repo.On("GetByConfirmToken", mock.Anything, "token").Return(nil, nil)
+
+		assert.ErrorIs(t, service.Confirm(ctx, "token"), derr.ErrNotFound)
+	})
+}
+
+func TestNewsletterService_Unsubscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, _ := newTestNewsletterService(t, repo)
+
+		repo.On("GetByUnsubscribeToken", mock.Anything, "token").Return(&domain.Subscriber{ID: 1, Status: domain.SubscriberStatusConfirmed}, nil)
+		repo.On("Unsubscribe", mock.Anything, 1).Return(nil)
+
+		require.NoError(t, service.Unsubscribe(ctx, "token"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Already unsubscribed", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, _ := newTestNewsletterService(t, repo)
+
+		repo.On("GetByUnsubscribeToken", mock.Anything, "token").Return(&domain.Subscriber{ID: 1, Status: domain.SubscriberStatusUnsubscribed}, nil)
+
+		require.NoError(t, service.Unsubscribe(ctx, "token"))
+		repo.AssertNotCalled(t, "Unsubscribe", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, _ := newTestNewsletterService(t, repo)
+
+		repo.On("GetByUnsubscribeToken", mock.Anything, "token").Return(nil, nil)
+
+		assert.ErrorIs(t, service.Unsubscribe(ctx, "token"), derr.ErrNotFound)
+	})
+}
+
+func TestNewsletterService_PostPublished(t *testing.T) {
+	repo := new(MockSubscriberRepository)
+	service, _ := newTestNewsletterService(t, repo)
+
+	repo.On("EnqueuePost", mock.Anything, 7).Return(int64(2), nil).Once()
+
+	service.PostPublished(context.Background(), &domain.Post{ID: 7, Published: true})
+	service.PostPublished(context.Background(), &domain.Post{ID: 8})
+	repo.AssertExpectations(t)
+}
+
+func TestNewsletterService_ProcessQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Queued posts are emailed", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service, server := newTestNewsletterService(t, repo)
+
+		post := domain.NewsletterDelivery{
+			PostID:         7,
+			PostTitle:      "Hello <World>",
+			PostSlug:       "hello-world",
+			PostPreview:    "A short preview",
+			PostCoverImage: "/uploads/cover.png",
+			Status:         domain.NewsletterDeliveryPending,
+		}
+		first, second := post, post
+		first.ID, first.SubscriberID, first.Email, first.UnsubscribeToken = 1, 1, "first@example.com", "unsub-1"
+		second.ID, second.SubscriberID, second.Email, second.UnsubscribeToken = 2, 2, "second@example.com", "unsub-2"
+
+		repo.On("ListDueDeliveries", mock.Anything, 100).Return([]domain.NewsletterDelivery{first, second}, nil)
+		repo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.NewsletterDelivery) bool {
+			return d.Status == domain.NewsletterDeliverySent && d.Attempts == 1
+		})).Return(nil).Twice()
+
+		require.NoError(t, service.ProcessQueue(ctx))
+		repo.AssertExpectations(t)
+
+		emails := server.WaitForEmails(t, 2)
+		byRecipient := make(map[string]testutil.Email)
+		for _, email := range emails {
+			byRecipient[email.To[0]] = email
+		}
+
+		email := byRecipient["first@example.com"]
+		assert.Equal(t, "Hello <World>", email.Message.Header.Get("Subject"))
+		assert.Equal(t, "<https://blog.example/api/v1/unsubscribe?token=unsub-1>", email.Message.Header.Get("List-Unsubscribe"))
+		assert.Equal(t, "List-Unsubscribe=One-Click", email.Message.Header.Get("List-Unsubscribe-Post"))
+
+		assert.Contains(t, email.Text, "Hello <World>")
+		assert.Contains(t, email.Text, "Read the post: https://blog.example/blog/hello-world")
+		assert.Contains(t, email.Text, "https://blog.example/api/v1/unsubscribe?token=unsub-1")
+
+		// HTML is escaped, relative images become absolute
+		assert.Contains(t, email.HTML, "Hello &lt;World&gt;")
+		assert.Contains(t, email.HTML, `src="https://blog.example/uploads/cover.png"`)
+
+		assert.Contains(t, byRecipient["second@example.com"].Text, "token=unsub-2")
+	})
+
+	t.Run("Failures are retried, then dropped", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		service := newTestNewsletterServiceWithSender(repo, &fakeSender{err: errors.New("smtp down")})
+
+		repo.On("ListDueDeliveries", mock.Anything, 100).Return([]domain.NewsletterDelivery{
+			{ID: 1, PostID: 7, Email: "first@example.com", Status: domain.NewsletterDeliveryPending},
+			{ID: 2, PostID: 7, Email: "second@example.com", Status: domain.NewsletterDeliveryPending, Attempts: 2},
+		}, nil)
+		repo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.NewsletterDelivery) bool {
+			return d.ID == 1 && d.Status == domain.NewsletterDeliveryPending && d.Attempts == 1 &&
+				d.LastError == "smtp down" && d.NextAttemptAt.After(time.Now().Add(4*time.Minute))
+		})).Return(nil).Once()
+		repo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.NewsletterDelivery) bool {
+			return d.ID == 2 && d.Status == domain.NewsletterDeliveryFailed && d.Attempts == 3
+		})).Return(nil).Once()
+
+		require.NoError(t, service.ProcessQueue(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Withdrawn posts are skipped", func(t *testing.T) {
+		repo := new(MockSubscriberRepository)
+		sender := &fakeSender{}
+		service := newTestNewsletterServiceWithSender(repo, sender)
+
+		repo.On("ListDueDeliveries", mock.Anything, 100).Return([]domain.NewsletterDelivery{
+			{ID: 1, PostID: 7, Email: "first@example.com", Status: domain.NewsletterDeliveryPending, Withdrawn: true},
+		}, nil)
+		repo.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d *domain.NewsletterDelivery) bool {
+			return d.Status == domain.NewsletterDeliverySkipped && d.Attempts == 0
+		})).Return(nil).Once()
+
+		require.NoError(t, service.ProcessQueue(ctx))
+		repo.AssertExpectations(t)
+		assert.Empty(t, sender.sent)
+	})
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/language"
+	"personal-web-platform/internal/pkg/mailer"
+	"personal-web-platform/internal/pkg/validator"
+	"personal-web-platform/internal/repository"
+)
+
+const (
+	// notificationUsersPerRun limits how many users are emailed per worker run
+	notificationUsersPerRun = 50
+	// notificationExcerptLength is the number of characters quoted from a comment or post preview
+	notificationExcerptLength = 300
+	// notificationTitleLength is the size of the title column of in-app notifications
+	notificationTitleLength = 255
+)
+
+// NotificationService defines methods for user notification settings and email notifications
+type NotificationService interface {
+	GetSettings(ctx context.Context, userID int) (*domain.NotificationSettings, error)
+	UpdateSettings(ctx context.Context, userID int, req *domain.UpdateNotificationSettingsRequest) (*domain.NotificationSettings, error)
+
+	// ProcessEmails sends queued notifications, batched into one email per user
+	ProcessEmails(ctx context.Context) error
+
+	// CommentCreated, CommentUpdated and PostPublished store and queue notifications for the event
+	CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment)
+	CommentUpdated(ctx context.Context, comment *domain.Comment)
+	PostPublished(ctx context.Context, post *domain.Post)
+}
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	authRepo         repository.AuthRepository
+	postRepo         repository.PostRepository
+	sender           mailer.Sender
+	templates        *mailer.Templates
+	cfg              config.Notifications
+	languages        config.Languages
+	from             string
+	siteName         string
+	frontendURL      string
+	log              *slog.Logger
+}
+
+// NewNotificationService creates a new notification service implementation
+func NewNotificationService(notificationRepo repository.NotificationRepository, authRepo repository.AuthRepository, postRepo repository.PostRepository, sender mailer.Sender, cfg *config.Config, log *slog.Logger) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		authRepo:         authRepo,
+		postRepo:         postRepo,
+		sender:           sender,
+		templates:        emailTemplates(cfg.Languages.Default),
+		cfg:              cfg.Notifications,
+		languages:        cfg.Languages,
+		from:             cfg.SMTP.From,
+		siteName:         cfg.Profile.Name,
+		frontendURL:      strings.TrimSuffix(cfg.OAuth.FrontendURL, "/"),
+		log:              log,
+	}
+}
+
+func (s *notificationService) GetSettings(ctx context.Context, userID int) (*domain.NotificationSettings, error) {
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *notificationService) UpdateSettings(ctx context.Context, userID int, req *domain.UpdateNotificationSettingsRequest) (*domain.NotificationSettings, error) {
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	if req.EmailEnabled != nil {
+		settings.EmailEnabled = *req.EmailEnabled
+	}
+	if req.PushEnabled != nil {
+		settings.PushEnabled = *req.PushEnabled
+	}
+	if req.NewPostsEnabled != nil {
+		settings.NewPostsEnabled = *req.NewPostsEnabled
+	}
+	if req.Language != nil {
+		// Empty resets to the site default
+		lang := language.Normalize(*req.Language)
+		if lang != "" && !language.Contains(s.languages.Supported, lang) {
+			return nil, fmt.Errorf("%w: unsupported language %q", derr.ErrValidation, *req.Language)
+		}
+		settings.Language = lang
+	}
+
+	if err := s.notificationRepo.UpsertSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// CommentCreated stores an in-app notification for every user mentioned in
+// the comment and queues emails to them and to the author of the comment
+// being replied to
+func (s *notificationService) CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment) {
+	if s.cfg.EmailEnabled && parent != nil && parent.UserID != comment.UserID {
+		s.queueComment(ctx, domain.NotificationTypeCommentReply, parent.UserID, comment)
+	}
+
+	s.notifyMentions(ctx, post, comment, func(userID int) bool {
+		// The parent's author already gets a reply email
+		return parent == nil || userID != parent.UserID
+	})
+}
+
+// CommentUpdated notifies users mentioned by an edit. Users mentioned
+// before were notified already and are not notified again.
+func (s *notificationService) CommentUpdated(ctx context.Context, comment *domain.Comment) {
+	if comment.ModerationStatus != domain.CommentStatusApproved || len(comment.Mentions) == 0 {
+		return
+	}
+
+	post, err := s.postRepo.GetByID(ctx, comment.PostID, 0)
+	if err != nil {
+		s.log.Error("failed to get post", slog.Int("post_id", comment.PostID), slog.String("error", err.Error()))
+		return
+	}
+	if post == nil {
+		return
+	}
+
+	s.notifyMentions(ctx, post, comment, func(int) bool { return true })
+}
+
+// notifyMentions stores an in-app notification for every user mentioned in
+// a comment, whatever their email settings are, and queues an email for
+// those who get it according to email
+func (s *notificationService) notifyMentions(ctx context.Context, post *domain.Post, comment *domain.Comment, email func(userID int) bool) {
+	// The author is looked up once someone is mentioned
+	actor, actorLoaded := "", false
+
+	for _, m := range comment.Mentions {
+		if m.UserID == comment.UserID {
+			continue
+		}
+
+		if !actorLoaded {
+			actorLoaded = true
+			if user, err := s.authRepo.GetUserByID(ctx, comment.UserID); err != nil {
+				s.log.Error("failed to get comment author", slog.Int("user_id", comment.UserID), slog.String("error", err.Error()))
+			} else if user != nil {
+				actor = user.Name
+			}
+		}
+
+		item := notificationItem{
+			Type:      domain.NotificationTypeCommentMention,
+			Actor:     actor,
+			PostTitle: post.Title,
+			URL:       s.frontendURL + "/blog/" + post.Slug + "#" + domain.CommentAnchor(comment.ID),
+			Excerpt:   excerpt(comment.Content, notificationExcerptLength),
+		}
+		s.storeNotification(ctx, m.UserID, item, comment)
+
+		if s.cfg.EmailEnabled && email(m.UserID) {
+			s.queueComment(ctx, domain.NotificationTypeCommentMention, m.UserID, comment)
+		}
+	}
+}
+
+// storeNotification saves an in-app notification about a comment in the user's language
+func (s *notificationService) storeNotification(ctx context.Context, userID int, item notificationItem, comment *domain.Comment) {
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get notification settings", slog.Int("user_id", userID), slog.String("error", err.Error()))
+		return
+	}
+	lang := settings.Language
+	if lang == "" {
+		lang = s.languages.Default
+	}
+
+	msg, err := s.templates.Render("push_"+item.Type, lang, map[string]any{
+		"SiteName": s.siteName,
+		"Item":     item,
+	})
+	if err != nil {
+		s.log.Error("failed to render notification", slog.String("type", item.Type), slog.String("error", err.Error()))
+		return
+	}
+
+	title := msg.Subject
+	if runes := []rune(title); len(runes) > notificationTitleLength {
+		title = string(runes[:notificationTitleLength])
+	}
+	notification := &domain.Notification{
+		UserID:    userID,
+		Type:      item.Type,
+		Title:     title,
+		Message:   strings.TrimSpace(msg.Text),
+		PostID:    &comment.PostID,
+		CommentID: &comment.ID,
+	}
+	if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
+		s.log.Error("failed to store notification",
+			slog.String("type", item.Type), slog.Int("comment_id", comment.ID), slog.String("error", err.Error()))
+	}
+}
+
+// queueComment queues a notification about a comment for a user who wants email
+func (s *notificationService) queueComment(ctx context.Context, notificationType string, userID int, comment *domain.Comment) {
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get notification settings", slog.Int("user_id", userID), slog.String("error", err.Error()))
+		return
+	}
+	if !settings.EmailEnabled {
+		return
+	}
+
+	enqueue := s.notificationRepo.EnqueueCommentReply
+	if notificationType == domain.NotificationTypeCommentMention {
+		enqueue = s.notificationRepo.EnqueueCommentMention
+	}
+	if err := enqueue(ctx, userID, comment.PostID, comment.ID); err != nil {
+		s.log.Error("failed to queue comment notification",
+			slog.String("type", notificationType), slog.Int("comment_id", comment.ID), slog.String("error", err.Error()))
+	}
+}
+
+// PostPublished queues an email about the post to every interested user
+func (s *notificationService) PostPublished(ctx context.Context, post *domain.Post) {
+	if !s.cfg.EmailEnabled || !post.Published || post.DeletedAt != nil {
+		return
+	}
+
+	queued, err := s.notificationRepo.EnqueueNewPost(ctx, post.ID, post.AuthorID)
+	if err != nil {
+		s.log.Error("failed to queue new post notifications", slog.Int("post_id", post.ID), slog.String("error", err.Error()))
+		return
+	}
+	s.log.Info("new post notifications queued", slog.Int("post_id", post.ID), slog.Int64("count", queued))
+}
+
+func (s *notificationService) ProcessEmails(ctx context.Context) error {
+	now := time.Now()
+
+	// Notifications wait BatchDelay so that several of them go in one email,
+	// and a user gets at most one email per MinInterval
+	userIDs, err := s.notificationRepo.ListDueUsers(ctx, now.Add(-s.cfg.BatchDelay), now.Add(-s.cfg.MinInterval), notificationUsersPerRun)
+	if err != nil {
+		return fmt.Errorf("failed to list users to notify: %w", err)
+	}
+
+	for _, userID := range userIDs {
+		if err := s.emailUser(ctx, userID); err != nil {
+			s.log.Error("failed to email notifications", slog.Int("user_id", userID), slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// notificationItem is a single event in a notification email
+type notificationItem struct {
+	Type      string
+	Actor     string
+	PostTitle string
+	URL       string
+	Excerpt   string
+}
+
+// emailUser sends the pending notifications of a user as one email
+func (s *notificationService) emailUser(ctx context.Context, userID int) error {
+	pending, err := s.notificationRepo.ListPending(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification settings: %w", err)
+	}
+
+	// Settings may have changed and content may be gone since the notifications were queued
+	canEmail := user != nil && settings.EmailEnabled && !strings.HasSuffix(user.Email, ".invalid")
+	var send, skip []domain.EmailNotification
+	for _, n := range pending {
+		if !canEmail || n.Withdrawn || (n.Type == domain.NotificationTypeNewPost && !settings.NewPostsEnabled) {
+			skip = append(skip, n)
+		} else {
+			send = append(send, n)
+		}
+	}
+	if len(skip) > 0 {
+		if err := s.notificationRepo.MarkSkipped(ctx, notificationIDs(skip)); err != nil {
+			return err
+		}
+	}
+	if len(send) == 0 {
+		return nil
+	}
+
+	lang := settings.Language
+	if lang == "" {
+		lang = s.languages.Default
+	}
+
+	items := make([]notificationItem, 0, len(send))
+	for _, n := range send {
+		items = append(items, s.notificationItem(&n))
+	}
+	data := map[string]any{
+		"Name":     user.Name,
+		"SiteName": s.siteName,
+		"SiteURL":  s.frontendURL + "/",
+		"Items":    items,
+		"Item":     items[0],
+	}
+
+	template := "notification_digest"
+	if len(items) == 1 {
+		template = "notification_" + items[0].Type
+	}
+	msg, err := s.templates.Render(template, lang, data)
+	if err != nil {
+		return err
+	}
+	msg.From = s.from
+	msg.To = user.Email
+
+	attempt := 1
+	for _, n := range send {
+		attempt = max(attempt, n.Attempts+1)
+	}
+
+	delivery := &domain.EmailDelivery{
+		UserID:        userID,
+		Recipient:     user.Email,
+		Template:      template,
+		Subject:       msg.Subject,
+		Notifications: len(send),
+		Status:        domain.EmailNotificationSent,
+		Attempt:       attempt,
+	}
+	sendErr := s.sender.Send(ctx, msg)
+	if sendErr != nil {
+		delivery.Status = domain.EmailNotificationFailed
+		delivery.Error = sendErr.Error()
+	}
+	if err := s.notificationRepo.RecordDelivery(ctx, delivery); err != nil {
+		return err
+	}
+
+	ids := notificationIDs(send)
+	switch {
+	case sendErr == nil:
+		return s.notificationRepo.MarkSent(ctx, ids, delivery.ID)
+	case attempt >= s.cfg.MaxAttempts:
+		if err := s.notificationRepo.MarkFailed(ctx, ids); err != nil {
+			return err
+		}
+	default:
+		if err := s.notificationRepo.Reschedule(ctx, ids, time.Now().Add(s.cfg.RetryInterval<<(attempt-1))); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("failed to send email (attempt %d): %w", attempt, sendErr)
+}
+
+func (s *notificationService) notificationItem(n *domain.EmailNotification) notificationItem {
+	item := notificationItem{
+		Type:      n.Type,
+		Actor:     n.ActorName,
+		PostTitle: n.PostTitle,
+		URL:       s.frontendURL + "/blog/" + n.PostSlug,
+		Excerpt:   excerpt(n.PostPreview, notificationExcerptLength),
+	}
+	if n.Type == domain.NotificationTypeCommentReply || n.Type == domain.NotificationTypeCommentMention {
+		if n.CommentID != nil {
+			item.URL += "#" + domain.CommentAnchor(*n.CommentID)
+		} else {
+			item.URL += "#comments"
+		}
+		item.Excerpt = excerpt(n.CommentContent, notificationExcerptLength)
+	}
+	return item
+}
+
+func notificationIDs(notifications []domain.EmailNotification) []int {
+	ids := make([]int, 0, len(notifications))
+	for _, n := range notifications {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+// excerpt shortens text to at most n characters, cutting at a word boundary
+func excerpt(text string, n int) string {
+	text = strings.TrimSpace(text)
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	cut := string(runes[:n])
+	if i := strings.LastIndexAny(cut, " \n\t"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " \n\t.,;:") + "…"
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockNotificationRepository is a mock implementation of NotificationRepository
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) GetSettings(ctx context.Context, userID int) (*domain.NotificationSettings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.NotificationSettings), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockNotificationRepository) UpsertSettings(ctx context.Context, settings *domain.NotificationSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) CreateNotification(ctx context.Context, notification *domain.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) EnqueueCommentMention(ctx context.Context, userID, postID, commentID int) error {
+	args := m.Called(ctx, userID, postID, commentID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) EnqueueCommentReply(ctx context.Context, userID, postID, commentID int) error {
+	args := m.Called(ctx, userID, postID, commentID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) EnqueueNewPost(ctx context.Context, postID, authorID int) (int64, error) {
+	args := m.Called(ctx, postID, authorID)
+	return args.Get(0).(int64), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockNotificationRepository) ListDueUsers(ctx context.Context, batchedBefore, sentBefore time.Time, limit int) ([]int, error) {
+	args := m.Called(ctx, batchedBefore, sentBefore, limit)
+	return args.Get(0).([]int), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockNotificationRepository) ListPending(ctx context.Context, userID int) ([]domain.EmailNotification, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.EmailNotification), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockNotificationRepository) MarkSent(ctx context.Context, ids []int, deliveryID int) error {
+	args := m.Called(ctx, ids, deliveryID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) Reschedule(ctx context.Context, ids []int, nextAttemptAt time.Time) error {
+	args := m.Called(ctx, ids, nextAttemptAt)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkFailed(ctx context.Context, ids []int) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkSkipped(ctx context.Context, ids []int) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) RecordDelivery(ctx context.Context, delivery *domain.EmailDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+// fakeSender records sent messages and fails with err when it is set
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []*mailer.Message
+	err  error
+}
+
+func (s *fakeSender) Send(_ context.Context, msg *mailer.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func newTestNotificationService(repo *MockNotificationRepository, authRepo *MockAuthRepository, postRepo *MockPostRepository, sender mailer.Sender) NotificationService {
+	cfg := &config.Config{
+		Profile:   config.ProfileConfig{Name: "Test Blog"},
+		OAuth:     config.OAuth{FrontendURL: "https://blog.example"},
+		SMTP:      config.SMTP{From: "Test Blog <blog@blog.example>"},
+		Languages: config.Languages{Default: "ru", Supported: []string{"ru", "en"}},
+		Notifications: config.Notifications{
+			EmailEnabled:  true,
+			BatchDelay:    5 * time.Minute,
+			MinInterval:   time.Hour,
+			MaxAttempts:   3,
+			RetryInterval: 5 * time.Minute,
+		},
+	}
+	return NewNotificationService(repo, authRepo, postRepo, sender, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestNotificationService_CommentCreated(t *testing.T) {
+	ctx := context.Background()
+	post := &domain.Post{ID: 1}
+	parent := &domain.Comment{ID: 10, UserID: 2}
+
+	t.Run("Reply notifies the parent author", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		service := newTestNotificationService(repo, new(MockAuthRepository), new(MockPostRepository), &fakeSender{})
+
+		repo.On("GetSettings", mock.Anything, 2).Return(&domain.NotificationSettings{UserID: 2, EmailEnabled: true}, nil)
+		repo.On("EnqueueCommentReply", mock.Anything, 2, 1, 11).Return(nil)
+
+		service.CommentCreated(ctx, post, &domain.Comment{ID: 11, PostID: 1, UserID: 3}, parent)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Email disabled by the user", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		service := newTestNotificationService(repo, new(MockAuthRepository), new(MockPostRepository), &fakeSender{})
+
+		repo.On("GetSettings", mock.Anything, 2).Return(&domain.NotificationSettings{UserID: 2, EmailEnabled: false}, nil)
+
+		service.CommentCreated(ctx, post, &domain.Comment{ID: 11, PostID: 1, UserID: 3}, parent)
+		repo.AssertNotCalled(t, "EnqueueCommentReply", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Top-level comments and replies to yourself are ignored", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		service := newTestNotificationService(repo, new(MockAuthRepository), new(MockPostRepository), &fakeSender{})
+
+		service.CommentCreated(ctx, post, &domain.Comment{ID: 11, PostID: 1, UserID: 3}, nil)
+		service.CommentCreated(ctx, post, &domain.Comment{ID: 12, PostID: 1, UserID: 2}, parent)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Mentions notify mentioned users once", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		authRepo := new(MockAuthRepository)
+		service := newTestNotificationService(repo, authRepo, new(MockPostRepository), &fakeSender{})
+
+		authRepo.On("GetUserByID", mock.Anything, 3).Return(&domain.User{ID: 3, Name: "Petr"}, nil).Once()
+		repo.On("GetSettings", mock.Anything, 2).Return(&domain.NotificationSettings{UserID: 2, EmailEnabled: true}, nil)
+		repo.On("GetSettings", mock.Anything, 4).Return(&domain.NotificationSettings{UserID: 4, EmailEnabled: true, Language: "en"}, nil)
+		repo.On("EnqueueCommentReply", mock.Anything, 2, 1, 11).Return(nil)
+		repo.On("EnqueueCommentMention", mock.Anything, 4, 1, 11).Return(nil)
+		repo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.UserID == 2 && n.Type == domain.NotificationTypeCommentMention && *n.CommentID == 11
+		})).Return(nil)
+		repo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.UserID == 4 && n.Title == "Petr mentioned you" && n.Message == "Hello: Hi @Ivan" &&
+				*n.PostID == 1 && *n.CommentID == 11
+		})).Return(nil)
+
+		// The parent's author is mentioned too but gets only the reply by email
+		comment := &domain.Comment{ID: 11, PostID: 1, UserID: 3, Content: "Hi @Ivan", Mentions: []domain.CommentMention{
+			{UserID: 2, Name: "Anna"},
+			{UserID: 4, Name: "Ivan"},
+		}}
+		service.CommentCreated(ctx, &domain.Post{ID: 1, Title: "Hello", Slug: "hello"}, comment, parent)
+		repo.AssertExpectations(t)
+		authRepo.AssertExpectations(t)
+		repo.AssertNumberOfCalls(t, "EnqueueCommentMention", 1)
+		repo.AssertNumberOfCalls(t, "CreateNotification", 2)
+	})
+
+	t.Run("Mentions are stored when email is off", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		authRepo := new(MockAuthRepository)
+		service := newTestNotificationService(repo, authRepo, new(MockPostRepository), &fakeSender{})
+
+		authRepo.On("GetUserByID", mock.Anything, 3).Return(&domain.User{ID: 3, Name: "Petr"}, nil)
+		repo.On("GetSettings", mock.Anything, 4).Return(&domain.NotificationSettings{UserID: 4, EmailEnabled: false}, nil)
+		repo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.UserID == 4 && n.Type == domain.NotificationTypeCommentMention
+		})).Return(nil)
+
+		comment := &domain.Comment{ID: 11, PostID: 1, UserID: 3, Mentions: []domain.CommentMention{{UserID: 4, Name: "Ivan"}}}
+		service.CommentCreated(ctx, post, comment, nil)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "EnqueueCommentMention", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestNotificationService_CommentUpdated(t *testing.T) {
+	ctx := context.Background()
+	mentions := []domain.CommentMention{{UserID: 4, Name: "Ivan"}}
+
+	t.Run("Edit mentions are stored and queued", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		authRepo := new(MockAuthRepository)
+		postRepo := new(MockPostRepository)
+		service := newTestNotificationService(repo, authRepo, postRepo, &fakeSender{})
+
+		postRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Title: "Hello", Slug: "hello"}, nil)
+		authRepo.On("GetUserByID", mock.Anything, 3).Return(&domain.User{ID: 3, Name: "Petr"}, nil)
+		repo.On("GetSettings", mock.Anything, 4).Return(&domain.NotificationSettings{UserID: 4, EmailEnabled: true}, nil)
+		repo.On("CreateNotification", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.UserID == 4 && *n.CommentID == 11
+		})).Return(nil)
+		repo.On("EnqueueCommentMention", mock.Anything, 4, 1, 11).Return(nil)
+
+		service.CommentUpdated(ctx, &domain.Comment{
+			ID: 11, PostID: 1, UserID: 3, ModerationStatus: domain.CommentStatusApproved, Mentions: mentions,
+		})
+		repo.AssertExpectations(t)
+		postRepo.AssertExpectations(t)
+	})
+
+	t.Run("Held comments notify nobody", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		service := newTestNotificationService(repo, new(MockAuthRepository), new(MockPostRepository), &fakeSender{})
+
+		service.CommentUpdated(ctx, &domain.Comment{
+			ID: 11, PostID: 1, UserID: 3, ModerationStatus: domain.CommentStatusPending, Mentions: mentions,
+		})
+		repo.AssertNotCalled(t, "GetSettings", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "CreateNotification", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNotificationService_PostPublished(t *testing.T) {
+	repo := new(MockNotificationRepository)
+	service := newTestNotificationService(repo, new(MockAuthRepository), new(MockPostRepository), &fakeSender{})
+
+	repo.On("EnqueueNewPost", mock.Anything, 5, 1).Return(int64(3), nil)
+
+	service.PostPublished(context.Background(), &domain.Post{ID: 5, AuthorID: 1, Published: true})
+	service.PostPublished(context.Background(), &domain.Post{ID: 6, AuthorID: 1})
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "EnqueueNewPost", 1)
+}
+
+func TestNotificationService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	enabled := false
+
+	t.Run("Omitted fields are kept", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		service := newTestNotificationService(repo, new(MockAuthRepository), new(MockPostRepository), &fakeSender{})
+
+		repo.On("GetSettings", mock.Anything, 2).Return(&domain.NotificationSettings{UserID: 2, EmailEnabled: true, PushEnabled: true, NewPostsEnabled: true}, nil)
+		repo.On("UpsertSettings", mock.Anything, mock.MatchedBy(func(s *domain.NotificationSettings) bool {
+			return s.EmailEnabled && s.PushEnabled && !s.NewPostsEnabled && s.Language == "en"
+		})).Return(nil)
+
+		lang := "EN"
+		settings, err := service.UpdateSettings(ctx, 2, &domain.UpdateNotificationSettingsRequest{NewPostsEnabled: &enabled, Language: &lang})
+		require.NoError(t, err)
+		assert.Equal(t, "en", settings.Language)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Unsupported language", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		service := newTestNotificationService(repo, new(MockAuthRepository), new(MockPostRepository), &fakeSender{})
+
+		repo.On("GetSettings", mock.Anything, 2).Return(&domain.NotificationSettings{UserID: 2}, nil)
+
+		lang := "de"
+		_, err := service.UpdateSettings(ctx, 2, &domain.UpdateNotificationSettingsRequest{Language: &lang})
+		assert.ErrorIs(t, err, derr.ErrValidation)
+		repo.AssertNotCalled(t, "UpsertSettings", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNotificationService_ProcessEmails(t *testing.T) {
+	ctx := context.Background()
+	commentID := 11
+	reply := domain.EmailNotification{
+		ID: 1, UserID: 2, Type: domain.NotificationTypeCommentReply, PostID: 1, CommentID: &commentID,
+		PostTitle: "Hello", PostSlug: "hello", CommentContent: "Nice point", ActorName: "Bob",
+	}
+	newPost := domain.EmailNotification{
+		ID: 2, UserID: 2, Type: domain.NotificationTypeNewPost, PostID: 3,
+		PostTitle: "Second post", PostSlug: "second", PostPreview: "About things",
+	}
+	user := &domain.User{ID: 2, Email: "alice@example.com", Name: "Alice"}
+
+	setup := func(sender *fakeSender, pending []domain.EmailNotification, settings *domain.NotificationSettings) (NotificationService, *MockNotificationRepository) {
+		repo := new(MockNotificationRepository)
+		authRepo := new(MockAuthRepository)
+		repo.On("ListDueUsers", mock.Anything, mock.Anything, mock.Anything, notificationUsersPerRun).Return([]int{2}, nil)
+		repo.On("ListPending", mock.Anything, 2).Return(pending, nil)
+		repo.On("GetSettings", mock.Anything, 2).Return(settings, nil)
+		authRepo.On("GetUserByID", mock.Anything, 2).Return(user, nil)
+		return newTestNotificationService(repo, authRepo, new(MockPostRepository), sender), repo
+	}
+
+	t.Run("Single notification in the user's language", func(t *testing.T) {
+		sender := &fakeSender{}
+		service, repo := setup(sender, []domain.EmailNotification{reply}, &domain.NotificationSettings{UserID: 2, EmailEnabled: true, Language: "en"})
+
+		repo.On("RecordDelivery", mock.Anything, mock.MatchedBy(func(d *domain.EmailDelivery) bool {
+			return d.Status == domain.EmailNotificationSent && d.Template == "notification_comment_reply" && d.Attempt == 1 && d.Notifications == 1
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.EmailDelivery).ID = 7
+		}).Return(nil)
+		repo.On("MarkSent", mock.Anything, []int{1}, 7).Return(nil)
+
+		require.NoError(t, service.ProcessEmails(ctx))
+		repo.AssertExpectations(t)
+
+		require.Len(t, sender.sent, 1)
+		msg := sender.sent[0]
+		assert.Equal(t, "alice@example.com", msg.To)
+		assert.Equal(t, `Bob replied to your comment on "Hello"`, msg.Subject)
+		assert.Contains(t, msg.Text, "Nice point")
+		assert.Contains(t, msg.Text, "https://blog.example/blog/hello#comment-11")
+		assert.Contains(t, msg.HTML, "Nice point")
+	})
+
+	t.Run("Several notifications are batched into a digest", func(t *testing.T) {
+		sender := &fakeSender{}
+		service, repo := setup(sender, []domain.EmailNotification{reply, newPost}, &domain.NotificationSettings{UserID: 2, EmailEnabled: true, NewPostsEnabled: true, Language: "en"})
+
+		repo.On("RecordDelivery", mock.Anything, mock.MatchedBy(func(d *domain.EmailDelivery) bool {
+			return d.Template == "notification_digest" && d.Notifications == 2
+		})).Return(nil)
+		repo.On("MarkSent", mock.Anything, []int{1, 2}, 0).Return(nil)
+
+		require.NoError(t, service.ProcessEmails(ctx))
+		repo.AssertExpectations(t)
+
+		require.Len(t, sender.sent, 1)
+		assert.Contains(t, sender.sent[0].Text, "Nice point")
+		assert.Contains(t, sender.sent[0].Text, "Second post")
+	})
+
+	t.Run("Withdrawn and unwanted notifications are skipped", func(t *testing.T) {
+		sender := &fakeSender{}
+		withdrawn := reply
+		withdrawn.Withdrawn = true
+		service, repo := setup(sender, []domain.EmailNotification{withdrawn, newPost}, &domain.NotificationSettings{UserID: 2, EmailEnabled: true, NewPostsEnabled: false})
+
+		repo.On("MarkSkipped", mock.Anything, []int{1, 2}).Return(nil)
+
+		require.NoError(t, service.ProcessEmails(ctx))
+		repo.AssertExpectations(t)
+		assert.Empty(t, sender.sent)
+	})
+
+	t.Run("Failed email is retried with backoff", func(t *testing.T) {
+		sender := &fakeSender{err: errors.New("connection refused")}
+		retried := reply
+		retried.Attempts = 1
+		service, repo := setup(sender, []domain.EmailNotification{retried}, &domain.NotificationSettings{UserID: 2, EmailEnabled: true})
+
+		repo.On("RecordDelivery", mock.Anything, mock.MatchedBy(func(d *domain.EmailDelivery) bool {
+			return d.Status == domain.EmailNotificationFailed && d.Attempt == 2 && d.Error == "connection refused"
+		})).Return(nil)
+		repo.On("Reschedule", mock.Anything, []int{1}, mock.MatchedBy(func(next time.Time) bool {
+			return next.Sub(time.Now()) > 9*time.Minute
+		})).Return(nil)
+
+		// Failures are logged, the worker keeps going
+		require.NoError(t, service.ProcessEmails(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Gives up after the last attempt", func(t *testing.T) {
+		sender := &fakeSender{err: errors.New("connection refused")}
+		retried := reply
+		retried.Attempts = 2
+		service, repo := setup(sender, []domain.EmailNotification{retried}, &domain.NotificationSettings{UserID: 2, EmailEnabled: true})
+
+		repo.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+		repo.On("MarkFailed", mock.Anything, []int{1}).Return(nil)
+
+		require.NoError(t, service.ProcessEmails(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Default language is used when the user has none", func(t *testing.T) {
+		sender := &fakeSender{}
+		service, repo := setup(sender, []domain.EmailNotification{newPost}, &domain.NotificationSettings{UserID: 2, EmailEnabled: true, NewPostsEnabled: true})
+
+		repo.On("RecordDelivery", mock.Anything, mock.Anything).Return(nil)
+		repo.On("MarkSent", mock.Anything, []int{2}, 0).Return(nil)
+
+		require.NoError(t, service.ProcessEmails(ctx))
+		require.Len(t, sender.sent, 1)
+		assert.Equal(t, "Новый пост: Second post", sender.sent[0].Subject)
+	})
+}
+
+func TestExcerpt(t *testing.T) {
+	assert.Equal(t, "short", excerpt("  short ", 10))
+	assert.Equal(t, "one two…", excerpt("one two three", 10))
+	assert.Equal(t, "абвгд…", excerpt("абвгдежзик", 5))
+}
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"personal-web-platform/config"
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 	"personal-web-platform/internal/pkg/slugify"
 	"personal-web-platform/internal/pkg/validator"
 	"personal-web-platform/internal/repository"
@@ -16,18 +19,46 @@ type PostService interface {
 	UpdatePost(ctx context.Context, postID int, req *domain.UpdatePostRequest, userID int, isAdmin bool) (*domain.Post, error)
 	DeletePost(ctx context.Context, postID int, userID int, isAdmin bool) error
 	GetPostByID(ctx context.Context, id, userID int) (*domain.Post, error)
-	GetPostBySlug(ctx context.Context, slug string, userID int) (*domain.Post, error)
+	GetPostBySlug(ctx context.Context, slug string, userID int, pref domain.LanguagePreference) (*domain.Post, error)
 	ListPosts(ctx context.Context, req *domain.ListPostsRequest) (*domain.PostsListResponse, error)
+
+	// Translations (admin)
+	ListTranslations(ctx context.Context, postID int) ([]domain.PostTranslation, error)
+	UpsertTranslation(ctx context.Context, postID int, lang string, req *domain.UpsertTranslationRequest) (*domain.PostTranslation, error)
+	DeleteTranslation(ctx context.Context, postID int, lang string) error
+
+	// Bulk operations (admin)
+	BulkUpdatePosts(ctx context.Context, req *domain.BulkPostsRequest) (*domain.BulkResponse, error)
+}
+
+// PostPublishedHook is notified after a post becomes published.
+// Implementations must not block, the hook runs on the request path.
+type PostPublishedHook interface {
+	PostPublished(ctx context.Context, post *domain.Post)
+}
+
+// PostUpdatedHook can be implemented by a publish hook that also wants to
+// know when an already published post is edited
+type PostUpdatedHook interface {
+	PostUpdated(ctx context.Context, post *domain.Post)
 }
 
 type postService struct {
-	postRepo repository.PostRepository
+	postRepo        repository.PostRepository
+	translationRepo repository.PostTranslationRepository
+	transactor      repository.Transactor
+	languages       config.Languages
+	hooks           []PostPublishedHook
 }
 
 // NewPostService creates a new post service implementation
-func NewPostService(postRepo repository.PostRepository) PostService {
+func NewPostService(postRepo repository.PostRepository, translationRepo repository.PostTranslationRepository, transactor repository.Transactor, languages config.Languages, hooks ...PostPublishedHook) PostService {
 	return &postService{
-		postRepo: postRepo,
+		postRepo:        postRepo,
+		translationRepo: translationRepo,
+		transactor:      transactor,
+		languages:       languages,
+		hooks:           hooks,
 	}
 }
 
@@ -37,13 +68,18 @@ func (s *postService) CreatePost(ctx context.Context, req *domain.CreatePostRequ
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	lang, err := s.postLanguage(req.Language)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate slug from title
 	slug := slugify.Generate(req.Title)
 	if slug == "" {
 		return nil, fmt.Errorf("failed to generate slug from title")
 	}
 
-	// Check if slug already exists
+	// Check if slug already exists (either as a post or a translation slug)
 	existingPost, err := s.postRepo.GetBySlug(ctx, slug, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check slug uniqueness: %w", err)
@@ -54,13 +90,18 @@ func (s *postService) CreatePost(ctx context.Context, req *domain.CreatePostRequ
 
 	// Create post
 	post := &domain.Post{
-		Title:      req.Title,
-		Slug:       slug,
-		Content:    req.Content,
-		Preview:    req.Preview,
-		AuthorID:   authorID,
-		Published:  req.Published,
-		CoverImage: req.CoverImage,
+		Title:                     req.Title,
+		Slug:                      slug,
+		Content:                   req.Content,
+		Preview:                   req.Preview,
+		Language:                  lang,
+		AuthorID:                  authorID,
+		Published:                 req.Published,
+		CoverImage:                req.CoverImage,
+		Series:                    strings.TrimSpace(req.Series),
+		CommentsDisabled:          req.CommentsDisabled,
+		CommentsCloseAfterDays:    req.CommentsCloseAfterDays,
+		CommentsMinAccountAgeDays: req.CommentsMinAccountAgeDays,
 	}
 
 	createdPost, err := s.postRepo.Create(ctx, post)
@@ -68,6 +109,10 @@ func (s *postService) CreatePost(ctx context.Context, req *domain.CreatePostRequ
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	if createdPost.Published {
+		s.notifyPublished(ctx, createdPost)
+	}
+
 	return createdPost, nil
 }
 
@@ -91,6 +136,15 @@ func (s *postService) UpdatePost(ctx context.Context, postID int, req *domain.Up
 		return nil, fmt.Errorf("permission denied: you can only edit your own posts")
 	}
 
+	// Keep the current language unless explicitly changed
+	lang := post.Language
+	if req.Language != "" || lang == "" {
+		lang, err = s.postLanguage(req.Language)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate new slug if title changed
 	newSlug := slugify.Generate(req.Title)
 	if newSlug == "" {
@@ -108,19 +162,42 @@ func (s *postService) UpdatePost(ctx context.Context, postID int, req *domain.Up
 		}
 	}
 
+	wasPublished := post.Published
+
 	// Update post fields
 	post.Title = req.Title
 	post.Slug = newSlug
 	post.Content = req.Content
 	post.Preview = req.Preview
+	post.Language = lang
 	post.Published = req.Published
 	post.CoverImage = req.CoverImage
+	if req.Series != nil {
+		post.Series = strings.TrimSpace(*req.Series)
+	}
+	if req.CommentsDisabled != nil {
+		post.CommentsDisabled = *req.CommentsDisabled
+	}
+	if req.CommentsCloseAfterDays != nil {
+		post.CommentsCloseAfterDays = *req.CommentsCloseAfterDays
+	}
+	if req.CommentsMinAccountAgeDays != nil {
+		post.CommentsMinAccountAgeDays = *req.CommentsMinAccountAgeDays
+	}
 
 	updatedPost, err := s.postRepo.Update(ctx, post)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
 
+	if updatedPost.Published && updatedPost.DeletedAt == nil {
+		if wasPublished {
+			s.notifyUpdated(ctx, updatedPost)
+		} else {
+			s.notifyPublished(ctx, updatedPost)
+		}
+	}
+
 	return updatedPost, nil
 }
 
@@ -157,21 +234,40 @@ func (s *postService) GetPostByID(ctx context.Context, id, userID int) (*domain.
 	return post, nil
 }
 
-func (s *postService) GetPostBySlug(ctx context.Context, slug string, userID int) (*domain.Post, error) {
+func (s *postService) GetPostBySlug(ctx context.Context, slug string, userID int, pref domain.LanguagePreference) (*domain.Post, error) {
+	// The repository resolves both original and translated slugs
 	post, err := s.postRepo.GetBySlug(ctx, slug, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
-	if post == nil {
+	if post == nil || post.DeletedAt != nil {
 		return nil, fmt.Errorf("post not found")
 	}
+
+	translations, err := s.translationRepo.ListByPostID(ctx, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post translations: %w", err)
+	}
+
+	// A translated slug pins the page to that language
+	var pinned string
+	if post.Slug != slug {
+		for _, tr := range translations {
+			if tr.Slug == slug {
+				pinned = tr.Language
+				break
+			}
+		}
+	}
+
+	s.localize(post, translations, pref, pinned)
 	return post, nil
 }
 
 func (s *postService) ListPosts(ctx context.Context, req *domain.ListPostsRequest) (*domain.PostsListResponse, error) {
 	// Validate request
 	if err := validator.Validate(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("%w: %w", derr.ErrValidation, err)
 	}
 
 	// Set defaults
@@ -187,6 +283,10 @@ func (s *postService) ListPosts(ctx context.Context, req *domain.ListPostsReques
 		return nil, fmt.Errorf("failed to list posts: %w", err)
 	}
 
+	if err := s.localizeList(ctx, posts, req.Language); err != nil {
+		return nil, err
+	}
+
 	// Calculate total pages
 	totalPages := (totalCount + req.Limit - 1) / req.Limit
 
@@ -198,3 +298,19 @@ func (s *postService) ListPosts(ctx context.Context, req *domain.ListPostsReques
 		TotalPages: totalPages,
 	}, nil
 }
+
+// notifyPublished runs publish hooks for a post that just became public
+func (s *postService) notifyPublished(ctx context.Context, post *domain.Post) {
+	for _, hook := range s.hooks {
+		hook.PostPublished(ctx, post)
+	}
+}
+
+// notifyUpdated runs update hooks for a public post that was edited
+func (s *postService) notifyUpdated(ctx context.Context, post *domain.Post) {
+	for _, hook := range s.hooks {
+		if h, ok := hook.(PostUpdatedHook); ok {
+			h.PostUpdated(ctx, post)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/validator"
+)
+
+// errBulkItem marks a per-item failure that does not abort the batch
+var errBulkItem = errors.New("bulk item failed")
+
+func (s *postService) BulkUpdatePosts(ctx context.Context, req *domain.BulkPostsRequest) (*domain.BulkResponse, error) {
+	// Validate request
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", derr.ErrValidation, err)
+	}
+
+	response := &domain.BulkResponse{Action: req.Action, Results: make([]domain.BulkItemResult, 0, len(req.IDs))}
+	var published []int
+	err := s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range uniqueIDs(req.IDs) {
+			changed, err := s.applyPostAction(ctx, id, req)
+			if err != nil && !errors.Is(err, errBulkItem) {
+				return err
+			}
+			addBulkResult(response, id, err)
+			if changed && req.Action == domain.BulkActionPublish && len(s.hooks) > 0 {
+				published = append(published, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run bulk post action: %w", err)
+	}
+
+	// Hooks only see committed posts
+	for _, id := range published {
+		post, err := s.postRepo.GetByID(ctx, id, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get post: %w", err)
+		}
+		if post != nil {
+			s.notifyPublished(ctx, post)
+		}
+	}
+
+	return response, nil
+}
+
+// applyPostAction applies a single bulk action and reports whether the post changed.
+// Logical failures are wrapped with errBulkItem, any other error aborts the whole transaction.
+func (s *postService) applyPostAction(ctx context.Context, id int, req *domain.BulkPostsRequest) (bool, error) {
+	action := req.Action
+	post, err := s.postRepo.GetByID(ctx, id, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return false, fmt.Errorf("%w: post not found", errBulkItem)
+	}
+
+	switch action {
+	case domain.BulkActionPublish, domain.BulkActionUnpublish:
+		if post.DeletedAt != nil {
+			return false, fmt.Errorf("%w: post is deleted", errBulkItem)
+		}
+		published := action == domain.BulkActionPublish
+		if post.Published == published {
+			return false, nil
+		}
+		if err := s.postRepo.SetPublished(ctx, id, published); err != nil {
+			return false, fmt.Errorf("failed to update post: %w", err)
+		}
+	case domain.BulkActionDelete:
+		if post.DeletedAt != nil {
+			return false, fmt.Errorf("%w: post already deleted", errBulkItem)
+		}
+		if err := s.postRepo.SoftDelete(ctx, id); err != nil {
+			return false, fmt.Errorf("failed to delete post: %w", err)
+		}
+	case domain.BulkActionRestore:
+		if post.DeletedAt == nil {
+			return false, fmt.Errorf("%w: post is not deleted", errBulkItem)
+		}
+		if err := s.postRepo.Restore(ctx, id); err != nil {
+			return false, fmt.Errorf("failed to restore post: %w", err)
+		}
+	case domain.BulkActionMoveToSeries:
+		if post.DeletedAt != nil {
+			return false, fmt.Errorf("%w: post is deleted", errBulkItem)
+		}
+		series := strings.TrimSpace(req.Series)
+		if post.Series == series {
+			return false, nil
+		}
+		if err := s.postRepo.SetSeries(ctx, id, series); err != nil {
+			return false, fmt.Errorf("failed to move post to series: %w", err)
+		}
+	default:
+		return false, fmt.Errorf("%w: unsupported action %q", errBulkItem, action)
+	}
+
+	return true, nil
+}
+
+// addBulkResult records the outcome of a single item
+func addBulkResult(response *domain.BulkResponse, id int, err error) {
+	if err != nil {
+		// Strip the errBulkItem prefix, the client only needs the reason
+		msg := strings.TrimPrefix(err.Error(), errBulkItem.Error()+": ")
+		response.Results = append(response.Results, domain.BulkItemResult{ID: id, Error: msg})
+		response.Failed++
+		return
+	}
+	response.Results = append(response.Results, domain.BulkItemResult{ID: id, Success: true})
+	response.Succeeded++
+}
+
+// uniqueIDs removes duplicate IDs preserving order
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPostService_BulkUpdatePosts(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		request       *domain.BulkPostsRequest
+		setupMocks    func(*MockPostRepository)
+		wantErr       error
+		wantAnyErr    bool
+		wantResults   []domain.BulkItemResult
+		wantSucceeded int
+	}{
+		{
+			name:    "publish - per-item results",
+			request: &domain.BulkPostsRequest{IDs: []int{1, 2, 3, 1}, Action: domain.BulkActionPublish},
+			setupMocks: func(m *MockPostRepository) {
+				m.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+				m.On("GetByID", mock.Anything, 2, 0).Return(nil, nil)
+				m.On("GetByID", mock.Anything, 3, 0).Return(&domain.Post{ID: 3, DeletedAt: &deletedAt}, nil)
+				m.On("SetPublished", mock.Anything, 1, true).Return(nil).Once()
+			},
+			wantResults: []domain.BulkItemResult{
+				{ID: 1, Success: true},
+				{ID: 2, Error: "post not found"},
+				{ID: 3, Error: "post is deleted"},
+			},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "unpublish - already unpublished is a no-op",
+			request: &domain.BulkPostsRequest{IDs: []int{1}, Action: domain.BulkActionUnpublish},
+			setupMocks: func(m *MockPostRepository) {
+				m.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: false}, nil)
+			},
+			wantResults:   []domain.BulkItemResult{{ID: 1, Success: true}},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "delete and restore state checks",
+			request: &domain.BulkPostsRequest{IDs: []int{1, 2}, Action: domain.BulkActionDelete},
+			setupMocks: func(m *MockPostRepository) {
+				m.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+				m.On("GetByID", mock.Anything, 2, 0).Return(&domain.Post{ID: 2, DeletedAt: &deletedAt}, nil)
+				m.On("SoftDelete", mock.Anything, 1).Return(nil)
+			},
+			wantResults: []domain.BulkItemResult{
+				{ID: 1, Success: true},
+				{ID: 2, Error: "post already deleted"},
+			},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "restore",
+			request: &domain.BulkPostsRequest{IDs: []int{1, 2}, Action: domain.BulkActionRestore},
+			setupMocks: func(m *MockPostRepository) {
+				m.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, DeletedAt: &deletedAt}, nil)
+				m.On("GetByID", mock.Anything, 2, 0).Return(&domain.Post{ID: 2}, nil)
+				m.On("Restore", mock.Anything, 1).Return(nil)
+			},
+			wantResults: []domain.BulkItemResult{
+				{ID: 1, Success: true},
+				{ID: 2, Error: "post is not deleted"},
+			},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "move to series",
+			request: &domain.BulkPostsRequest{IDs: []int{1, 2, 3}, Action: domain.BulkActionMoveToSeries, Series: " Go internals "},
+			setupMocks: func(m *MockPostRepository) {
+				m.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Series: "Drafts"}, nil)
+				m.On("GetByID", mock.Anything, 2, 0).Return(&domain.Post{ID: 2, Series: "Go internals"}, nil)
+				m.On("GetByID", mock.Anything, 3, 0).Return(&domain.Post{ID: 3, DeletedAt: &deletedAt}, nil)
+				m.On("SetSeries", mock.Anything, 1, "Go internals").Return(nil).Once()
+			},
+			wantResults: []domain.BulkItemResult{
+				{ID: 1, Success: true},
+				{ID: 2, Success: true},
+				{ID: 3, Error: "post is deleted"},
+			},
+			wantSucceeded: 2,
+		},
+		{
+			name:    "move to series - empty series removes posts from their series",
+			request: &domain.BulkPostsRequest{IDs: []int{1}, Action: domain.BulkActionMoveToSeries},
+			setupMocks: func(m *MockPostRepository) {
+				m.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Series: "Go internals"}, nil)
+				m.On("SetSeries", mock.Anything, 1, "").Return(nil).Once()
+			},
+			wantResults:   []domain.BulkItemResult{{ID: 1, Success: true}},
+			wantSucceeded: 1,
+		},
+		{
+			name:    "error - repository failure aborts the batch",
+			request: &domain.BulkPostsRequest{IDs: []int{1, 2}, Action: domain.BulkActionDelete},
+			setupMocks: func(m *MockPostRepository) {
+				m.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+				m.On("SoftDelete", mock.Anything, 1).Return(errors.New("db error"))
+			},
+			wantAnyErr: true,
+		},
+		{
+			name:       "error - unknown action",
+			request:    &domain.BulkPostsRequest{IDs: []int{1}, Action: "move"},
+			setupMocks: func(_ *MockPostRepository) {},
+			wantErr:    derr.ErrValidation,
+		},
+		{
+			name:       "error - empty ids",
+			request:    &domain.BulkPostsRequest{Action: domain.BulkActionPublish},
+			setupMocks: func(_ *MockPostRepository) {},
+			wantErr:    derr.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockPostRepository)
+			mockTransactor := new(MockTransactor)
+			mockTransactor.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil).Maybe()
+			tt.setupMocks(mockRepo)
+
+			service := NewPostService(mockRepo, newTranslationRepoStub(), mockTransactor, testLanguages)
+			response, err := service.BulkUpdatePosts(context.Background(), tt.request)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, response)
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+				assert.Nil(t, response)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.request.Action, response.Action)
+				assert.Equal(t, tt.wantResults, response.Results)
+				assert.Equal(t, tt.wantSucceeded, response.Succeeded)
+				assert.Equal(t, len(tt.wantResults)-tt.wantSucceeded, response.Failed)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPostService_GetPostBySlug_Deleted(t *testing.T) {
+	deletedAt := time.Now()
+	mockRepo := new(MockPostRepository)
+	mockRepo.On("GetBySlug", mock.Anything, "trashed", 0).Return(&domain.Post{ID: 1, Slug: "trashed", DeletedAt: &deletedAt}, nil)
+
+	service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
+	post, err := service.GetPostBySlug(context.Background(), "trashed", 0, domain.LanguagePreference{})
+
+	assert.Error(t, err)
+	assert.Nil(t, post)
+}
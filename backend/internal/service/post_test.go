@@ -38,6 +38,26 @@ func (m *MockPostRepository) Delete(ctx context.Context, id int) error {
 	return args.Error(0)
 }
 
+func (m *MockPostRepository) SoftDelete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) Restore(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) SetPublished(ctx context.Context, id int, published bool) error {
+	args := m.Called(ctx, id, published)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) SetSeries(ctx context.Context, id int, series string) error {
+	args := m.Called(ctx, id, series)
+	return args.Error(0)
+}
+
 func (m *MockPostRepository) GetByID(ctx context.Context, id, userID int) (*domain.Post, error) {
 	args := m.Called(ctx, id, userID)
 	if args.Get(0) == nil {
@@ -149,7 +169,7 @@ func TestPostService_CreatePost(t *testing.T) {
 			mockRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewPostService(mockRepo)
+			service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
 			post, err := service.CreatePost(context.Background(), tt.request, tt.authorID)
 
 			if tt.wantErr {
@@ -284,7 +304,7 @@ func TestPostService_UpdatePost(t *testing.T) {
 			mockRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewPostService(mockRepo)
+			service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
 			post, err := service.UpdatePost(context.Background(), tt.postID, tt.request, tt.userID, tt.isAdmin)
 
 			if tt.wantErr {
@@ -303,6 +323,46 @@ func TestPostService_UpdatePost(t *testing.T) {
 	}
 }
 
+func TestPostService_UpdatePost_DiscussionSettings(t *testing.T) {
+	existing := func() *domain.Post {
+		return &domain.Post{ID: 1, Slug: "same-title", AuthorID: 1, CommentsCloseAfterDays: 30, CommentsMinAccountAgeDays: 7}
+	}
+	request := func() *domain.UpdatePostRequest {
+		return &domain.UpdatePostRequest{Title: "Same Title", Content: "Content of the post"}
+	}
+
+	t.Run("Kept when omitted", func(t *testing.T) {
+		mockRepo := new(MockPostRepository)
+		mockRepo.On("GetByID", mock.Anything, 1, 0).Return(existing(), nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Post) bool {
+			return !p.CommentsDisabled && p.CommentsCloseAfterDays == 30 && p.CommentsMinAccountAgeDays == 7
+		})).Return(&domain.Post{ID: 1}, nil)
+
+		service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
+		_, err := service.UpdatePost(context.Background(), 1, request(), 1, false)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Changed when given", func(t *testing.T) {
+		mockRepo := new(MockPostRepository)
+		mockRepo.On("GetByID", mock.Anything, 1, 0).Return(existing(), nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.Post) bool {
+			return p.CommentsDisabled && p.CommentsCloseAfterDays == 0 && p.CommentsMinAccountAgeDays == 7
+		})).Return(&domain.Post{ID: 1}, nil)
+
+		disabled, closeAfter := true, 0
+		req := request()
+		req.CommentsDisabled = &disabled
+		req.CommentsCloseAfterDays = &closeAfter
+
+		service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
+		_, err := service.UpdatePost(context.Background(), 1, req, 1, false)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestPostService_DeletePost(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -373,7 +433,7 @@ func TestPostService_DeletePost(t *testing.T) {
 			mockRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewPostService(mockRepo)
+			service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
 			err := service.DeletePost(context.Background(), tt.postID, tt.userID, tt.isAdmin)
 
 			if tt.wantErr {
@@ -471,7 +531,7 @@ func TestPostService_ListPosts(t *testing.T) {
 			mockRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewPostService(mockRepo)
+			service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
 			result, err := service.ListPosts(context.Background(), tt.request)
 
 			if tt.wantErr {
@@ -535,7 +595,7 @@ func TestPostService_GetPostByID(t *testing.T) { //nolint:dupl // similar test p
 			mockRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewPostService(mockRepo)
+			service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
 			post, err := service.GetPostByID(context.Background(), tt.postID, 0)
 
 			if tt.wantErr {
@@ -600,8 +660,8 @@ func TestPostService_GetPostBySlug(t *testing.T) { //nolint:dupl // similar test
 			mockRepo := new(MockPostRepository)
 			tt.setupMock(mockRepo)
 
-			service := NewPostService(mockRepo)
-			post, err := service.GetPostBySlug(context.Background(), tt.slug, 0)
+			service := NewPostService(mockRepo, newTranslationRepoStub(), new(MockTransactor), testLanguages)
+			post, err := service.GetPostBySlug(context.Background(), tt.slug, 0, domain.LanguagePreference{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -619,3 +679,86 @@ func TestPostService_GetPostBySlug(t *testing.T) { //nolint:dupl // similar test
 		})
 	}
 }
+
+// recordingHook records posts passed to PostPublished and PostUpdated
+type recordingHook struct {
+	published []int
+	updated   []int
+}
+
+func (h *recordingHook) PostPublished(_ context.Context, post *domain.Post) {
+	h.published = append(h.published, post.ID)
+}
+
+func (h *recordingHook) PostUpdated(_ context.Context, post *domain.Post) {
+	h.updated = append(h.updated, post.ID)
+}
+
+func TestPostService_PublishHooks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create published post", func(t *testing.T) {
+		mockRepo := new(MockPostRepository)
+		hook := &recordingHook{}
+		service := NewPostService(mockRepo, nil, nil, testLanguages, hook)
+
+		mockRepo.On("GetBySlug", mock.Anything, "hello-world", 0).Return(nil, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&domain.Post{ID: 1, Published: true}, nil)
+
+		_, err := service.CreatePost(ctx, &domain.CreatePostRequest{Title: "Hello World", Content: "Long enough content", Published: true}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1}, hook.published)
+	})
+
+	t.Run("create draft", func(t *testing.T) {
+		mockRepo := new(MockPostRepository)
+		hook := &recordingHook{}
+		service := NewPostService(mockRepo, nil, nil, testLanguages, hook)
+
+		mockRepo.On("GetBySlug", mock.Anything, "hello-world", 0).Return(nil, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&domain.Post{ID: 1}, nil)
+
+		_, err := service.CreatePost(ctx, &domain.CreatePostRequest{Title: "Hello World", Content: "Long enough content"}, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, hook.published)
+	})
+
+	t.Run("update notifies publish once, later edits as updates", func(t *testing.T) {
+		for _, wasPublished := range []bool{false, true} {
+			mockRepo := new(MockPostRepository)
+			hook := &recordingHook{}
+			service := NewPostService(mockRepo, nil, nil, testLanguages, hook)
+
+			mockRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Slug: "hello-world", AuthorID: 1, Language: "ru", Published: wasPublished}, nil)
+			mockRepo.On("Update", mock.Anything, mock.Anything).Return(&domain.Post{ID: 1, Published: true}, nil)
+
+			_, err := service.UpdatePost(ctx, 1, &domain.UpdatePostRequest{Title: "Hello World", Content: "Long enough content", Published: true}, 1, false)
+			assert.NoError(t, err)
+			if wasPublished {
+				assert.Empty(t, hook.published)
+				assert.Equal(t, []int{1}, hook.updated)
+			} else {
+				assert.Equal(t, []int{1}, hook.published)
+				assert.Empty(t, hook.updated)
+			}
+		}
+	})
+
+	t.Run("bulk publish notifies after commit", func(t *testing.T) {
+		mockRepo := new(MockPostRepository)
+		mockTx := new(MockTransactor)
+		hook := &recordingHook{}
+		service := NewPostService(mockRepo, nil, mockTx, testLanguages, hook)
+
+		mockTx.On("RunInTransaction", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, 2, 0).Return(&domain.Post{ID: 2, Published: true}, nil)
+		mockRepo.On("SetPublished", mock.Anything, 1, true).Return(nil)
+		mockRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil).Once()
+
+		_, err := service.BulkUpdatePosts(ctx, &domain.BulkPostsRequest{IDs: []int{1, 2}, Action: domain.BulkActionPublish})
+		assert.NoError(t, err)
+		// Post 2 was already published
+		assert.Equal(t, []int{1}, hook.published)
+	})
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/language"
+	"personal-web-platform/internal/pkg/slugify"
+	"personal-web-platform/internal/pkg/validator"
+)
+
+func (s *postService) ListTranslations(ctx context.Context, postID int) ([]domain.PostTranslation, error) {
+	post, err := s.postRepo.GetByID(ctx, postID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	translations, err := s.translationRepo.ListByPostID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list translations: %w", err)
+	}
+	return translations, nil
+}
+
+func (s *postService) UpsertTranslation(ctx context.Context, postID int, lang string, req *domain.UpsertTranslationRequest) (*domain.PostTranslation, error) {
+	// Validate request
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", derr.ErrValidation, err)
+	}
+
+	lang = language.Normalize(lang)
+	if !language.Contains(s.languages.Supported, lang) {
+		return nil, fmt.Errorf("%w: unsupported language %q", derr.ErrValidation, lang)
+	}
+
+	post, err := s.postRepo.GetByID(ctx, postID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil {
+		return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	if lang == s.originalLanguage(post) {
+		return nil, fmt.Errorf("%w: %q is the original language of the post", derr.ErrValidation, lang)
+	}
+
+	slug := slugify.Generate(req.Title)
+	if slug == "" {
+		return nil, fmt.Errorf("%w: failed to generate slug from title", derr.ErrValidation)
+	}
+
+	// Translations often keep the original title, so fall back to a language suffix
+	taken, err := s.isTranslationSlugTaken(ctx, slug, postID, lang)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		slug = slug + "-" + lang
+		taken, err = s.isTranslationSlugTaken(ctx, slug, postID, lang)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, fmt.Errorf("%w: post with this slug already exists", derr.ErrConflict)
+		}
+	}
+
+	translation, err := s.translationRepo.Upsert(ctx, &domain.PostTranslation{
+		PostID:   postID,
+		Language: lang,
+		Title:    req.Title,
+		Slug:     slug,
+		Content:  req.Content,
+		Preview:  req.Preview,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	return translation, nil
+}
+
+func (s *postService) DeleteTranslation(ctx context.Context, postID int, lang string) error {
+	lang = language.Normalize(lang)
+
+	translations, err := s.translationRepo.ListByPostID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to list translations: %w", err)
+	}
+
+	found := false
+	for _, tr := range translations {
+		if tr.Language == lang {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: translation not found", derr.ErrNotFound)
+	}
+
+	if err := s.translationRepo.Delete(ctx, postID, lang); err != nil {
+		return fmt.Errorf("failed to delete translation: %w", err)
+	}
+	return nil
+}
+
+// isTranslationSlugTaken reports whether slug is used by any post or by a
+// translation other than the (postID, lang) pair being saved
+func (s *postService) isTranslationSlugTaken(ctx context.Context, slug string, postID int, lang string) (bool, error) {
+	post, err := s.postRepo.GetBySlug(ctx, slug, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to check slug uniqueness: %w", err)
+	}
+	// GetBySlug also resolves translated slugs, those are checked below
+	if post != nil && (post.ID != postID || post.Slug == slug) {
+		return true, nil
+	}
+
+	translation, err := s.translationRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return false, fmt.Errorf("failed to check slug uniqueness: %w", err)
+	}
+	if translation != nil && (translation.PostID != postID || translation.Language != lang) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// postLanguage validates the language of a post, falling back to the default one
+func (s *postService) postLanguage(lang string) (string, error) {
+	if lang == "" {
+		return s.languages.Default, nil
+	}
+
+	lang = language.Normalize(lang)
+	if !language.Contains(s.languages.Supported, lang) {
+		return "", fmt.Errorf("validation failed: unsupported language %q", lang)
+	}
+	return lang, nil
+}
+
+// originalLanguage returns the language the post was written in
+func (s *postService) originalLanguage(post *domain.Post) string {
+	if post.Language != "" {
+		return post.Language
+	}
+	return s.languages.Default
+}
+
+// localize replaces post content with the best matching translation and
+// fills hreflang alternates. pinned is the language implied by the requested
+// slug, empty when the original slug was used.
+func (s *postService) localize(post *domain.Post, translations []domain.PostTranslation, pref domain.LanguagePreference, pinned string) {
+	original := s.originalLanguage(post)
+
+	available := make([]string, 0, len(translations)+1)
+	available = append(available, original)
+	alternates := make([]domain.PostAlternate, 0, len(translations)+1)
+	alternates = append(alternates, domain.PostAlternate{Language: original, Slug: post.Slug, Default: true})
+	for _, tr := range translations {
+		available = append(available, tr.Language)
+		alternates = append(alternates, domain.PostAlternate{Language: tr.Language, Slug: tr.Slug})
+	}
+
+	// Priority: explicit ?lang=, then the language of the slug, then Accept-Language
+	chosen := ""
+	if pref.Explicit != "" {
+		chosen = language.Match([]string{pref.Explicit}, available)
+	}
+	if chosen == "" {
+		chosen = pinned
+	}
+	if chosen == "" {
+		chosen = language.Match(pref.Accepted, available)
+	}
+
+	post.Language = original
+	if len(translations) > 0 {
+		post.Alternates = alternates
+	}
+
+	if chosen == "" || chosen == original {
+		return
+	}
+
+	for _, tr := range translations {
+		if tr.Language == chosen {
+			post.Title = tr.Title
+			post.Slug = tr.Slug
+			post.Content = tr.Content
+			post.Preview = tr.Preview
+			post.Language = tr.Language
+			return
+		}
+	}
+}
+
+// localizeList applies language negotiation to every post of a page
+func (s *postService) localizeList(ctx context.Context, posts []domain.Post, pref domain.LanguagePreference) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+
+	translations, err := s.translationRepo.ListByPostIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get post translations: %w", err)
+	}
+
+	byPost := make(map[int][]domain.PostTranslation, len(posts))
+	for _, tr := range translations {
+		byPost[tr.PostID] = append(byPost[tr.PostID], tr)
+	}
+
+	for i := range posts {
+		s.localize(&posts[i], byPost[posts[i].ID], pref, "")
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testLanguages = config.Languages{Default: "ru", Supported: []string{"ru", "en"}}
+
+// MockPostTranslationRepository is a mock implementation of PostTranslationRepository
+type MockPostTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockPostTranslationRepository) Upsert(ctx context.Context, translation *domain.PostTranslation) (*domain.PostTranslation, error) {
+	args := m.Called(ctx, translation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.PostTranslation), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockPostTranslationRepository) Delete(ctx context.Context, postID int, language string) error {
+	args := m.Called(ctx, postID, language)
+	return args.Error(0)
+}
+
+func (m *MockPostTranslationRepository) GetBySlug(ctx context.Context, slug string) (*domain.PostTranslation, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.PostTranslation), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockPostTranslationRepository) ListByPostID(ctx context.Context, postID int) ([]domain.PostTranslation, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).([]domain.PostTranslation), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockPostTranslationRepository) ListByPostIDs(ctx context.Context, postIDs []int) ([]domain.PostTranslation, error) {
+	args := m.Called(ctx, postIDs)
+	return args.Get(0).([]domain.PostTranslation), args.Error(1) //nolint:errcheck // mock method
+}
+
+// newTranslationRepoStub returns a translation repository without any translations
+func newTranslationRepoStub() *MockPostTranslationRepository {
+	m := new(MockPostTranslationRepository)
+	m.On("GetBySlug", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	m.On("ListByPostID", mock.Anything, mock.Anything).Return([]domain.PostTranslation{}, nil).Maybe()
+	m.On("ListByPostIDs", mock.Anything, mock.Anything).Return([]domain.PostTranslation{}, nil).Maybe()
+	return m
+}
+
+func TestPostService_GetPostBySlug_Localization(t *testing.T) {
+	translations := []domain.PostTranslation{
+		{PostID: 5, Language: "en", Title: "Hello", Slug: "hello", Content: "English content", Preview: "EN"},
+	}
+
+	newPost := func() *domain.Post {
+		return &domain.Post{ID: 5, Title: "Privet", Slug: "privet", Content: "Russian content", Language: "ru"}
+	}
+
+	tests := []struct {
+		name         string
+		slug         string
+		pref         domain.LanguagePreference
+		wantLanguage string
+		wantSlug     string
+	}{
+		{
+			name:         "original language without preferences",
+			slug:         "privet",
+			wantLanguage: "ru",
+			wantSlug:     "privet",
+		},
+		{
+			name:         "accept-language selects translation",
+			slug:         "privet",
+			pref:         domain.LanguagePreference{Accepted: []string{"en-us", "ru"}},
+			wantLanguage: "en",
+			wantSlug:     "hello",
+		},
+		{
+			name:         "unavailable language falls back to original",
+			slug:         "privet",
+			pref:         domain.LanguagePreference{Accepted: []string{"de"}},
+			wantLanguage: "ru",
+			wantSlug:     "privet",
+		},
+		{
+			name:         "translated slug pins its language",
+			slug:         "hello",
+			pref:         domain.LanguagePreference{Accepted: []string{"ru"}},
+			wantLanguage: "en",
+			wantSlug:     "hello",
+		},
+		{
+			name:         "explicit lang overrides translated slug",
+			slug:         "hello",
+			pref:         domain.LanguagePreference{Explicit: "ru", Accepted: []string{"en"}},
+			wantLanguage: "ru",
+			wantSlug:     "privet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := new(MockPostRepository)
+			translationRepo := new(MockPostTranslationRepository)
+
+			postRepo.On("GetBySlug", mock.Anything, tt.slug, 1).Return(newPost(), nil)
+			translationRepo.On("ListByPostID", mock.Anything, 5).Return(translations, nil)
+
+			service := NewPostService(postRepo, translationRepo, new(MockTransactor), testLanguages)
+			post, err := service.GetPostBySlug(context.Background(), tt.slug, 1, tt.pref)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLanguage, post.Language)
+			assert.Equal(t, tt.wantSlug, post.Slug)
+			assert.Equal(t, []domain.PostAlternate{
+				{Language: "ru", Slug: "privet", Default: true},
+				{Language: "en", Slug: "hello"},
+			}, post.Alternates)
+		})
+	}
+}
+
+func TestPostService_ListPosts_Localization(t *testing.T) {
+	postRepo := new(MockPostRepository)
+	translationRepo := new(MockPostTranslationRepository)
+
+	postRepo.On("List", mock.Anything, mock.Anything).Return([]domain.Post{
+		{ID: 1, Title: "Pervyi", Slug: "pervyi", Language: "ru"},
+		{ID: 2, Title: "Vtoroi", Slug: "vtoroi", Language: "ru"},
+	}, 2, nil)
+	translationRepo.On("ListByPostIDs", mock.Anything, []int{1, 2}).Return([]domain.PostTranslation{
+		{PostID: 2, Language: "en", Title: "Second", Slug: "second"},
+	}, nil)
+
+	service := NewPostService(postRepo, translationRepo, new(MockTransactor), testLanguages)
+	result, err := service.ListPosts(context.Background(), &domain.ListPostsRequest{
+		Language: domain.LanguagePreference{Explicit: "en"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Pervyi", result.Posts[0].Title)
+	assert.Equal(t, "ru", result.Posts[0].Language)
+	assert.Empty(t, result.Posts[0].Alternates)
+	assert.Equal(t, "Second", result.Posts[1].Title)
+	assert.Equal(t, "en", result.Posts[1].Language)
+	assert.Len(t, result.Posts[1].Alternates, 2)
+}
+
+func TestPostService_UpsertTranslation(t *testing.T) {
+	validRequest := &domain.UpsertTranslationRequest{
+		Title:   "Hello World",
+		Content: "English content of the post",
+		Preview: "Preview",
+	}
+
+	tests := []struct {
+		name       string
+		lang       string
+		request    *domain.UpsertTranslationRequest
+		setupMocks func(*MockPostRepository, *MockPostTranslationRepository)
+		wantSlug   string
+		wantErr    error
+	}{
+		{
+			name:    "success - new translation",
+			lang:    "EN",
+			request: validRequest,
+			setupMocks: func(p *MockPostRepository, tr *MockPostTranslationRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Slug: "privet-mir", Language: "ru"}, nil)
+				p.On("GetBySlug", mock.Anything, "hello-world", 0).Return(nil, nil)
+				tr.On("GetBySlug", mock.Anything, "hello-world").Return(nil, nil)
+				tr.On("Upsert", mock.Anything, mock.MatchedBy(func(t *domain.PostTranslation) bool {
+					return t.PostID == 1 && t.Language == "en" && t.Slug == "hello-world"
+				})).Return(&domain.PostTranslation{ID: 1, PostID: 1, Language: "en", Slug: "hello-world"}, nil)
+			},
+			wantSlug: "hello-world",
+		},
+		{
+			name:    "success - slug collision gets language suffix",
+			lang:    "en",
+			request: validRequest,
+			setupMocks: func(p *MockPostRepository, tr *MockPostTranslationRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Slug: "hello-world", Language: "ru"}, nil)
+				p.On("GetBySlug", mock.Anything, "hello-world", 0).Return(&domain.Post{ID: 1, Slug: "hello-world"}, nil)
+				p.On("GetBySlug", mock.Anything, "hello-world-en", 0).Return(nil, nil)
+				tr.On("GetBySlug", mock.Anything, "hello-world-en").Return(nil, nil)
+				tr.On("Upsert", mock.Anything, mock.Anything).Return(&domain.PostTranslation{ID: 1, Slug: "hello-world-en"}, nil)
+			},
+			wantSlug: "hello-world-en",
+		},
+		{
+			name:    "error - unsupported language",
+			lang:    "de",
+			request: validRequest,
+			setupMocks: func(_ *MockPostRepository, _ *MockPostTranslationRepository) {
+			},
+			wantErr: derr.ErrValidation,
+		},
+		{
+			name:    "error - original language",
+			lang:    "ru",
+			request: validRequest,
+			setupMocks: func(p *MockPostRepository, _ *MockPostTranslationRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Language: "ru"}, nil)
+			},
+			wantErr: derr.ErrValidation,
+		},
+		{
+			name:    "error - post not found",
+			lang:    "en",
+			request: validRequest,
+			setupMocks: func(p *MockPostRepository, _ *MockPostTranslationRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(nil, nil)
+			},
+			wantErr: derr.ErrNotFound,
+		},
+		{
+			name:    "error - invalid request",
+			lang:    "en",
+			request: &domain.UpsertTranslationRequest{Title: "Hi"},
+			setupMocks: func(_ *MockPostRepository, _ *MockPostTranslationRepository) {
+			},
+			wantErr: derr.ErrValidation,
+		},
+		{
+			name:    "error - slug taken by another post",
+			lang:    "en",
+			request: validRequest,
+			setupMocks: func(p *MockPostRepository, _ *MockPostTranslationRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Language: "ru"}, nil)
+				p.On("GetBySlug", mock.Anything, "hello-world", 0).Return(&domain.Post{ID: 2, Slug: "hello-world"}, nil)
+				p.On("GetBySlug", mock.Anything, "hello-world-en", 0).Return(&domain.Post{ID: 3, Slug: "hello-world-en"}, nil)
+			},
+			wantErr: derr.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			postRepo := new(MockPostRepository)
+			translationRepo := new(MockPostTranslationRepository)
+			tt.setupMocks(postRepo, translationRepo)
+
+			service := NewPostService(postRepo, translationRepo, new(MockTransactor), testLanguages)
+			translation, err := service.UpsertTranslation(context.Background(), 1, tt.lang, tt.request)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, translation)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantSlug, translation.Slug)
+			}
+
+			postRepo.AssertExpectations(t)
+			translationRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPostService_DeleteTranslation(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		translationRepo := new(MockPostTranslationRepository)
+		translationRepo.On("ListByPostID", mock.Anything, 1).Return([]domain.PostTranslation{{PostID: 1, Language: "en"}}, nil)
+		translationRepo.On("Delete", mock.Anything, 1, "en").Return(nil)
+
+		service := NewPostService(new(MockPostRepository), translationRepo, new(MockTransactor), testLanguages)
+		assert.NoError(t, service.DeleteTranslation(context.Background(), 1, "en"))
+		translationRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		translationRepo := new(MockPostTranslationRepository)
+		translationRepo.On("ListByPostID", mock.Anything, 1).Return([]domain.PostTranslation{}, nil)
+
+		service := NewPostService(new(MockPostRepository), translationRepo, new(MockTransactor), testLanguages)
+		assert.ErrorIs(t, service.DeleteTranslation(context.Background(), 1, "en"), derr.ErrNotFound)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		translationRepo := new(MockPostTranslationRepository)
+		translationRepo.On("ListByPostID", mock.Anything, 1).Return([]domain.PostTranslation{}, errors.New("db error"))
+
+		service := NewPostService(new(MockPostRepository), translationRepo, new(MockTransactor), testLanguages)
+		assert.Error(t, service.DeleteTranslation(context.Background(), 1, "en"))
+	})
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/mailer"
+	"personal-web-platform/internal/pkg/netguard"
+	"personal-web-platform/internal/pkg/validator"
+	"personal-web-platform/internal/pkg/webpush"
+	"personal-web-platform/internal/repository"
+)
+
+const (
+	// pushExcerptLength keeps reply excerpts short, notifications show a line or two
+	pushExcerptLength = 120
+	// maxUserAgentLength matches the user_agent column
+	maxUserAgentLength = 255
+)
+
+// PushService defines methods for Web Push subscriptions and delivery
+type PushService interface {
+	// PublicKey is the VAPID key browsers subscribe with
+	PublicKey() string
+	Subscribe(ctx context.Context, userID int, req *domain.PushSubscriptionRequest, userAgent string) error
+	Unsubscribe(ctx context.Context, userID int, req *domain.PushUnsubscribeRequest) error
+
+	// CommentCreated and PostPublished push the event to subscribed browsers in the background
+	CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment)
+	PostPublished(ctx context.Context, post *domain.Post)
+	// Wait blocks until background pushes finish or ctx is done
+	Wait(ctx context.Context) error
+}
+
+// pushMessage is the payload the service worker turns into a notification
+type pushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url"`
+	// Tag replaces an earlier notification about the same thing
+	Tag string `json:"tag"`
+}
+
+type pushService struct {
+	pushRepo    repository.PushRepository
+	authRepo    repository.AuthRepository
+	client      *webpush.Client
+	vapid       *webpush.VAPID
+	resolver    netguard.Resolver
+	templates   *mailer.Templates
+	cfg         config.Push
+	language    string
+	siteName    string
+	frontendURL string
+	log         *slog.Logger
+
+	// deliveries tracks background sending
+	deliveries sync.WaitGroup
+}
+
+// NewPushService creates a new push service implementation.
+// Keys are validated when the config is loaded. Endpoints are supplied by
+// users, so the client never connects to internal addresses.
+func NewPushService(pushRepo repository.PushRepository, authRepo repository.AuthRepository, cfg *config.Config, log *slog.Logger) PushService {
+	s := &pushService{
+		pushRepo:    pushRepo,
+		authRepo:    authRepo,
+		resolver:    net.DefaultResolver,
+		templates:   emailTemplates(cfg.Languages.Default),
+		cfg:         cfg.Push,
+		language:    cfg.Languages.Default,
+		siteName:    cfg.Profile.Name,
+		frontendURL: strings.TrimSuffix(cfg.OAuth.FrontendURL, "/"),
+		log:         log,
+	}
+
+	vapid, err := webpush.NewVAPID(cfg.Push.VAPIDPublicKey, cfg.Push.VAPIDPrivateKey, cfg.Push.Subject)
+	if err != nil {
+		log.Error("push notifications are disabled", slog.String("error", err.Error()))
+		return s
+	}
+	s.vapid = vapid
+	s.client = webpush.NewClient(netguard.NewClient(cfg.Push.Timeout), vapid)
+	return s
+}
+
+func (s *pushService) PublicKey() string {
+	if s.vapid == nil {
+		return ""
+	}
+	return s.vapid.PublicKey()
+}
+
+func (s *pushService) Subscribe(ctx context.Context, userID int, req *domain.PushSubscriptionRequest, userAgent string) error {
+	if err := validator.Validate(req); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+	if err := webpush.ValidateEndpoint(req.Endpoint); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+	// Push services are public, anything else would make us post to the internal network
+	endpoint, err := url.Parse(req.Endpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+	if err := netguard.CheckHost(ctx, s.resolver, endpoint.Hostname()); err != nil {
+		return fmt.Errorf("%w: push endpoint: %w", derr.ErrValidation, err)
+	}
+	if err := (webpush.Keys{P256dh: req.Keys.P256dh, Auth: req.Keys.Auth}).Validate(); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	if runes := []rune(userAgent); len(runes) > maxUserAgentLength {
+		userAgent = string(runes[:maxUserAgentLength])
+	}
+
+	sub := &domain.PushSubscription{
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: userAgent,
+	}
+	if err := s.pushRepo.Save(ctx, sub); err != nil {
+		return fmt.Errorf("failed to save push subscription: %w", err)
+	}
+
+	return nil
+}
+
+// Unsubscribe is idempotent, browsers may unsubscribe a stale endpoint
+func (s *pushService) Unsubscribe(ctx context.Context, userID int, req *domain.PushUnsubscribeRequest) error {
+	if err := validator.Validate(req); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	if _, err := s.pushRepo.Delete(ctx, userID, req.Endpoint); err != nil {
+		return fmt.Errorf("failed to delete push subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (s *pushService) CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment) {
+	if s.client == nil {
+		return
+	}
+
+	// The parent's author is told about the reply, everyone else mentioned about the mention
+	recipients := make(map[int]string)
+	if parent != nil && parent.UserID != comment.UserID {
+		recipients[parent.UserID] = domain.NotificationTypeCommentReply
+	}
+	for _, m := range comment.Mentions {
+		if _, ok := recipients[m.UserID]; !ok && m.UserID != comment.UserID {
+			recipients[m.UserID] = domain.NotificationTypeCommentMention
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	s.sendAsync(ctx, func(ctx context.Context) {
+		// The author is looked up once someone has a subscription
+		actor, actorLoaded := "", false
+
+		for userID, notificationType := range recipients {
+			subs, err := s.pushRepo.ListForUser(ctx, userID)
+			if err != nil {
+				s.log.Error("failed to list push subscriptions", slog.Int("user_id", userID), slog.String("error", err.Error()))
+				continue
+			}
+			if len(subs) == 0 {
+				continue
+			}
+
+			if !actorLoaded {
+				actorLoaded = true
+				if user, err := s.authRepo.GetUserByID(ctx, comment.UserID); err != nil {
+					s.log.Error("failed to get comment author", slog.Int("user_id", comment.UserID), slog.String("error", err.Error()))
+				} else if user != nil {
+					actor = user.Name
+				}
+			}
+
+			item := notificationItem{
+				Type:      notificationType,
+				Actor:     actor,
+				PostTitle: post.Title,
+				URL:       s.frontendURL + "/blog/" + post.Slug + "#" + domain.CommentAnchor(comment.ID),
+				Excerpt:   excerpt(comment.Content, pushExcerptLength),
+			}
+			s.deliver(ctx, subs, item, "comment-"+strconv.Itoa(comment.ID), webpush.UrgencyNormal)
+		}
+	})
+}
+
+func (s *pushService) PostPublished(ctx context.Context, post *domain.Post) {
+	if s.client == nil || !post.Published || post.DeletedAt != nil {
+		return
+	}
+
+	s.sendAsync(ctx, func(ctx context.Context) {
+		subs, err := s.pushRepo.ListForNewPost(ctx, post.AuthorID)
+		if err != nil {
+			s.log.Error("failed to list push subscriptions", slog.Int("post_id", post.ID), slog.String("error", err.Error()))
+			return
+		}
+
+		item := notificationItem{
+			Type:      domain.NotificationTypeNewPost,
+			PostTitle: post.Title,
+			URL:       s.frontendURL + "/blog/" + post.Slug,
+		}
+		s.deliver(ctx, subs, item, "post-"+strconv.Itoa(post.ID), webpush.UrgencyLow)
+	})
+}
+
+// deliver pushes an event to subscriptions in their users' languages and
+// prunes subscriptions the push service reports as gone
+func (s *pushService) deliver(ctx context.Context, subs []domain.PushSubscription, item notificationItem, tag, urgency string) {
+	payloads := make(map[string][]byte)
+	sent := 0
+
+	for _, sub := range subs {
+		lang := sub.Language
+		if lang == "" {
+			lang = s.language
+		}
+		payload, ok := payloads[lang]
+		if !ok {
+			var err error
+			if payload, err = s.payload(item, tag, lang); err != nil {
+				s.log.Error("failed to render push message", slog.String("error", err.Error()))
+				return
+			}
+			payloads[lang] = payload
+		}
+
+		err := s.client.Send(ctx, &webpush.Subscription{
+			Endpoint: sub.Endpoint,
+			Keys:     webpush.Keys{P256dh: sub.P256dh, Auth: sub.Auth},
+		}, payload, webpush.Options{TTL: s.cfg.TTL, Urgency: urgency, Topic: tag})
+
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, webpush.ErrGone), errors.Is(err, netguard.ErrForbiddenAddress):
+			// An endpoint that started resolving to an internal address is dropped like an expired one
+			if err := s.pushRepo.DeleteByEndpoint(ctx, sub.Endpoint); err != nil {
+				s.log.Error("failed to prune push subscription", slog.Int("subscription_id", sub.ID), slog.String("error", err.Error()))
+			}
+		default:
+			s.log.Warn("failed to send push notification",
+				slog.Int("subscription_id", sub.ID), slog.Int("user_id", sub.UserID), slog.String("error", err.Error()))
+		}
+	}
+
+	s.log.Info("push notifications sent", slog.String("tag", tag), slog.Int("sent", sent), slog.Int("subscriptions", len(subs)))
+}
+
+func (s *pushService) payload(item notificationItem, tag, lang string) ([]byte, error) {
+	msg, err := s.templates.Render("push_"+item.Type, lang, map[string]any{
+		"SiteName": s.siteName,
+		"Item":     item,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(pushMessage{
+		Title: msg.Subject,
+		Body:  strings.TrimSpace(msg.Text),
+		URL:   item.URL,
+		Tag:   tag,
+	})
+}
+
+func (s *pushService) Wait(ctx context.Context) error {
+	return waitGroup(ctx, &s.deliveries)
+}
+
+// sendAsync sends pushes in the background, outliving the request and
+// any transaction the event happened in
+func (s *pushService) sendAsync(ctx context.Context, fn func(ctx context.Context)) {
+	ctx = repository.WithoutTransaction(context.WithoutCancel(ctx))
+
+	s.deliveries.Add(1)
+	go func() {
+		defer s.deliveries.Done()
+		fn(ctx)
+	}()
+}
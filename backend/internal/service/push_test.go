@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/webpush"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPushRepository is a mock implementation of PushRepository
+type MockPushRepository struct {
+	mock.Mock
+}
+
+func (m *MockPushRepository) Save(ctx context.Context, sub *domain.PushSubscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockPushRepository) Delete(ctx context.Context, userID int, endpoint string) (bool, error) {
+	args := m.Called(ctx, userID, endpoint)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPushRepository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	args := m.Called(ctx, endpoint)
+	return args.Error(0)
+}
+
+func (m *MockPushRepository) ListForUser(ctx context.Context, userID int) ([]domain.PushSubscription, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.PushSubscription), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockPushRepository) ListForNewPost(ctx context.Context, authorID int) ([]domain.PushSubscription, error) {
+	args := m.Called(ctx, authorID)
+	return args.Get(0).([]domain.PushSubscription), args.Error(1) //nolint:errcheck // mock method
+}
+
+// pushEndpoint is a push service recording the requests it receives
+type pushEndpoint struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+	status   int
+}
+
+func newPushEndpoint(t *testing.T, status int) *pushEndpoint {
+	t.Helper()
+
+	e := &pushEndpoint{status: status}
+	e.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.mu.Lock()
+		e.requests = append(e.requests, r)
+		e.mu.Unlock()
+		w.WriteHeader(e.status)
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func (e *pushEndpoint) Requests() []*http.Request {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.requests
+}
+
+// newPushSubscription creates a subscription with valid browser keys
+func newPushSubscription(t *testing.T, id, userID int, endpoint string) domain.PushSubscription {
+	t.Helper()
+
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	auth := make([]byte, 16)
+	_, err = rand.Read(auth)
+	require.NoError(t, err)
+
+	return domain.PushSubscription{
+		ID:       id,
+		UserID:   userID,
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}
+}
+
+// staticResolver resolves the hosts used in tests without DNS
+type staticResolver map[string]netip.Addr
+
+func (r staticResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	addr, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return []netip.Addr{addr}, nil
+}
+
+func newTestPushService(t *testing.T, repo *MockPushRepository, authRepo *MockAuthRepository, endpoint *pushEndpoint) *pushService {
+	t.Helper()
+
+	publicKey, privateKey, err := webpush.GenerateVAPIDKeys()
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Profile:   config.ProfileConfig{Name: "Test Blog"},
+		OAuth:     config.OAuth{FrontendURL: "https://blog.example"},
+		Languages: config.Languages{Default: "ru", Supported: []string{"ru", "en"}},
+		Push: config.Push{
+			Enabled:         true,
+			VAPIDPublicKey:  publicKey,
+			VAPIDPrivateKey: privateKey,
+			Subject:         "mailto:admin@blog.example",
+			TTL:             time.Hour,
+			Timeout:         time.Second,
+		},
+	}
+	service := NewPushService(repo, authRepo, cfg, slog.New(slog.NewTextHandler(io.Discard, nil))).(*pushService)
+	service.resolver = staticResolver{"push.example": netip.MustParseAddr("93.184.216.34"), "internal.example": netip.MustParseAddr("10.0.0.5")}
+	if endpoint != nil {
+		service.client = webpush.NewClient(endpoint.Client(), service.vapid)
+	}
+	return service
+}
+
+func TestPushService_Subscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Saves the subscription", func(t *testing.T) {
+		repo := new(MockPushRepository)
+		service := newTestPushService(t, repo, new(MockAuthRepository), nil)
+		sub := newPushSubscription(t, 0, 2, "https://push.example/send/abc")
+
+		repo.On("Save", mock.Anything, &domain.PushSubscription{
+			UserID:    2,
+			Endpoint:  sub.Endpoint,
+			P256dh:    sub.P256dh,
+			Auth:      sub.Auth,
+			UserAgent: "Firefox",
+		}).Return(nil)
+
+		err := service.Subscribe(ctx, 2, &domain.PushSubscriptionRequest{
+			Endpoint: sub.Endpoint,
+			Keys:     domain.PushSubscriptionKeys{P256dh: sub.P256dh, Auth: sub.Auth},
+		}, "Firefox")
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects invalid subscriptions", func(t *testing.T) {
+		repo := new(MockPushRepository)
+		service := newTestPushService(t, repo, new(MockAuthRepository), nil)
+		valid := newPushSubscription(t, 0, 2, "https://push.example/send/abc")
+
+		cases := map[string]*domain.PushSubscriptionRequest{
+			"plain http endpoint": {
+				Endpoint: "http://push.example/send/abc",
+				Keys:     domain.PushSubscriptionKeys{P256dh: valid.P256dh, Auth: valid.Auth},
+			},
+			"key not on the curve": {
+				Endpoint: valid.Endpoint,
+				Keys:     domain.PushSubscriptionKeys{P256dh: "BAAA", Auth: valid.Auth},
+			},
+			"short auth secret": {
+				Endpoint: valid.Endpoint,
+				Keys:     domain.PushSubscriptionKeys{P256dh: valid.P256dh, Auth: "AAAA"},
+			},
+			"missing keys": {Endpoint: valid.Endpoint},
+			"internal address": {
+				Endpoint: "https://10.0.0.5/send/abc",
+				Keys:     domain.PushSubscriptionKeys{P256dh: valid.P256dh, Auth: valid.Auth},
+			},
+			"host resolving to an internal address": {
+				Endpoint: "https://internal.example/send/abc",
+				Keys:     domain.PushSubscriptionKeys{P256dh: valid.P256dh, Auth: valid.Auth},
+			},
+			"unknown host": {
+				Endpoint: "https://unknown.example/send/abc",
+				Keys:     domain.PushSubscriptionKeys{P256dh: valid.P256dh, Auth: valid.Auth},
+			},
+		}
+		for name, req := range cases {
+			t.Run(name, func(t *testing.T) {
+				err := service.Subscribe(ctx, 2, req, "")
+				assert.ErrorIs(t, err, derr.ErrValidation)
+			})
+		}
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPushService_Unsubscribe(t *testing.T) {
+	repo := new(MockPushRepository)
+	service := newTestPushService(t, repo, new(MockAuthRepository), nil)
+
+	// Unknown endpoints are not an error
+	repo.On("Delete", mock.Anything, 2, "https://push.example/send/abc").Return(false, nil)
+
+	err := service.Unsubscribe(context.Background(), 2, &domain.PushUnsubscribeRequest{Endpoint: "https://push.example/send/abc"})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestPushService_CommentCreated(t *testing.T) {
+	ctx := context.Background()
+	post := &domain.Post{ID: 1, Title: "Hello", Slug: "hello"}
+	parent := &domain.Comment{ID: 10, UserID: 2}
+
+	t.Run("Reply is pushed to the parent author", func(t *testing.T) {
+		endpoint := newPushEndpoint(t, http.StatusCreated)
+		repo := new(MockPushRepository)
+		authRepo := new(MockAuthRepository)
+		service := newTestPushService(t, repo, authRepo, endpoint)
+
+		repo.On("ListForUser", mock.Anything, 2).Return([]domain.PushSubscription{
+			newPushSubscription(t, 1, 2, endpoint.URL+"/a"),
+			newPushSubscription(t, 2, 2, endpoint.URL+"/b"),
+		}, nil)
+		authRepo.On("GetUserByID", mock.Anything, 3).Return(&domain.User{ID: 3, Name: "Bob"}, nil)
+
+		service.CommentCreated(ctx, post, &domain.Comment{ID: 11, UserID: 3, Content: "Nice"}, parent)
+		require.NoError(t, service.Wait(ctx))
+
+		requests := endpoint.Requests()
+		require.Len(t, requests, 2)
+		assert.Equal(t, "aes128gcm", requests[0].Header.Get("Content-Encoding"))
+		assert.Equal(t, webpush.UrgencyNormal, requests[0].Header.Get("Urgency"))
+		assert.Equal(t, "comment-11", requests[0].Header.Get("Topic"))
+		repo.AssertNotCalled(t, "DeleteByEndpoint", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Own replies and top level comments are not pushed", func(t *testing.T) {
+		repo := new(MockPushRepository)
+		service := newTestPushService(t, repo, new(MockAuthRepository), newPushEndpoint(t, http.StatusCreated))
+
+		service.CommentCreated(ctx, post, &domain.Comment{ID: 11, UserID: 3}, nil)
+		service.CommentCreated(ctx, post, &domain.Comment{ID: 12, UserID: 2}, parent)
+		require.NoError(t, service.Wait(ctx))
+
+		repo.AssertNotCalled(t, "ListForUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPushService_PostPublished(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Gone subscriptions are pruned", func(t *testing.T) {
+		endpoint := newPushEndpoint(t, http.StatusGone)
+		repo := new(MockPushRepository)
+		service := newTestPushService(t, repo, new(MockAuthRepository), endpoint)
+		sub := newPushSubscription(t, 1, 2, endpoint.URL+"/a")
+
+		repo.On("ListForNewPost", mock.Anything, 1).Return([]domain.PushSubscription{sub}, nil)
+		repo.On("DeleteByEndpoint", mock.Anything, sub.Endpoint).Return(nil)
+
+		service.PostPublished(ctx, &domain.Post{ID: 5, AuthorID: 1, Title: "Hello", Slug: "hello", Published: true})
+		require.NoError(t, service.Wait(ctx))
+
+		require.Len(t, endpoint.Requests(), 1)
+		assert.Equal(t, webpush.UrgencyLow, endpoint.Requests()[0].Header.Get("Urgency"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Internal endpoints are refused and pruned", func(t *testing.T) {
+		endpoint := newPushEndpoint(t, http.StatusCreated)
+		repo := new(MockPushRepository)
+		// Without a test endpoint the production client is used, it refuses loopback
+		service := newTestPushService(t, repo, new(MockAuthRepository), nil)
+		sub := newPushSubscription(t, 1, 2, endpoint.URL+"/a")
+
+		repo.On("ListForNewPost", mock.Anything, 1).Return([]domain.PushSubscription{sub}, nil)
+		repo.On("DeleteByEndpoint", mock.Anything, sub.Endpoint).Return(nil)
+
+		service.PostPublished(ctx, &domain.Post{ID: 5, AuthorID: 1, Title: "Hello", Slug: "hello", Published: true})
+		require.NoError(t, service.Wait(ctx))
+
+		assert.Empty(t, endpoint.Requests())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Other failures keep the subscription", func(t *testing.T) {
+		endpoint := newPushEndpoint(t, http.StatusInternalServerError)
+		repo := new(MockPushRepository)
+		service := newTestPushService(t, repo, new(MockAuthRepository), endpoint)
+
+		repo.On("ListForNewPost", mock.Anything, 1).Return([]domain.PushSubscription{
+			newPushSubscription(t, 1, 2, endpoint.URL+"/a"),
+		}, nil)
+
+		service.PostPublished(ctx, &domain.Post{ID: 5, AuthorID: 1, Published: true})
+		require.NoError(t, service.Wait(ctx))
+
+		repo.AssertNotCalled(t, "DeleteByEndpoint", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Drafts are not pushed", func(t *testing.T) {
+		repo := new(MockPushRepository)
+		service := newTestPushService(t, repo, new(MockAuthRepository), nil)
+
+		service.PostPublished(ctx, &domain.Post{ID: 5, AuthorID: 1})
+		require.NoError(t, service.Wait(ctx))
+
+		repo.AssertNotCalled(t, "ListForNewPost", mock.Anything, mock.Anything)
+	})
+
+	t.Run("List error", func(t *testing.T) {
+		repo := new(MockPushRepository)
+		service := newTestPushService(t, repo, new(MockAuthRepository), nil)
+
+		repo.On("ListForNewPost", mock.Anything, 1).Return([]domain.PushSubscription(nil), errors.New("db down"))
+
+		service.PostPublished(ctx, &domain.Post{ID: 5, AuthorID: 1, Published: true})
+		require.NoError(t, service.Wait(ctx))
+
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestPushService_payload(t *testing.T) {
+	service := newTestPushService(t, new(MockPushRepository), new(MockAuthRepository), nil)
+	item := notificationItem{
+		Type:      domain.NotificationTypeCommentReply,
+		Actor:     "Bob",
+		PostTitle: "Hello",
+		URL:       "https://blog.example/blog/hello#comments",
+		Excerpt:   "Nice",
+	}
+
+	payload, err := service.payload(item, "comment-11", "en")
+	require.NoError(t, err)
+
+	var msg pushMessage
+	require.NoError(t, json.Unmarshal(payload, &msg))
+	assert.Equal(t, pushMessage{
+		Title: "Bob replied to your comment",
+		Body:  "Hello: Nice",
+		URL:   "https://blog.example/blog/hello#comments",
+		Tag:   "comment-11",
+	}, msg)
+
+	payload, err = service.payload(item, "comment-11", "ru")
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), "ответил на ваш комментарий")
+}
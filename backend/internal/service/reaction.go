@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/repository"
+)
+
+// ReactionService defines methods for emoji reactions business logic
+type ReactionService interface {
+	AllowedReactions() []string
+	AddReaction(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) ([]domain.ReactionSummary, error)
+	RemoveReaction(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) ([]domain.ReactionSummary, error)
+}
+
+type reactionService struct {
+	reactionRepo repository.ReactionRepository
+	postRepo     repository.PostRepository
+	commentRepo  repository.CommentRepository
+	banRepo      repository.BanRepository
+	allowed      []string
+	hooks        []LikesChangedHook
+}
+
+// NewReactionService creates a new reaction service implementation.
+// Hooks are notified when a "👍" reaction changes the like count.
+func NewReactionService(reactionRepo repository.ReactionRepository, postRepo repository.PostRepository, commentRepo repository.CommentRepository, banRepo repository.BanRepository, cfg config.Reactions, hooks ...LikesChangedHook) ReactionService {
+	// Likes are stored as "👍", so it is always allowed
+	allowed := []string{domain.LikeEmoji}
+	for _, emoji := range cfg.Emoji {
+		if emoji != "" && !slices.Contains(allowed, emoji) {
+			allowed = append(allowed, emoji)
+		}
+	}
+
+	return &reactionService{
+		reactionRepo: reactionRepo,
+		postRepo:     postRepo,
+		commentRepo:  commentRepo,
+		banRepo:      banRepo,
+		allowed:      allowed,
+		hooks:        hooks,
+	}
+}
+
+func (s *reactionService) AllowedReactions() []string {
+	return slices.Clone(s.allowed)
+}
+
+func (s *reactionService) AddReaction(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) ([]domain.ReactionSummary, error) {
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.checkReaction(ctx, target, targetID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adding an existing reaction is a no-op
+	added, err := s.reactionRepo.Add(ctx, target, targetID, userID, emoji)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	summary, err := s.summary(ctx, target, targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if added && emoji == domain.LikeEmoji {
+		s.likesChanged(ctx, target, targetID, comment, summary)
+	}
+
+	return summary, nil
+}
+
+func (s *reactionService) RemoveReaction(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) ([]domain.ReactionSummary, error) {
+	if err := checkNotBanned(ctx, s.banRepo, userID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.checkReaction(ctx, target, targetID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.reactionRepo.Remove(ctx, target, targetID, userID, emoji)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	summary, err := s.summary(ctx, target, targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if removed && emoji == domain.LikeEmoji {
+		s.likesChanged(ctx, target, targetID, comment, summary)
+	}
+
+	return summary, nil
+}
+
+// checkReaction validates the emoji and makes sure the target exists,
+// returning the comment for comment reactions
+func (s *reactionService) checkReaction(ctx context.Context, target domain.ReactionTarget, targetID int, emoji string) (*domain.Comment, error) {
+	if !slices.Contains(s.allowed, emoji) {
+		return nil, fmt.Errorf("%w: unsupported reaction %q", derr.ErrValidation, emoji)
+	}
+
+	switch target {
+	case domain.ReactionTargetPost:
+		post, err := s.postRepo.GetByID(ctx, targetID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get post: %w", err)
+		}
+		if post == nil || post.DeletedAt != nil || !post.Published {
+			return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+		}
+		return nil, nil
+	case domain.ReactionTargetComment:
+		comment, err := s.commentRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get comment: %w", err)
+		}
+		if comment == nil || comment.DeletedAt != nil {
+			return nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound)
+		}
+		return comment, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown reaction target %q", derr.ErrValidation, target)
+	}
+}
+
+// likesChanged notifies hooks about the like count in summary
+func (s *reactionService) likesChanged(ctx context.Context, target domain.ReactionTarget, targetID int, comment *domain.Comment, summary []domain.ReactionSummary) {
+	count := 0
+	for _, r := range summary {
+		if r.Emoji == domain.LikeEmoji {
+			count = r.Count
+		}
+	}
+
+	for _, hook := range s.hooks {
+		if target == domain.ReactionTargetComment {
+			hook.CommentLikesChanged(ctx, comment, count)
+		} else {
+			hook.PostLikesChanged(ctx, targetID, count)
+		}
+	}
+}
+
+func (s *reactionService) summary(ctx context.Context, target domain.ReactionTarget, targetID, userID int) ([]domain.ReactionSummary, error) {
+	summary, err := s.reactionRepo.Summary(ctx, target, targetID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+	return summary, nil
+}
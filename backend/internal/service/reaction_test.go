@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReactionRepository is a mock implementation of ReactionRepository
+type MockReactionRepository struct {
+	mock.Mock
+}
+
+func (m *MockReactionRepository) Add(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error) {
+	args := m.Called(ctx, target, targetID, userID, emoji)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) Remove(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error) {
+	args := m.Called(ctx, target, targetID, userID, emoji)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) Exists(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) (bool, error) {
+	args := m.Called(ctx, target, targetID, userID, emoji)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) Count(ctx context.Context, target domain.ReactionTarget, targetID int, emoji string) (int, error) {
+	args := m.Called(ctx, target, targetID, emoji)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockReactionRepository) Summary(ctx context.Context, target domain.ReactionTarget, targetID, userID int) ([]domain.ReactionSummary, error) {
+	args := m.Called(ctx, target, targetID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).([]domain.ReactionSummary), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockReactionRepository) RemoveByUser(ctx context.Context, target domain.ReactionTarget, userID int) (int64, error) {
+	args := m.Called(ctx, target, userID)
+	return args.Get(0).(int64), args.Error(1) //nolint:errcheck // mock method
+}
+
+var testReactions = config.Reactions{Emoji: []string{"❤️", "🔥", "❤️"}}
+
+func TestReactionService_AllowedReactions(t *testing.T) {
+	service := NewReactionService(new(MockReactionRepository), new(MockPostRepository), new(MockCommentRepository), noBans(), testReactions)
+
+	// "👍" is always first, duplicates are dropped
+	assert.Equal(t, []string{"👍", "❤️", "🔥"}, service.AllowedReactions())
+}
+
+func TestReactionService_AddReaction(t *testing.T) {
+	deletedAt := time.Now()
+	summary := []domain.ReactionSummary{{Emoji: "🔥", Count: 2, Reacted: true}}
+
+	tests := []struct {
+		name       string
+		target     domain.ReactionTarget
+		emoji      string
+		setupMocks func(*MockReactionRepository, *MockPostRepository, *MockCommentRepository)
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{
+			name:   "success - post",
+			target: domain.ReactionTargetPost,
+			emoji:  "🔥",
+			setupMocks: func(r *MockReactionRepository, p *MockPostRepository, _ *MockCommentRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+				r.On("Add", mock.Anything, domain.ReactionTargetPost, 1, 7, "🔥").Return(true, nil)
+				r.On("Summary", mock.Anything, domain.ReactionTargetPost, 1, 7).Return(summary, nil)
+			},
+		},
+		{
+			name:   "success - comment, repeated reaction is a no-op",
+			target: domain.ReactionTargetComment,
+			emoji:  "👍",
+			setupMocks: func(r *MockReactionRepository, _ *MockPostRepository, c *MockCommentRepository) {
+				c.On("GetByID", mock.Anything, 1).Return(&domain.Comment{ID: 1}, nil)
+				r.On("Add", mock.Anything, domain.ReactionTargetComment, 1, 7, "👍").Return(false, nil)
+				r.On("Summary", mock.Anything, domain.ReactionTargetComment, 1, 7).Return(summary, nil)
+			},
+		},
+		{
+			name:       "error - unsupported emoji",
+			target:     domain.ReactionTargetPost,
+			emoji:      "💩",
+			setupMocks: func(_ *MockReactionRepository, _ *MockPostRepository, _ *MockCommentRepository) {},
+			wantErr:    derr.ErrValidation,
+		},
+		{
+			name:   "error - deleted post",
+			target: domain.ReactionTargetPost,
+			emoji:  "🔥",
+			setupMocks: func(_ *MockReactionRepository, p *MockPostRepository, _ *MockCommentRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true, DeletedAt: &deletedAt}, nil)
+			},
+			wantErr: derr.ErrNotFound,
+		},
+		{
+			name:   "error - draft post",
+			target: domain.ReactionTargetPost,
+			emoji:  "🔥",
+			setupMocks: func(_ *MockReactionRepository, p *MockPostRepository, _ *MockCommentRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1}, nil)
+			},
+			wantErr: derr.ErrNotFound,
+		},
+		{
+			name:   "error - comment not found",
+			target: domain.ReactionTargetComment,
+			emoji:  "🔥",
+			setupMocks: func(_ *MockReactionRepository, _ *MockPostRepository, c *MockCommentRepository) {
+				c.On("GetByID", mock.Anything, 1).Return(nil, nil)
+			},
+			wantErr: derr.ErrNotFound,
+		},
+		{
+			name:   "error - repository failure",
+			target: domain.ReactionTargetPost,
+			emoji:  "🔥",
+			setupMocks: func(r *MockReactionRepository, p *MockPostRepository, _ *MockCommentRepository) {
+				p.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+				r.On("Add", mock.Anything, domain.ReactionTargetPost, 1, 7, "🔥").Return(false, errors.New("db error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reactionRepo := new(MockReactionRepository)
+			postRepo := new(MockPostRepository)
+			commentRepo := new(MockCommentRepository)
+			tt.setupMocks(reactionRepo, postRepo, commentRepo)
+
+			service := NewReactionService(reactionRepo, postRepo, commentRepo, noBans(), testReactions)
+			result, err := service.AddReaction(context.Background(), tt.target, 1, 7, tt.emoji)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, summary, result)
+			}
+
+			reactionRepo.AssertExpectations(t)
+			postRepo.AssertExpectations(t)
+			commentRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReactionService_RemoveReaction(t *testing.T) {
+	reactionRepo := new(MockReactionRepository)
+	postRepo := new(MockPostRepository)
+	postRepo.On("GetByID", mock.Anything, 1, 0).Return(&domain.Post{ID: 1, Published: true}, nil)
+	reactionRepo.On("Remove", mock.Anything, domain.ReactionTargetPost, 1, 7, "❤️").Return(true, nil)
+	reactionRepo.On("Summary", mock.Anything, domain.ReactionTargetPost, 1, 7).Return([]domain.ReactionSummary{}, nil)
+
+	service := NewReactionService(reactionRepo, postRepo, new(MockCommentRepository), noBans(), testReactions)
+	result, err := service.RemoveReaction(context.Background(), domain.ReactionTargetPost, 1, 7, "❤️")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	reactionRepo.AssertExpectations(t)
+}
+
+func TestReactionService_LikesChangedHook(t *testing.T) {
+	ctx := context.Background()
+	reactionRepo := new(MockReactionRepository)
+	commentRepo := new(MockCommentRepository)
+	hook := &recordingLikesHook{}
+	service := NewReactionService(reactionRepo, new(MockPostRepository), commentRepo, noBans(), config.Reactions{Emoji: []string{"🔥"}}, hook)
+
+	commentRepo.On("GetByID", mock.Anything, 3).Return(&domain.Comment{ID: 3, PostID: 1}, nil)
+	reactionRepo.On("Add", mock.Anything, domain.ReactionTargetComment, 3, 7, mock.Anything).Return(true, nil)
+	reactionRepo.On("Summary", mock.Anything, domain.ReactionTargetComment, 3, 7).Return([]domain.ReactionSummary{
+		{Emoji: "👍", Count: 5, Reacted: true},
+		{Emoji: "🔥", Count: 1},
+	}, nil)
+
+	// Other emoji don't change likes
+	_, err := service.AddReaction(ctx, domain.ReactionTargetComment, 3, 7, "🔥")
+	assert.NoError(t, err)
+	assert.Zero(t, hook.count)
+
+	_, err = service.AddReaction(ctx, domain.ReactionTargetComment, 3, 7, "👍")
+	assert.NoError(t, err)
+	assert.Equal(t, recordingLikesHook{postID: 1, commentID: 3, count: 5}, *hook)
+}
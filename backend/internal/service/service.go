@@ -4,6 +4,7 @@ package service
 import (
 	"context"
 	"log/slog"
+	"sync"
 
 	"personal-web-platform/config"
 	"personal-web-platform/internal/repository"
@@ -11,25 +12,86 @@ import (
 
 // Services aggregates all service interfaces
 type Services struct {
-	Profile ProfileService
-	Auth    AuthService
-	Post    PostService
-	Comment CommentService
-	Like    LikeService
-	repos   *repository.Repositories
-	cfg     *config.Config
+	Profile      ProfileService
+	Auth         AuthService
+	User         UserService
+	Post         PostService
+	Comment      CommentService
+	Like         LikeService
+	Reaction     ReactionService
+	Bookmark     BookmarkService
+	ActivityPub  ActivityPubService
+	Webmention   WebmentionService
+	Newsletter   NewsletterService
+	Notification NotificationService
+	Push         PushService
+	Event        EventService
+	Counter      CounterService
+	repos        *repository.Repositories
+	cfg          *config.Config
 }
 
 // NewServices creates a new Services instance with all implementations
 func NewServices(repos *repository.Repositories, cfg *config.Config, log *slog.Logger) *Services {
+	sender := NewMailSender(cfg, log)
+	notification := NewNotificationService(repos.Notification, repos.Auth, repos.Post, sender, cfg, log)
+
+	push := NewPushService(repos.Push, repos.Auth, cfg, log)
+	event := NewEventService(repos.Event, repos.Post, repos.Comment, cfg.Events, log)
+
+	// Replies notify the parent comment's author by email and push,
+	// comment and like changes are streamed to readers of the post
+	commentHooks := []CommentCreatedHook{notification}
+	if cfg.Push.Enabled {
+		commentHooks = append(commentHooks, push)
+	}
+	var likeHooks []LikesChangedHook
+	if cfg.Events.Enabled {
+		commentHooks = append(commentHooks, event)
+		likeHooks = append(likeHooks, event)
+	}
+	comment := NewCommentService(repos.Comment, repos.Post, repos.Auth, repos.Ban, repos.Transactor, cfg.Comments, commentHooks...)
+	activityPub := NewActivityPubService(repos.ActivityPub, repos.Post, repos.Comment, repos.Auth, repos.Profile, repos.Transactor, comment, cfg, log)
+
+	webmention := NewWebmentionService(repos.Webmention, repos.Post, cfg, log)
+	newsletter := NewNewsletterService(repos.Subscriber, sender, cfg, log)
+
+	// Posts notify federation, linked sites, subscribers and users when they get published
+	var publishHooks []PostPublishedHook
+	if cfg.ActivityPub.Enabled {
+		publishHooks = append(publishHooks, activityPub)
+	}
+	if cfg.Webmention.Enabled {
+		publishHooks = append(publishHooks, webmention)
+	}
+	if cfg.Newsletter.Enabled {
+		publishHooks = append(publishHooks, newsletter)
+	}
+	if cfg.Notifications.EmailEnabled {
+		publishHooks = append(publishHooks, notification)
+	}
+	if cfg.Push.Enabled {
+		publishHooks = append(publishHooks, push)
+	}
+
 	return &Services{
-		Profile: NewProfileService(repos.Profile, log),
-		Auth:    NewAuthService(repos.Auth, repos.Session, repos.Transactor, cfg, log),
-		Post:    NewPostService(repos.Post),
-		Comment: NewCommentService(repos.Comment, repos.Post),
-		Like:    NewLikeService(repos.Like, repos.Post, repos.Comment),
-		repos:   repos,
-		cfg:     cfg,
+		Profile:      NewProfileService(repos.Profile, log),
+		Auth:         NewAuthService(repos.Auth, repos.Session, repos.Ban, repos.Transactor, cfg, log),
+		User:         NewUserService(repos.Auth, repos.Session, repos.Ban, repos.Comment, repos.Reaction, repos.Transactor),
+		Post:         NewPostService(repos.Post, repos.Translation, repos.Transactor, cfg.Languages, publishHooks...),
+		Comment:      comment,
+		Like:         NewLikeService(repos.Like, repos.Post, repos.Comment, repos.Ban, likeHooks...),
+		Reaction:     NewReactionService(repos.Reaction, repos.Post, repos.Comment, repos.Ban, cfg.Reactions, likeHooks...),
+		Bookmark:     NewBookmarkService(repos.Bookmark, repos.Post),
+		ActivityPub:  activityPub,
+		Webmention:   webmention,
+		Newsletter:   newsletter,
+		Notification: notification,
+		Push:         push,
+		Event:        event,
+		Counter:      NewCounterService(repos.Counter, repos.Transactor, log),
+		repos:        repos,
+		cfg:          cfg,
 	}
 }
 
@@ -42,3 +104,30 @@ func (s *Services) HealthCheck(ctx context.Context) error {
 
 	return nil
 }
+
+// Wait blocks until background deliveries started by requests finish or ctx is done
+func (s *Services) Wait(ctx context.Context) error {
+	if err := s.ActivityPub.Wait(ctx); err != nil {
+		return err
+	}
+	if err := s.Newsletter.Wait(ctx); err != nil {
+		return err
+	}
+	return s.Push.Wait(ctx)
+}
+
+// waitGroup waits for wg, giving up when ctx is done
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
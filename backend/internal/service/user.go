@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/validator"
+	"personal-web-platform/internal/repository"
+)
+
+// UserService defines methods for managing users as an admin
+type UserService interface {
+	ListUsers(ctx context.Context, req *domain.ListUsersRequest) (*domain.UserListResponse, error)
+	GetUser(ctx context.Context, id int) (*domain.UserDetails, error)
+	UpdateUserRole(ctx context.Context, id int, req *domain.UpdateUserRoleRequest, adminID int) (*domain.User, error)
+	// DeleteUser deletes a user, anonymizing or removing their comments as
+	// requested. Likes and reactions are removed.
+	DeleteUser(ctx context.Context, id int, req *domain.DeleteUserRequest, adminID int) error
+
+	// BanUser puts a user in read-only mode and signs them out everywhere
+	BanUser(ctx context.Context, id int, req *domain.BanUserRequest, adminID int) (*domain.UserBan, error)
+	// LiftBan ends the ban in effect for a user and returns it
+	LiftBan(ctx context.Context, id, adminID int) (*domain.UserBan, error)
+	// ListBans returns every ban of a user, newest first
+	ListBans(ctx context.Context, id int) ([]domain.UserBan, error)
+}
+
+type userService struct {
+	authRepo     repository.AuthRepository
+	sessionRepo  repository.SessionRepository
+	banRepo      repository.BanRepository
+	commentRepo  repository.CommentRepository
+	reactionRepo repository.ReactionRepository
+	transactor   repository.Transactor
+}
+
+// NewUserService creates a new user service implementation
+func NewUserService(authRepo repository.AuthRepository, sessionRepo repository.SessionRepository, banRepo repository.BanRepository, commentRepo repository.CommentRepository, reactionRepo repository.ReactionRepository, transactor repository.Transactor) UserService {
+	return &userService{
+		authRepo:     authRepo,
+		sessionRepo:  sessionRepo,
+		banRepo:      banRepo,
+		commentRepo:  commentRepo,
+		reactionRepo: reactionRepo,
+		transactor:   transactor,
+	}
+}
+
+func (s *userService) ListUsers(ctx context.Context, req *domain.ListUsersRequest) (*domain.UserListResponse, error) {
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 {
+		req.Limit = 20
+	}
+
+	users, totalCount, err := s.authRepo.ListUsers(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return &domain.UserListResponse{
+		Users:      users,
+		TotalCount: totalCount,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: (totalCount + req.Limit - 1) / req.Limit,
+	}, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, id int) (*domain.UserDetails, error) {
+	summary, err := s.authRepo.GetUserSummary(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if summary == nil {
+		return nil, fmt.Errorf("%w: user not found", derr.ErrNotFound)
+	}
+
+	providers, err := s.authRepo.ListOAuthProviders(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+
+	sessions, err := s.sessionRepo.ListUserSessions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	summary.Ban, err = s.banRepo.GetActive(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active ban: %w", err)
+	}
+
+	return &domain.UserDetails{
+		UserSummary: *summary,
+		Providers:   providers,
+		Sessions:    sessions,
+	}, nil
+}
+
+func (s *userService) UpdateUserRole(ctx context.Context, id int, req *domain.UpdateUserRoleRequest, adminID int) (*domain.User, error) {
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	// Admins cannot lock themselves out
+	if id == adminID {
+		return nil, fmt.Errorf("%w: cannot change your own role", derr.ErrConflict)
+	}
+
+	existing, err := s.authRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("%w: user not found", derr.ErrNotFound)
+	}
+	if existing.Email == domain.DeletedUserEmail {
+		return nil, fmt.Errorf("%w: the deleted user account cannot be changed", derr.ErrConflict)
+	}
+
+	user, err := s.authRepo.UpdateUserRole(ctx, id, req.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: user not found", derr.ErrNotFound)
+	}
+
+	return user, nil
+}
+
+func (s *userService) DeleteUser(ctx context.Context, id int, req *domain.DeleteUserRequest, adminID int) error {
+	if err := validator.Validate(req); err != nil {
+		return fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+
+	if id == adminID {
+		return fmt.Errorf("%w: cannot delete your own account", derr.ErrConflict)
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return fmt.Errorf("%w: user not found", derr.ErrNotFound)
+	}
+	// Deleting an admin would delete their posts as well
+	if user.Role == domain.RoleAdmin {
+		return fmt.Errorf("%w: change the admin's role before deleting them", derr.ErrConflict)
+	}
+	if user.Email == domain.DeletedUserEmail {
+		return fmt.Errorf("%w: the deleted user account cannot be deleted", derr.ErrConflict)
+	}
+
+	return s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		deletedUser, err := s.deletedUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Removed comments become tombstones first, so that replies by
+		// others stay in their threads. Their edit history and mentions go too.
+		var postIDs []int
+		if req.Comments == domain.UserCommentsRemove {
+			postIDs, err = s.commentRepo.SoftDeleteByUser(ctx, id, deletedCommentContent)
+			if err != nil {
+				return fmt.Errorf("failed to delete comments: %w", err)
+			}
+		}
+
+		// Comments would be deleted along with the user otherwise
+		if _, err := s.commentRepo.ReassignUser(ctx, id, deletedUser.ID); err != nil {
+			return fmt.Errorf("failed to anonymize comments: %w", err)
+		}
+
+		for _, postID := range postIDs {
+			if _, err := s.commentRepo.PurgeTombstones(ctx, postID); err != nil {
+				return fmt.Errorf("failed to purge deleted comments: %w", err)
+			}
+		}
+
+		// Reactions go through the repository to keep like counts right
+		for _, target := range []domain.ReactionTarget{domain.ReactionTargetPost, domain.ReactionTargetComment} {
+			if _, err := s.reactionRepo.RemoveByUser(ctx, target, id); err != nil {
+				return fmt.Errorf("failed to remove reactions: %w", err)
+			}
+		}
+
+		if err := s.authRepo.DeleteUser(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *userService) BanUser(ctx context.Context, id int, req *domain.BanUserRequest, adminID int) (*domain.UserBan, error) {
+	if err := validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %w", derr.ErrValidation, err)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", derr.ErrValidation)
+	}
+
+	if id == adminID {
+		return nil, fmt.Errorf("%w: cannot ban yourself", derr.ErrConflict)
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: user not found", derr.ErrNotFound)
+	}
+	if user.Role == domain.RoleAdmin {
+		return nil, fmt.Errorf("%w: change the admin's role before banning them", derr.ErrConflict)
+	}
+
+	ban := &domain.UserBan{
+		UserID:    id,
+		Reason:    req.Reason,
+		IssuedBy:  &adminID,
+		ExpiresAt: req.ExpiresAt,
+	}
+	err = s.transactor.RunInTransaction(ctx, func(ctx context.Context) error {
+		// Concurrent bans of the user wait here, so only one of them finds no active ban.
+		// A unique index can't do it since whether a ban expired depends on the time.
+		if err := s.banRepo.LockUser(ctx, id); err != nil {
+			return err
+		}
+
+		active, err := s.banRepo.GetActive(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get active ban: %w", err)
+		}
+		if active != nil {
+			return fmt.Errorf("%w: user is already banned", derr.ErrConflict)
+		}
+
+		if err := s.banRepo.Create(ctx, ban); err != nil {
+			return fmt.Errorf("failed to create ban: %w", err)
+		}
+
+		// Signed in again, they are read-only
+		if err := s.sessionRepo.DeleteUserSessions(ctx, id); err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
+
+func (s *userService) LiftBan(ctx context.Context, id, adminID int) (*domain.UserBan, error) {
+	ban, err := s.banRepo.LiftActive(ctx, id, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lift ban: %w", err)
+	}
+	if ban == nil {
+		return nil, fmt.Errorf("%w: user is not banned", derr.ErrNotFound)
+	}
+
+	return ban, nil
+}
+
+func (s *userService) ListBans(ctx context.Context, id int) ([]domain.UserBan, error) {
+	user, err := s.authRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: user not found", derr.ErrNotFound)
+	}
+
+	bans, err := s.banRepo.ListByUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bans: %w", err)
+	}
+
+	return bans, nil
+}
+
+// deletedUser returns the account comments of deleted users are moved to,
+// creating it on first use
+func (s *userService) deletedUser(ctx context.Context) (*domain.User, error) {
+	user, err := s.authRepo.GetUserByEmail(ctx, domain.DeletedUserEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted user account: %w", err)
+	}
+	if user != nil {
+		return user, nil
+	}
+
+	user, err = s.authRepo.CreateUser(ctx, domain.DeletedUserEmail, domain.DeletedUserName, "", domain.RoleUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deleted user account: %w", err)
+	}
+	return user, nil
+}
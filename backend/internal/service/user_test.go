@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type userServiceMocks struct {
+	auth     *MockAuthRepository
+	session  *MockSessionRepository
+	ban      *MockBanRepository
+	comment  *MockCommentRepository
+	reaction *MockReactionRepository
+}
+
+func newTestUserService() (UserService, *userServiceMocks) {
+	m := &userServiceMocks{
+		auth:     new(MockAuthRepository),
+		session:  new(MockSessionRepository),
+		ban:      new(MockBanRepository),
+		comment:  new(MockCommentRepository),
+		reaction: new(MockReactionRepository),
+	}
+	return NewUserService(m.auth, m.session, m.ban, m.comment, m.reaction, passthroughTransactor()), m
+}
+
+func TestUserService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Default paging", func(t *testing.T) {
+		service, m := newTestUserService()
+		users := []domain.UserSummary{{User: domain.User{ID: 2, Email: "bob@example.com"}, CommentsCount: 3}}
+		m.auth.On("ListUsers", ctx, &domain.ListUsersRequest{Search: "bob", Page: 1, Limit: 20}).Return(users, 21, nil)
+
+		resp, err := service.ListUsers(ctx, &domain.ListUsersRequest{Search: "bob"})
+		require.NoError(t, err)
+		assert.Equal(t, users, resp.Users)
+		assert.Equal(t, 21, resp.TotalCount)
+		assert.Equal(t, 2, resp.TotalPages)
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		service, _ := newTestUserService()
+
+		_, err := service.ListUsers(ctx, &domain.ListUsersRequest{Role: "owner"})
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+}
+
+func TestUserService_GetUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserSummary", ctx, 2).Return(&domain.UserSummary{User: domain.User{ID: 2}, LikesCount: 4}, nil)
+		m.auth.On("ListOAuthProviders", ctx, 2).Return([]domain.OAuthProvider{{Provider: "github"}}, nil)
+		m.session.On("ListUserSessions", ctx, 2).Return([]domain.Session{{ID: 7, UserID: 2}}, nil)
+		m.ban.On("GetActive", ctx, 2).Return(&domain.UserBan{ID: 3, UserID: 2, Reason: "spam"}, nil)
+
+		details, err := service.GetUser(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 4, details.LikesCount)
+		require.NotNil(t, details.Ban)
+		assert.Equal(t, "spam", details.Ban.Reason)
+		require.Len(t, details.Providers, 1)
+		assert.Equal(t, "github", details.Providers[0].Provider)
+		require.Len(t, details.Sessions, 1)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserSummary", ctx, 9).Return(nil, nil)
+
+		_, err := service.GetUser(ctx, 9)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+}
+
+func TestUserService_UpdateUserRole(t *testing.T) {
+	ctx := context.Background()
+	promote := &domain.UpdateUserRoleRequest{Role: domain.RoleAdmin}
+
+	t.Run("Promote", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 2).Return(&domain.User{ID: 2, Role: domain.RoleUser}, nil)
+		m.auth.On("UpdateUserRole", ctx, 2, domain.RoleAdmin).Return(&domain.User{ID: 2, Role: domain.RoleAdmin}, nil)
+
+		user, err := service.UpdateUserRole(ctx, 2, promote, 1)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, user.Role)
+	})
+
+	t.Run("Own role", func(t *testing.T) {
+		service, m := newTestUserService()
+
+		_, err := service.UpdateUserRole(ctx, 1, &domain.UpdateUserRoleRequest{Role: domain.RoleUser}, 1)
+		assert.ErrorIs(t, err, derr.ErrConflict)
+		m.auth.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		service, _ := newTestUserService()
+
+		_, err := service.UpdateUserRole(ctx, 2, &domain.UpdateUserRoleRequest{Role: "owner"}, 1)
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 9).Return(nil, nil)
+
+		_, err := service.UpdateUserRole(ctx, 9, promote, 1)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+}
+
+func TestUserService_DeleteUser(t *testing.T) {
+	ctx := context.Background()
+	deletedUser := &domain.User{ID: 50, Email: domain.DeletedUserEmail, Name: domain.DeletedUserName}
+
+	expectReactionsRemoved := func(m *userServiceMocks) {
+		m.reaction.On("RemoveByUser", ctx, domain.ReactionTargetPost, 2).Return(int64(1), nil)
+		m.reaction.On("RemoveByUser", ctx, domain.ReactionTargetComment, 2).Return(int64(0), nil)
+	}
+
+	t.Run("Anonymize comments", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 2).Return(&domain.User{ID: 2, Role: domain.RoleUser}, nil)
+		m.auth.On("GetUserByEmail", ctx, domain.DeletedUserEmail).Return(deletedUser, nil)
+		m.comment.On("ReassignUser", ctx, 2, 50).Return(int64(3), nil)
+		expectReactionsRemoved(m)
+		m.auth.On("DeleteUser", ctx, 2).Return(nil)
+
+		require.NoError(t, service.DeleteUser(ctx, 2, &domain.DeleteUserRequest{}, 1))
+		m.comment.AssertNotCalled(t, "SoftDeleteByUser", mock.Anything, mock.Anything, mock.Anything)
+		m.auth.AssertExpectations(t)
+		m.reaction.AssertExpectations(t)
+	})
+
+	t.Run("Remove comments", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 2).Return(&domain.User{ID: 2, Role: domain.RoleUser}, nil)
+		// The deleted user account is created on first use
+		m.auth.On("GetUserByEmail", ctx, domain.DeletedUserEmail).Return(nil, nil)
+		m.auth.On("CreateUser", ctx, domain.DeletedUserEmail, domain.DeletedUserName, "", domain.RoleUser).Return(deletedUser, nil)
+		m.comment.On("SoftDeleteByUser", ctx, 2, deletedCommentContent).Return([]int{4, 5}, nil)
+		m.comment.On("ReassignUser", ctx, 2, 50).Return(int64(3), nil)
+		m.comment.On("PurgeTombstones", ctx, 4).Return([]domain.Comment{{ID: 8}}, nil)
+		m.comment.On("PurgeTombstones", ctx, 5).Return([]domain.Comment{}, nil)
+		expectReactionsRemoved(m)
+		m.auth.On("DeleteUser", ctx, 2).Return(nil)
+
+		require.NoError(t, service.DeleteUser(ctx, 2, &domain.DeleteUserRequest{Comments: domain.UserCommentsRemove}, 1))
+		m.comment.AssertExpectations(t)
+		m.auth.AssertExpectations(t)
+	})
+
+	t.Run("Admins are demoted first", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 3).Return(&domain.User{ID: 3, Role: domain.RoleAdmin}, nil)
+
+		err := service.DeleteUser(ctx, 3, &domain.DeleteUserRequest{}, 1)
+		assert.ErrorIs(t, err, derr.ErrConflict)
+		m.auth.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Own account", func(t *testing.T) {
+		service, _ := newTestUserService()
+
+		err := service.DeleteUser(ctx, 1, &domain.DeleteUserRequest{}, 1)
+		assert.ErrorIs(t, err, derr.ErrConflict)
+	})
+
+	t.Run("Deleted user account", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 50).Return(deletedUser, nil)
+
+		err := service.DeleteUser(ctx, 50, &domain.DeleteUserRequest{}, 1)
+		assert.ErrorIs(t, err, derr.ErrConflict)
+	})
+
+	t.Run("Unknown comments mode", func(t *testing.T) {
+		service, _ := newTestUserService()
+
+		err := service.DeleteUser(ctx, 2, &domain.DeleteUserRequest{Comments: "keep"}, 1)
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+}
+
+func TestUserService_BanUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success revokes sessions", func(t *testing.T) {
+		service, m := newTestUserService()
+		expires := time.Now().Add(24 * time.Hour)
+		m.auth.On("GetUserByID", ctx, 2).Return(&domain.User{ID: 2, Role: domain.RoleUser}, nil)
+		m.ban.On("LockUser", ctx, 2).Return(nil)
+		m.ban.On("GetActive", ctx, 2).Return(nil, nil)
+		m.ban.On("Create", ctx, mock.MatchedBy(func(b *domain.UserBan) bool {
+			return b.UserID == 2 && b.Reason == "spam" && *b.IssuedBy == 1 && b.ExpiresAt.Equal(expires)
+		})).Return(nil)
+		m.session.On("DeleteUserSessions", ctx, 2).Return(nil)
+
+		ban, err := service.BanUser(ctx, 2, &domain.BanUserRequest{Reason: "spam", ExpiresAt: &expires}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "spam", ban.Reason)
+		m.ban.AssertExpectations(t)
+		m.session.AssertExpectations(t)
+	})
+
+	t.Run("Already banned", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 2).Return(&domain.User{ID: 2, Role: domain.RoleUser}, nil)
+		m.ban.On("LockUser", ctx, 2).Return(nil)
+		m.ban.On("GetActive", ctx, 2).Return(&domain.UserBan{ID: 3, UserID: 2}, nil)
+
+		_, err := service.BanUser(ctx, 2, &domain.BanUserRequest{Reason: "spam"}, 1)
+		assert.ErrorIs(t, err, derr.ErrConflict)
+		m.ban.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		m.session.AssertNotCalled(t, "DeleteUserSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 3).Return(&domain.User{ID: 3, Role: domain.RoleAdmin}, nil)
+
+		_, err := service.BanUser(ctx, 3, &domain.BanUserRequest{Reason: "spam"}, 1)
+		assert.ErrorIs(t, err, derr.ErrConflict)
+	})
+
+	t.Run("Own account", func(t *testing.T) {
+		service, _ := newTestUserService()
+
+		_, err := service.BanUser(ctx, 1, &domain.BanUserRequest{Reason: "spam"}, 1)
+		assert.ErrorIs(t, err, derr.ErrConflict)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 9).Return(nil, nil)
+
+		_, err := service.BanUser(ctx, 9, &domain.BanUserRequest{Reason: "spam"}, 1)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+
+	t.Run("Expiry in the past", func(t *testing.T) {
+		service, _ := newTestUserService()
+		expires := time.Now().Add(-time.Hour)
+
+		_, err := service.BanUser(ctx, 2, &domain.BanUserRequest{Reason: "spam", ExpiresAt: &expires}, 1)
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+
+	t.Run("Missing reason", func(t *testing.T) {
+		service, _ := newTestUserService()
+
+		_, err := service.BanUser(ctx, 2, &domain.BanUserRequest{}, 1)
+		assert.ErrorIs(t, err, derr.ErrValidation)
+	})
+}
+
+func TestUserService_LiftBan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newTestUserService()
+		now := time.Now()
+		m.ban.On("LiftActive", ctx, 2, 1).Return(&domain.UserBan{ID: 3, UserID: 2, LiftedAt: &now, LiftedBy: intPtr(1)}, nil)
+
+		ban, err := service.LiftBan(ctx, 2, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, *ban.LiftedBy)
+	})
+
+	t.Run("Not banned", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.ban.On("LiftActive", ctx, 2, 1).Return(nil, nil)
+
+		_, err := service.LiftBan(ctx, 2, 1)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+}
+
+func TestUserService_ListBans(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 2).Return(&domain.User{ID: 2}, nil)
+		m.ban.On("ListByUser", ctx, 2).Return([]domain.UserBan{{ID: 4}, {ID: 3}}, nil)
+
+		bans, err := service.ListBans(ctx, 2)
+		require.NoError(t, err)
+		assert.Len(t, bans, 2)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		service, m := newTestUserService()
+		m.auth.On("GetUserByID", ctx, 9).Return(nil, nil)
+
+		_, err := service.ListBans(ctx, 9)
+		assert.ErrorIs(t, err, derr.ErrNotFound)
+	})
+}
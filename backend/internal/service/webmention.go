@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/netguard"
+	"personal-web-platform/internal/pkg/webmention"
+	"personal-web-platform/internal/repository"
+)
+
+// webmentionUserAgent identifies the blog when fetching remote pages
+const webmentionUserAgent = "personal-web-platform-webmention"
+
+// WebmentionService defines methods for receiving and sending webmentions
+type WebmentionService interface {
+	// Receive queues a mention of one of our posts for verification
+	Receive(ctx context.Context, source, target string) error
+	ListMentions(ctx context.Context, slug string) ([]domain.Webmention, error)
+
+	// ProcessQueue verifies received and delivers sent mentions that are due
+	ProcessQueue(ctx context.Context) error
+
+	// PostPublished and PostUpdated queue mentions of links in the post
+	PostPublished(ctx context.Context, post *domain.Post)
+	PostUpdated(ctx context.Context, post *domain.Post)
+}
+
+type webmentionService struct {
+	webmentionRepo repository.WebmentionRepository
+	postRepo       repository.PostRepository
+	client         *webmention.Client
+	cfg            config.Webmention
+	frontendURL    string
+	log            *slog.Logger
+}
+
+// NewWebmentionService creates a new webmention service implementation.
+// Sources and link targets are user supplied, so the client never connects to internal addresses.
+func NewWebmentionService(webmentionRepo repository.WebmentionRepository, postRepo repository.PostRepository, cfg *config.Config, log *slog.Logger) WebmentionService {
+	return &webmentionService{
+		webmentionRepo: webmentionRepo,
+		postRepo:       postRepo,
+		client:         webmention.NewClient(netguard.NewClient(cfg.Webmention.Timeout), webmentionUserAgent),
+		cfg:            cfg.Webmention,
+		frontendURL:    strings.TrimSuffix(cfg.OAuth.FrontendURL, "/"),
+		log:            log,
+	}
+}
+
+func (s *webmentionService) postURL(slug string) string {
+	return s.frontendURL + "/blog/" + slug
+}
+
+func (s *webmentionService) Receive(ctx context.Context, source, target string) error {
+	sourceURL, err := parseWebURL(source)
+	if err != nil {
+		return fmt.Errorf("%w: invalid source: %w", derr.ErrValidation, err)
+	}
+	targetURL, err := parseWebURL(target)
+	if err != nil {
+		return fmt.Errorf("%w: invalid target: %w", derr.ErrValidation, err)
+	}
+	if sourceURL.String() == targetURL.String() {
+		return fmt.Errorf("%w: source and target must differ", derr.ErrValidation)
+	}
+
+	slug, ok := s.slugFromURL(targetURL)
+	if !ok {
+		return fmt.Errorf("%w: target is not a post of this site", derr.ErrValidation)
+	}
+
+	post, err := s.postRepo.GetBySlug(ctx, slug, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil || !post.Published || post.DeletedAt != nil {
+		return fmt.Errorf("%w: target post not found", derr.ErrValidation)
+	}
+
+	mention := &domain.Webmention{PostID: post.ID, Source: source, Target: target}
+	if err := s.webmentionRepo.UpsertReceived(ctx, mention); err != nil {
+		return fmt.Errorf("failed to save webmention: %w", err)
+	}
+
+	return nil
+}
+
+func (s *webmentionService) ListMentions(ctx context.Context, slug string) ([]domain.Webmention, error) {
+	post, err := s.postRepo.GetBySlug(ctx, slug, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if post == nil || post.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: post not found", derr.ErrNotFound)
+	}
+
+	mentions, err := s.webmentionRepo.ListVerified(ctx, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webmentions: %w", err)
+	}
+
+	return mentions, nil
+}
+
+func (s *webmentionService) ProcessQueue(ctx context.Context) error {
+	received, err := s.webmentionRepo.ListDueReceived(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list received webmentions: %w", err)
+	}
+	for i := range received {
+		if err := s.verify(ctx, &received[i]); err != nil {
+			return err
+		}
+	}
+
+	outgoing, err := s.webmentionRepo.ListDueOutgoing(ctx, s.cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list outgoing webmentions: %w", err)
+	}
+	for i := range outgoing {
+		if err := s.deliver(ctx, &outgoing[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verify checks that the source of a received mention links to its target
+func (s *webmentionService) verify(ctx context.Context, m *domain.Webmention) error {
+	m.Attempts++
+	title, err := s.client.Verify(ctx, m.Source, m.Target)
+
+	switch {
+	case err == nil:
+		now := time.Now()
+		m.Status = domain.WebmentionStatusVerified
+		m.Title = title
+		m.LastError = ""
+		m.VerifiedAt = &now
+	case webmention.IsTemporary(err) && m.Attempts < s.cfg.MaxAttempts:
+		m.NextAttemptAt = s.nextAttempt(m.Attempts)
+		m.LastError = err.Error()
+	default:
+		// A source that no longer links to us also hides a previously verified mention
+		m.Status = domain.WebmentionStatusRejected
+		m.LastError = err.Error()
+		s.log.Info("webmention rejected", slog.String("source", m.Source), slog.String("error", err.Error()))
+	}
+
+	if err := s.webmentionRepo.UpdateReceived(ctx, m); err != nil {
+		return fmt.Errorf("failed to update webmention: %w", err)
+	}
+	return nil
+}
+
+// deliver discovers the endpoint of an outgoing mention and sends it
+func (s *webmentionService) deliver(ctx context.Context, m *domain.OutgoingWebmention) error {
+	m.Attempts++
+	err := s.send(ctx, m)
+
+	switch {
+	case err == nil:
+		m.LastError = ""
+	case webmention.IsTemporary(err) && m.Attempts < s.cfg.MaxAttempts:
+		m.NextAttemptAt = s.nextAttempt(m.Attempts)
+		m.LastError = err.Error()
+	default:
+		m.Status = domain.WebmentionStatusFailed
+		m.LastError = err.Error()
+		s.log.Warn("failed to send webmention", slog.String("target", m.Target), slog.String("error", err.Error()))
+	}
+
+	if err := s.webmentionRepo.UpdateOutgoing(ctx, m); err != nil {
+		return fmt.Errorf("failed to update outgoing webmention: %w", err)
+	}
+	return nil
+}
+
+// send sets the mention status on success, errors are handled by deliver
+func (s *webmentionService) send(ctx context.Context, m *domain.OutgoingWebmention) error {
+	if m.Endpoint == "" {
+		endpoint, err := s.client.DiscoverEndpoint(ctx, m.Target)
+		if err != nil {
+			return err
+		}
+		if endpoint == "" {
+			m.Status = domain.WebmentionStatusNoEndpoint
+			return nil
+		}
+		m.Endpoint = endpoint
+	}
+
+	if err := s.client.Send(ctx, m.Endpoint, m.Source, m.Target); err != nil {
+		return err
+	}
+	m.Status = domain.WebmentionStatusSent
+	return nil
+}
+
+// nextAttempt doubles the retry delay on every failed attempt
+func (s *webmentionService) nextAttempt(attempts int) time.Time {
+	return time.Now().Add(s.cfg.RetryInterval << (attempts - 1))
+}
+
+func (s *webmentionService) PostPublished(ctx context.Context, post *domain.Post) {
+	s.enqueue(ctx, post)
+}
+
+func (s *webmentionService) PostUpdated(ctx context.Context, post *domain.Post) {
+	s.enqueue(ctx, post)
+}
+
+// enqueue queues mentions for the links of a post. Links mentioned before are
+// notified again so receivers can notice updates and removed links.
+func (s *webmentionService) enqueue(ctx context.Context, post *domain.Post) {
+	if !post.Published || post.DeletedAt != nil {
+		return
+	}
+
+	previous, err := s.webmentionRepo.ListOutgoingTargets(ctx, post.ID)
+	if err != nil {
+		s.log.Error("failed to list webmention targets", slog.Int("post_id", post.ID), slog.String("error", err.Error()))
+		return
+	}
+
+	source := s.postURL(post.Slug)
+	seen := make(map[string]bool)
+	for _, target := range append(webmention.ExtractLinks(post.Content), previous...) {
+		if seen[target] || s.isLocal(target) {
+			continue
+		}
+		seen[target] = true
+
+		if err := s.webmentionRepo.EnqueueOutgoing(ctx, post.ID, source, target); err != nil {
+			s.log.Error("failed to enqueue webmention", slog.Int("post_id", post.ID), slog.String("target", target), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// isLocal reports whether link points to this site
+func (s *webmentionService) isLocal(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return true
+	}
+	site, err := url.Parse(s.frontendURL)
+	return err == nil && strings.EqualFold(u.Host, site.Host)
+}
+
+// slugFromURL extracts the post slug from a frontend post URL
+func (s *webmentionService) slugFromURL(u *url.URL) (string, bool) {
+	site, err := url.Parse(s.frontendURL)
+	if err != nil || !strings.EqualFold(u.Host, site.Host) {
+		return "", false
+	}
+
+	slug, ok := strings.CutPrefix(strings.TrimSuffix(u.Path, "/"), site.Path+"/blog/")
+	if !ok || slug == "" || strings.Contains(slug, "/") {
+		return "", false
+	}
+	return slug, true
+}
+
+// parseWebURL accepts absolute http(s) URLs only
+func parseWebURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("must be an absolute http(s) url")
+	}
+	u.Fragment = ""
+	return u, nil
+}
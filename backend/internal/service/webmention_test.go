@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"personal-web-platform/config"
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/webmention"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWebmentionRepository is a mock implementation of WebmentionRepository
+type MockWebmentionRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebmentionRepository) UpsertReceived(ctx context.Context, mention *domain.Webmention) error {
+	args := m.Called(ctx, mention)
+	return args.Error(0)
+}
+
+func (m *MockWebmentionRepository) ListDueReceived(ctx context.Context, limit int) ([]domain.Webmention, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]domain.Webmention), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockWebmentionRepository) UpdateReceived(ctx context.Context, mention *domain.Webmention) error {
+	args := m.Called(ctx, mention)
+	return args.Error(0)
+}
+
+func (m *MockWebmentionRepository) ListVerified(ctx context.Context, postID int) ([]domain.Webmention, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).([]domain.Webmention), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockWebmentionRepository) EnqueueOutgoing(ctx context.Context, postID int, source, target string) error {
+	args := m.Called(ctx, postID, source, target)
+	return args.Error(0)
+}
+
+func (m *MockWebmentionRepository) ListOutgoingTargets(ctx context.Context, postID int) ([]string, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).([]string), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockWebmentionRepository) ListDueOutgoing(ctx context.Context, limit int) ([]domain.OutgoingWebmention, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]domain.OutgoingWebmention), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockWebmentionRepository) UpdateOutgoing(ctx context.Context, mention *domain.OutgoingWebmention) error {
+	args := m.Called(ctx, mention)
+	return args.Error(0)
+}
+
+func newTestWebmentionService(wmRepo *MockWebmentionRepository, postRepo *MockPostRepository) WebmentionService {
+	cfg := &config.Config{
+		OAuth: config.OAuth{FrontendURL: "https://blog.example/"},
+		Webmention: config.Webmention{
+			Enabled:       true,
+			Timeout:       time.Second,
+			BatchSize:     10,
+			MaxAttempts:   3,
+			RetryInterval: time.Minute,
+		},
+	}
+	return NewWebmentionService(wmRepo, postRepo, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestWebmentionService_Receive(t *testing.T) {
+	ctx := context.Background()
+	published := &domain.Post{ID: 7, Slug: "hello", Published: true}
+
+	t.Run("Queues mention of a published post", func(t *testing.T) {
+		wmRepo, postRepo := new(MockWebmentionRepository), new(MockPostRepository)
+		service := newTestWebmentionService(wmRepo, postRepo)
+
+		postRepo.On("GetBySlug", mock.Anything, "hello", 0).Return(published, nil)
+		wmRepo.On("UpsertReceived", mock.Anything, mock.MatchedBy(func(m *domain.Webmention) bool {
+			return m.PostID == 7 && m.Source == "https://other.example/post" && m.Target == "https://blog.example/blog/hello/"
+		})).Return(nil)
+
+		err := service.Receive(ctx, "https://other.example/post", "https://blog.example/blog/hello/")
+		require.NoError(t, err)
+		wmRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name   string
+		source string
+		target string
+	}{
+		{"Relative source", "/post", "https://blog.example/blog/hello"},
+		{"Unsupported scheme", "ftp://other.example/post", "https://blog.example/blog/hello"},
+		{"Same source and target", "https://blog.example/blog/hello", "https://blog.example/blog/hello"},
+		{"Foreign target", "https://other.example/post", "https://elsewhere.example/blog/hello"},
+		{"Target is not a post", "https://other.example/post", "https://blog.example/about"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestWebmentionService(new(MockWebmentionRepository), new(MockPostRepository))
+
+			err := service.Receive(ctx, tt.source, tt.target)
+			assert.ErrorIs(t, err, derr.ErrValidation)
+		})
+	}
+
+	t.Run("Draft target", func(t *testing.T) {
+		wmRepo, postRepo := new(MockWebmentionRepository), new(MockPostRepository)
+		service := newTestWebmentionService(wmRepo, postRepo)
+
+		postRepo.On("GetBySlug", mock.Anything, "draft", 0).Return(&domain.Post{ID: 8, Slug: "draft"}, nil)
+
+		err := service.Receive(ctx, "https://other.example/post", "https://blog.example/blog/draft")
+		assert.ErrorIs(t, err, derr.ErrValidation)
+		wmRepo.AssertNotCalled(t, "UpsertReceived", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebmentionService_ProcessQueue(t *testing.T) {
+	ctx := context.Background()
+
+	var sent url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /linking", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<title>Nice post</title><a href="https://blog.example/blog/hello">hello</a>`))
+	})
+	mux.HandleFunc("GET /unrelated", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<a href="https://blog.example/blog/other">other</a>`))
+	})
+	mux.HandleFunc("GET /down", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("GET /with-endpoint", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Link", `</webmention>; rel="webmention"`)
+	})
+	mux.HandleFunc("GET /without-endpoint", func(_ http.ResponseWriter, _ *http.Request) {})
+	mux.HandleFunc("POST /webmention", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		sent = r.PostForm
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	wmRepo, postRepo := new(MockWebmentionRepository), new(MockPostRepository)
+	service := newTestWebmentionService(wmRepo, postRepo)
+	// The test server listens on loopback, which the production client refuses
+	service.(*webmentionService).client = webmention.NewClient(server.Client(), webmentionUserAgent)
+
+	target := "https://blog.example/blog/hello"
+	wmRepo.On("ListDueReceived", mock.Anything, 10).Return([]domain.Webmention{
+		{ID: 1, Source: server.URL + "/linking", Target: target},
+		{ID: 2, Source: server.URL + "/unrelated", Target: target},
+		{ID: 3, Source: server.URL + "/down", Target: target, Status: domain.WebmentionStatusPending},
+		{ID: 4, Source: server.URL + "/down", Target: target, Status: domain.WebmentionStatusPending, Attempts: 2},
+	}, nil)
+	wmRepo.On("ListDueOutgoing", mock.Anything, 10).Return([]domain.OutgoingWebmention{
+		{ID: 1, Source: target, Target: server.URL + "/with-endpoint"},
+		{ID: 2, Source: target, Target: server.URL + "/without-endpoint"},
+	}, nil)
+
+	received := make(map[int]domain.Webmention)
+	wmRepo.On("UpdateReceived", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		m := args.Get(1).(*domain.Webmention)
+		received[m.ID] = *m
+	}).Return(nil)
+	outgoing := make(map[int]domain.OutgoingWebmention)
+	wmRepo.On("UpdateOutgoing", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		m := args.Get(1).(*domain.OutgoingWebmention)
+		outgoing[m.ID] = *m
+	}).Return(nil)
+
+	require.NoError(t, service.ProcessQueue(ctx))
+
+	assert.Equal(t, domain.WebmentionStatusVerified, received[1].Status)
+	assert.Equal(t, "Nice post", received[1].Title)
+	assert.NotNil(t, received[1].VerifiedAt)
+
+	assert.Equal(t, domain.WebmentionStatusRejected, received[2].Status)
+
+	// Temporary failures are retried with backoff until attempts run out
+	assert.Equal(t, domain.WebmentionStatusPending, received[3].Status)
+	assert.Equal(t, 1, received[3].Attempts)
+	assert.True(t, received[3].NextAttemptAt.After(time.Now()))
+	assert.Equal(t, domain.WebmentionStatusRejected, received[4].Status)
+
+	assert.Equal(t, domain.WebmentionStatusSent, outgoing[1].Status)
+	assert.Equal(t, server.URL+"/webmention", outgoing[1].Endpoint)
+	assert.Equal(t, target, sent.Get("source"))
+	assert.Equal(t, server.URL+"/with-endpoint", sent.Get("target"))
+
+	assert.Equal(t, domain.WebmentionStatusNoEndpoint, outgoing[2].Status)
+}
+
+func TestWebmentionService_ProcessQueue_InternalAddresses(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<a href="https://blog.example/blog/hello">hello</a>`))
+	}))
+	defer internal.Close()
+
+	wmRepo, postRepo := new(MockWebmentionRepository), new(MockPostRepository)
+	service := newTestWebmentionService(wmRepo, postRepo)
+
+	target := "https://blog.example/blog/hello"
+	wmRepo.On("ListDueReceived", mock.Anything, 10).Return([]domain.Webmention{
+		{ID: 1, Source: internal.URL + "/linking", Target: target},
+		{ID: 2, Source: "http://169.254.169.254/latest/meta-data/", Target: target},
+	}, nil)
+	wmRepo.On("ListDueOutgoing", mock.Anything, 10).Return([]domain.OutgoingWebmention{
+		{ID: 1, Source: target, Target: internal.URL + "/with-endpoint"},
+	}, nil)
+
+	// Internal addresses are refused without retrying
+	wmRepo.On("UpdateReceived", mock.Anything, mock.MatchedBy(func(m *domain.Webmention) bool {
+		return m.Status == domain.WebmentionStatusRejected && m.VerifiedAt == nil
+	})).Return(nil).Twice()
+	wmRepo.On("UpdateOutgoing", mock.Anything, mock.MatchedBy(func(m *domain.OutgoingWebmention) bool {
+		return m.Status == domain.WebmentionStatusFailed && m.Endpoint == ""
+	})).Return(nil).Once()
+
+	require.NoError(t, service.ProcessQueue(context.Background()))
+	wmRepo.AssertExpectations(t)
+}
+
+func TestWebmentionService_PostHooks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Queues external links and previously mentioned targets", func(t *testing.T) {
+		wmRepo, postRepo := new(MockWebmentionRepository), new(MockPostRepository)
+		service := newTestWebmentionService(wmRepo, postRepo)
+
+		post := &domain.Post{
+			ID:        7,
+			Slug:      "hello",
+			Published: true,
+			Content:   "See https://a.example/post and [our older post](https://blog.example/blog/older).",
+		}
+		source := "https://blog.example/blog/hello"
+
+		wmRepo.On("ListOutgoingTargets", mock.Anything, 7).Return([]string{"https://a.example/post", "https://removed.example/link"}, nil)
+		wmRepo.On("EnqueueOutgoing", mock.Anything, 7, source, "https://a.example/post").Return(nil).Once()
+		wmRepo.On("EnqueueOutgoing", mock.Anything, 7, source, "https://removed.example/link").Return(nil).Once()
+
+		service.PostUpdated(ctx, post)
+		wmRepo.AssertExpectations(t)
+	})
+
+	t.Run("Ignores drafts", func(t *testing.T) {
+		wmRepo := new(MockWebmentionRepository)
+		service := newTestWebmentionService(wmRepo, new(MockPostRepository))
+
+		service.PostPublished(ctx, &domain.Post{ID: 7, Content: "https://a.example/post"})
+		wmRepo.AssertNotCalled(t, "ListOutgoingTargets", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,161 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/pkg/activitypub"
+	"personal-web-platform/internal/pkg/httpsig"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Delivery is an activity received by a FakeInstance inbox
+type Delivery struct {
+	Request  *http.Request
+	Body     []byte
+	Activity activitypub.Activity
+}
+
+// FakeInstance is a local stand-in for a remote ActivityPub server (e.g. Mastodon)
+// hosting a single account. It serves the actor document over https and records
+// deliveries. Its loopback address is refused by guarded clients, reach it with
+// the client of Server instead.
+type FakeInstance struct {
+	Server     *httptest.Server
+	Username   string
+	PrivateKey string
+	PublicKey  string
+
+	mu         sync.Mutex
+	deliveries []Delivery
+	notify     chan struct{}
+}
+
+// NewFakeInstance starts a stand-in instance, it is closed with the test
+func NewFakeInstance(t *testing.T, username string) *FakeInstance {
+	t.Helper()
+
+	privatePEM, publicPEM, err := httpsig.GenerateKey()
+	require.NoError(t, err)
+
+	f := &FakeInstance{
+		Username:   username,
+		PrivateKey: privatePEM,
+		PublicKey:  publicPEM,
+		notify:     make(chan struct{}, 100),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/"+username, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", activitypub.ContentType)
+		_ = json.NewEncoder(w).Encode(f.Actor())
+	})
+	mux.HandleFunc("POST /users/"+username+"/inbox", f.receive)
+	mux.HandleFunc("POST /inbox", f.receive)
+
+	f.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(f.Server.Close)
+
+	return f
+}
+
+// ActorID returns the id of the hosted account
+func (f *FakeInstance) ActorID() string {
+	return f.Server.URL + "/users/" + f.Username
+}
+
+// KeyID returns the id of the account public key
+func (f *FakeInstance) KeyID() string {
+	return f.ActorID() + "#main-key"
+}
+
+// Actor returns the actor document of the hosted account
+func (f *FakeInstance) Actor() *activitypub.Actor {
+	return &activitypub.Actor{
+		Context:           []string{activitypub.ActivityStreamsContext, activitypub.SecurityContext},
+		ID:                f.ActorID(),
+		Type:              activitypub.TypePerson,
+		PreferredUsername: f.Username,
+		Name:              f.Username + " (remote)",
+		Icon:              &activitypub.Image{Type: activitypub.TypeImage, URL: f.Server.URL + "/avatars/" + f.Username + ".png"},
+		Inbox:             f.ActorID() + "/inbox",
+		Endpoints:         &activitypub.Endpoints{SharedInbox: f.Server.URL + "/inbox"},
+		PublicKey: activitypub.PublicKey{
+			ID:           f.KeyID(),
+			Owner:        f.ActorID(),
+			PublicKeyPem: f.PublicKey,
+		},
+	}
+}
+
+// SignedRequest builds an inbox request from the hosted account signed with its key
+func (f *FakeInstance) SignedRequest(t *testing.T, target string, activity any) (*http.Request, []byte) {
+	t.Helper()
+
+	body, err := json.Marshal(activity)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", activitypub.ContentType)
+
+	key, err := httpsig.ParsePrivateKey(f.PrivateKey)
+	require.NoError(t, err)
+	require.NoError(t, httpsig.Sign(req, f.KeyID(), key, body))
+
+	return req, body
+}
+
+// WaitForDeliveries waits until at least n activities were received
+func (f *FakeInstance) WaitForDeliveries(t *testing.T, n int) []Delivery {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		if deliveries := f.Deliveries(); len(deliveries) >= n {
+			return deliveries
+		}
+		select {
+		case <-f.notify:
+		case <-timeout:
+			t.Fatalf("expected %d deliveries, got %d", n, len(f.Deliveries()))
+		}
+	}
+}
+
+// Deliveries returns the activities received so far
+func (f *FakeInstance) Deliveries() []Delivery {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Delivery(nil), f.deliveries...)
+}
+
+func (f *FakeInstance) receive(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var activity activitypub.Activity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	f.deliveries = append(f.deliveries, Delivery{Request: r, Body: body, Activity: activity})
+	f.mu.Unlock()
+
+	select {
+	case f.notify <- struct{}{}:
+	default:
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
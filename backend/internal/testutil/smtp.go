@@ -0,0 +1,261 @@
+package testutil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Email is a message received by a FakeSMTPServer
+type Email struct {
+	From    string
+	To      []string
+	Data    []byte
+	Message *mail.Message
+	Text    string
+	HTML    string
+}
+
+// FakeSMTPServer is a minimal local SMTP server that accepts every message.
+// It supports AUTH PLAIN but not STARTTLS, which keeps the session readable.
+type FakeSMTPServer struct {
+	Host string
+	Port int
+
+	listener net.Listener
+	mu       sync.Mutex
+	emails   []Email
+	username string
+	password string
+	notify   chan struct{}
+}
+
+// NewFakeSMTPServer starts a fake SMTP server, it is closed with the test
+func NewFakeSMTPServer(t *testing.T) *FakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &FakeSMTPServer{
+		Host:     addr.IP.String(),
+		Port:     addr.Port,
+		listener: listener,
+		notify:   make(chan struct{}, 100),
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go s.serve(t)
+	return s
+}
+
+// WaitForEmails waits until at least n messages were received
+func (s *FakeSMTPServer) WaitForEmails(t *testing.T, n int) []Email {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		if emails := s.Emails(); len(emails) >= n {
+			return emails
+		}
+		select {
+		case <-s.notify:
+		case <-timeout:
+			t.Fatalf("expected %d emails, got %d", n, len(s.Emails()))
+		}
+	}
+}
+
+// Emails returns the messages received so far
+func (s *FakeSMTPServer) Emails() []Email {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Email(nil), s.emails...)
+}
+
+func (s *FakeSMTPServer) serve(t *testing.T) {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(t, conn)
+	}
+}
+
+func (s *FakeSMTPServer) handle(t *testing.T, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	reply := func(lines ...string) {
+		for _, line := range lines {
+			_, _ = io.WriteString(conn, line+"\r\n")
+		}
+	}
+	readLine := func() (string, bool) {
+		line, err := r.ReadString('\n')
+		return strings.TrimRight(line, "\r\n"), err == nil
+	}
+
+	reply("220 fake ESMTP ready")
+
+	var email Email
+	for {
+		line, ok := readLine()
+		if !ok {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250-fake", "250-AUTH PLAIN", "250 8BITMIME")
+		case "AUTH":
+			mechanism, initial, _ := strings.Cut(arg, " ")
+			if !strings.EqualFold(mechanism, "PLAIN") {
+				reply("504 unsupported mechanism")
+				continue
+			}
+			if initial == "" {
+				reply("334 ")
+				if initial, ok = readLine(); !ok {
+					return
+				}
+			}
+			s.setCredentials(initial)
+			reply("235 authenticated")
+		case "MAIL":
+			email = Email{From: addressArg(arg)}
+			reply("250 ok")
+		case "RCPT":
+			email.To = append(email.To, addressArg(arg))
+			reply("250 ok")
+		case "DATA":
+			reply("354 end with <CRLF>.<CRLF>")
+			var data bytes.Buffer
+			for {
+				line, ok := readLine()
+				if !ok {
+					return
+				}
+				if line == "." {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, ".") + "\r\n")
+			}
+			email.Data = data.Bytes()
+			s.store(t, email)
+			reply("250 queued")
+		case "RSET", "NOOP":
+			reply("250 ok")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func (s *FakeSMTPServer) setCredentials(encoded string) {
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return
+	}
+	// identity \0 username \0 password
+	parts := strings.Split(string(decoded), "\x00")
+	if len(parts) != 3 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username, s.password = parts[1], parts[2]
+}
+
+// Credentials returns the username and password of the last AUTH PLAIN
+func (s *FakeSMTPServer) Credentials() (username, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.username, s.password
+}
+
+func (s *FakeSMTPServer) store(t *testing.T, email Email) {
+	msg, err := mail.ReadMessage(bytes.NewReader(email.Data))
+	if err != nil {
+		t.Errorf("fake smtp: invalid message: %v", err)
+		return
+	}
+	email.Message = msg
+	email.Text, email.HTML = readBodies(t, msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+
+	s.mu.Lock()
+	s.emails = append(s.emails, email)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// readBodies decodes the text and HTML parts of a single or multipart message
+func readBodies(t *testing.T, contentType, encoding string, body io.Reader) (text, html string) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Errorf("fake smtp: invalid content type %q: %v", contentType, err)
+		return "", ""
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				return text, html
+			}
+			// multipart.Reader decodes quoted-printable parts itself
+			partText, partHTML := readBodies(t, part.Header.Get("Content-Type"), "", part)
+			text += partText
+			html += partHTML
+		}
+	}
+
+	if strings.EqualFold(encoding, "quoted-printable") {
+		body = quotedprintable.NewReader(body)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Errorf("fake smtp: failed to read body: %v", err)
+		return "", ""
+	}
+
+	decoded := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if mediaType == "text/html" {
+		return "", decoded
+	}
+	return decoded, ""
+}
+
+// addressArg extracts the address from "FROM:<address>" or "TO:<address> PARAMS"
+func addressArg(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr = strings.TrimSpace(addr)
+	if end := strings.Index(addr, ">"); end != -1 {
+		addr = addr[:end]
+	}
+	return strings.TrimPrefix(addr, "<")
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/activitypub"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxInboxBodySize limits activities accepted by the inbox
+const maxInboxBodySize = 1 << 20
+
+// respondActivity writes a federation document with its own media type (no API envelope)
+func respondActivity(w http.ResponseWriter, contentType string, data any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+}
+
+// webFinger handles GET /.well-known/webfinger - resolve @username@host to the blog actor
+func (h *Handler) webFinger(w http.ResponseWriter, r *http.Request) {
+	jrd, err := h.services.ActivityPub.WebFinger(r.Context(), r.URL.Query().Get("resource"))
+	if err != nil {
+		RespondWithError(w, err)
+		return
+	}
+
+	respondActivity(w, activitypub.JRDContentType, jrd)
+}
+
+// apActor handles GET /ap/actor - the blog owner actor document
+func (h *Handler) apActor(w http.ResponseWriter, r *http.Request) {
+	actor, err := h.services.ActivityPub.Actor(r.Context())
+	if err != nil {
+		h.log.Error("failed to get activitypub actor", "error", err)
+		RespondWithError(w, err)
+		return
+	}
+
+	respondActivity(w, activitypub.ContentType, actor)
+}
+
+// apOutbox handles GET /ap/outbox - published posts as Create activities, paginated with ?page=N
+func (h *Handler) apOutbox(w http.ResponseWriter, r *http.Request) {
+	page := 0
+	if p := r.URL.Query().Get("page"); p != "" {
+		var err error
+		if page, err = strconv.Atoi(p); err != nil || page < 1 {
+			RespondBadRequest(w, "invalid page")
+			return
+		}
+	}
+
+	outbox, err := h.services.ActivityPub.Outbox(r.Context(), page)
+	if err != nil {
+		h.log.Error("failed to get activitypub outbox", "error", err, "page", page)
+		RespondWithError(w, err)
+		return
+	}
+
+	respondActivity(w, activitypub.ContentType, outbox)
+}
+
+// apFollowers handles GET /ap/followers - followers collection (count only)
+func (h *Handler) apFollowers(w http.ResponseWriter, r *http.Request) {
+	followers, err := h.services.ActivityPub.Followers(r.Context())
+	if err != nil {
+		h.log.Error("failed to get activitypub followers", "error", err)
+		RespondWithError(w, err)
+		return
+	}
+
+	respondActivity(w, activitypub.ContentType, followers)
+}
+
+// apPost handles GET /ap/posts/{id} - a published post as an Article object
+func (h *Handler) apPost(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid post ID")
+		return
+	}
+
+	object, err := h.services.ActivityPub.PostObject(r.Context(), postID)
+	if err != nil {
+		RespondWithError(w, err)
+		return
+	}
+
+	respondActivity(w, activitypub.ContentType, object)
+}
+
+// apInbox handles POST /ap/inbox - signed activities from remote servers
+func (h *Handler) apInbox(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInboxBodySize))
+	if err != nil {
+		RespondBadRequest(w, "failed to read body")
+		return
+	}
+
+	if err := h.services.ActivityPub.HandleInbox(r.Context(), r, body); err != nil {
+		if errors.Is(err, derr.ErrPermission) {
+			h.log.Warn("rejected activitypub request", "error", err)
+			RespondUnauthorized(w, "invalid signature")
+			return
+		}
+		h.log.Error("failed to handle activitypub activity", "error", err)
+		RespondWithError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
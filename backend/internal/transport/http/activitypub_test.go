@@ -0,0 +1,186 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"personal-web-platform/internal/domain/derr"
+	"personal-web-platform/internal/pkg/activitypub"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_webFinger(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/.well-known/webfinger?resource=acct:blog@blog.example", nil)
+
+		mocks.ActivityPub.On("WebFinger", mock.Anything, "acct:blog@blog.example").Return(&activitypub.WebFinger{
+			Subject: "acct:blog@blog.example",
+			Links:   []activitypub.WebFingerLink{{Rel: "self", Type: activitypub.ContentType, Href: "https://blog.example/ap/actor"}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		h.webFinger(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, activitypub.JRDContentType, w.Header().Get("Content-Type"))
+		// Federation documents are not wrapped in the API envelope
+		assert.True(t, strings.HasPrefix(w.Body.String(), `{"subject":"acct:blog@blog.example"`))
+	})
+
+	t.Run("Unknown Resource", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/.well-known/webfinger?resource=acct:nobody@blog.example", nil)
+
+		mocks.ActivityPub.On("WebFinger", mock.Anything, "acct:nobody@blog.example").Return(nil, fmt.Errorf("%w: unknown resource", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.webFinger(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_apActor(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := httptest.NewRequest("GET", "/ap/actor", nil)
+
+	mocks.ActivityPub.On("Actor", mock.Anything).Return(&activitypub.Actor{ID: "https://blog.example/ap/actor", Type: activitypub.TypePerson}, nil)
+
+	w := httptest.NewRecorder()
+	h.apActor(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, activitypub.ContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"type":"Person"`)
+}
+
+func TestHandler_apOutbox(t *testing.T) {
+	t.Run("Collection", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/ap/outbox", nil)
+
+		mocks.ActivityPub.On("Outbox", mock.Anything, 0).Return(&activitypub.OrderedCollection{Type: activitypub.TypeOrderedCollection, TotalItems: 3}, nil)
+
+		w := httptest.NewRecorder()
+		h.apOutbox(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"totalItems":3`)
+	})
+
+	t.Run("Page", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/ap/outbox?page=2", nil)
+
+		mocks.ActivityPub.On("Outbox", mock.Anything, 2).Return(&activitypub.OrderedCollection{Type: activitypub.TypeOrderedCollectionPage}, nil)
+
+		w := httptest.NewRecorder()
+		h.apOutbox(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid Page", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("GET", "/ap/outbox?page=0", nil)
+
+		w := httptest.NewRecorder()
+		h.apOutbox(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_apPost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/ap/posts/1", nil)
+		req = injectParam(req, "id", "1")
+
+		mocks.ActivityPub.On("PostObject", mock.Anything, 1).Return(&activitypub.Object{ID: "https://blog.example/ap/posts/1", Type: activitypub.TypeArticle}, nil)
+
+		w := httptest.NewRecorder()
+		h.apPost(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"type":"Article"`)
+	})
+
+	t.Run("Draft Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/ap/posts/2", nil)
+		req = injectParam(req, "id", "2")
+
+		mocks.ActivityPub.On("PostObject", mock.Anything, 2).Return(nil, fmt.Errorf("%w: post not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.apPost(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_apInbox(t *testing.T) {
+	body := `{"type":"Follow"}`
+
+	t.Run("Accepted", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/ap/inbox", strings.NewReader(body))
+
+		mocks.ActivityPub.On("HandleInbox", mock.Anything, req, []byte(body)).Return(nil)
+
+		w := httptest.NewRecorder()
+		h.apInbox(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+	})
+
+	t.Run("Invalid Signature", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/ap/inbox", strings.NewReader(body))
+
+		mocks.ActivityPub.On("HandleInbox", mock.Anything, req, []byte(body)).Return(fmt.Errorf("%w: invalid http signature", derr.ErrPermission))
+
+		w := httptest.NewRecorder()
+		h.apInbox(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Malformed Activity", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/ap/inbox", strings.NewReader("{"))
+
+		mocks.ActivityPub.On("HandleInbox", mock.Anything, req, []byte("{")).Return(fmt.Errorf("%w: invalid activity", derr.ErrValidation))
+
+		w := httptest.NewRecorder()
+		h.apInbox(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_ActivityPubRoutes(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			h, mocks := setupHandler(t)
+			h.cfg.ActivityPub.Enabled = enabled
+			mocks.ActivityPub.On("Actor", mock.Anything).Return(&activitypub.Actor{ID: "https://blog.example/ap/actor"}, nil).Maybe()
+
+			w := httptest.NewRecorder()
+			h.InitRoutes().ServeHTTP(w, httptest.NewRequest("GET", "/ap/actor", nil))
+
+			if enabled {
+				assert.Equal(t, http.StatusOK, w.Code)
+			} else {
+				assert.Equal(t, http.StatusNotFound, w.Code)
+			}
+		})
+	}
+}
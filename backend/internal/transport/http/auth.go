@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"personal-web-platform/internal/domain"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/markbates/goth"
 	"github.com/markbates/goth/gothic"
@@ -144,7 +146,11 @@ func (h *Handler) authCallback(w http.ResponseWriter, r *http.Request) {
 	// Login with OAuth
 	h.log.Info("auth: creating user session", "provider", providerName)
 
-	user, session, err := h.services.Auth.LoginWithOAuth(r.Context(), gothUser)
+	client := domain.SessionClient{
+		UserAgent: r.Header.Get("User-Agent"),
+		IPAddress: clientIP(r),
+	}
+	user, session, err := h.services.Auth.LoginWithOAuth(r.Context(), gothUser, client)
 	if err != nil {
 		h.log.Error("auth: failed to login with oauth", "error", err, "provider", providerName, "email", gothUser.Email)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
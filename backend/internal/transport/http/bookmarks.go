@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// bookmarkPost handles POST /api/v1/posts/{id}/bookmark - add a post to the reading list
+func (h *Handler) bookmarkPost(w http.ResponseWriter, r *http.Request) {
+	h.handleBookmark(w, r, true)
+}
+
+// unbookmarkPost handles DELETE /api/v1/posts/{id}/bookmark - remove a post from the reading list
+func (h *Handler) unbookmarkPost(w http.ResponseWriter, r *http.Request) {
+	h.handleBookmark(w, r, false)
+}
+
+func (h *Handler) handleBookmark(w http.ResponseWriter, r *http.Request, add bool) {
+	postID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid post ID")
+		return
+	}
+
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	if add {
+		err = h.services.Bookmark.AddBookmark(r.Context(), user.ID, postID)
+	} else {
+		err = h.services.Bookmark.RemoveBookmark(r.Context(), user.ID, postID)
+	}
+	if err != nil {
+		h.log.Error("failed to update bookmark", "error", err, "postID", postID, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, map[string]bool{"is_bookmarked": add})
+}
+
+// listMyBookmarks handles GET /api/v1/me/bookmarks - list bookmarked posts with pagination
+func (h *Handler) listMyBookmarks(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	published := true
+	req := &domain.ListPostsRequest{
+		Published:    &published,
+		UserID:       user.ID,
+		BookmarkedBy: user.ID,
+		Language:     languagePreference(r),
+	}
+
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+		req.Page = page
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		req.Limit = limit
+	}
+
+	response, err := h.services.Post.ListPosts(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to list bookmarks", "error", err, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+	RespondSuccess(w, response)
+}
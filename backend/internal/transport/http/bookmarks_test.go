@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_bookmarkPost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/posts/1/bookmark", nil)
+		req = injectParam(req, "id", "1")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Bookmark.On("AddBookmark", mock.Anything, 2, 1).Return(nil)
+
+		w := httptest.NewRecorder()
+		h.bookmarkPost(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"is_bookmarked":true`)
+	})
+
+	t.Run("Post Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/posts/9/bookmark", nil)
+		req = injectParam(req, "id", "9")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Bookmark.On("AddBookmark", mock.Anything, 2, 9).Return(fmt.Errorf("%w: post not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.bookmarkPost(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/posts/abc/bookmark", nil)
+		req = injectParam(req, "id", "abc")
+
+		w := httptest.NewRecorder()
+		h.bookmarkPost(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_unbookmarkPost(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := httptest.NewRequest("DELETE", "/api/v1/posts/1/bookmark", nil)
+	req = injectParam(req, "id", "1")
+	req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+	mocks.Bookmark.On("RemoveBookmark", mock.Anything, 2, 1).Return(nil)
+
+	w := httptest.NewRecorder()
+	h.unbookmarkPost(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"is_bookmarked":false`)
+}
+
+func TestHandler_listMyBookmarks(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/me/bookmarks?page=2&limit=5", nil)
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Post.On("ListPosts", mock.Anything, mock.MatchedBy(func(r *domain.ListPostsRequest) bool {
+			return r.BookmarkedBy == 2 && r.UserID == 2 && r.Page == 2 && r.Limit == 5 &&
+				r.Published != nil && *r.Published
+		})).Return(&domain.PostsListResponse{Posts: []domain.Post{{ID: 1, IsBookmarked: true}}, TotalCount: 6, Page: 2, Limit: 5, TotalPages: 2}, nil)
+
+		w := httptest.NewRecorder()
+		h.listMyBookmarks(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"is_bookmarked":true`)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		h, _ := setupHandler(t)
+
+		w := httptest.NewRecorder()
+		h.listMyBookmarks(w, httptest.NewRequest("GET", "/api/v1/me/bookmarks", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
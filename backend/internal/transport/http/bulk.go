@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+
+	"personal-web-platform/internal/domain"
+)
+
+// bulkPosts handles POST /api/v1/admin/posts/bulk - apply an action to a batch of posts
+func (h *Handler) bulkPosts(w http.ResponseWriter, r *http.Request) {
+	var req domain.BulkPostsRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	response, err := h.services.Post.BulkUpdatePosts(r.Context(), &req)
+	if err != nil {
+		h.log.Error("failed to run bulk post action", "error", err, "action", req.Action)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
+
+// bulkComments handles POST /api/v1/admin/comments/bulk - apply a moderation action to a batch of comments
+func (h *Handler) bulkComments(w http.ResponseWriter, r *http.Request) {
+	var req domain.BulkCommentsRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	response, err := h.services.Comment.BulkModerateComments(r.Context(), &req)
+	if err != nil {
+		h.log.Error("failed to run bulk comment action", "error", err, "action", req.Action)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_bulkPosts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		body := `{"ids": [1, 2], "action": "publish"}`
+		req := httptest.NewRequest("POST", "/api/v1/admin/posts/bulk", bytes.NewBufferString(body))
+
+		mocks.Post.On("BulkUpdatePosts", mock.Anything, &domain.BulkPostsRequest{IDs: []int{1, 2}, Action: domain.BulkActionPublish}).
+			Return(&domain.BulkResponse{
+				Action:    domain.BulkActionPublish,
+				Results:   []domain.BulkItemResult{{ID: 1, Success: true}, {ID: 2, Error: "post not found"}},
+				Succeeded: 1,
+				Failed:    1,
+			}, nil)
+
+		w := httptest.NewRecorder()
+		h.bulkPosts(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"succeeded":1`)
+		assert.Contains(t, w.Body.String(), "post not found")
+	})
+
+	t.Run("Invalid Action", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		body := `{"ids": [1], "action": "archive"}`
+		req := httptest.NewRequest("POST", "/api/v1/admin/posts/bulk", bytes.NewBufferString(body))
+
+		w := httptest.NewRecorder()
+		h.bulkPosts(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Service Error", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		body := `{"ids": [1], "action": "delete"}`
+		req := httptest.NewRequest("POST", "/api/v1/admin/posts/bulk", bytes.NewBufferString(body))
+
+		mocks.Post.On("BulkUpdatePosts", mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		h.bulkPosts(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestHandler_bulkComments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		body := `{"ids": [5], "action": "mark_spam"}`
+		req := httptest.NewRequest("POST", "/api/v1/admin/comments/bulk", bytes.NewBufferString(body))
+
+		mocks.Comment.On("BulkModerateComments", mock.Anything, &domain.BulkCommentsRequest{IDs: []int{5}, Action: domain.BulkActionMarkSpam}).
+			Return(&domain.BulkResponse{
+				Action:    domain.BulkActionMarkSpam,
+				Results:   []domain.BulkItemResult{{ID: 5, Success: true}},
+				Succeeded: 1,
+			}, nil)
+
+		w := httptest.NewRecorder()
+		h.bulkComments(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Empty IDs", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		body := `{"ids": [], "action": "approve"}`
+		req := httptest.NewRequest("POST", "/api/v1/admin/comments/bulk", bytes.NewBufferString(body))
+
+		w := httptest.NewRecorder()
+		h.bulkComments(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
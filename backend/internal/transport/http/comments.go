@@ -2,15 +2,18 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// getCommentsByPostSlug handles GET /api/v1/posts/{slug}/comments - get all comments for a post
+// getCommentsByPostSlug handles GET /api/v1/posts/{slug}/comments - get all comments for a post.
+// ?sort=oldest (default), newest or top orders the threads.
 func (h *Handler) getCommentsByPostSlug(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
@@ -18,14 +21,22 @@ func (h *Handler) getCommentsByPostSlug(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	order := r.URL.Query().Get("sort")
+	if order != "" && !domain.IsValidCommentSort(order) {
+		http.Error(w, "sort must be oldest, newest or top", http.StatusBadRequest)
+		return
+	}
+
 	var userID int
+	var isAdmin bool
 	if user := h.getUserFromContext(r.Context()); user != nil {
 		userID = user.ID
+		isAdmin = user.Role == domain.RoleAdmin
 	}
 
-	comments, err := h.services.Comment.GetCommentsByPostSlug(r.Context(), slug, userID)
+	comments, err := h.services.Comment.GetCommentsByPostSlug(r.Context(), slug, userID, isAdmin, order)
 	if err != nil {
-		h.log.Error("failed to get comments by post slug", "error", err, "slug", slug)
+		h.log.Error("failed to get comments by post slug", "error", err, "slug", slug, "sort", order)
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
@@ -36,6 +47,32 @@ func (h *Handler) getCommentsByPostSlug(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// getComment handles GET /api/v1/comments/{id} - a comment permalink with its thread context.
+// The post slug, thread position and anchor let the page scroll to the comment.
+func (h *Handler) getComment(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid comment ID")
+		return
+	}
+
+	var userID int
+	var isAdmin bool
+	if user := h.getUserFromContext(r.Context()); user != nil {
+		userID = user.ID
+		isAdmin = user.Role == domain.RoleAdmin
+	}
+
+	permalink, err := h.services.Comment.GetCommentPermalink(r.Context(), commentID, userID, isAdmin)
+	if err != nil {
+		h.log.Error("failed to get comment permalink", "error", err, "commentID", commentID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, permalink)
+}
+
 // createComment handles POST /api/v1/posts/{slug}/comments - create new comment
 func (h *Handler) createComment(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
@@ -61,7 +98,7 @@ func (h *Handler) createComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get post by slug to get post ID
-	post, err := h.services.Post.GetPostBySlug(r.Context(), slug, user.ID)
+	post, err := h.services.Post.GetPostBySlug(r.Context(), slug, user.ID, domain.LanguagePreference{})
 	if err != nil {
 		h.log.Error("failed to get post by slug", "error", err, "slug", slug)
 		http.Error(w, "post not found", http.StatusNotFound)
@@ -72,7 +109,11 @@ func (h *Handler) createComment(w http.ResponseWriter, r *http.Request) {
 	comment, err := h.services.Comment.CreateComment(r.Context(), post.ID, &req, user.ID)
 	if err != nil {
 		h.log.Error("failed to create comment", "error", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		status := http.StatusBadRequest
+		if errors.Is(err, derr.ErrPermission) {
+			status = http.StatusForbidden // closed for comments
+		}
+		http.Error(w, err.Error(), status)
 		return
 	}
 
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +25,7 @@ func TestHandler_getCommentsByPostSlug(t *testing.T) {
 		req = injectParam(req, "slug", "test-post")
 
 		comments := []domain.Comment{{ID: 1, Content: "Test"}}
-		mocks.Comment.On("GetCommentsByPostSlug", mock.Anything, "test-post", 0).Return(comments, nil)
+		mocks.Comment.On("GetCommentsByPostSlug", mock.Anything, "test-post", 0, false, "").Return(comments, nil)
 
 		w := httptest.NewRecorder()
 		h.getCommentsByPostSlug(w, req)
@@ -31,6 +33,28 @@ func TestHandler_getCommentsByPostSlug(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("Sort", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/posts/slug/comments?sort=top", nil)
+		req = injectParam(req, "slug", "sorted-post")
+
+		mocks.Comment.On("GetCommentsByPostSlug", mock.Anything, "sorted-post", 0, false, "top").Return([]domain.Comment{}, nil)
+
+		w := httptest.NewRecorder()
+		h.getCommentsByPostSlug(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unknown Sort", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/posts/slug/comments?sort=random", nil)
+		req = injectParam(req, "slug", "test-post")
+
+		w := httptest.NewRecorder()
+		h.getCommentsByPostSlug(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("Missing Slug", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/api/v1/posts//comments", nil)
 		// empty slug
@@ -42,6 +66,54 @@ func TestHandler_getCommentsByPostSlug(t *testing.T) {
 	})
 }
 
+func TestHandler_getComment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/comments/4", nil)
+		req = injectParam(req, "id", "4")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Comment.On("GetCommentPermalink", mock.Anything, 4, 2, false).Return(&domain.CommentPermalink{
+			Comment:   &domain.Comment{ID: 4, Replies: []*domain.Comment{}},
+			Ancestors: []domain.Comment{{ID: 2}},
+			PostSlug:  "hello",
+			ThreadID:  2,
+			Anchor:    "comment-4",
+		}, nil)
+
+		w := httptest.NewRecorder()
+		h.getComment(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"anchor":"comment-4"`)
+		assert.Contains(t, w.Body.String(), `"post_slug":"hello"`)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/comments/9", nil)
+		req = injectParam(req, "id", "9")
+
+		mocks.Comment.On("GetCommentPermalink", mock.Anything, 9, 0, false).Return(nil, derr.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		h.getComment(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/comments/abc", nil)
+		req = injectParam(req, "id", "abc")
+
+		w := httptest.NewRecorder()
+		h.getComment(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestHandler_createComment(t *testing.T) {
 	h, mocks := setupHandler(t)
 
@@ -65,6 +137,22 @@ func TestHandler_createComment(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, w.Code)
 	})
 
+	t.Run("Comments Closed", func(t *testing.T) {
+		body := `{"content": "New comment"}`
+		req := httptest.NewRequest("POST", "/api/v1/posts/closed-post/comments", bytes.NewBufferString(body))
+		req = injectParam(req, "slug", "closed-post")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 1}))
+
+		mocks.Post.On("GetPostBySlug", mock.Anything, "closed-post", 1).Return(&domain.Post{ID: 11, Slug: "closed-post"}, nil)
+		mocks.Comment.On("CreateComment", mock.Anything, 11, mock.Anything, 1).
+			Return(nil, fmt.Errorf("%w: comments are closed: disabled", derr.ErrPermission))
+
+		w := httptest.NewRecorder()
+		h.createComment(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
 	t.Run("Unauthorized", func(t *testing.T) {
 		body := `{"content": "New comment"}`
 		req := httptest.NewRequest("POST", "/api/v1/posts/test-post/comments", bytes.NewBufferString(body))
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"net/http"
+)
+
+// reconcileCounters handles POST /api/v1/admin/counters/reconcile - recompute like and comment counts.
+// ?dry_run=true only reports the counts that drifted.
+func (h *Handler) reconcileCounters(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	report, err := h.services.Counter.Reconcile(r.Context(), dryRun)
+	if err != nil {
+		h.log.Error("failed to reconcile counters", "error", err, "dryRun", dryRun)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, report)
+}
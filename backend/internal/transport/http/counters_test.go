@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_reconcileCounters(t *testing.T) {
+	t.Run("Fix", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/admin/counters/reconcile", nil)
+
+		mocks.Counter.On("Reconcile", mock.Anything, false).Return(&domain.CounterReport{
+			Drift: []domain.CounterDrift{{Counter: domain.CounterPostLikes, ID: 3, Stored: 5, Actual: 4}},
+			Fixed: true,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		h.reconcileCounters(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"counter":"post_likes"`)
+		assert.Contains(t, w.Body.String(), `"fixed":true`)
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/admin/counters/reconcile?dry_run=true", nil)
+
+		mocks.Counter.On("Reconcile", mock.Anything, true).Return(&domain.CounterReport{Drift: []domain.CounterDrift{}}, nil)
+
+		w := httptest.NewRecorder()
+		h.reconcileCounters(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mocks.Counter.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// sseRetry is how long browsers wait before reconnecting a dropped stream
+const sseRetry = 3 * time.Second
+
+// streamPostEvents handles GET /api/v1/posts/{slug}/events - a Server-Sent Events
+// stream of new, edited and deleted comments and like counts of a post.
+// Reconnecting browsers send Last-Event-ID and get the events they missed.
+func (h *Handler) streamPostEvents(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	lastEventID, err := lastEventID(r)
+	if err != nil {
+		RespondBadRequest(w, "invalid Last-Event-ID")
+		return
+	}
+
+	events, err := h.services.Event.Subscribe(r.Context(), slug, lastEventID)
+	if err != nil {
+		h.log.Error("failed to subscribe to post events", "error", err, "slug", slug)
+		RespondWithError(w, err)
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.log.Error("failed to clear write deadline", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Proxies must not buffer the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+
+	heartbeat := time.NewTicker(h.cfg.Events.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Shutdown, a closed connection or a reader too slow to keep up
+				return
+			}
+			writeEvent(w, &event)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+	}
+}
+
+// writeEvent writes an event in the text/event-stream format; data is single line JSON
+func writeEvent(w http.ResponseWriter, event *domain.PostEvent) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+}
+
+// lastEventID reads the ID a reconnecting browser resumes after. The query
+// parameter is for clients that can't set headers on the first connection.
+func lastEventID(r *http.Request) (int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid event id %q", value)
+	}
+	return id, nil
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newEventsRequest(slug string) *http.Request {
+	req := httptest.NewRequest("GET", "/api/v1/posts/"+slug+"/events", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", slug)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// closedEvents returns a channel holding events, closed like a finished stream
+func closedEvents(events ...domain.PostEvent) <-chan domain.PostEvent {
+	ch := make(chan domain.PostEvent, len(events))
+	for _, e := range events {
+		ch <- e
+	}
+	close(ch)
+	return ch
+}
+
+func TestHandler_streamPostEvents(t *testing.T) {
+	t.Run("Streams events", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := newEventsRequest("hello")
+
+		mocks.Event.On("Subscribe", mock.Anything, "hello", int64(0)).Return(closedEvents(
+			domain.PostEvent{ID: 7, PostID: 1, Type: domain.PostEventCommentCreated, Data: json.RawMessage(`{"id":3}`)},
+			domain.PostEvent{ID: 8, PostID: 1, Type: domain.PostEventLikesUpdated, Data: json.RawMessage(`{"post_id":1,"likes_count":2}`)},
+		), nil)
+
+		w := httptest.NewRecorder()
+		h.streamPostEvents(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "retry: 3000\n\n"+
+			"id: 7\nevent: comment.created\ndata: {\"id\":3}\n\n"+
+			"id: 8\nevent: likes.updated\ndata: {\"post_id\":1,\"likes_count\":2}\n\n", w.Body.String())
+	})
+
+	t.Run("Resumes after Last-Event-ID", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := newEventsRequest("hello")
+		req.Header.Set("Last-Event-ID", "42")
+
+		mocks.Event.On("Subscribe", mock.Anything, "hello", int64(42)).Return(closedEvents(), nil)
+
+		w := httptest.NewRecorder()
+		h.streamPostEvents(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mocks.Event.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Last-Event-ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := newEventsRequest("hello")
+		req.Header.Set("Last-Event-ID", "abc")
+
+		w := httptest.NewRecorder()
+		h.streamPostEvents(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Post Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := newEventsRequest("missing")
+
+		mocks.Event.On("Subscribe", mock.Anything, "missing", int64(0)).
+			Return(nil, fmt.Errorf("%w: post not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.streamPostEvents(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLastEventID(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/posts/hello/events?last_event_id=5", nil)
+	id, err := lastEventID(req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+
+	// The header set by the browser wins
+	req.Header.Set("Last-Event-ID", "9")
+	id, err = lastEventID(req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), id)
+
+	req.Header.Set("Last-Event-ID", "-1")
+	_, err = lastEventID(req)
+	assert.Error(t, err)
+}
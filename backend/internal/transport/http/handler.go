@@ -46,6 +46,23 @@ func (h *Handler) InitRoutes() http.Handler {
 	r.Get("/health", h.health)
 	r.Get("/ready", h.ready)
 
+	// ActivityPub federation (remote servers, no session auth)
+	if h.cfg.ActivityPub.Enabled {
+		r.Get("/.well-known/webfinger", h.webFinger)
+		r.Route("/ap", func(r chi.Router) {
+			r.Get("/actor", h.apActor)
+			r.Get("/outbox", h.apOutbox)
+			r.Get("/followers", h.apFollowers)
+			r.Get("/posts/{id}", h.apPost)
+			r.Post("/inbox", h.apInbox)
+		})
+	}
+
+	// Webmention endpoint (other sites notify us about links to posts)
+	if h.cfg.Webmention.Enabled {
+		r.Post("/webmention", h.receiveWebmention)
+	}
+
 	// Auth routes
 	r.Route("/auth", func(r chi.Router) {
 		r.Get("/{provider}", h.authLogin)
@@ -56,6 +73,9 @@ func (h *Handler) InitRoutes() http.Handler {
 			r.Use(h.AuthRequired)
 			r.Get("/me", h.authMe)
 			r.Post("/logout", h.authLogout)
+			r.Get("/sessions", h.listSessions)
+			r.Delete("/sessions", h.revokeOtherSessions)
+			r.Delete("/sessions/{id}", h.revokeSession)
 		})
 	})
 
@@ -63,6 +83,19 @@ func (h *Handler) InitRoutes() http.Handler {
 		// Public endpoints
 		r.Get("/profile", h.getProfile)
 
+		// Newsletter (no account required, links come from emails)
+		if h.cfg.Newsletter.Enabled {
+			r.Post("/subscribe", h.subscribe)
+			r.Get("/subscribe/confirm", h.confirmSubscription)
+			r.Get("/unsubscribe", h.unsubscribe)
+			r.Post("/unsubscribe", h.unsubscribeOneClick)
+		}
+
+		// Web Push key the browser subscribes with
+		if h.cfg.Push.Enabled {
+			r.Get("/push/public-key", h.getPushPublicKey)
+		}
+
 		// Protected endpoints (require authentication)
 		r.Group(func(r chi.Router) {
 			r.Use(h.AuthRequired)
@@ -71,17 +104,54 @@ func (h *Handler) InitRoutes() http.Handler {
 			r.Get("/posts", h.listPosts)
 			r.Get("/posts/{slug}", h.getPostBySlug)
 			r.Get("/posts/{slug}/comments", h.getCommentsByPostSlug)
+			r.Get("/posts/{slug}/mentions", h.getPostMentions)
+
+			// Live comment and like updates
+			if h.cfg.Events.Enabled {
+				r.Get("/posts/{slug}/events", h.streamPostEvents)
+			}
 
 			// Comments (authenticated users)
 			r.Post("/posts/{slug}/comments", h.createComment)
+			r.Get("/comments/{id}", h.getComment)
 			r.Put("/comments/{id}", h.updateComment)
 			r.Delete("/comments/{id}", h.deleteComment)
+			r.Post("/comments/{id}/report", h.reportComment)
 
 			// Likes endpoints
 			r.Post("/posts/{id}/like", h.togglePostLike)
+			r.Put("/posts/{id}/like", h.likePost)
+			r.Delete("/posts/{id}/like", h.unlikePost)
 			r.Get("/posts/{id}/likes", h.getPostLikesCount)
+			r.Get("/posts/{id}/likes/users", h.getPostLikers)
 			r.Post("/comments/{id}/like", h.toggleCommentLike)
+			r.Put("/comments/{id}/like", h.likeComment)
+			r.Delete("/comments/{id}/like", h.unlikeComment)
 			r.Get("/comments/{id}/likes", h.getCommentLikesCount)
+			r.Get("/comments/{id}/likes/users", h.getCommentLikers)
+			r.Get("/me/likes", h.listMyLikes)
+
+			// Reactions endpoints
+			r.Get("/reactions", h.listReactions)
+			r.Put("/posts/{id}/reactions/{emoji}", h.addPostReaction)
+			r.Delete("/posts/{id}/reactions/{emoji}", h.removePostReaction)
+			r.Put("/comments/{id}/reactions/{emoji}", h.addCommentReaction)
+			r.Delete("/comments/{id}/reactions/{emoji}", h.removeCommentReaction)
+
+			// Bookmarks (reading list)
+			r.Post("/posts/{id}/bookmark", h.bookmarkPost)
+			r.Delete("/posts/{id}/bookmark", h.unbookmarkPost)
+			r.Get("/me/bookmarks", h.listMyBookmarks)
+
+			// Notification preferences
+			r.Get("/me/notification-settings", h.getNotificationSettings)
+			r.Put("/me/notification-settings", h.updateNotificationSettings)
+
+			// Browser push subscriptions
+			if h.cfg.Push.Enabled {
+				r.Post("/me/push-subscriptions", h.subscribePush)
+				r.Delete("/me/push-subscriptions", h.unsubscribePush)
+			}
 		})
 
 		// Admin endpoints
@@ -91,9 +161,27 @@ func (h *Handler) InitRoutes() http.Handler {
 			r.Put("/admin/profile", h.updateProfile)
 			r.Post("/admin/upload", h.uploadImage)
 			r.Post("/admin/posts", h.createPost)
+			r.Post("/admin/posts/bulk", h.bulkPosts)
 			r.Get("/admin/posts/{id}", h.getPostByID)
 			r.Put("/admin/posts/{id}", h.updatePost)
 			r.Delete("/admin/posts/{id}", h.deletePost)
+			r.Get("/admin/posts/{id}/translations", h.listPostTranslations)
+			r.Put("/admin/posts/{id}/translations/{lang}", h.upsertPostTranslation)
+			r.Delete("/admin/posts/{id}/translations/{lang}", h.deletePostTranslation)
+			r.Post("/admin/comments/bulk", h.bulkComments)
+			r.Get("/admin/comments/queue", h.listModerationQueue)
+			r.Get("/admin/comments/{id}/revisions", h.listCommentRevisions)
+			r.Get("/admin/comments/reports", h.listCommentReports)
+			r.Post("/admin/comments/{id}/reports/resolve", h.resolveCommentReports)
+			r.Post("/admin/comments/{id}/reports/dismiss", h.dismissCommentReports)
+			r.Post("/admin/counters/reconcile", h.reconcileCounters)
+			r.Get("/admin/users", h.listUsers)
+			r.Get("/admin/users/{id}", h.getUser)
+			r.Put("/admin/users/{id}/role", h.updateUserRole)
+			r.Delete("/admin/users/{id}", h.deleteUser)
+			r.Post("/admin/users/{id}/ban", h.banUser)
+			r.Delete("/admin/users/{id}/ban", h.liftUserBan)
+			r.Get("/admin/users/{id}/bans", h.listUserBans)
 		})
 	})
 
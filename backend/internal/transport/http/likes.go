@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"personal-web-platform/internal/domain"
+
 	"github.com/go-chi/chi/v5"
 )
 
@@ -29,7 +31,7 @@ func (h *Handler) togglePostLike(w http.ResponseWriter, r *http.Request) { //nol
 	liked, err := h.services.Like.TogglePostLike(r.Context(), user.ID, postID)
 	if err != nil {
 		h.log.Error("failed to toggle post like", "error", err, "postID", postID, "userID", user.ID)
-		RespondInternalError(w)
+		RespondWithError(w, err)
 		return
 	}
 
@@ -69,7 +71,7 @@ func (h *Handler) toggleCommentLike(w http.ResponseWriter, r *http.Request) { //
 	liked, err := h.services.Like.ToggleCommentLike(r.Context(), user.ID, commentID)
 	if err != nil {
 		h.log.Error("failed to toggle comment like", "error", err, "commentID", commentID, "userID", user.ID)
-		RespondInternalError(w)
+		RespondWithError(w, err)
 		return
 	}
 
@@ -88,6 +90,55 @@ func (h *Handler) toggleCommentLike(w http.ResponseWriter, r *http.Request) { //
 	}
 }
 
+// likePost handles PUT /api/v1/posts/{id}/like - like a post, repeating it is a no-op
+func (h *Handler) likePost(w http.ResponseWriter, r *http.Request) {
+	h.handleSetLike(w, r, domain.ReactionTargetPost, true)
+}
+
+// unlikePost handles DELETE /api/v1/posts/{id}/like - unlike a post, repeating it is a no-op
+func (h *Handler) unlikePost(w http.ResponseWriter, r *http.Request) {
+	h.handleSetLike(w, r, domain.ReactionTargetPost, false)
+}
+
+// likeComment handles PUT /api/v1/comments/{id}/like - like a comment, repeating it is a no-op
+func (h *Handler) likeComment(w http.ResponseWriter, r *http.Request) {
+	h.handleSetLike(w, r, domain.ReactionTargetComment, true)
+}
+
+// unlikeComment handles DELETE /api/v1/comments/{id}/like - unlike a comment, repeating it is a no-op
+func (h *Handler) unlikeComment(w http.ResponseWriter, r *http.Request) {
+	h.handleSetLike(w, r, domain.ReactionTargetComment, false)
+}
+
+// handleSetLike likes or unlikes a post or comment and responds with the resulting status
+func (h *Handler) handleSetLike(w http.ResponseWriter, r *http.Request, target domain.ReactionTarget, liked bool) {
+	targetID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid "+string(target)+" ID")
+		return
+	}
+
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var status *domain.LikeStatus
+	if target == domain.ReactionTargetComment {
+		status, err = h.services.Like.SetCommentLike(r.Context(), user.ID, targetID, liked)
+	} else {
+		status, err = h.services.Like.SetPostLike(r.Context(), user.ID, targetID, liked)
+	}
+	if err != nil {
+		h.log.Error("failed to set like", "error", err, "target", target, "targetID", targetID, "userID", user.ID, "liked", liked)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, status)
+}
+
 // getPostLikesCount handles GET /api/v1/posts/{id}/likes - get likes count for a post
 func (h *Handler) getPostLikesCount(w http.ResponseWriter, r *http.Request) {
 	// Get post ID from URL
@@ -145,3 +196,69 @@ func (h *Handler) getCommentLikesCount(w http.ResponseWriter, r *http.Request) {
 		h.log.Error("failed to encode likes count response", "error", err)
 	}
 }
+
+// getPostLikers handles GET /api/v1/posts/{id}/likes/users - users who liked a post, newest first
+func (h *Handler) getPostLikers(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid post ID")
+		return
+	}
+
+	response, err := h.services.Like.ListPostLikers(r.Context(), postID, likesRequest(r))
+	if err != nil {
+		h.log.Error("failed to list post likes", "error", err, "postID", postID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
+
+// getCommentLikers handles GET /api/v1/comments/{id}/likes/users - users who liked a comment, newest first
+func (h *Handler) getCommentLikers(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid comment ID")
+		return
+	}
+
+	response, err := h.services.Like.ListCommentLikers(r.Context(), commentID, likesRequest(r))
+	if err != nil {
+		h.log.Error("failed to list comment likes", "error", err, "commentID", commentID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
+
+// listMyLikes handles GET /api/v1/me/likes - posts and comments the current user liked, newest first
+func (h *Handler) listMyLikes(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	response, err := h.services.Like.ListUserLikes(r.Context(), user.ID, likesRequest(r))
+	if err != nil {
+		h.log.Error("failed to list liked items", "error", err, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
+
+// likesRequest reads ?page and ?limit of like lists
+func likesRequest(r *http.Request) *domain.LikesRequest {
+	req := &domain.LikesRequest{}
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+		req.Page = page
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		req.Limit = limit
+	}
+	return req
+}
@@ -3,11 +3,13 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -221,3 +223,146 @@ func TestHandler_getCommentLikesCount(t *testing.T) { //nolint:dupl // similar t
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestHandler_getPostLikers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/posts/1/likes/users?page=2&limit=5", nil)
+		req = injectParam(req, "id", "1")
+
+		mocks.Like.On("ListPostLikers", mock.Anything, 1, &domain.LikesRequest{Page: 2, Limit: 5}).
+			Return(&domain.LikersResponse{Users: []domain.Liker{{UserID: 2, Name: "Bob"}}, TotalCount: 6, Page: 2, Limit: 5, TotalPages: 2}, nil)
+
+		w := httptest.NewRecorder()
+		h.getPostLikers(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"Bob"`)
+	})
+
+	t.Run("Post Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/posts/9/likes/users", nil)
+		req = injectParam(req, "id", "9")
+
+		mocks.Like.On("ListPostLikers", mock.Anything, 9, &domain.LikesRequest{}).
+			Return(nil, fmt.Errorf("%w: post not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.getPostLikers(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_getCommentLikers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/comments/3/likes/users", nil)
+		req = injectParam(req, "id", "3")
+
+		mocks.Like.On("ListCommentLikers", mock.Anything, 3, &domain.LikesRequest{}).
+			Return(&domain.LikersResponse{Users: []domain.Liker{}, Page: 1, Limit: 20}, nil)
+
+		w := httptest.NewRecorder()
+		h.getCommentLikers(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Invalid Comment ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/comments/abc/likes/users", nil)
+		req = injectParam(req, "id", "abc")
+
+		w := httptest.NewRecorder()
+		h.getCommentLikers(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_listMyLikes(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/me/likes", nil)
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Like.On("ListUserLikes", mock.Anything, 2, &domain.LikesRequest{}).
+			Return(&domain.LikedItemsResponse{Items: []domain.LikedItem{{Target: domain.ReactionTargetPost, PostID: 1, PostSlug: "hello"}}, TotalCount: 1, Page: 1, Limit: 20, TotalPages: 1}, nil)
+
+		w := httptest.NewRecorder()
+		h.listMyLikes(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"post_slug":"hello"`)
+	})
+
+	t.Run("No User", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/me/likes", nil)
+
+		w := httptest.NewRecorder()
+		h.listMyLikes(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestHandler_setLike(t *testing.T) {
+	t.Run("Like Post", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/posts/1/like", nil)
+		req = injectParam(req, "id", "1")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Like.On("SetPostLike", mock.Anything, 2, 1, true).Return(&domain.LikeStatus{IsLiked: true, LikesCount: 5}, nil)
+
+		w := httptest.NewRecorder()
+		h.likePost(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"is_liked":true`)
+		assert.Contains(t, w.Body.String(), `"likes_count":5`)
+	})
+
+	t.Run("Unlike Comment", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/comments/3/like", nil)
+		req = injectParam(req, "id", "3")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Like.On("SetCommentLike", mock.Anything, 2, 3, false).Return(&domain.LikeStatus{}, nil)
+
+		w := httptest.NewRecorder()
+		h.unlikeComment(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"is_liked":false`)
+	})
+
+	t.Run("Post Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/posts/9/like", nil)
+		req = injectParam(req, "id", "9")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Like.On("SetPostLike", mock.Anything, 2, 9, false).Return(nil, fmt.Errorf("%w: post not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.unlikePost(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("No User", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/comments/3/like", nil)
+		req = injectParam(req, "id", "3")
+
+		w := httptest.NewRecorder()
+		h.likeComment(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -43,12 +44,42 @@ func (h *Handler) AuthRequired(next http.Handler) http.Handler {
 			return
 		}
 
+		// Banned users can read, but not change anything
+		if user.Ban != nil && !readOnlyAllowed(r) {
+			RespondForbidden(w, "account is banned")
+			return
+		}
+
 		// Add user to context
 		ctx := context.WithValue(r.Context(), userContextKey, user)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// readOnlyAllowed reports whether a banned user may make the request,
+// signing out and ending their other sessions is always allowed
+func readOnlyAllowed(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return r.URL.Path == "/auth/logout" || strings.HasPrefix(r.URL.Path, "/auth/sessions")
+}
+
+// clientIP returns the IP address of the client, or "" when RemoteAddr
+// does not hold one. RealIP has already applied the proxy headers.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
 // AdminRequired middleware checks if user is admin
 func (h *Handler) AdminRequired(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
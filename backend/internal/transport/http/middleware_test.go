@@ -38,6 +38,49 @@ func TestHandler_AuthRequired(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("Banned User Reads", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/posts", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "banned-token"})
+
+		user := &domain.User{ID: 2, Ban: &domain.UserBan{Reason: "spam"}}
+		mocks.Auth.On("ValidateSession", mock.Anything, "banned-token").Return(user, nil)
+
+		w := httptest.NewRecorder()
+		h.AuthRequired(nextHandler).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Banned User Writes", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/posts/hello/comments", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "banned-token"})
+
+		w := httptest.NewRecorder()
+		h.AuthRequired(nextHandler).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Banned User Logs Out", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/auth/logout", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "banned-token"})
+
+		w := httptest.NewRecorder()
+		h.AuthRequired(nextHandler).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Banned User Revokes Sessions", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/auth/sessions", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "banned-token"})
+
+		w := httptest.NewRecorder()
+		h.AuthRequired(nextHandler).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
 	t.Run("No Cookie", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/", nil)
 		w := httptest.NewRecorder()
@@ -103,3 +146,24 @@ func TestHandler_AdminRequired(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		remoteAddr string
+		want       string
+	}{
+		{"203.0.113.7:5123", "203.0.113.7"},
+		{"203.0.113.7", "203.0.113.7"},
+		{"[2001:db8::1]:443", "2001:db8::1"},
+		{"2001:db8::1", "2001:db8::1"},
+		{"not-an-ip", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remoteAddr, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			assert.Equal(t, tt.want, clientIP(req))
+		})
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"net/http"
 	"testing"
+	"time"
 
 	"personal-web-platform/config"
 	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/pkg/activitypub"
 	"personal-web-platform/internal/service"
 
 	"github.com/markbates/goth"
@@ -16,33 +19,65 @@ import (
 
 // MockServices holds all mocked services
 type MockServices struct {
-	Post    *MockPostService
-	Comment *MockCommentService
-	Profile *MockProfileService
-	Auth    *MockAuthService
-	Like    *MockLikeService
+	Post         *MockPostService
+	Comment      *MockCommentService
+	Profile      *MockProfileService
+	Auth         *MockAuthService
+	Like         *MockLikeService
+	Reaction     *MockReactionService
+	Bookmark     *MockBookmarkService
+	ActivityPub  *MockActivityPubService
+	Webmention   *MockWebmentionService
+	Newsletter   *MockNewsletterService
+	Notification *MockNotificationService
+	Push         *MockPushService
+	Event        *MockEventService
+	Counter      *MockCounterService
+	User         *MockUserService
 }
 
 // setupHandler creates a handler with mocked services
 func setupHandler(_ *testing.T) (*Handler, *MockServices) { //nolint:revive // t is kept for consistency
 	mocks := &MockServices{
-		Post:    new(MockPostService),
-		Comment: new(MockCommentService),
-		Profile: new(MockProfileService),
-		Auth:    new(MockAuthService),
-		Like:    new(MockLikeService),
+		Post:         new(MockPostService),
+		Comment:      new(MockCommentService),
+		Profile:      new(MockProfileService),
+		Auth:         new(MockAuthService),
+		Like:         new(MockLikeService),
+		Reaction:     new(MockReactionService),
+		Bookmark:     new(MockBookmarkService),
+		ActivityPub:  new(MockActivityPubService),
+		Webmention:   new(MockWebmentionService),
+		Newsletter:   new(MockNewsletterService),
+		Notification: new(MockNotificationService),
+		Push:         new(MockPushService),
+		Event:        new(MockEventService),
+		Counter:      new(MockCounterService),
+		User:         new(MockUserService),
 	}
 
 	services := &service.Services{
-		Post:    mocks.Post,
-		Comment: mocks.Comment,
-		Profile: mocks.Profile,
-		Auth:    mocks.Auth,
-		Like:    mocks.Like,
+		Post:         mocks.Post,
+		Comment:      mocks.Comment,
+		Profile:      mocks.Profile,
+		Auth:         mocks.Auth,
+		Like:         mocks.Like,
+		Reaction:     mocks.Reaction,
+		Bookmark:     mocks.Bookmark,
+		ActivityPub:  mocks.ActivityPub,
+		Webmention:   mocks.Webmention,
+		Newsletter:   mocks.Newsletter,
+		Notification: mocks.Notification,
+		Push:         mocks.Push,
+		Event:        mocks.Event,
+		Counter:      mocks.Counter,
+		User:         mocks.User,
 	}
 
 	cfg := &config.Config{
-		Auth: config.Auth{CookieName: "session_id"},
+		Auth:   config.Auth{CookieName: "session_id"},
+		OAuth:  config.OAuth{FrontendURL: "https://blog.example"},
+		Events: config.Events{Enabled: true, Heartbeat: time.Minute},
 	}
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
@@ -84,7 +119,7 @@ func (m *MockPostService) GetPostByID(ctx context.Context, id, userID int) (*dom
 	return args.Get(0).(*domain.Post), args.Error(1)
 }
 
-func (m *MockPostService) GetPostBySlug(ctx context.Context, slug string, userID int) (*domain.Post, error) {
+func (m *MockPostService) GetPostBySlug(ctx context.Context, slug string, userID int, _ domain.LanguagePreference) (*domain.Post, error) {
 	args := m.Called(ctx, slug, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -100,6 +135,35 @@ func (m *MockPostService) ListPosts(ctx context.Context, req *domain.ListPostsRe
 	return args.Get(0).(*domain.PostsListResponse), args.Error(1)
 }
 
+func (m *MockPostService) ListTranslations(ctx context.Context, postID int) ([]domain.PostTranslation, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PostTranslation), args.Error(1)
+}
+
+func (m *MockPostService) UpsertTranslation(ctx context.Context, postID int, lang string, req *domain.UpsertTranslationRequest) (*domain.PostTranslation, error) {
+	args := m.Called(ctx, postID, lang, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PostTranslation), args.Error(1)
+}
+
+func (m *MockPostService) DeleteTranslation(ctx context.Context, postID int, lang string) error {
+	args := m.Called(ctx, postID, lang)
+	return args.Error(0)
+}
+
+func (m *MockPostService) BulkUpdatePosts(ctx context.Context, req *domain.BulkPostsRequest) (*domain.BulkResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkResponse), args.Error(1)
+}
+
 type MockCommentService struct {
 	mock.Mock
 }
@@ -125,6 +189,11 @@ func (m *MockCommentService) DeleteComment(ctx context.Context, commentID int, u
 	return args.Error(0)
 }
 
+func (m *MockCommentService) PurgeTombstones(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockCommentService) GetCommentByID(ctx context.Context, id int) (*domain.Comment, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -133,14 +202,69 @@ func (m *MockCommentService) GetCommentByID(ctx context.Context, id int) (*domai
 	return args.Get(0).(*domain.Comment), args.Error(1)
 }
 
-func (m *MockCommentService) GetCommentsByPostSlug(ctx context.Context, slug string, userID int) ([]domain.Comment, error) {
-	args := m.Called(ctx, slug, userID)
+func (m *MockCommentService) GetCommentPermalink(ctx context.Context, id int, userID int, isAdmin bool) (*domain.CommentPermalink, error) {
+	args := m.Called(ctx, id, userID, isAdmin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CommentPermalink), args.Error(1)
+}
+
+func (m *MockCommentService) GetCommentRevisions(ctx context.Context, id int) ([]domain.CommentRevision, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CommentRevision), args.Error(1)
+}
+
+func (m *MockCommentService) GetCommentsByPostSlug(ctx context.Context, slug string, userID int, isAdmin bool, order string) ([]domain.Comment, error) {
+	args := m.Called(ctx, slug, userID, isAdmin, order)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Comment), args.Error(1)
 }
 
+func (m *MockCommentService) BulkModerateComments(ctx context.Context, req *domain.BulkCommentsRequest) (*domain.BulkResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkResponse), args.Error(1)
+}
+
+func (m *MockCommentService) ListModerationQueue(ctx context.Context, req *domain.ModerationQueueRequest) (*domain.ModerationQueueResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ModerationQueueResponse), args.Error(1)
+}
+
+func (m *MockCommentService) ReportComment(ctx context.Context, commentID int, req *domain.ReportCommentRequest, userID int) error {
+	args := m.Called(ctx, commentID, req, userID)
+	return args.Error(0)
+}
+
+func (m *MockCommentService) ListReports(ctx context.Context, req *domain.ReportsRequest) (*domain.ReportsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReportsResponse), args.Error(1)
+}
+
+func (m *MockCommentService) ResolveReports(ctx context.Context, commentID, adminID int) error {
+	args := m.Called(ctx, commentID, adminID)
+	return args.Error(0)
+}
+
+func (m *MockCommentService) DismissReports(ctx context.Context, commentID, adminID int) error {
+	args := m.Called(ctx, commentID, adminID)
+	return args.Error(0)
+}
+
 type MockProfileService struct {
 	mock.Mock
 }
@@ -165,8 +289,8 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) LoginWithOAuth(ctx context.Context, gothUser goth.User) (*domain.User, *domain.Session, error) {
-	args := m.Called(ctx, gothUser)
+func (m *MockAuthService) LoginWithOAuth(ctx context.Context, gothUser goth.User, client domain.SessionClient) (*domain.User, *domain.Session, error) {
+	args := m.Called(ctx, gothUser, client)
 	if args.Get(0) == nil {
 		return nil, nil, args.Error(2)
 	}
@@ -194,6 +318,24 @@ func (m *MockAuthService) GetUserByID(ctx context.Context, userID int) (*domain.
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockAuthService) ListSessions(ctx context.Context, userID int, currentToken string) ([]domain.Session, error) {
+	args := m.Called(ctx, userID, currentToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Session), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID, sessionID int, currentToken string) error {
+	args := m.Called(ctx, userID, sessionID, currentToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) RevokeOtherSessions(ctx context.Context, userID int, currentToken string) (int64, error) {
+	args := m.Called(ctx, userID, currentToken)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 type MockLikeService struct {
 	mock.Mock
 }
@@ -227,3 +369,390 @@ func (m *MockLikeService) IsCommentLikedByUser(ctx context.Context, userID, comm
 	args := m.Called(ctx, userID, commentID)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockLikeService) SetPostLike(ctx context.Context, userID, postID int, liked bool) (*domain.LikeStatus, error) {
+	args := m.Called(ctx, userID, postID, liked)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LikeStatus), args.Error(1)
+}
+
+func (m *MockLikeService) SetCommentLike(ctx context.Context, userID, commentID int, liked bool) (*domain.LikeStatus, error) {
+	args := m.Called(ctx, userID, commentID, liked)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LikeStatus), args.Error(1)
+}
+
+func (m *MockLikeService) ListPostLikers(ctx context.Context, postID int, req *domain.LikesRequest) (*domain.LikersResponse, error) {
+	args := m.Called(ctx, postID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LikersResponse), args.Error(1)
+}
+
+func (m *MockLikeService) ListCommentLikers(ctx context.Context, commentID int, req *domain.LikesRequest) (*domain.LikersResponse, error) {
+	args := m.Called(ctx, commentID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LikersResponse), args.Error(1)
+}
+
+func (m *MockLikeService) ListUserLikes(ctx context.Context, userID int, req *domain.LikesRequest) (*domain.LikedItemsResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LikedItemsResponse), args.Error(1)
+}
+
+type MockReactionService struct {
+	mock.Mock
+}
+
+func (m *MockReactionService) AllowedReactions() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
+func (m *MockReactionService) AddReaction(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) ([]domain.ReactionSummary, error) {
+	args := m.Called(ctx, target, targetID, userID, emoji)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReactionSummary), args.Error(1)
+}
+
+func (m *MockReactionService) RemoveReaction(ctx context.Context, target domain.ReactionTarget, targetID, userID int, emoji string) ([]domain.ReactionSummary, error) {
+	args := m.Called(ctx, target, targetID, userID, emoji)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ReactionSummary), args.Error(1)
+}
+
+type MockBookmarkService struct {
+	mock.Mock
+}
+
+func (m *MockBookmarkService) AddBookmark(ctx context.Context, userID, postID int) error {
+	args := m.Called(ctx, userID, postID)
+	return args.Error(0)
+}
+
+func (m *MockBookmarkService) RemoveBookmark(ctx context.Context, userID, postID int) error {
+	args := m.Called(ctx, userID, postID)
+	return args.Error(0)
+}
+
+type MockActivityPubService struct {
+	mock.Mock
+}
+
+func (m *MockActivityPubService) WebFinger(ctx context.Context, resource string) (*activitypub.WebFinger, error) {
+	args := m.Called(ctx, resource)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*activitypub.WebFinger), args.Error(1)
+}
+
+func (m *MockActivityPubService) Actor(ctx context.Context) (*activitypub.Actor, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*activitypub.Actor), args.Error(1)
+}
+
+func (m *MockActivityPubService) Outbox(ctx context.Context, page int) (*activitypub.OrderedCollection, error) {
+	args := m.Called(ctx, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*activitypub.OrderedCollection), args.Error(1)
+}
+
+func (m *MockActivityPubService) Followers(ctx context.Context) (*activitypub.OrderedCollection, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*activitypub.OrderedCollection), args.Error(1)
+}
+
+func (m *MockActivityPubService) PostObject(ctx context.Context, postID int) (*activitypub.Object, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*activitypub.Object), args.Error(1)
+}
+
+func (m *MockActivityPubService) HandleInbox(ctx context.Context, r *http.Request, body []byte) error {
+	args := m.Called(ctx, r, body)
+	return args.Error(0)
+}
+
+func (m *MockActivityPubService) PostPublished(ctx context.Context, post *domain.Post) {
+	m.Called(ctx, post)
+}
+
+func (m *MockActivityPubService) Wait(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockWebmentionService struct {
+	mock.Mock
+}
+
+func (m *MockWebmentionService) Receive(ctx context.Context, source, target string) error {
+	args := m.Called(ctx, source, target)
+	return args.Error(0)
+}
+
+func (m *MockWebmentionService) ListMentions(ctx context.Context, slug string) ([]domain.Webmention, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Webmention), args.Error(1)
+}
+
+func (m *MockWebmentionService) ProcessQueue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockWebmentionService) PostPublished(ctx context.Context, post *domain.Post) {
+	m.Called(ctx, post)
+}
+
+func (m *MockWebmentionService) PostUpdated(ctx context.Context, post *domain.Post) {
+	m.Called(ctx, post)
+}
+
+type MockNewsletterService struct {
+	mock.Mock
+}
+
+func (m *MockNewsletterService) Subscribe(ctx context.Context, req *domain.SubscribeRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockNewsletterService) Confirm(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockNewsletterService) Unsubscribe(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockNewsletterService) PostPublished(ctx context.Context, post *domain.Post) {
+	m.Called(ctx, post)
+}
+
+func (m *MockNewsletterService) ProcessQueue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockNewsletterService) Wait(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) GetSettings(ctx context.Context, userID int) (*domain.NotificationSettings, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.NotificationSettings), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockNotificationService) UpdateSettings(ctx context.Context, userID int, req *domain.UpdateNotificationSettingsRequest) (*domain.NotificationSettings, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(*domain.NotificationSettings), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockNotificationService) ProcessEmails(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment) {
+	m.Called(ctx, post, comment, parent)
+}
+
+func (m *MockNotificationService) CommentUpdated(ctx context.Context, comment *domain.Comment) {
+	m.Called(ctx, comment)
+}
+
+func (m *MockNotificationService) PostPublished(ctx context.Context, post *domain.Post) {
+	m.Called(ctx, post)
+}
+
+type MockPushService struct {
+	mock.Mock
+}
+
+func (m *MockPushService) PublicKey() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockPushService) Subscribe(ctx context.Context, userID int, req *domain.PushSubscriptionRequest, userAgent string) error {
+	args := m.Called(ctx, userID, req, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockPushService) Unsubscribe(ctx context.Context, userID int, req *domain.PushUnsubscribeRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockPushService) CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment) {
+	m.Called(ctx, post, comment, parent)
+}
+
+func (m *MockPushService) PostPublished(ctx context.Context, post *domain.Post) {
+	m.Called(ctx, post)
+}
+
+func (m *MockPushService) Wait(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type MockCounterService struct {
+	mock.Mock
+}
+
+func (m *MockCounterService) Reconcile(ctx context.Context, dryRun bool) (*domain.CounterReport, error) {
+	args := m.Called(ctx, dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CounterReport), args.Error(1)
+}
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) ListUsers(ctx context.Context, req *domain.ListUsersRequest) (*domain.UserListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserListResponse), args.Error(1)
+}
+
+func (m *MockUserService) GetUser(ctx context.Context, id int) (*domain.UserDetails, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserDetails), args.Error(1)
+}
+
+func (m *MockUserService) UpdateUserRole(ctx context.Context, id int, req *domain.UpdateUserRoleRequest, adminID int) (*domain.User, error) {
+	args := m.Called(ctx, id, req, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) DeleteUser(ctx context.Context, id int, req *domain.DeleteUserRequest, adminID int) error {
+	args := m.Called(ctx, id, req, adminID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) BanUser(ctx context.Context, id int, req *domain.BanUserRequest, adminID int) (*domain.UserBan, error) {
+	args := m.Called(ctx, id, req, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserBan), args.Error(1)
+}
+
+func (m *MockUserService) LiftBan(ctx context.Context, id, adminID int) (*domain.UserBan, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserBan), args.Error(1)
+}
+
+func (m *MockUserService) ListBans(ctx context.Context, id int) ([]domain.UserBan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.UserBan), args.Error(1)
+}
+
+type MockEventService struct {
+	mock.Mock
+}
+
+func (m *MockEventService) Subscribe(ctx context.Context, slug string, lastEventID int64) (<-chan domain.PostEvent, error) {
+	args := m.Called(ctx, slug, lastEventID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) //nolint:errcheck // mock method
+	}
+	return args.Get(0).(<-chan domain.PostEvent), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockEventService) Listen(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *MockEventService) Cleanup(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1) //nolint:errcheck // mock method
+}
+
+func (m *MockEventService) Close() {
+	m.Called()
+}
+
+func (m *MockEventService) CommentCreated(ctx context.Context, post *domain.Post, comment, parent *domain.Comment) {
+	m.Called(ctx, post, comment, parent)
+}
+
+func (m *MockEventService) CommentUpdated(ctx context.Context, comment *domain.Comment) {
+	m.Called(ctx, comment)
+}
+
+func (m *MockEventService) CommentDeleted(ctx context.Context, comment *domain.Comment, removed bool) {
+	m.Called(ctx, comment, removed)
+}
+
+func (m *MockEventService) CommentRestored(ctx context.Context, comment *domain.Comment) {
+	m.Called(ctx, comment)
+}
+
+func (m *MockEventService) PostLikesChanged(ctx context.Context, postID, count int) {
+	m.Called(ctx, postID, count)
+}
+
+func (m *MockEventService) CommentLikesChanged(ctx context.Context, comment *domain.Comment, count int) {
+	m.Called(ctx, comment, count)
+}
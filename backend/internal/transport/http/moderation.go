@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// listModerationQueue handles GET /api/v1/admin/comments/queue - comments held for moderation.
+// ?status=spam or ?status=rejected lists hidden comments instead of pending ones.
+func (h *Handler) listModerationQueue(w http.ResponseWriter, r *http.Request) {
+	req := &domain.ModerationQueueRequest{Status: r.URL.Query().Get("status")}
+
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+		req.Page = page
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		req.Limit = limit
+	}
+
+	response, err := h.services.Comment.ListModerationQueue(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to list moderation queue", "error", err, "status", req.Status)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
+
+// listCommentRevisions handles GET /api/v1/admin/comments/{id}/revisions - earlier versions of an edited comment
+func (h *Handler) listCommentRevisions(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid comment ID")
+		return
+	}
+
+	revisions, err := h.services.Comment.GetCommentRevisions(r.Context(), commentID)
+	if err != nil {
+		h.log.Error("failed to list comment revisions", "error", err, "commentID", commentID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, revisions)
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_listModerationQueue(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/admin/comments/queue?status=spam&page=2&limit=10", nil)
+
+		mocks.Comment.On("ListModerationQueue", mock.Anything, &domain.ModerationQueueRequest{Status: "spam", Page: 2, Limit: 10}).
+			Return(&domain.ModerationQueueResponse{
+				Comments:   []domain.QueuedComment{{Comment: domain.Comment{ID: 7, Content: "Buy now"}, PostSlug: "hello"}},
+				TotalCount: 11,
+				Page:       2,
+				Limit:      10,
+				TotalPages: 2,
+			}, nil)
+
+		w := httptest.NewRecorder()
+		h.listModerationQueue(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"post_slug":"hello"`)
+		assert.Contains(t, w.Body.String(), `"total_count":11`)
+	})
+
+	t.Run("Invalid Status", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/admin/comments/queue?status=approved", nil)
+
+		mocks.Comment.On("ListModerationQueue", mock.Anything, mock.Anything).Return(nil, derr.ErrValidation)
+
+		w := httptest.NewRecorder()
+		h.listModerationQueue(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_listCommentRevisions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/admin/comments/3/revisions", nil)
+		req = injectParam(req, "id", "3")
+
+		mocks.Comment.On("GetCommentRevisions", mock.Anything, 3).
+			Return([]domain.CommentRevision{{ID: 1, CommentID: 3, Content: "First version"}}, nil)
+
+		w := httptest.NewRecorder()
+		h.listCommentRevisions(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "First version")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/admin/comments/9/revisions", nil)
+		req = injectParam(req, "id", "9")
+
+		mocks.Comment.On("GetCommentRevisions", mock.Anything, 9).Return(nil, derr.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		h.listCommentRevisions(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"personal-web-platform/internal/domain"
+)
+
+// subscribe handles POST /api/v1/subscribe - request a newsletter subscription.
+// The same response is returned whether or not the address is already subscribed.
+func (h *Handler) subscribe(w http.ResponseWriter, r *http.Request) {
+	var req domain.SubscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondBadRequest(w, "invalid request body")
+		return
+	}
+
+	if err := h.services.Newsletter.Subscribe(r.Context(), &req); err != nil {
+		h.log.Error("failed to subscribe", "error", err)
+		RespondWithError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, Response{
+		Success: true,
+		Data:    map[string]string{"message": "check your inbox to confirm the subscription"},
+	})
+}
+
+// confirmSubscription handles GET /api/v1/subscribe/confirm?token= - the link from the confirmation email
+func (h *Handler) confirmSubscription(w http.ResponseWriter, r *http.Request) {
+	status := "confirmed"
+	if err := h.services.Newsletter.Confirm(r.Context(), r.URL.Query().Get("token")); err != nil {
+		h.log.Info("failed to confirm subscription", "error", err)
+		status = "invalid"
+	}
+
+	http.Redirect(w, r, h.cfg.OAuth.FrontendURL+"/?newsletter="+status, http.StatusFound)
+}
+
+// unsubscribe handles GET /api/v1/unsubscribe?token= - the link from newsletter emails
+func (h *Handler) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	status := "unsubscribed"
+	if err := h.services.Newsletter.Unsubscribe(r.Context(), r.URL.Query().Get("token")); err != nil {
+		h.log.Info("failed to unsubscribe", "error", err)
+		status = "invalid"
+	}
+
+	http.Redirect(w, r, h.cfg.OAuth.FrontendURL+"/?newsletter="+status, http.StatusFound)
+}
+
+// unsubscribeOneClick handles POST /api/v1/unsubscribe?token= - RFC 8058 one-click unsubscribe from mail clients
+func (h *Handler) unsubscribeOneClick(w http.ResponseWriter, r *http.Request) {
+	if err := h.services.Newsletter.Unsubscribe(r.Context(), r.URL.Query().Get("token")); err != nil {
+		h.log.Info("failed to unsubscribe", "error", err)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, map[string]string{"message": "unsubscribed"})
+}
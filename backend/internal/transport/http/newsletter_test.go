@@ -0,0 +1,119 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_subscribe(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/subscribe", strings.NewReader(`{"email":"reader@example.com"}`))
+
+		mocks.Newsletter.On("Subscribe", mock.Anything, &domain.SubscribeRequest{Email: "reader@example.com"}).Return(nil)
+
+		w := httptest.NewRecorder()
+		h.subscribe(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mocks.Newsletter.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Email", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/subscribe", strings.NewReader(`{"email":"nope"}`))
+
+		mocks.Newsletter.On("Subscribe", mock.Anything, mock.Anything).Return(fmt.Errorf("%w: invalid email", derr.ErrValidation))
+
+		w := httptest.NewRecorder()
+		h.subscribe(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid Body", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/subscribe", strings.NewReader(`{`))
+
+		w := httptest.NewRecorder()
+		h.subscribe(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_confirmSubscription(t *testing.T) {
+	t.Run("Confirmed", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/subscribe/confirm?token=abc", nil)
+
+		mocks.Newsletter.On("Confirm", mock.Anything, "abc").Return(nil)
+
+		w := httptest.NewRecorder()
+		h.confirmSubscription(w, req)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://blog.example/?newsletter=confirmed", w.Header().Get("Location"))
+	})
+
+	t.Run("Expired Link", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/subscribe/confirm?token=abc", nil)
+
+		mocks.Newsletter.On("Confirm", mock.Anything, "abc").Return(fmt.Errorf("%w: expired", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.confirmSubscription(w, req)
+
+		assert.Equal(t, "https://blog.example/?newsletter=invalid", w.Header().Get("Location"))
+	})
+}
+
+func TestHandler_unsubscribe(t *testing.T) {
+	t.Run("Link", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/unsubscribe?token=abc", nil)
+
+		mocks.Newsletter.On("Unsubscribe", mock.Anything, "abc").Return(nil)
+
+		w := httptest.NewRecorder()
+		h.unsubscribe(w, req)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://blog.example/?newsletter=unsubscribed", w.Header().Get("Location"))
+	})
+
+	t.Run("One-Click", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/unsubscribe?token=abc", strings.NewReader("List-Unsubscribe=One-Click"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		mocks.Newsletter.On("Unsubscribe", mock.Anything, "abc").Return(nil)
+
+		w := httptest.NewRecorder()
+		h.unsubscribeOneClick(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("One-Click Unknown Token", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/unsubscribe?token=abc", nil)
+
+		mocks.Newsletter.On("Unsubscribe", mock.Anything, "abc").Return(fmt.Errorf("%w: invalid link", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.unsubscribeOneClick(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+
+	"personal-web-platform/internal/domain"
+)
+
+// getNotificationSettings handles GET /api/v1/me/notification-settings
+func (h *Handler) getNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	settings, err := h.services.Notification.GetSettings(r.Context(), user.ID)
+	if err != nil {
+		h.log.Error("failed to get notification settings", "error", err, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, settings)
+}
+
+// updateNotificationSettings handles PUT /api/v1/me/notification-settings - omitted fields are kept
+func (h *Handler) updateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var req domain.UpdateNotificationSettingsRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	settings, err := h.services.Notification.UpdateSettings(r.Context(), user.ID, &req)
+	if err != nil {
+		h.log.Error("failed to update notification settings", "error", err, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, settings)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_getNotificationSettings(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/me/notification-settings", nil)
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Notification.On("GetSettings", mock.Anything, 2).Return(&domain.NotificationSettings{
+			UserID: 2, EmailEnabled: true, NewPostsEnabled: false,
+		}, nil)
+
+		w := httptest.NewRecorder()
+		h.getNotificationSettings(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"email_enabled":true`)
+		assert.Contains(t, w.Body.String(), `"new_posts_enabled":false`)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/me/notification-settings", nil)
+
+		w := httptest.NewRecorder()
+		h.getNotificationSettings(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestHandler_updateNotificationSettings(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/me/notification-settings", strings.NewReader(`{"email_enabled":false,"language":"en"}`))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Notification.On("UpdateSettings", mock.Anything, 2, mock.MatchedBy(func(r *domain.UpdateNotificationSettingsRequest) bool {
+			return r.EmailEnabled != nil && !*r.EmailEnabled && r.NewPostsEnabled == nil && r.Language != nil && *r.Language == "en"
+		})).Return(&domain.NotificationSettings{UserID: 2, Language: "en"}, nil)
+
+		w := httptest.NewRecorder()
+		h.updateNotificationSettings(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"language":"en"`)
+		mocks.Notification.AssertExpectations(t)
+	})
+
+	t.Run("Unsupported language", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/me/notification-settings", strings.NewReader(`{"language":"xx"}`))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Notification.On("UpdateSettings", mock.Anything, 2, mock.Anything).
+			Return(nil, fmt.Errorf("%w: unsupported language", derr.ErrValidation))
+
+		w := httptest.NewRecorder()
+		h.updateNotificationSettings(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid body", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/me/notification-settings", strings.NewReader(`{`))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		w := httptest.NewRecorder()
+		h.updateNotificationSettings(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
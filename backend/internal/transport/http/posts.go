@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"personal-web-platform/internal/domain"
 
@@ -34,10 +35,20 @@ func (h *Handler) listPosts(w http.ResponseWriter, r *http.Request) {
 		req.Published = &published
 	}
 
-	if user := h.getUserFromContext(r.Context()); user != nil {
+	req.Series = r.URL.Query().Get("series")
+
+	user := h.getUserFromContext(r.Context())
+	if user != nil {
 		req.UserID = user.ID
 	}
 
+	// Deleted posts (trash) are visible to admins only
+	if r.URL.Query().Get("deleted") == "true" && user != nil && user.Role == domain.RoleAdmin {
+		req.Deleted = true
+	}
+
+	req.Language = languagePreference(r)
+
 	// Get posts
 	response, err := h.services.Post.ListPosts(r.Context(), req)
 	if err != nil {
@@ -47,6 +58,7 @@ func (h *Handler) listPosts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Vary", "Accept-Language")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		h.log.Error("failed to encode posts response", "error", err)
 	}
@@ -88,18 +100,22 @@ func (h *Handler) getPostBySlug(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var userID int
-	if user := h.getUserFromContext(r.Context()); user != nil {
+	user := h.getUserFromContext(r.Context())
+	if user != nil {
 		userID = user.ID
 	}
 
-	post, err := h.services.Post.GetPostBySlug(r.Context(), slug, userID)
+	post, err := h.services.Post.GetPostBySlug(r.Context(), slug, userID, languagePreference(r))
 	if err != nil {
 		h.log.Error("failed to get post by slug", "error", err, "slug", slug)
 		http.Error(w, "post not found", http.StatusNotFound)
 		return
 	}
+	post.CommentsClosedReason = post.CommentingClosed(user, time.Now())
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", post.Language)
+	w.Header().Add("Vary", "Accept-Language")
 	if err := json.NewEncoder(w).Encode(post); err != nil {
 		h.log.Error("failed to encode post response", "error", err)
 	}
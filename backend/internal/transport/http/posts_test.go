@@ -0,0 +1,50 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_getPostBySlug(t *testing.T) {
+	t.Run("Comments Closed For A New Account", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/posts/hello", nil)
+		req = injectParam(req, "slug", "hello")
+		user := &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now()}
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, user))
+
+		mocks.Post.On("GetPostBySlug", mock.Anything, "hello", 2).
+			Return(&domain.Post{ID: 1, Slug: "hello", CommentsMinAccountAgeDays: 7}, nil)
+
+		w := httptest.NewRecorder()
+		h.getPostBySlug(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"comments_closed_reason":"account_too_new"`)
+	})
+
+	t.Run("Comments Open", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/posts/hello", nil)
+		req = injectParam(req, "slug", "hello")
+		user := &domain.User{ID: 2, Role: domain.RoleUser, CreatedAt: time.Now().AddDate(-1, 0, 0)}
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, user))
+
+		mocks.Post.On("GetPostBySlug", mock.Anything, "hello", 2).
+			Return(&domain.Post{ID: 1, Slug: "hello", CommentsMinAccountAgeDays: 7}, nil)
+
+		w := httptest.NewRecorder()
+		h.getPostBySlug(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "comments_closed_reason")
+	})
+}
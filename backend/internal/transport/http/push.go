@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+
+	"personal-web-platform/internal/domain"
+)
+
+// getPushPublicKey handles GET /api/v1/push/public-key - the VAPID key for PushManager.subscribe
+func (h *Handler) getPushPublicKey(w http.ResponseWriter, _ *http.Request) {
+	RespondSuccess(w, map[string]string{"public_key": h.services.Push.PublicKey()})
+}
+
+// subscribePush handles POST /api/v1/me/push-subscriptions - registers a browser for push notifications
+func (h *Handler) subscribePush(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var req domain.PushSubscriptionRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.services.Push.Subscribe(r.Context(), user.ID, &req, r.UserAgent()); err != nil {
+		h.log.Error("failed to save push subscription", "error", err, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondCreated(w, map[string]string{"message": "subscribed"})
+}
+
+// unsubscribePush handles DELETE /api/v1/me/push-subscriptions - the endpoint is in the body
+func (h *Handler) unsubscribePush(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var req domain.PushUnsubscribeRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.services.Push.Unsubscribe(r.Context(), user.ID, &req); err != nil {
+		h.log.Error("failed to delete push subscription", "error", err, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondNoContent(w)
+}
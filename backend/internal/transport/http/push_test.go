@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const pushSubscriptionJSON = `{"endpoint":"https://push.example/send/abc","keys":{"p256dh":"BKey","auth":"secret"}}`
+
+func TestHandler_getPushPublicKey(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := httptest.NewRequest("GET", "/api/v1/push/public-key", nil)
+
+	mocks.Push.On("PublicKey").Return("BPublicKey")
+
+	w := httptest.NewRecorder()
+	h.getPushPublicKey(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"public_key":"BPublicKey"`)
+}
+
+func TestHandler_subscribePush(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/me/push-subscriptions", strings.NewReader(pushSubscriptionJSON))
+		req.Header.Set("User-Agent", "Firefox")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Push.On("Subscribe", mock.Anything, 2, &domain.PushSubscriptionRequest{
+			Endpoint: "https://push.example/send/abc",
+			Keys:     domain.PushSubscriptionKeys{P256dh: "BKey", Auth: "secret"},
+		}, "Firefox").Return(nil)
+
+		w := httptest.NewRecorder()
+		h.subscribePush(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mocks.Push.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Keys", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/me/push-subscriptions", strings.NewReader(pushSubscriptionJSON))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Push.On("Subscribe", mock.Anything, 2, mock.Anything, mock.Anything).
+			Return(fmt.Errorf("%w: invalid p256dh key", derr.ErrValidation))
+
+		w := httptest.NewRecorder()
+		h.subscribePush(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Missing Endpoint", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/me/push-subscriptions", strings.NewReader(`{"keys":{"p256dh":"a","auth":"b"}}`))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		w := httptest.NewRecorder()
+		h.subscribePush(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/me/push-subscriptions", strings.NewReader(pushSubscriptionJSON))
+
+		w := httptest.NewRecorder()
+		h.subscribePush(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestHandler_unsubscribePush(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/me/push-subscriptions", strings.NewReader(`{"endpoint":"https://push.example/send/abc"}`))
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Push.On("Unsubscribe", mock.Anything, 2, &domain.PushUnsubscribeRequest{Endpoint: "https://push.example/send/abc"}).Return(nil)
+
+		w := httptest.NewRecorder()
+		h.unsubscribePush(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mocks.Push.AssertExpectations(t)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/me/push-subscriptions", strings.NewReader(`{"endpoint":"https://push.example/send/abc"}`))
+
+		w := httptest.NewRecorder()
+		h.unsubscribePush(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
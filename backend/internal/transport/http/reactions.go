@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// listReactions handles GET /api/v1/reactions - list emoji available for reactions
+func (h *Handler) listReactions(w http.ResponseWriter, _ *http.Request) {
+	RespondSuccess(w, h.services.Reaction.AllowedReactions())
+}
+
+// addPostReaction handles PUT /api/v1/posts/{id}/reactions/{emoji} - react to a post
+func (h *Handler) addPostReaction(w http.ResponseWriter, r *http.Request) {
+	h.handleReaction(w, r, domain.ReactionTargetPost, true)
+}
+
+// removePostReaction handles DELETE /api/v1/posts/{id}/reactions/{emoji} - remove a reaction from a post
+func (h *Handler) removePostReaction(w http.ResponseWriter, r *http.Request) {
+	h.handleReaction(w, r, domain.ReactionTargetPost, false)
+}
+
+// addCommentReaction handles PUT /api/v1/comments/{id}/reactions/{emoji} - react to a comment
+func (h *Handler) addCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.handleReaction(w, r, domain.ReactionTargetComment, true)
+}
+
+// removeCommentReaction handles DELETE /api/v1/comments/{id}/reactions/{emoji} - remove a reaction from a comment
+func (h *Handler) removeCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.handleReaction(w, r, domain.ReactionTargetComment, false)
+}
+
+// handleReaction adds or removes a reaction and responds with the updated summary
+func (h *Handler) handleReaction(w http.ResponseWriter, r *http.Request, target domain.ReactionTarget, add bool) {
+	targetID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid "+string(target)+" ID")
+		return
+	}
+
+	// Emoji arrive percent-encoded in the path
+	emoji, err := url.PathUnescape(chi.URLParam(r, "emoji"))
+	if err != nil || emoji == "" {
+		RespondBadRequest(w, "invalid emoji")
+		return
+	}
+
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var reactions []domain.ReactionSummary
+	if add {
+		reactions, err = h.services.Reaction.AddReaction(r.Context(), target, targetID, user.ID, emoji)
+	} else {
+		reactions, err = h.services.Reaction.RemoveReaction(r.Context(), target, targetID, user.ID, emoji)
+	}
+	if err != nil {
+		h.log.Error("failed to update reaction", "error", err, "target", target, "targetID", targetID, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, map[string]any{"reactions": reactions})
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func injectReactionParams(r *http.Request, id, emoji string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	rctx.URLParams.Add("emoji", emoji)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_listReactions(t *testing.T) {
+	h, mocks := setupHandler(t)
+	mocks.Reaction.On("AllowedReactions").Return([]string{"👍", "🔥"})
+
+	w := httptest.NewRecorder()
+	h.listReactions(w, httptest.NewRequest("GET", "/api/v1/reactions", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "🔥")
+}
+
+func TestHandler_addPostReaction(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/posts/1/reactions/%F0%9F%94%A5", nil)
+		req = injectReactionParams(req, "1", "%F0%9F%94%A5")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 3}))
+
+		mocks.Reaction.On("AddReaction", mock.Anything, domain.ReactionTargetPost, 1, 3, "🔥").
+			Return([]domain.ReactionSummary{{Emoji: "🔥", Count: 1, Reacted: true}}, nil)
+
+		w := httptest.NewRecorder()
+		h.addPostReaction(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"reacted":true`)
+	})
+
+	t.Run("Unsupported Emoji", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/posts/1/reactions/x", nil)
+		req = injectReactionParams(req, "1", "x")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 3}))
+
+		mocks.Reaction.On("AddReaction", mock.Anything, domain.ReactionTargetPost, 1, 3, "x").
+			Return(nil, fmt.Errorf("%w: unsupported reaction", derr.ErrValidation))
+
+		w := httptest.NewRecorder()
+		h.addPostReaction(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/posts/abc/reactions/x", nil)
+		req = injectReactionParams(req, "abc", "x")
+
+		w := httptest.NewRecorder()
+		h.addPostReaction(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/posts/1/reactions/x", nil)
+		req = injectReactionParams(req, "1", "x")
+
+		w := httptest.NewRecorder()
+		h.addPostReaction(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestHandler_removeCommentReaction(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := httptest.NewRequest("DELETE", "/api/v1/comments/2/reactions/x", nil)
+	req = injectReactionParams(req, "2", "👍")
+	req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 3}))
+
+	mocks.Reaction.On("RemoveReaction", mock.Anything, domain.ReactionTargetComment, 2, 3, "👍").
+		Return(nil, fmt.Errorf("%w: comment not found", derr.ErrNotFound))
+
+	w := httptest.NewRecorder()
+	h.removeCommentReaction(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
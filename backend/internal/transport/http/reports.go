@@ -0,0 +1,98 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// reportComment handles POST /api/v1/comments/{id}/report - flag a comment for admins.
+// Repeated reports by the same user succeed without being counted again.
+func (h *Handler) reportComment(w http.ResponseWriter, r *http.Request) {
+	commentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid comment ID")
+		return
+	}
+
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var req domain.ReportCommentRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.services.Comment.ReportComment(r.Context(), commentID, &req, user.ID); err != nil {
+		h.log.Error("failed to report comment", "error", err, "commentID", commentID, "userID", user.ID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, map[string]string{"message": "reported"})
+}
+
+// listCommentReports handles GET /api/v1/admin/comments/reports - reported comments with their reports.
+// ?status=resolved or ?status=dismissed lists closed reports instead of open ones.
+func (h *Handler) listCommentReports(w http.ResponseWriter, r *http.Request) {
+	req := &domain.ReportsRequest{Status: r.URL.Query().Get("status")}
+
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+		req.Page = page
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		req.Limit = limit
+	}
+
+	response, err := h.services.Comment.ListReports(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to list comment reports", "error", err, "status", req.Status)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
+
+// resolveCommentReports handles POST /api/v1/admin/comments/{id}/reports/resolve - uphold the reports and reject the comment
+func (h *Handler) resolveCommentReports(w http.ResponseWriter, r *http.Request) {
+	h.closeCommentReports(w, r, domain.ReportStatusResolved)
+}
+
+// dismissCommentReports handles POST /api/v1/admin/comments/{id}/reports/dismiss - keep the comment
+func (h *Handler) dismissCommentReports(w http.ResponseWriter, r *http.Request) {
+	h.closeCommentReports(w, r, domain.ReportStatusDismissed)
+}
+
+func (h *Handler) closeCommentReports(w http.ResponseWriter, r *http.Request, status string) {
+	commentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid comment ID")
+		return
+	}
+
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	if status == domain.ReportStatusResolved {
+		err = h.services.Comment.ResolveReports(r.Context(), commentID, user.ID)
+	} else {
+		err = h.services.Comment.DismissReports(r.Context(), commentID, user.ID)
+	}
+	if err != nil {
+		h.log.Error("failed to close comment reports", "error", err, "commentID", commentID, "status", status)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, map[string]string{"status": status})
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_reportComment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/comments/5/report", strings.NewReader(`{"reason":"abuse","details":"Insults"}`))
+		req = injectParam(req, "id", "5")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Comment.On("ReportComment", mock.Anything, 5, &domain.ReportCommentRequest{Reason: "abuse", Details: "Insults"}, 2).Return(nil)
+
+		w := httptest.NewRecorder()
+		h.reportComment(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mocks.Comment.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Reason", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/comments/5/report", strings.NewReader(`{"reason":"boring"}`))
+		req = injectParam(req, "id", "5")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		w := httptest.NewRecorder()
+		h.reportComment(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Comment Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/comments/9/report", strings.NewReader(`{"reason":"spam"}`))
+		req = injectParam(req, "id", "9")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 2}))
+
+		mocks.Comment.On("ReportComment", mock.Anything, 9, mock.Anything, 2).Return(derr.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		h.reportComment(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_listCommentReports(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := httptest.NewRequest("GET", "/api/v1/admin/comments/reports?status=dismissed&page=2", nil)
+
+	mocks.Comment.On("ListReports", mock.Anything, &domain.ReportsRequest{Status: "dismissed", Page: 2}).
+		Return(&domain.ReportsResponse{
+			Comments: []domain.ReportedComment{{
+				QueuedComment: domain.QueuedComment{Comment: domain.Comment{ID: 5}, PostSlug: "hello"},
+				Reports:       []domain.CommentReport{{ID: 1, CommentID: 5, Reason: "abuse", Status: "dismissed"}},
+			}},
+			TotalCount: 21,
+			Page:       2,
+			Limit:      20,
+			TotalPages: 2,
+		}, nil)
+
+	w := httptest.NewRecorder()
+	h.listCommentReports(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reason":"abuse"`)
+}
+
+func TestHandler_closeCommentReports(t *testing.T) {
+	admin := &domain.User{ID: 1, Role: domain.RoleAdmin}
+
+	t.Run("Resolve", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/admin/comments/5/reports/resolve", nil)
+		req = injectParam(req, "id", "5")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, admin))
+
+		mocks.Comment.On("ResolveReports", mock.Anything, 5, 1).Return(nil)
+
+		w := httptest.NewRecorder()
+		h.resolveCommentReports(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mocks.Comment.AssertExpectations(t)
+	})
+
+	t.Run("Dismiss Without Open Reports", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/admin/comments/5/reports/dismiss", nil)
+		req = injectParam(req, "id", "5")
+		req = req.WithContext(context.WithValue(req.Context(), userContextKey, admin))
+
+		mocks.Comment.On("DismissReports", mock.Anything, 5, 1).Return(derr.ErrNotFound)
+
+		w := httptest.NewRecorder()
+		h.dismissCommentReports(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
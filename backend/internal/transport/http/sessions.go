@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// listSessions handles GET /auth/sessions - the current user's active sessions,
+// the one the request was made with has "current": true
+func (h *Handler) listSessions(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	sessions, err := h.services.Auth.ListSessions(r.Context(), user.ID, h.getSessionToken(r))
+	if err != nil {
+		h.log.Error("failed to list sessions", "error", err, "userID", user.ID)
+		RespondInternalError(w)
+		return
+	}
+
+	RespondSuccess(w, sessions)
+}
+
+// revokeSession handles DELETE /auth/sessions/{id} - sign out of another session
+func (h *Handler) revokeSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid session ID")
+		return
+	}
+
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	if err := h.services.Auth.RevokeSession(r.Context(), user.ID, sessionID, h.getSessionToken(r)); err != nil {
+		h.log.Error("failed to revoke session", "error", err, "userID", user.ID, "sessionID", sessionID)
+		RespondWithError(w, err)
+		return
+	}
+
+	h.log.Info("session revoked", "userID", user.ID, "sessionID", sessionID)
+	RespondNoContent(w)
+}
+
+// revokeOtherSessions handles DELETE /auth/sessions - sign out everywhere but here
+func (h *Handler) revokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	user := h.getUserFromContext(r.Context())
+	if user == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	revoked, err := h.services.Auth.RevokeOtherSessions(r.Context(), user.ID, h.getSessionToken(r))
+	if err != nil {
+		h.log.Error("failed to revoke sessions", "error", err, "userID", user.ID)
+		RespondInternalError(w)
+		return
+	}
+
+	h.log.Info("other sessions revoked", "userID", user.ID, "count", revoked)
+	RespondSuccess(w, map[string]int64{"revoked": revoked})
+}
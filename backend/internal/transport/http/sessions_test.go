@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withSession adds the session cookie and the signed in user to the request
+func withSession(req *http.Request, token string) *http.Request {
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: token})
+	return req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 1, Role: domain.RoleUser}))
+}
+
+func TestHandler_listSessions(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := withSession(httptest.NewRequest("GET", "/auth/sessions", nil), "mine")
+
+	mocks.Auth.On("ListSessions", mock.Anything, 1, "mine").Return([]domain.Session{
+		{ID: 3, UserID: 1, Token: "other", UserAgent: "Firefox"},
+		{ID: 2, UserID: 1, Token: "mine", UserAgent: "Chrome", Current: true},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	h.listSessions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"current":true`)
+	assert.Contains(t, w.Body.String(), `"user_agent":"Firefox"`)
+	assert.NotContains(t, w.Body.String(), "mine")
+}
+
+func TestHandler_revokeSession(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := withSession(httptest.NewRequest("DELETE", "/auth/sessions/3", nil), "mine")
+		req = injectParam(req, "id", "3")
+
+		mocks.Auth.On("RevokeSession", mock.Anything, 1, 3, "mine").Return(nil)
+
+		w := httptest.NewRecorder()
+		h.revokeSession(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Current Session", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := withSession(httptest.NewRequest("DELETE", "/auth/sessions/2", nil), "mine")
+		req = injectParam(req, "id", "2")
+
+		mocks.Auth.On("RevokeSession", mock.Anything, 1, 2, "mine").
+			Return(fmt.Errorf("%w: log out to end the current session", derr.ErrConflict))
+
+		w := httptest.NewRecorder()
+		h.revokeSession(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := withSession(httptest.NewRequest("DELETE", "/auth/sessions/9", nil), "mine")
+		req = injectParam(req, "id", "9")
+
+		mocks.Auth.On("RevokeSession", mock.Anything, 1, 9, "mine").
+			Return(fmt.Errorf("%w: session not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.revokeSession(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Invalid Session ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := withSession(httptest.NewRequest("DELETE", "/auth/sessions/abc", nil), "mine")
+		req = injectParam(req, "id", "abc")
+
+		w := httptest.NewRecorder()
+		h.revokeSession(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_revokeOtherSessions(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := withSession(httptest.NewRequest("DELETE", "/auth/sessions", nil), "mine")
+
+	mocks.Auth.On("RevokeOtherSessions", mock.Anything, 1, "mine").Return(int64(2), nil)
+
+	w := httptest.NewRecorder()
+	h.revokeOtherSessions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"revoked":2`)
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/pkg/language"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// listPostTranslations handles GET /api/v1/admin/posts/{id}/translations - list translations of a post
+func (h *Handler) listPostTranslations(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid post ID")
+		return
+	}
+
+	translations, err := h.services.Post.ListTranslations(r.Context(), postID)
+	if err != nil {
+		h.log.Error("failed to list post translations", "error", err, "postID", postID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, translations)
+}
+
+// upsertPostTranslation handles PUT /api/v1/admin/posts/{id}/translations/{lang} - add or edit a translation
+func (h *Handler) upsertPostTranslation(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid post ID")
+		return
+	}
+	lang := chi.URLParam(r, "lang")
+
+	var req domain.UpsertTranslationRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	translation, err := h.services.Post.UpsertTranslation(r.Context(), postID, lang, &req)
+	if err != nil {
+		h.log.Error("failed to save post translation", "error", err, "postID", postID, "lang", lang)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, translation)
+}
+
+// deletePostTranslation handles DELETE /api/v1/admin/posts/{id}/translations/{lang} - remove a translation
+func (h *Handler) deletePostTranslation(w http.ResponseWriter, r *http.Request) {
+	postID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid post ID")
+		return
+	}
+	lang := chi.URLParam(r, "lang")
+
+	if err := h.services.Post.DeleteTranslation(r.Context(), postID, lang); err != nil {
+		h.log.Error("failed to delete post translation", "error", err, "postID", postID, "lang", lang)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondNoContent(w)
+}
+
+// languagePreference extracts the requested content language from ?lang= and Accept-Language
+func languagePreference(r *http.Request) domain.LanguagePreference {
+	return domain.LanguagePreference{
+		Explicit: language.Normalize(r.URL.Query().Get("lang")),
+		Accepted: language.ParseAcceptLanguage(r.Header.Get("Accept-Language")),
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func injectTranslationParams(r *http.Request, id, lang string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	rctx.URLParams.Add("lang", lang)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_upsertPostTranslation(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		body := `{"title": "Hello World", "content": "English content of the post"}`
+		req := httptest.NewRequest("PUT", "/api/v1/admin/posts/1/translations/en", bytes.NewBufferString(body))
+		req = injectTranslationParams(req, "1", "en")
+
+		mocks.Post.On("UpsertTranslation", mock.Anything, 1, "en", mock.AnythingOfType("*domain.UpsertTranslationRequest")).
+			Return(&domain.PostTranslation{ID: 1, PostID: 1, Language: "en", Slug: "hello-world"}, nil)
+
+		w := httptest.NewRecorder()
+		h.upsertPostTranslation(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "hello-world")
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/admin/posts/abc/translations/en", bytes.NewBufferString(`{}`))
+		req = injectTranslationParams(req, "abc", "en")
+
+		w := httptest.NewRecorder()
+		h.upsertPostTranslation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unsupported Language", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		body := `{"title": "Hallo Welt", "content": "Deutscher Inhalt des Beitrags"}`
+		req := httptest.NewRequest("PUT", "/api/v1/admin/posts/1/translations/de", bytes.NewBufferString(body))
+		req = injectTranslationParams(req, "1", "de")
+
+		mocks.Post.On("UpsertTranslation", mock.Anything, 1, "de", mock.Anything).
+			Return(nil, fmt.Errorf("%w: unsupported language", derr.ErrValidation))
+
+		w := httptest.NewRecorder()
+		h.upsertPostTranslation(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_deletePostTranslation(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/admin/posts/1/translations/en", nil)
+		req = injectTranslationParams(req, "1", "en")
+
+		mocks.Post.On("DeleteTranslation", mock.Anything, 1, "en").Return(nil)
+
+		w := httptest.NewRecorder()
+		h.deletePostTranslation(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/admin/posts/1/translations/en", nil)
+		req = injectTranslationParams(req, "1", "en")
+
+		mocks.Post.On("DeleteTranslation", mock.Anything, 1, "en").
+			Return(fmt.Errorf("%w: translation not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.deletePostTranslation(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLanguagePreference(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/posts/hello?lang=EN_us", nil)
+	req.Header.Set("Accept-Language", "ru;q=0.8, en")
+
+	pref := languagePreference(req)
+
+	assert.Equal(t, "en-us", pref.Explicit)
+	assert.Equal(t, []string{"en", "ru"}, pref.Accepted)
+}
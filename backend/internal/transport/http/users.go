@@ -0,0 +1,183 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"personal-web-platform/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// listUsers handles GET /api/v1/admin/users - users with activity counts, newest first.
+// ?search matches the email or name, ?role=admin or ?role=user filters by role.
+func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
+	req := &domain.ListUsersRequest{
+		Search: r.URL.Query().Get("search"),
+		Role:   domain.Role(r.URL.Query().Get("role")),
+	}
+
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
+		req.Page = page
+	}
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		req.Limit = limit
+	}
+
+	response, err := h.services.User.ListUsers(r.Context(), req)
+	if err != nil {
+		h.log.Error("failed to list users", "error", err, "search", req.Search, "role", req.Role)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, response)
+}
+
+// getUser handles GET /api/v1/admin/users/{id} - a user with linked providers and active sessions
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid user ID")
+		return
+	}
+
+	details, err := h.services.User.GetUser(r.Context(), userID)
+	if err != nil {
+		h.log.Error("failed to get user", "error", err, "userID", userID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, details)
+}
+
+// updateUserRole handles PUT /api/v1/admin/users/{id}/role - promote or demote a user
+func (h *Handler) updateUserRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid user ID")
+		return
+	}
+
+	admin := h.getUserFromContext(r.Context())
+	if admin == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var req domain.UpdateUserRoleRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.services.User.UpdateUserRole(r.Context(), userID, &req, admin.ID)
+	if err != nil {
+		h.log.Error("failed to update user role", "error", err, "userID", userID, "role", req.Role)
+		RespondWithError(w, err)
+		return
+	}
+
+	h.log.Info("user role changed", "userID", userID, "role", user.Role, "adminID", admin.ID)
+	RespondSuccess(w, user)
+}
+
+// deleteUser handles DELETE /api/v1/admin/users/{id} - delete a user.
+// ?comments=remove removes their comments, by default they are kept anonymized.
+func (h *Handler) deleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid user ID")
+		return
+	}
+
+	admin := h.getUserFromContext(r.Context())
+	if admin == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	req := &domain.DeleteUserRequest{Comments: r.URL.Query().Get("comments")}
+	if err := h.services.User.DeleteUser(r.Context(), userID, req, admin.ID); err != nil {
+		h.log.Error("failed to delete user", "error", err, "userID", userID, "comments", req.Comments)
+		RespondWithError(w, err)
+		return
+	}
+
+	h.log.Info("user deleted", "userID", userID, "comments", req.Comments, "adminID", admin.ID)
+	RespondNoContent(w)
+}
+
+// banUser handles POST /api/v1/admin/users/{id}/ban - make a user read-only and sign them out.
+// Without expires_at the ban lasts until it is lifted.
+func (h *Handler) banUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid user ID")
+		return
+	}
+
+	admin := h.getUserFromContext(r.Context())
+	if admin == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	var req domain.BanUserRequest
+	if !h.DecodeAndValidateRequest(w, r, &req) {
+		return
+	}
+
+	ban, err := h.services.User.BanUser(r.Context(), userID, &req, admin.ID)
+	if err != nil {
+		h.log.Error("failed to ban user", "error", err, "userID", userID)
+		RespondWithError(w, err)
+		return
+	}
+
+	h.log.Info("user banned", "userID", userID, "banID", ban.ID, "expiresAt", ban.ExpiresAt, "adminID", admin.ID)
+	RespondCreated(w, ban)
+}
+
+// liftUserBan handles DELETE /api/v1/admin/users/{id}/ban - end the ban in effect
+func (h *Handler) liftUserBan(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid user ID")
+		return
+	}
+
+	admin := h.getUserFromContext(r.Context())
+	if admin == nil {
+		RespondUnauthorized(w, "authentication required")
+		return
+	}
+
+	ban, err := h.services.User.LiftBan(r.Context(), userID, admin.ID)
+	if err != nil {
+		h.log.Error("failed to lift ban", "error", err, "userID", userID)
+		RespondWithError(w, err)
+		return
+	}
+
+	h.log.Info("user ban lifted", "userID", userID, "banID", ban.ID, "adminID", admin.ID)
+	RespondSuccess(w, ban)
+}
+
+// listUserBans handles GET /api/v1/admin/users/{id}/bans - every ban of a user with who issued and lifted it
+func (h *Handler) listUserBans(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		RespondBadRequest(w, "invalid user ID")
+		return
+	}
+
+	bans, err := h.services.User.ListBans(r.Context(), userID)
+	if err != nil {
+		h.log.Error("failed to list bans", "error", err, "userID", userID)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, bans)
+}
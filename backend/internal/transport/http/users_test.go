@@ -0,0 +1,225 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func withAdmin(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), userContextKey, &domain.User{ID: 1, Role: domain.RoleAdmin}))
+}
+
+func TestHandler_listUsers(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := httptest.NewRequest("GET", "/api/v1/admin/users?search=bob&role=user&page=2&limit=10", nil)
+
+	mocks.User.On("ListUsers", mock.Anything, &domain.ListUsersRequest{Search: "bob", Role: domain.RoleUser, Page: 2, Limit: 10}).
+		Return(&domain.UserListResponse{
+			Users: []domain.UserSummary{{User: domain.User{ID: 2, Email: "bob@example.com"}, CommentsCount: 3}},
+			Page:  2, Limit: 10, TotalCount: 11, TotalPages: 2,
+		}, nil)
+
+	w := httptest.NewRecorder()
+	h.listUsers(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"email":"bob@example.com"`)
+	assert.Contains(t, w.Body.String(), `"comments_count":3`)
+}
+
+func TestHandler_getUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/admin/users/2", nil)
+		req = injectParam(req, "id", "2")
+
+		mocks.User.On("GetUser", mock.Anything, 2).Return(&domain.UserDetails{
+			UserSummary: domain.UserSummary{User: domain.User{ID: 2}},
+			Providers:   []domain.OAuthProvider{{Provider: "github"}},
+			Sessions:    []domain.Session{{ID: 7, Token: "secret"}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		h.getUser(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"provider":"github"`)
+		assert.NotContains(t, w.Body.String(), "secret")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/admin/users/9", nil)
+		req = injectParam(req, "id", "9")
+
+		mocks.User.On("GetUser", mock.Anything, 9).Return(nil, fmt.Errorf("%w: user not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.getUser(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_updateUserRole(t *testing.T) {
+	t.Run("Promote", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/admin/users/2/role", strings.NewReader(`{"role":"admin"}`))
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		mocks.User.On("UpdateUserRole", mock.Anything, 2, &domain.UpdateUserRoleRequest{Role: domain.RoleAdmin}, 1).
+			Return(&domain.User{ID: 2, Role: domain.RoleAdmin}, nil)
+
+		w := httptest.NewRecorder()
+		h.updateUserRole(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"role":"admin"`)
+	})
+
+	t.Run("Invalid Role", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/admin/users/2/role", strings.NewReader(`{"role":"owner"}`))
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		w := httptest.NewRecorder()
+		h.updateUserRole(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Own Role", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("PUT", "/api/v1/admin/users/1/role", strings.NewReader(`{"role":"user"}`))
+		req = withAdmin(injectParam(req, "id", "1"))
+
+		mocks.User.On("UpdateUserRole", mock.Anything, 1, &domain.UpdateUserRoleRequest{Role: domain.RoleUser}, 1).
+			Return(nil, fmt.Errorf("%w: cannot change your own role", derr.ErrConflict))
+
+		w := httptest.NewRecorder()
+		h.updateUserRole(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestHandler_deleteUser(t *testing.T) {
+	t.Run("Remove Comments", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/admin/users/2?comments=remove", nil)
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		mocks.User.On("DeleteUser", mock.Anything, 2, &domain.DeleteUserRequest{Comments: domain.UserCommentsRemove}, 1).Return(nil)
+
+		w := httptest.NewRecorder()
+		h.deleteUser(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mocks.User.AssertExpectations(t)
+	})
+
+	t.Run("Invalid User ID", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/admin/users/abc", nil)
+		req = withAdmin(injectParam(req, "id", "abc"))
+
+		w := httptest.NewRecorder()
+		h.deleteUser(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_banUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/admin/users/2/ban", strings.NewReader(`{"reason":"spam"}`))
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		mocks.User.On("BanUser", mock.Anything, 2, &domain.BanUserRequest{Reason: "spam"}, 1).
+			Return(&domain.UserBan{ID: 3, UserID: 2, Reason: "spam"}, nil)
+
+		w := httptest.NewRecorder()
+		h.banUser(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"reason":"spam"`)
+	})
+
+	t.Run("Missing Reason", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/admin/users/2/ban", strings.NewReader(`{}`))
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		w := httptest.NewRecorder()
+		h.banUser(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Already Banned", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/api/v1/admin/users/2/ban", strings.NewReader(`{"reason":"spam"}`))
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		mocks.User.On("BanUser", mock.Anything, 2, mock.Anything, 1).
+			Return(nil, fmt.Errorf("%w: user is already banned", derr.ErrConflict))
+
+		w := httptest.NewRecorder()
+		h.banUser(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestHandler_liftUserBan(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/admin/users/2/ban", nil)
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		mocks.User.On("LiftBan", mock.Anything, 2, 1).Return(&domain.UserBan{ID: 3, UserID: 2}, nil)
+
+		w := httptest.NewRecorder()
+		h.liftUserBan(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Not Banned", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("DELETE", "/api/v1/admin/users/2/ban", nil)
+		req = withAdmin(injectParam(req, "id", "2"))
+
+		mocks.User.On("LiftBan", mock.Anything, 2, 1).Return(nil, fmt.Errorf("%w: user is not banned", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.liftUserBan(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_listUserBans(t *testing.T) {
+	h, mocks := setupHandler(t)
+	req := httptest.NewRequest("GET", "/api/v1/admin/users/2/bans", nil)
+	req = withAdmin(injectParam(req, "id", "2"))
+
+	mocks.User.On("ListBans", mock.Anything, 2).Return([]domain.UserBan{{ID: 4, UserID: 2}, {ID: 3, UserID: 2}}, nil)
+
+	w := httptest.NewRecorder()
+	h.listUserBans(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":4`)
+}
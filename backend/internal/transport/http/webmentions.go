@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxWebmentionBodySize limits the form accepted by the webmention endpoint
+const maxWebmentionBodySize = 16 << 10
+
+// receiveWebmention handles POST /webmention - the W3C Webmention endpoint.
+// Mentions are verified asynchronously, so a valid request is only accepted.
+func (h *Handler) receiveWebmention(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxWebmentionBodySize)
+	if err := r.ParseForm(); err != nil {
+		RespondBadRequest(w, "invalid form body")
+		return
+	}
+
+	source, target := r.PostForm.Get("source"), r.PostForm.Get("target")
+	if source == "" || target == "" {
+		RespondBadRequest(w, "source and target are required")
+		return
+	}
+
+	if err := h.services.Webmention.Receive(r.Context(), source, target); err != nil {
+		h.log.Error("failed to receive webmention", "error", err, "source", source, "target", target)
+		RespondWithError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write([]byte("Webmention accepted for verification\n"))
+}
+
+// getPostMentions handles GET /api/v1/posts/{slug}/mentions - verified webmentions of a post
+func (h *Handler) getPostMentions(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	mentions, err := h.services.Webmention.ListMentions(r.Context(), slug)
+	if err != nil {
+		h.log.Error("failed to list webmentions", "error", err, "slug", slug)
+		RespondWithError(w, err)
+		return
+	}
+
+	RespondSuccess(w, mentions)
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"personal-web-platform/internal/domain"
+	"personal-web-platform/internal/domain/derr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_receiveWebmention(t *testing.T) {
+	form := url.Values{"source": {"https://other.example/post"}, "target": {"https://blog.example/blog/hello"}}
+
+	t.Run("Accepted", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/webmention", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		mocks.Webmention.On("Receive", mock.Anything, "https://other.example/post", "https://blog.example/blog/hello").Return(nil)
+
+		w := httptest.NewRecorder()
+		h.receiveWebmention(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mocks.Webmention.AssertExpectations(t)
+	})
+
+	t.Run("Missing Target", func(t *testing.T) {
+		h, _ := setupHandler(t)
+		req := httptest.NewRequest("POST", "/webmention", strings.NewReader("source=https://other.example/post"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		w := httptest.NewRecorder()
+		h.receiveWebmention(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unknown Target", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("POST", "/webmention", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		mocks.Webmention.On("Receive", mock.Anything, mock.Anything, mock.Anything).
+			Return(fmt.Errorf("%w: target post not found", derr.ErrValidation))
+
+		w := httptest.NewRecorder()
+		h.receiveWebmention(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandler_getPostMentions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/posts/hello/mentions", nil)
+		req = injectParam(req, "slug", "hello")
+
+		mocks.Webmention.On("ListMentions", mock.Anything, "hello").Return([]domain.Webmention{
+			{ID: 1, PostID: 1, Source: "https://other.example/post", Title: "Their post"},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		h.getPostMentions(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"source":"https://other.example/post"`)
+		// Queue state is internal
+		assert.NotContains(t, w.Body.String(), "attempts")
+	})
+
+	t.Run("Post Not Found", func(t *testing.T) {
+		h, mocks := setupHandler(t)
+		req := httptest.NewRequest("GET", "/api/v1/posts/missing/mentions", nil)
+		req = injectParam(req, "slug", "missing")
+
+		mocks.Webmention.On("ListMentions", mock.Anything, "missing").Return(nil, fmt.Errorf("%w: post not found", derr.ErrNotFound))
+
+		w := httptest.NewRecorder()
+		h.getPostMentions(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
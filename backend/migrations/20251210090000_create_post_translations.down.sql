@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS post_translations;
+ALTER TABLE posts DROP COLUMN IF EXISTS language;
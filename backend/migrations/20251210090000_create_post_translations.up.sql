@@ -0,0 +1,18 @@
+-- Language of the original post content
+ALTER TABLE posts ADD COLUMN language VARCHAR(8) NOT NULL DEFAULT 'ru';
+
+CREATE TABLE IF NOT EXISTS post_translations (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    language VARCHAR(8) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    content TEXT NOT NULL,
+    preview TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (post_id, language)
+);
+
+CREATE INDEX idx_post_translations_post_id ON post_translations(post_id);
+CREATE INDEX idx_post_translations_slug ON post_translations(slug);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_comments_moderation_status;
+ALTER TABLE comments DROP COLUMN IF EXISTS moderation_status;
+
+DROP INDEX IF EXISTS idx_posts_deleted_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS deleted_at;
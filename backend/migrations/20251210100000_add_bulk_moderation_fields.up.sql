@@ -0,0 +1,8 @@
+-- Soft delete for posts, so bulk deletions can be restored
+ALTER TABLE posts ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX idx_posts_deleted_at ON posts(deleted_at);
+
+-- Moderation status of comments; spam is hidden from readers and not counted
+ALTER TABLE comments ADD COLUMN moderation_status VARCHAR(16) NOT NULL DEFAULT 'approved'
+    CONSTRAINT comments_moderation_status_check CHECK (moderation_status IN ('approved', 'spam'));
+CREATE INDEX idx_comments_moderation_status ON comments(moderation_status);
@@ -0,0 +1,26 @@
+DROP VIEW IF EXISTS comment_likes;
+DROP VIEW IF EXISTS post_likes;
+
+CREATE TABLE IF NOT EXISTS post_likes (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE TABLE IF NOT EXISTS comment_likes (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, comment_id)
+);
+
+-- Only "👍" reactions can be represented as likes
+INSERT INTO post_likes (user_id, post_id, created_at)
+SELECT user_id, post_id, created_at FROM post_reactions WHERE emoji = '👍';
+
+INSERT INTO comment_likes (user_id, comment_id, created_at)
+SELECT user_id, comment_id, created_at FROM comment_reactions WHERE emoji = '👍';
+
+DROP TABLE IF EXISTS comment_reactions;
+DROP TABLE IF EXISTS post_reactions;
@@ -0,0 +1,37 @@
+-- Emoji reactions replace binary likes; a like is the "👍" reaction
+CREATE TABLE IF NOT EXISTS post_reactions (
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, user_id, emoji)
+);
+
+CREATE INDEX idx_post_reactions_user_id ON post_reactions(user_id);
+
+CREATE TABLE IF NOT EXISTS comment_reactions (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (comment_id, user_id, emoji)
+);
+
+CREATE INDEX idx_comment_reactions_user_id ON comment_reactions(user_id);
+
+INSERT INTO post_reactions (post_id, user_id, emoji, created_at)
+SELECT post_id, user_id, '👍', created_at FROM post_likes;
+
+INSERT INTO comment_reactions (comment_id, user_id, emoji, created_at)
+SELECT comment_id, user_id, '👍', created_at FROM comment_likes;
+
+DROP TABLE IF EXISTS comment_likes;
+DROP TABLE IF EXISTS post_likes;
+
+-- Likes stay readable under their old names for queries and reports written
+-- against them. Deleting through the views removes the reaction.
+CREATE VIEW post_likes AS
+SELECT user_id, post_id, created_at FROM post_reactions WHERE emoji = '👍';
+
+CREATE VIEW comment_likes AS
+SELECT user_id, comment_id, created_at FROM comment_reactions WHERE emoji = '👍';
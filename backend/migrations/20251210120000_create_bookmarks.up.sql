@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX idx_bookmarks_user_created_at ON bookmarks(user_id, created_at DESC);
+CREATE INDEX idx_bookmarks_post_id ON bookmarks(post_id);
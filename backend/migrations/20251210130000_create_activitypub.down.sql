@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS activitypub_comments;
+DROP TABLE IF EXISTS activitypub_followers;
+DROP TABLE IF EXISTS activitypub_keys;
@@ -0,0 +1,23 @@
+-- Key pair the blog actor signs outgoing requests with (single row)
+CREATE TABLE IF NOT EXISTS activitypub_keys (
+    id INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    private_key_pem TEXT NOT NULL,
+    public_key_pem TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Remote actors following the blog
+CREATE TABLE IF NOT EXISTS activitypub_followers (
+    id SERIAL PRIMARY KEY,
+    actor_id TEXT NOT NULL UNIQUE,
+    inbox TEXT NOT NULL,
+    shared_inbox TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Remote replies stored as comments, keyed by their ActivityPub object id
+CREATE TABLE IF NOT EXISTS activitypub_comments (
+    object_id TEXT PRIMARY KEY,
+    comment_id INTEGER NOT NULL UNIQUE REFERENCES comments(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS webmentions_outgoing;
+DROP TABLE IF EXISTS webmentions;
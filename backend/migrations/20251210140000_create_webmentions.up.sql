@@ -0,0 +1,39 @@
+-- Received webmentions, verified asynchronously
+CREATE TABLE IF NOT EXISTS webmentions (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    target TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    title TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    verified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT webmentions_status_check CHECK (status IN ('pending', 'verified', 'rejected')),
+    UNIQUE (source, target)
+);
+
+CREATE INDEX idx_webmentions_post_id ON webmentions(post_id) WHERE status = 'verified';
+CREATE INDEX idx_webmentions_due ON webmentions(next_attempt_at) WHERE status = 'pending';
+
+-- Webmentions sent for links in our posts
+CREATE TABLE IF NOT EXISTS webmentions_outgoing (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    target TEXT NOT NULL,
+    endpoint TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT webmentions_outgoing_status_check CHECK (status IN ('pending', 'sent', 'no_endpoint', 'failed')),
+    UNIQUE (post_id, target)
+);
+
+CREATE INDEX idx_webmentions_outgoing_due ON webmentions_outgoing(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,15 @@
+-- Newsletter subscribers (double opt-in, no account required)
+CREATE TABLE IF NOT EXISTS subscribers (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    confirm_token VARCHAR(64) UNIQUE,
+    confirm_expires_at TIMESTAMP WITH TIME ZONE,
+    unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT subscribers_status_check CHECK (status IN ('pending', 'confirmed', 'unsubscribed'))
+);
+
+CREATE INDEX idx_subscribers_confirmed ON subscribers(id) WHERE status = 'confirmed';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS email_notifications;
+DROP TABLE IF EXISTS email_deliveries;
+
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS language;
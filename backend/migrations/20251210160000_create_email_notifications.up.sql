@@ -0,0 +1,39 @@
+-- Language of notification emails, empty means the site default
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS language VARCHAR(10) NOT NULL DEFAULT '';
+
+-- Every email sent (or attempted) to a user, kept for troubleshooting
+CREATE TABLE IF NOT EXISTS email_deliveries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient VARCHAR(255) NOT NULL,
+    template VARCHAR(64) NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    notifications INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(16) NOT NULL,
+    attempt INTEGER NOT NULL DEFAULT 1,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT email_deliveries_status_check CHECK (status IN ('sent', 'failed'))
+);
+
+CREATE INDEX idx_email_deliveries_user_id ON email_deliveries(user_id, created_at DESC);
+
+-- Events waiting to be emailed, batched per user
+CREATE TABLE IF NOT EXISTS email_notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivery_id INTEGER REFERENCES email_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT email_notifications_status_check CHECK (status IN ('pending', 'sent', 'failed', 'skipped'))
+);
+
+-- An event is emailed once, e.g. a post published again doesn't notify twice
+CREATE UNIQUE INDEX idx_email_notifications_unique ON email_notifications(user_id, type, post_id, COALESCE(comment_id, 0));
+CREATE INDEX idx_email_notifications_pending ON email_notifications(user_id, created_at) WHERE status = 'pending';
@@ -0,0 +1,12 @@
+-- Discussion changes streamed to readers; kept for a while so that
+-- reconnecting readers can resume with Last-Event-ID
+CREATE TABLE IF NOT EXISTS post_events (
+    id BIGSERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_post_events_post_id ON post_events(post_id, id);
+CREATE INDEX idx_post_events_created_at ON post_events(created_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_comments_pending;
+
+-- Held comments stay hidden
+UPDATE comments SET moderation_status = 'spam' WHERE moderation_status IN ('pending', 'rejected');
+ALTER TABLE comments DROP CONSTRAINT comments_moderation_status_check;
+ALTER TABLE comments ADD CONSTRAINT comments_moderation_status_check
+    CHECK (moderation_status IN ('approved', 'spam'));
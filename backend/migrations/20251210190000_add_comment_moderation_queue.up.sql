@@ -0,0 +1,7 @@
+-- Comments held for moderation are pending until an admin approves or rejects them
+ALTER TABLE comments DROP CONSTRAINT comments_moderation_status_check;
+ALTER TABLE comments ADD CONSTRAINT comments_moderation_status_check
+    CHECK (moderation_status IN ('pending', 'approved', 'spam', 'rejected'));
+
+-- The moderation queue lists pending comments oldest first
+CREATE INDEX idx_comments_pending ON comments(created_at) WHERE moderation_status = 'pending';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_comments_user_created_at;
+DROP INDEX IF EXISTS idx_comments_fingerprint;
+ALTER TABLE comments DROP COLUMN IF EXISTS content_fingerprint;
+ALTER TABLE comments DROP COLUMN IF EXISTS spam_reasons;
+ALTER TABLE comments DROP COLUMN IF EXISTS spam_score;
@@ -0,0 +1,9 @@
+-- Spam score of a comment and the signals behind it, for admin review
+ALTER TABLE comments ADD COLUMN spam_score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN spam_reasons TEXT[] NOT NULL DEFAULT '{}';
+
+-- Hash of the normalized text, to find the same comment posted again
+ALTER TABLE comments ADD COLUMN content_fingerprint VARCHAR(64);
+
+CREATE INDEX idx_comments_fingerprint ON comments(content_fingerprint, created_at) WHERE content_fingerprint IS NOT NULL;
+CREATE INDEX idx_comments_user_created_at ON comments(user_id, created_at);
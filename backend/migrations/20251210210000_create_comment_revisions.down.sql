@@ -0,0 +1,2 @@
+ALTER TABLE comments DROP COLUMN IF EXISTS edit_count;
+DROP TABLE IF EXISTS comment_revisions;
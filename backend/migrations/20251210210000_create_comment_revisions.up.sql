@@ -0,0 +1,12 @@
+-- Previous versions of edited comments, for admins
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    id SERIAL PRIMARY KEY,
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    content TEXT NOT NULL, -- content before the edit
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW() -- when it was replaced
+);
+
+CREATE INDEX idx_comment_revisions_comment_id ON comment_revisions(comment_id, created_at);
+
+-- Shown to readers as an "edited" mark
+ALTER TABLE comments ADD COLUMN edit_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+-- Users mentioned in comments with @handle
+CREATE TABLE IF NOT EXISTS comment_mentions (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    handle VARCHAR(100) NOT NULL, -- as written, without "@"
+    position INTEGER NOT NULL, -- order of appearance in the comment
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX idx_comment_mentions_user_id ON comment_mentions(user_id);
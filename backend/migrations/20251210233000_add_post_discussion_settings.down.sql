@@ -0,0 +1,3 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS comments_min_account_age_days;
+ALTER TABLE posts DROP COLUMN IF EXISTS comments_close_after_days;
+ALTER TABLE posts DROP COLUMN IF EXISTS comments_disabled;
@@ -0,0 +1,4 @@
+-- Per-post discussion settings, zero days means no limit
+ALTER TABLE posts ADD COLUMN comments_disabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE posts ADD COLUMN comments_close_after_days INTEGER NOT NULL DEFAULT 0 CHECK (comments_close_after_days >= 0);
+ALTER TABLE posts ADD COLUMN comments_min_account_age_days INTEGER NOT NULL DEFAULT 0 CHECK (comments_min_account_age_days >= 0);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_posts_series;
+ALTER TABLE posts DROP COLUMN IF EXISTS series;
@@ -0,0 +1,4 @@
+-- Posts can be grouped into a named series, empty means no series
+ALTER TABLE posts ADD COLUMN series VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_posts_series ON posts(series) WHERE series <> '';
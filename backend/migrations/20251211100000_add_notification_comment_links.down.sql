@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notifications_comment_unique;
+ALTER TABLE notifications DROP COLUMN IF EXISTS comment_id;
+ALTER TABLE notifications DROP COLUMN IF EXISTS post_id;
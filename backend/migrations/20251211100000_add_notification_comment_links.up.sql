@@ -0,0 +1,6 @@
+-- In-app notifications about comments link to them, and go away with them
+ALTER TABLE notifications ADD COLUMN post_id INTEGER REFERENCES posts(id) ON DELETE CASCADE;
+ALTER TABLE notifications ADD COLUMN comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE;
+
+-- A comment notifies a user once per type, e.g. an edit keeping a mention doesn't notify again
+CREATE UNIQUE INDEX idx_notifications_comment_unique ON notifications(user_id, type, comment_id) WHERE comment_id IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE subscribers DROP COLUMN IF EXISTS confirm_sent_at;
+DROP TABLE IF EXISTS newsletter_deliveries;
@@ -0,0 +1,20 @@
+-- Posts waiting to be emailed to newsletter subscribers
+CREATE TABLE IF NOT EXISTS newsletter_deliveries (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    subscriber_id INTEGER NOT NULL REFERENCES subscribers(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT newsletter_deliveries_status_check CHECK (status IN ('pending', 'sent', 'failed', 'skipped'))
+);
+
+-- A post is emailed to a subscriber once, e.g. publishing it again doesn't resend it
+CREATE UNIQUE INDEX idx_newsletter_deliveries_unique ON newsletter_deliveries(post_id, subscriber_id);
+CREATE INDEX idx_newsletter_deliveries_pending ON newsletter_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- When the last confirmation email went out, to limit resending
+ALTER TABLE subscribers ADD COLUMN IF NOT EXISTS confirm_sent_at TIMESTAMP WITH TIME ZONE;
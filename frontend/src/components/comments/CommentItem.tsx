@@ -66,7 +66,7 @@ export function CommentItem({ comment, postSlug, onReply }: CommentItemProps) {
   const isAuthor = user?.id === comment.user_id;
 
   return (
-    <div className="space-y-3">
+    <div id={`comment-${comment.id}`} className="space-y-3">
       <div className="flex gap-3 p-3 rounded-lg hover:bg-muted/50 transition-colors">
         <Avatar className="h-10 w-10">
           <AvatarImage src={comment.user?.avatar_url} />